@@ -18,6 +18,8 @@ var (
 	errNoPipelines            = errors.New("invalid route: no pipelines defined")
 	errUnexpectedConsumer     = errors.New("expected consumer to be a connector router")
 	errNoTableItems           = errors.New("invalid routing table: the routing table is empty")
+	errNoSplitPipelines       = errors.New("invalid route: no split pipelines defined")
+	errInvalidSplitPercentage = errors.New("invalid route: the split percentage must be between 0 and 100")
 )
 
 // Config defines configuration for the Routing processor.
@@ -39,6 +41,10 @@ type Config struct {
 	// Table contains the routing table for this processor.
 	// Required.
 	Table []RoutingTableItem `mapstructure:"table"`
+	// Functions declares reusable OTTL functions composed of other OTTL functions, which can be
+	// called in the conditions and statements of the routing table.
+	// Optional.
+	Functions []ottl.FunctionDefinition `mapstructure:"functions"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -62,6 +68,14 @@ func (c *Config) Validate() error {
 		if len(item.Pipelines) == 0 {
 			return errNoPipelines
 		}
+		if item.Split != nil {
+			if len(item.Split.Pipelines) == 0 {
+				return errNoSplitPipelines
+			}
+			if item.Split.Percentage < 0 || item.Split.Percentage > 100 {
+				return errInvalidSplitPercentage
+			}
+		}
 
 		switch item.Context {
 		case "", "resource", "span", "metric", "datapoint", "log": // ok
@@ -102,6 +116,37 @@ type RoutingTableItem struct {
 	// The routing processor will fail upon the first failure from these pipelines.
 	// Optional.
 	Pipelines []pipeline.ID `mapstructure:"pipelines"`
+
+	// Split sends a percentage of the data matching this route to other pipelines, e.g. to canary a new backend.
+	// Optional.
+	Split *SplitConfig `mapstructure:"split"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// SplitConfig specifies how the data matching a route is split between the pipelines of the route and other
+// pipelines. The data is selected deterministically by hashing a key, so that the data sharing the same key, e.g.
+// the spans of a trace, is always sent to the same pipelines.
+type SplitConfig struct {
+	// Percentage is the percentage of the data matching the route which is sent to the split pipelines, between 0
+	// and 100, with a precision of 0.01.
+	// Required.
+	Percentage float64 `mapstructure:"percentage"`
+
+	// Pipelines contains the list of pipelines the selected data is sent to.
+	// Required.
+	Pipelines []pipeline.ID `mapstructure:"pipelines"`
+
+	// Mirror sends the selected data to the pipelines of the route as well, instead of only to the split pipelines.
+	// Optional.
+	Mirror bool `mapstructure:"mirror"`
+
+	// HashAttribute is the name of the attribute whose value is hashed to select the data. The attribute is looked
+	// up in the attributes of the span, log record or data point first, then in the resource attributes. Data
+	// without the attribute is not selected. By default, the trace ID of spans and log records is hashed, and the
+	// resource attributes are hashed for the log records without a trace ID and for the metrics.
+	// Optional.
+	HashAttribute string `mapstructure:"hash_attribute"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -45,6 +45,13 @@ func TestLoadConfig(t *testing.T) {
 						Pipelines: []pipeline.ID{
 							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp-globex"),
 						},
+						Split: &SplitConfig{
+							Percentage: 5,
+							Pipelines: []pipeline.ID{
+								pipeline.NewIDWithName(pipeline.SignalTraces, "otlp-globex-canary"),
+							},
+							Mirror: true,
+						},
 					},
 				},
 			},
@@ -70,6 +77,13 @@ func TestLoadConfig(t *testing.T) {
 						Pipelines: []pipeline.ID{
 							pipeline.NewIDWithName(pipeline.SignalMetrics, "otlp-globex"),
 						},
+						Split: &SplitConfig{
+							Percentage: 12.5,
+							Pipelines: []pipeline.ID{
+								pipeline.NewIDWithName(pipeline.SignalMetrics, "otlp-globex-canary"),
+							},
+							HashAttribute: "host.name",
+						},
 					},
 				},
 			},
@@ -96,6 +110,19 @@ func TestLoadConfig(t *testing.T) {
 							pipeline.NewIDWithName(pipeline.SignalLogs, "otlp-globex"),
 						},
 					},
+					{
+						Condition: `IsTenant("initech")`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalLogs, "otlp-initech"),
+						},
+					},
+				},
+				Functions: []ottl.FunctionDefinition{
+					{
+						Name:      "IsTenant",
+						Params:    []string{"tenant"},
+						Condition: `attributes["X-Tenant"] == tenant`,
+					},
 				},
 			},
 		},
@@ -375,6 +402,62 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "split",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+						Split: &SplitConfig{
+							Percentage: 5,
+							Pipelines: []pipeline.ID{
+								pipeline.NewIDWithName(pipeline.SignalTraces, "canary"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "split without pipelines",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+						Split: &SplitConfig{
+							Percentage: 5,
+						},
+					},
+				},
+			},
+			error: "invalid route: no split pipelines defined",
+		},
+		{
+			name: "split with invalid percentage",
+			config: &Config{
+				Table: []RoutingTableItem{
+					{
+						Condition: `attributes["attr"] == "acme"`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+						Split: &SplitConfig{
+							Percentage: 101,
+							Pipelines: []pipeline.ID{
+								pipeline.NewIDWithName(pipeline.SignalTraces, "canary"),
+							},
+						},
+					},
+				},
+			},
+			error: "invalid route: the split percentage must be between 0 and 100",
+		},
 	}
 
 	for _, tt := range tests {
@@ -401,6 +484,16 @@ func withRoute(context, condition string, pipelines ...pipeline.ID) testConfigOp
 	}
 }
 
+func withSplit(percentage float64, mirror bool, pipelines ...pipeline.ID) testConfigOption {
+	return func(cfg *Config) {
+		cfg.Table[len(cfg.Table)-1].Split = &SplitConfig{
+			Percentage: percentage,
+			Pipelines:  pipelines,
+			Mirror:     mirror,
+		}
+	}
+}
+
 func withDefault(pipelines ...pipeline.ID) testConfigOption {
 	return func(cfg *Config) {
 		cfg.DefaultPipelines = pipelines
@@ -41,6 +41,7 @@ func newLogsConnector(
 
 	r, err := newRouter(
 		cfg.Table,
+		cfg.Functions,
 		cfg.DefaultPipelines,
 		lr.Consumer,
 		set.TelemetrySettings)
@@ -103,6 +104,9 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 		if errs != nil && c.config.ErrorMode == ottl.PropagateError {
 			return errs
 		}
+		if route.split != nil {
+			splitLogs(groups, route, matched)
+		}
 		groupAllLogs(groups, route.consumer, matched)
 	}
 	// anything left wasn't matched by any route. Send to default consumer
@@ -117,6 +121,27 @@ func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	return errs
 }
 
+// splitLogs moves the part of the matched logs selected by the split of the route to the split pipelines. In
+// mirror mode, the selected logs are sent to the pipelines of the route as well.
+func splitLogs(
+	groups map[consumer.Logs]plog.Logs,
+	route routingItem[consumer.Logs],
+	matched plog.Logs,
+) {
+	selected := plog.NewLogs()
+	plogutil.MoveRecordsWithContextIf(matched, selected,
+		func(rl plog.ResourceLogs, _ plog.ScopeLogs, lr plog.LogRecord) bool {
+			return route.split.selectLogRecord(rl, lr)
+		},
+	)
+	if route.split.mirror {
+		mirrored := plog.NewLogs()
+		selected.CopyTo(mirrored)
+		groupAllLogs(groups, route.consumer, mirrored)
+	}
+	groupAllLogs(groups, route.split.consumer, selected)
+}
+
 func groupAllLogs(
 	groups map[consumer.Logs]plog.Logs,
 	cons consumer.Logs,
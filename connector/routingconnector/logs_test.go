@@ -5,6 +5,7 @@ package routingconnector // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -987,3 +988,78 @@ func setLogRecordMap(lr plog.LogRecord, key, value string) plog.LogRecord {
 	lr.Body().SetEmptyMap().PutStr(key, value)
 	return lr
 }
+
+func TestLogsSplit(t *testing.T) {
+	logsDefault := pipeline.NewIDWithName(pipeline.SignalLogs, "default")
+	logs0 := pipeline.NewIDWithName(pipeline.SignalLogs, "0")
+	logs1 := pipeline.NewIDWithName(pipeline.SignalLogs, "1")
+
+	// 100 resources of 2 log records each, the log records being split by tenant
+	newInput := func() plog.Logs {
+		input := plog.NewLogs()
+		for i := range 100 {
+			rl := input.ResourceLogs().AppendEmpty()
+			rl.Resource().Attributes().PutStr("tenant", fmt.Sprintf("tenant-%d", i))
+			lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+			lrs.AppendEmpty().Body().SetStr("first")
+			lrs.AppendEmpty().Body().SetStr("second")
+		}
+		return input
+	}
+
+	logTenants := func(ld plog.Logs) map[string]int {
+		tenants := map[string]int{}
+		for _, rl := range ld.ResourceLogs().All() {
+			tenant, _ := rl.Resource().Attributes().Get("tenant")
+			tenants[tenant.Str()] += rl.ScopeLogs().At(0).LogRecords().Len()
+		}
+		return tenants
+	}
+
+	for _, mirror := range []bool{false, true} {
+		t.Run(fmt.Sprintf("mirror=%t", mirror), func(t *testing.T) {
+			cfg := testConfig(
+				withRoute("log", `body != nil`, logs0),
+				withSplit(50, mirror, logs1),
+				withDefault(logsDefault),
+			)
+			cfg.Table[0].Split.HashAttribute = "tenant"
+			require.NoError(t, cfg.Validate())
+
+			var sinkD, sink0, sink1 consumertest.LogsSink
+			conn, err := NewFactory().CreateLogsToLogs(t.Context(),
+				connectortest.NewNopSettings(metadata.Type), cfg, connector.NewLogsRouter(map[pipeline.ID]consumer.Logs{
+					logsDefault: &sinkD,
+					logs0:       &sink0,
+					logs1:       &sink1,
+				}))
+			require.NoError(t, err)
+			require.NoError(t, conn.ConsumeLogs(t.Context(), newInput()))
+
+			assert.Empty(t, sinkD.AllLogs())
+			require.Len(t, sink0.AllLogs(), 1)
+			require.Len(t, sink1.AllLogs(), 1)
+
+			// the log records of a tenant are all sent to the same pipelines
+			split := newRouteSplit(cfg.Table[0].Split, struct{}{})
+			tenants0 := logTenants(sink0.AllLogs()[0])
+			tenants1 := logTenants(sink1.AllLogs()[0])
+			assert.NotEmpty(t, tenants1)
+			for tenant, count := range tenants1 {
+				assert.True(t, split.selectKey([]byte(tenant)))
+				assert.Equal(t, 2, count)
+			}
+			for tenant, count := range tenants0 {
+				assert.Equal(t, 2, count)
+				if !mirror {
+					assert.NotContains(t, tenants1, tenant)
+				}
+			}
+			if mirror {
+				assert.Equal(t, 200, sink0.LogRecordCount())
+			} else {
+				assert.Equal(t, 200, sink0.LogRecordCount()+sink1.LogRecordCount())
+			}
+		})
+	}
+}
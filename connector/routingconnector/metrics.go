@@ -42,6 +42,7 @@ func newMetricsConnector(
 
 	r, err := newRouter(
 		cfg.Table,
+		cfg.Functions,
 		cfg.DefaultPipelines,
 		mr.Consumer,
 		set.TelemetrySettings)
@@ -118,6 +119,9 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 		if errs != nil && c.config.ErrorMode == ottl.PropagateError {
 			return errs
 		}
+		if route.split != nil {
+			splitMetrics(groups, route, matched)
+		}
 		groupAllMetrics(groups, route.consumer, matched)
 	}
 	// anything left wasn't matched by any route. Send to default consumer
@@ -132,6 +136,27 @@ func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metric
 	return errs
 }
 
+// splitMetrics moves the part of the matched metrics selected by the split of the route to the split pipelines. In
+// mirror mode, the selected metrics are sent to the pipelines of the route as well.
+func splitMetrics(
+	groups map[consumer.Metrics]pmetric.Metrics,
+	route routingItem[consumer.Metrics],
+	matched pmetric.Metrics,
+) {
+	selected := pmetric.NewMetrics()
+	pmetricutil.MoveDataPointsWithContextIf(matched, selected,
+		func(rm pmetric.ResourceMetrics, _ pmetric.ScopeMetrics, _ pmetric.Metric, dp any) bool {
+			return route.split.selectDataPoint(rm, dp)
+		},
+	)
+	if route.split.mirror {
+		mirrored := pmetric.NewMetrics()
+		selected.CopyTo(mirrored)
+		groupAllMetrics(groups, route.consumer, mirrored)
+	}
+	groupAllMetrics(groups, route.split.consumer, selected)
+}
+
 func groupAllMetrics(
 	groups map[consumer.Metrics]pmetric.Metrics,
 	cons consumer.Metrics,
@@ -5,6 +5,7 @@ package routingconnector // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -1216,3 +1217,81 @@ func TestMetricsForIgnoreError(t *testing.T) {
 	assert.Len(t, defaultSink.AllMetrics(), 1)
 	assert.Equal(t, pmetricutiltest.NewGauges("1", "2", "3", "4"), defaultSink.AllMetrics()[0])
 }
+
+func TestMetricsSplit(t *testing.T) {
+	metricsDefault := pipeline.NewIDWithName(pipeline.SignalMetrics, "default")
+	metrics0 := pipeline.NewIDWithName(pipeline.SignalMetrics, "0")
+	metrics1 := pipeline.NewIDWithName(pipeline.SignalMetrics, "1")
+
+	// 100 resources of 2 data points each, the data points being split by resource
+	newInput := func() pmetric.Metrics {
+		input := pmetric.NewMetrics()
+		for i := range 100 {
+			rm := input.ResourceMetrics().AppendEmpty()
+			rm.Resource().Attributes().PutStr("host.name", fmt.Sprintf("host-%d", i))
+			m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			m.SetName("requests")
+			dps := m.SetEmptySum().DataPoints()
+			dps.AppendEmpty().Attributes().PutStr("method", "GET")
+			dps.AppendEmpty().Attributes().PutStr("method", "POST")
+		}
+		return input
+	}
+
+	metricHosts := func(md pmetric.Metrics) map[string]int {
+		hosts := map[string]int{}
+		for _, rm := range md.ResourceMetrics().All() {
+			host, _ := rm.Resource().Attributes().Get("host.name")
+			hosts[host.Str()] += rm.ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().Len()
+		}
+		return hosts
+	}
+
+	for _, mirror := range []bool{false, true} {
+		t.Run(fmt.Sprintf("mirror=%t", mirror), func(t *testing.T) {
+			cfg := testConfig(
+				withRoute("datapoint", `metric.name == "requests"`, metrics0),
+				withSplit(50, mirror, metrics1),
+				withDefault(metricsDefault),
+			)
+			require.NoError(t, cfg.Validate())
+
+			var sinkD, sink0, sink1 consumertest.MetricsSink
+			conn, err := NewFactory().CreateMetricsToMetrics(t.Context(),
+				connectortest.NewNopSettings(metadata.Type), cfg, connector.NewMetricsRouter(map[pipeline.ID]consumer.Metrics{
+					metricsDefault: &sinkD,
+					metrics0:       &sink0,
+					metrics1:       &sink1,
+				}))
+			require.NoError(t, err)
+			require.NoError(t, conn.ConsumeMetrics(t.Context(), newInput()))
+
+			assert.Empty(t, sinkD.AllMetrics())
+			require.Len(t, sink0.AllMetrics(), 1)
+			require.Len(t, sink1.AllMetrics(), 1)
+
+			// the data points of a resource are all sent to the same pipelines
+			split := newRouteSplit(cfg.Table[0].Split, struct{}{})
+			hosts0 := metricHosts(sink0.AllMetrics()[0])
+			hosts1 := metricHosts(sink1.AllMetrics()[0])
+			assert.NotEmpty(t, hosts1)
+			for _, rm := range sink1.AllMetrics()[0].ResourceMetrics().All() {
+				assert.True(t, split.selectResource(rm.Resource()))
+			}
+			for host, count := range hosts1 {
+				assert.Equal(t, 2, count)
+				if !mirror {
+					assert.NotContains(t, hosts0, host)
+				}
+			}
+			for _, count := range hosts0 {
+				assert.Equal(t, 2, count)
+			}
+			if mirror {
+				assert.Equal(t, 200, sink0.DataPointCount())
+			} else {
+				assert.Equal(t, 200, sink0.DataPointCount()+sink1.DataPointCount())
+			}
+		})
+	}
+}
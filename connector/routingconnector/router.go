@@ -48,6 +48,7 @@ type router[C any] struct {
 // see router struct definition for the allowed types.
 func newRouter[C any](
 	table []RoutingTableItem,
+	definitions []ottl.FunctionDefinition,
 	defaultPipelineIDs []pipeline.ID,
 	provider consumerProvider[C],
 	settings component.TelemetrySettings,
@@ -59,7 +60,7 @@ func newRouter[C any](
 		consumerProvider: provider,
 	}
 
-	if err := r.buildParsers(table, settings); err != nil {
+	if err := r.buildParsers(table, definitions, settings); err != nil {
 		return nil, err
 	}
 
@@ -79,9 +80,10 @@ type routingItem[C any] struct {
 	dataPointStatement *ottl.Statement[*ottldatapoint.TransformContext]
 	logStatement       *ottl.Statement[*ottllog.TransformContext]
 	statementContext   string
+	split              *routeSplit[C]
 }
 
-func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.TelemetrySettings) error {
+func (r *router[C]) buildParsers(table []RoutingTableItem, definitions []ottl.FunctionDefinition, settings component.TelemetrySettings) error {
 	var buildResource, buildSpan, buildMetric, buildDataPoint, buildLog bool
 	for _, item := range table {
 		switch item.Context {
@@ -100,8 +102,10 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 
 	var errs error
 	if buildResource {
-		parser, err := ottlresource.NewParser(
+		parser, err := newParser(
+			ottlresource.NewParser,
 			standardFunctions[*ottlresource.TransformContext](),
+			definitions,
 			settings,
 		)
 		if err == nil {
@@ -111,8 +115,10 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		}
 	}
 	if buildSpan {
-		parser, err := ottlspan.NewParser(
+		parser, err := newParser(
+			ottlspan.NewParser,
 			spanFunctions(),
+			definitions,
 			settings,
 		)
 		if err == nil {
@@ -122,8 +128,10 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		}
 	}
 	if buildMetric {
-		parser, err := ottlmetric.NewParser(
+		parser, err := newParser(
+			ottlmetric.NewParser,
 			standardFunctions[*ottlmetric.TransformContext](),
+			definitions,
 			settings,
 		)
 		if err == nil {
@@ -133,8 +141,10 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		}
 	}
 	if buildDataPoint {
-		parser, err := ottldatapoint.NewParser(
+		parser, err := newParser(
+			ottldatapoint.NewParser,
 			standardFunctions[*ottldatapoint.TransformContext](),
+			definitions,
 			settings,
 		)
 		if err == nil {
@@ -144,8 +154,10 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		}
 	}
 	if buildLog {
-		parser, err := ottllog.NewParser(
+		parser, err := newParser(
+			ottllog.NewParser,
 			standardFunctions[*ottllog.TransformContext](),
+			definitions,
 			settings,
 		)
 		if err == nil {
@@ -157,6 +169,20 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 	return errs
 }
 
+// newParser creates a parser with the given functions and the functions declared in the configuration.
+func newParser[K any](
+	newParserFunc func(map[string]ottl.Factory[K], component.TelemetrySettings, ...ottl.Option[K]) (ottl.Parser[K], error),
+	functions map[string]ottl.Factory[K],
+	definitions []ottl.FunctionDefinition,
+	settings component.TelemetrySettings,
+) (ottl.Parser[K], error) {
+	functions, err := ottl.AddFunctionDefinitions(functions, definitions)
+	if err != nil {
+		return ottl.Parser[K]{}, err
+	}
+	return newParserFunc(functions, settings)
+}
+
 func (r *router[C]) registerConsumers(defaultPipelineIDs []pipeline.ID) error {
 	// register default pipelines
 	err := r.registerDefaultConsumer(defaultPipelineIDs)
@@ -258,6 +284,15 @@ func (r *router[C]) registerRouteConsumers() (err error) {
 			return fmt.Errorf("%w: %s", errPipelineNotFound, err.Error())
 		}
 		route.consumer = consumer
+
+		route.split = nil
+		if item.Split != nil {
+			splitConsumer, err := r.consumerProvider(item.Split.Pipelines...)
+			if err != nil {
+				return fmt.Errorf("%w: %s", errPipelineNotFound, err.Error())
+			}
+			route.split = newRouteSplit(item.Split, splitConsumer)
+		}
 		if !ok {
 			r.routeSlice = append(r.routeSlice, route)
 		}
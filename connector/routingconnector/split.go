@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"hash/fnv"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+// splitBuckets is the number of buckets the hashed keys are distributed into, which sets the precision of the split
+// percentage to 0.01.
+const splitBuckets = 10000
+
+// routeSplit selects the part of the data matching a route which is sent to the split consumer.
+type routeSplit[C any] struct {
+	consumer C
+	// threshold is the number of buckets, out of splitBuckets, which are selected
+	threshold     uint64
+	mirror        bool
+	hashAttribute string
+}
+
+func newRouteSplit[C any](cfg *SplitConfig, consumer C) *routeSplit[C] {
+	return &routeSplit[C]{
+		consumer:      consumer,
+		threshold:     uint64(math.Round(cfg.Percentage * splitBuckets / 100)),
+		mirror:        cfg.Mirror,
+		hashAttribute: cfg.HashAttribute,
+	}
+}
+
+// selectKey returns whether the data with the given key is selected.
+func (s *routeSplit[C]) selectKey(key []byte) bool {
+	h := fnv.New64a()
+	_, _ = h.Write(key)
+	return h.Sum64()%splitBuckets < s.threshold
+}
+
+// selectAttribute returns whether the data with the given attributes and resource is selected, based on the value
+// of the hash attribute.
+func (s *routeSplit[C]) selectAttribute(attrs pcommon.Map, resource pcommon.Resource) bool {
+	value, ok := attrs.Get(s.hashAttribute)
+	if !ok {
+		value, ok = resource.Attributes().Get(s.hashAttribute)
+	}
+	if !ok {
+		return false
+	}
+	return s.selectKey([]byte(value.AsString()))
+}
+
+// selectResource returns whether the data of the given resource is selected, based on its attributes.
+func (s *routeSplit[C]) selectResource(resource pcommon.Resource) bool {
+	h := pdatautil.MapHash(resource.Attributes())
+	return s.selectKey(h[:])
+}
+
+func (s *routeSplit[C]) selectSpan(rs ptrace.ResourceSpans, span ptrace.Span) bool {
+	if s.hashAttribute != "" {
+		return s.selectAttribute(span.Attributes(), rs.Resource())
+	}
+	traceID := span.TraceID()
+	return s.selectKey(traceID[:])
+}
+
+func (s *routeSplit[C]) selectLogRecord(rl plog.ResourceLogs, lr plog.LogRecord) bool {
+	if s.hashAttribute != "" {
+		return s.selectAttribute(lr.Attributes(), rl.Resource())
+	}
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		return s.selectKey(traceID[:])
+	}
+	return s.selectResource(rl.Resource())
+}
+
+func (s *routeSplit[C]) selectDataPoint(rm pmetric.ResourceMetrics, dp any) bool {
+	if s.hashAttribute != "" {
+		return s.selectAttribute(dataPointAttributes(dp), rm.Resource())
+	}
+	return s.selectResource(rm.Resource())
+}
+
+func dataPointAttributes(dp any) pcommon.Map {
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		return dp.Attributes()
+	case pmetric.HistogramDataPoint:
+		return dp.Attributes()
+	case pmetric.ExponentialHistogramDataPoint:
+		return dp.Attributes()
+	case pmetric.SummaryDataPoint:
+		return dp.Attributes()
+	}
+	return pcommon.NewMap()
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routingconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector"
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestRouteSplitPercentage(t *testing.T) {
+	for _, tt := range []struct {
+		percentage float64
+		minimum    int
+		maximum    int
+	}{
+		{percentage: 0, minimum: 0, maximum: 0},
+		{percentage: 5, minimum: 400, maximum: 600},
+		{percentage: 50, minimum: 4800, maximum: 5200},
+		{percentage: 100, minimum: 10000, maximum: 10000},
+	} {
+		t.Run(fmt.Sprint(tt.percentage), func(t *testing.T) {
+			split := newRouteSplit(&SplitConfig{Percentage: tt.percentage}, struct{}{})
+			selected := 0
+			for i := range 10000 {
+				if split.selectKey(fmt.Appendf(nil, "key-%d", i)) {
+					selected++
+				}
+			}
+			assert.GreaterOrEqual(t, selected, tt.minimum)
+			assert.LessOrEqual(t, selected, tt.maximum)
+		})
+	}
+}
+
+func TestRouteSplitThreshold(t *testing.T) {
+	assert.Equal(t, uint64(1), newRouteSplit(&SplitConfig{Percentage: 0.01}, struct{}{}).threshold)
+	assert.Equal(t, uint64(500), newRouteSplit(&SplitConfig{Percentage: 5}, struct{}{}).threshold)
+	assert.Equal(t, uint64(splitBuckets), newRouteSplit(&SplitConfig{Percentage: 100}, struct{}{}).threshold)
+}
+
+// findKey returns a key of the given format which is selected by the split or not.
+func findKey(split *routeSplit[struct{}], format string, selected bool) string {
+	for i := 0; ; i++ {
+		key := fmt.Sprintf(format, i)
+		if split.selectKey([]byte(key)) == selected {
+			return key
+		}
+	}
+}
+
+func TestRouteSplitSpan(t *testing.T) {
+	split := newRouteSplit(&SplitConfig{Percentage: 50}, struct{}{})
+	rs := ptrace.NewResourceSpans()
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+
+	// the spans of a trace are all selected or not
+	var selected, notSelected int
+	for i := range 100 {
+		traceID := pcommon.TraceID([16]byte{byte(i), 1, 2, 3})
+		span.SetTraceID(traceID)
+		isSelected := split.selectSpan(rs, span)
+		for j := range 10 {
+			span.SetSpanID(pcommon.SpanID([8]byte{byte(j)}))
+			span.Attributes().PutInt("index", int64(j))
+			assert.Equal(t, isSelected, split.selectSpan(rs, span))
+		}
+		if isSelected {
+			selected++
+		} else {
+			notSelected++
+		}
+	}
+	assert.Positive(t, selected)
+	assert.Positive(t, notSelected)
+}
+
+func TestRouteSplitHashAttribute(t *testing.T) {
+	split := newRouteSplit(&SplitConfig{Percentage: 50, HashAttribute: "tenant"}, struct{}{})
+	selectedTenant := findKey(split, "tenant-%d", true)
+	notSelectedTenant := findKey(split, "tenant-%d", false)
+
+	rs := ptrace.NewResourceSpans()
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+
+	// the attribute is missing
+	assert.False(t, split.selectSpan(rs, span))
+
+	// the attribute is looked up in the resource attributes
+	rs.Resource().Attributes().PutStr("tenant", selectedTenant)
+	assert.True(t, split.selectSpan(rs, span))
+
+	// the attribute of the span takes precedence over the resource attribute
+	span.Attributes().PutStr("tenant", notSelectedTenant)
+	assert.False(t, split.selectSpan(rs, span))
+
+	rl := plog.NewResourceLogs()
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutStr("tenant", selectedTenant)
+	lr.SetTraceID(pcommon.TraceID([16]byte{1}))
+	assert.True(t, split.selectLogRecord(rl, lr))
+
+	rm := pmetric.NewResourceMetrics()
+	rm.Resource().Attributes().PutStr("tenant", notSelectedTenant)
+	dps := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyHistogram().DataPoints()
+	dp := dps.AppendEmpty()
+	assert.False(t, split.selectDataPoint(rm, dp))
+	dp.Attributes().PutStr("tenant", selectedTenant)
+	assert.True(t, split.selectDataPoint(rm, dp))
+}
+
+func TestRouteSplitResource(t *testing.T) {
+	split := newRouteSplit(&SplitConfig{Percentage: 50}, struct{}{})
+
+	var selected, notSelected int
+	for i := range 100 {
+		rl := plog.NewResourceLogs()
+		rl.Resource().Attributes().PutStr("service.name", fmt.Sprintf("service-%d", i))
+		lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+		rm := pmetric.NewResourceMetrics()
+		rl.Resource().CopyTo(rm.Resource())
+		gauge := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge()
+
+		// the log records without trace ID and the data points of a resource are all selected or not
+		isSelected := split.selectLogRecord(rl, lrs.AppendEmpty())
+		for j := range 10 {
+			lr := lrs.AppendEmpty()
+			lr.Attributes().PutInt("index", int64(j))
+			assert.Equal(t, isSelected, split.selectLogRecord(rl, lr))
+			dp := gauge.DataPoints().AppendEmpty()
+			dp.Attributes().PutInt("index", int64(j))
+			assert.Equal(t, isSelected, split.selectDataPoint(rm, dp))
+		}
+		if isSelected {
+			selected++
+		} else {
+			notSelected++
+		}
+	}
+	assert.Positive(t, selected)
+	assert.Positive(t, notSelected)
+}
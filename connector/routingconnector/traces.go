@@ -41,6 +41,7 @@ func newTracesConnector(
 
 	r, err := newRouter(
 		cfg.Table,
+		cfg.Functions,
 		cfg.DefaultPipelines,
 		tr.Consumer,
 		set.TelemetrySettings)
@@ -103,6 +104,9 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 		if errs != nil && c.config.ErrorMode == ottl.PropagateError {
 			return errs
 		}
+		if route.split != nil {
+			splitTraces(groups, route, matched)
+		}
 		groupAllTraces(groups, route.consumer, matched)
 	}
 	// anything left wasn't matched by any route. Send to default consumer
@@ -117,6 +121,27 @@ func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) e
 	return errs
 }
 
+// splitTraces moves the part of the matched traces selected by the split of the route to the split pipelines. In
+// mirror mode, the selected traces are sent to the pipelines of the route as well.
+func splitTraces(
+	groups map[consumer.Traces]ptrace.Traces,
+	route routingItem[consumer.Traces],
+	matched ptrace.Traces,
+) {
+	selected := ptrace.NewTraces()
+	ptraceutil.MoveSpansWithContextIf(matched, selected,
+		func(rs ptrace.ResourceSpans, _ ptrace.ScopeSpans, s ptrace.Span) bool {
+			return route.split.selectSpan(rs, s)
+		},
+	)
+	if route.split.mirror {
+		mirrored := ptrace.NewTraces()
+		selected.CopyTo(mirrored)
+		groupAllTraces(groups, route.consumer, mirrored)
+	}
+	groupAllTraces(groups, route.split.consumer, selected)
+}
+
 func groupAllTraces(
 	groups map[consumer.Traces]ptrace.Traces,
 	cons consumer.Traces,
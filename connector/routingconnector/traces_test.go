@@ -5,6 +5,7 @@ package routingconnector // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pipeline"
 
@@ -310,6 +312,70 @@ func TestTracesCorrectlyMatchOnceWithOTTL(t *testing.T) {
 	})
 }
 
+func TestTracesRouteWithFunctionDefinitions(t *testing.T) {
+	tracesDefault := pipeline.NewIDWithName(pipeline.SignalTraces, "default")
+	traces0 := pipeline.NewIDWithName(pipeline.SignalTraces, "0")
+
+	cfg := &Config{
+		DefaultPipelines: []pipeline.ID{tracesDefault},
+		Functions: []ottl.FunctionDefinition{
+			{
+				Name:      "IsBetween",
+				Params:    []string{"value", "low", "high"},
+				Condition: `value >= low and value <= high`,
+			},
+		},
+		Table: []RoutingTableItem{
+			{
+				Condition: `IsBetween(attributes["value"], 1, 3)`,
+				Pipelines: []pipeline.ID{traces0},
+			},
+		},
+	}
+
+	var defaultSink, sink0 consumertest.TracesSink
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesDefault: &defaultSink,
+		traces0:       &sink0,
+	})
+
+	factory := NewFactory()
+	conn, err := factory.CreateTracesToTraces(
+		t.Context(),
+		connectortest.NewNopSettings(metadata.Type),
+		cfg,
+		router.(consumer.Traces),
+	)
+	require.NoError(t, err)
+
+	tr := ptrace.NewTraces()
+	for _, value := range []int64{2, 5} {
+		rl := tr.ResourceSpans().AppendEmpty()
+		rl.Resource().Attributes().PutInt("value", value)
+		rl.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	}
+
+	require.NoError(t, conn.ConsumeTraces(t.Context(), tr))
+
+	require.Len(t, sink0.AllTraces(), 1)
+	v, ok := sink0.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Get("value")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), v.Int())
+	require.Len(t, defaultSink.AllTraces(), 1)
+	v, ok = defaultSink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Get("value")
+	require.True(t, ok)
+	assert.Equal(t, int64(5), v.Int())
+
+	cfg.Functions = append(cfg.Functions, ottl.FunctionDefinition{Name: "IsMatch", Params: []string{"value"}, Condition: `value != nil`})
+	_, err = factory.CreateTracesToTraces(
+		t.Context(),
+		connectortest.NewNopSettings(metadata.Type),
+		cfg,
+		router.(consumer.Traces),
+	)
+	assert.EqualError(t, err, `function "IsMatch" is already defined`)
+}
+
 func TestTracesResourceAttributeDroppedByOTTL(t *testing.T) {
 	tracesDefault := pipeline.NewIDWithName(pipeline.SignalTraces, "default")
 	tracesOther := pipeline.NewIDWithName(pipeline.SignalTraces, "other")
@@ -966,3 +1032,83 @@ func TestTracesForIgnoreError(t *testing.T) {
 	assert.Len(t, defaultSink.AllTraces(), 1)
 	assert.Equal(t, ptraceutiltest.NewTraces("1", "2", "3", "4"), defaultSink.AllTraces()[0])
 }
+
+func TestTracesSplit(t *testing.T) {
+	tracesDefault := pipeline.NewIDWithName(pipeline.SignalTraces, "default")
+	traces0 := pipeline.NewIDWithName(pipeline.SignalTraces, "0")
+	traces1 := pipeline.NewIDWithName(pipeline.SignalTraces, "1")
+
+	// 100 traces of 2 spans each, spread over 2 resources
+	newInput := func() ptrace.Traces {
+		input := ptrace.NewTraces()
+		for i := range 2 {
+			rs := input.ResourceSpans().AppendEmpty()
+			rs.Resource().Attributes().PutInt("index", int64(i))
+			spans := rs.ScopeSpans().AppendEmpty().Spans()
+			for j := range 100 {
+				span := spans.AppendEmpty()
+				span.SetTraceID(pcommon.TraceID([16]byte{byte(j)}))
+				span.SetSpanID(pcommon.SpanID([8]byte{byte(i), byte(j)}))
+			}
+		}
+		return input
+	}
+
+	spanTraceIDs := func(td ptrace.Traces) map[pcommon.TraceID]int {
+		traceIDs := map[pcommon.TraceID]int{}
+		for _, rs := range td.ResourceSpans().All() {
+			for _, ss := range rs.ScopeSpans().All() {
+				for _, span := range ss.Spans().All() {
+					traceIDs[span.TraceID()]++
+				}
+			}
+		}
+		return traceIDs
+	}
+
+	for _, mirror := range []bool{false, true} {
+		t.Run(fmt.Sprintf("mirror=%t", mirror), func(t *testing.T) {
+			cfg := testConfig(
+				withRoute("span", "true", traces0),
+				withSplit(50, mirror, traces1),
+				withDefault(tracesDefault),
+			)
+			require.NoError(t, cfg.Validate())
+
+			var sinkD, sink0, sink1 consumertest.TracesSink
+			conn, err := NewFactory().CreateTracesToTraces(t.Context(),
+				connectortest.NewNopSettings(metadata.Type), cfg, connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+					tracesDefault: &sinkD,
+					traces0:       &sink0,
+					traces1:       &sink1,
+				}))
+			require.NoError(t, err)
+			require.NoError(t, conn.ConsumeTraces(t.Context(), newInput()))
+
+			assert.Empty(t, sinkD.AllTraces())
+			require.Len(t, sink0.AllTraces(), 1)
+			require.Len(t, sink1.AllTraces(), 1)
+
+			// the spans of a trace are all sent to the same pipelines
+			split := newRouteSplit(cfg.Table[0].Split, struct{}{})
+			traceIDs0 := spanTraceIDs(sink0.AllTraces()[0])
+			traceIDs1 := spanTraceIDs(sink1.AllTraces()[0])
+			assert.NotEmpty(t, traceIDs1)
+			for traceID, count := range traceIDs1 {
+				assert.True(t, split.selectKey(traceID[:]))
+				assert.Equal(t, 2, count)
+			}
+			for traceID, count := range traceIDs0 {
+				assert.Equal(t, 2, count)
+				if !mirror {
+					assert.NotContains(t, traceIDs1, traceID)
+				}
+			}
+			if mirror {
+				assert.Equal(t, 200, sink0.SpanCount())
+			} else {
+				assert.Equal(t, 200, sink0.SpanCount()+sink1.SpanCount())
+			}
+		})
+	}
+}
@@ -0,0 +1,525 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// The columnar formats use a flattened OTLP schema: every row holds one log
+// record, span or metric data point, together with the attributes of its
+// resource and scope. Attribute values are stored as strings; maps and slices
+// are stored as their JSON representation.
+
+var (
+	attributesType = arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String)
+	timestampType  = arrow.FixedWidthTypes.Timestamp_ns
+)
+
+var resourceScopeFields = []arrow.Field{
+	{Name: "resource_attributes", Type: attributesType},
+	{Name: "resource_schema_url", Type: arrow.BinaryTypes.String},
+	{Name: "scope_name", Type: arrow.BinaryTypes.String},
+	{Name: "scope_version", Type: arrow.BinaryTypes.String},
+	{Name: "scope_attributes", Type: attributesType},
+}
+
+var logsSchema = arrow.NewSchema(append(append([]arrow.Field{}, resourceScopeFields...),
+	arrow.Field{Name: "time", Type: timestampType},
+	arrow.Field{Name: "observed_time", Type: timestampType},
+	arrow.Field{Name: "severity_number", Type: arrow.PrimitiveTypes.Int32},
+	arrow.Field{Name: "severity_text", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "body", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+	arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+	arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "event_name", Type: arrow.BinaryTypes.String},
+), nil)
+
+var spanEventType = arrow.StructOf(
+	arrow.Field{Name: "time", Type: timestampType},
+	arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "attributes", Type: attributesType},
+)
+
+var spanLinkType = arrow.StructOf(
+	arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "attributes", Type: attributesType},
+)
+
+var tracesSchema = arrow.NewSchema(append(append([]arrow.Field{}, resourceScopeFields...),
+	arrow.Field{Name: "trace_id", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "span_id", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "parent_span_id", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "kind", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "start_time", Type: timestampType},
+	arrow.Field{Name: "end_time", Type: timestampType},
+	arrow.Field{Name: "duration_ns", Type: arrow.PrimitiveTypes.Int64},
+	arrow.Field{Name: "status_code", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "status_message", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+	arrow.Field{Name: "events", Type: arrow.ListOf(spanEventType)},
+	arrow.Field{Name: "dropped_events_count", Type: arrow.PrimitiveTypes.Uint32},
+	arrow.Field{Name: "links", Type: arrow.ListOf(spanLinkType)},
+	arrow.Field{Name: "dropped_links_count", Type: arrow.PrimitiveTypes.Uint32},
+), nil)
+
+var metricsSchema = arrow.NewSchema(append(append([]arrow.Field{}, resourceScopeFields...),
+	arrow.Field{Name: "metric_name", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "metric_description", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "metric_unit", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "metric_type", Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: "aggregation_temporality", Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: "is_monotonic", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+	arrow.Field{Name: "start_time", Type: timestampType},
+	arrow.Field{Name: "time", Type: timestampType},
+	arrow.Field{Name: "attributes", Type: attributesType},
+	arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+	arrow.Field{Name: "value_double", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "value_int", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	arrow.Field{Name: "count", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+	arrow.Field{Name: "sum", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "min", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "max", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: "bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+	arrow.Field{Name: "explicit_bounds", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64), Nullable: true},
+	arrow.Field{Name: "scale", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	arrow.Field{Name: "zero_count", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+	arrow.Field{Name: "positive_offset", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	arrow.Field{Name: "positive_bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+	arrow.Field{Name: "negative_offset", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	arrow.Field{Name: "negative_bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64), Nullable: true},
+	arrow.Field{Name: "quantiles", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64), Nullable: true},
+	arrow.Field{Name: "quantile_values", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64), Nullable: true},
+), nil)
+
+// fieldCursor hands out the field builders of a record builder in schema order.
+type fieldCursor struct {
+	builder *array.RecordBuilder
+	idx     int
+}
+
+func (c *fieldCursor) next() array.Builder {
+	b := c.builder.Field(c.idx)
+	c.idx++
+	return b
+}
+
+func (c *fieldCursor) str() *array.StringBuilder      { return c.next().(*array.StringBuilder) }
+func (c *fieldCursor) int32() *array.Int32Builder     { return c.next().(*array.Int32Builder) }
+func (c *fieldCursor) int64() *array.Int64Builder     { return c.next().(*array.Int64Builder) }
+func (c *fieldCursor) uint32() *array.Uint32Builder   { return c.next().(*array.Uint32Builder) }
+func (c *fieldCursor) uint64() *array.Uint64Builder   { return c.next().(*array.Uint64Builder) }
+func (c *fieldCursor) float64() *array.Float64Builder { return c.next().(*array.Float64Builder) }
+func (c *fieldCursor) bool() *array.BooleanBuilder    { return c.next().(*array.BooleanBuilder) }
+func (c *fieldCursor) timestamp() *array.TimestampBuilder {
+	return c.next().(*array.TimestampBuilder)
+}
+func (c *fieldCursor) attributes() *array.MapBuilder { return c.next().(*array.MapBuilder) }
+func (c *fieldCursor) list() *array.ListBuilder      { return c.next().(*array.ListBuilder) }
+
+type resourceScopeBuilders struct {
+	resourceAttributes *array.MapBuilder
+	resourceSchemaURL  *array.StringBuilder
+	scopeName          *array.StringBuilder
+	scopeVersion       *array.StringBuilder
+	scopeAttributes    *array.MapBuilder
+}
+
+func newResourceScopeBuilders(c *fieldCursor) resourceScopeBuilders {
+	return resourceScopeBuilders{
+		resourceAttributes: c.attributes(),
+		resourceSchemaURL:  c.str(),
+		scopeName:          c.str(),
+		scopeVersion:       c.str(),
+		scopeAttributes:    c.attributes(),
+	}
+}
+
+func (b resourceScopeBuilders) append(resource pcommon.Resource, schemaURL string, scope pcommon.InstrumentationScope) {
+	appendAttributes(b.resourceAttributes, resource.Attributes())
+	b.resourceSchemaURL.Append(schemaURL)
+	b.scopeName.Append(scope.Name())
+	b.scopeVersion.Append(scope.Version())
+	appendAttributes(b.scopeAttributes, scope.Attributes())
+}
+
+func appendAttributes(b *array.MapBuilder, attrs pcommon.Map) {
+	b.Append(true)
+	keys := b.KeyBuilder().(*array.StringBuilder)
+	values := b.ItemBuilder().(*array.StringBuilder)
+	for k, v := range attrs.All() {
+		keys.Append(k)
+		values.Append(v.AsString())
+	}
+}
+
+func appendTimestamp(b *array.TimestampBuilder, ts pcommon.Timestamp) {
+	b.Append(arrow.Timestamp(ts))
+}
+
+func appendTraceID(b *array.StringBuilder, id pcommon.TraceID) {
+	if id.IsEmpty() {
+		b.Append("")
+		return
+	}
+	b.Append(id.String())
+}
+
+func appendSpanID(b *array.StringBuilder, id pcommon.SpanID) {
+	if id.IsEmpty() {
+		b.Append("")
+		return
+	}
+	b.Append(id.String())
+}
+
+func appendUint64List(b *array.ListBuilder, values []uint64) {
+	b.Append(true)
+	b.ValueBuilder().(*array.Uint64Builder).AppendValues(values, nil)
+}
+
+func appendFloat64List(b *array.ListBuilder, values []float64) {
+	b.Append(true)
+	b.ValueBuilder().(*array.Float64Builder).AppendValues(values, nil)
+}
+
+func appendLogs(rb *array.RecordBuilder, ld plog.Logs) {
+	c := &fieldCursor{builder: rb}
+	common := newResourceScopeBuilders(c)
+	var (
+		timestamp         = c.timestamp()
+		observedTimestamp = c.timestamp()
+		severityNumber    = c.int32()
+		severityText      = c.str()
+		body              = c.str()
+		attributes        = c.attributes()
+		droppedAttributes = c.uint32()
+		flags             = c.uint32()
+		traceID           = c.str()
+		spanID            = c.str()
+		eventName         = c.str()
+	)
+
+	for _, rl := range ld.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				common.append(rl.Resource(), rl.SchemaUrl(), sl.Scope())
+				appendTimestamp(timestamp, lr.Timestamp())
+				appendTimestamp(observedTimestamp, lr.ObservedTimestamp())
+				severityNumber.Append(int32(lr.SeverityNumber()))
+				severityText.Append(lr.SeverityText())
+				body.Append(lr.Body().AsString())
+				appendAttributes(attributes, lr.Attributes())
+				droppedAttributes.Append(lr.DroppedAttributesCount())
+				flags.Append(uint32(lr.Flags()))
+				appendTraceID(traceID, lr.TraceID())
+				appendSpanID(spanID, lr.SpanID())
+				eventName.Append(lr.EventName())
+			}
+		}
+	}
+}
+
+func appendTraces(rb *array.RecordBuilder, td ptrace.Traces) {
+	c := &fieldCursor{builder: rb}
+	common := newResourceScopeBuilders(c)
+	var (
+		traceID           = c.str()
+		spanID            = c.str()
+		parentSpanID      = c.str()
+		traceState        = c.str()
+		name              = c.str()
+		kind              = c.str()
+		startTime         = c.timestamp()
+		endTime           = c.timestamp()
+		duration          = c.int64()
+		statusCode        = c.str()
+		statusMessage     = c.str()
+		attributes        = c.attributes()
+		droppedAttributes = c.uint32()
+		events            = c.list()
+		droppedEvents     = c.uint32()
+		links             = c.list()
+		droppedLinks      = c.uint32()
+	)
+
+	eventBuilder := events.ValueBuilder().(*array.StructBuilder)
+	eventTime := eventBuilder.FieldBuilder(0).(*array.TimestampBuilder)
+	eventName := eventBuilder.FieldBuilder(1).(*array.StringBuilder)
+	eventAttributes := eventBuilder.FieldBuilder(2).(*array.MapBuilder)
+
+	linkBuilder := links.ValueBuilder().(*array.StructBuilder)
+	linkTraceID := linkBuilder.FieldBuilder(0).(*array.StringBuilder)
+	linkSpanID := linkBuilder.FieldBuilder(1).(*array.StringBuilder)
+	linkTraceState := linkBuilder.FieldBuilder(2).(*array.StringBuilder)
+	linkAttributes := linkBuilder.FieldBuilder(3).(*array.MapBuilder)
+
+	for _, rs := range td.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				common.append(rs.Resource(), rs.SchemaUrl(), ss.Scope())
+				appendTraceID(traceID, span.TraceID())
+				appendSpanID(spanID, span.SpanID())
+				appendSpanID(parentSpanID, span.ParentSpanID())
+				traceState.Append(span.TraceState().AsRaw())
+				name.Append(span.Name())
+				kind.Append(span.Kind().String())
+				appendTimestamp(startTime, span.StartTimestamp())
+				appendTimestamp(endTime, span.EndTimestamp())
+				duration.Append(int64(span.EndTimestamp()) - int64(span.StartTimestamp()))
+				statusCode.Append(span.Status().Code().String())
+				statusMessage.Append(span.Status().Message())
+				appendAttributes(attributes, span.Attributes())
+				droppedAttributes.Append(span.DroppedAttributesCount())
+
+				events.Append(true)
+				for _, event := range span.Events().All() {
+					eventBuilder.Append(true)
+					appendTimestamp(eventTime, event.Timestamp())
+					eventName.Append(event.Name())
+					appendAttributes(eventAttributes, event.Attributes())
+				}
+				droppedEvents.Append(span.DroppedEventsCount())
+
+				links.Append(true)
+				for _, link := range span.Links().All() {
+					linkBuilder.Append(true)
+					appendTraceID(linkTraceID, link.TraceID())
+					appendSpanID(linkSpanID, link.SpanID())
+					linkTraceState.Append(link.TraceState().AsRaw())
+					appendAttributes(linkAttributes, link.Attributes())
+				}
+				droppedLinks.Append(span.DroppedLinksCount())
+			}
+		}
+	}
+}
+
+// metricsBuilders holds the builders of the metrics schema.
+type metricsBuilders struct {
+	common                 resourceScopeBuilders
+	name                   *array.StringBuilder
+	description            *array.StringBuilder
+	unit                   *array.StringBuilder
+	metricType             *array.StringBuilder
+	temporality            *array.StringBuilder
+	isMonotonic            *array.BooleanBuilder
+	startTime              *array.TimestampBuilder
+	time                   *array.TimestampBuilder
+	attributes             *array.MapBuilder
+	flags                  *array.Uint32Builder
+	valueDouble            *array.Float64Builder
+	valueInt               *array.Int64Builder
+	count                  *array.Uint64Builder
+	sum                    *array.Float64Builder
+	minimum                *array.Float64Builder
+	maximum                *array.Float64Builder
+	bucketCounts           *array.ListBuilder
+	explicitBounds         *array.ListBuilder
+	scale                  *array.Int32Builder
+	zeroCount              *array.Uint64Builder
+	positiveOffset         *array.Int32Builder
+	positiveBucketCounts   *array.ListBuilder
+	negativeOffset         *array.Int32Builder
+	negativeBucketCounts   *array.ListBuilder
+	quantiles              *array.ListBuilder
+	quantileValues         *array.ListBuilder
+	resource               pcommon.Resource
+	resourceSchemaURL      string
+	scope                  pcommon.InstrumentationScope
+	metric                 pmetric.Metric
+	aggregationTemporality pmetric.AggregationTemporality
+	monotonic              *bool
+}
+
+func newMetricsBuilders(rb *array.RecordBuilder) *metricsBuilders {
+	c := &fieldCursor{builder: rb}
+	return &metricsBuilders{
+		common:               newResourceScopeBuilders(c),
+		name:                 c.str(),
+		description:          c.str(),
+		unit:                 c.str(),
+		metricType:           c.str(),
+		temporality:          c.str(),
+		isMonotonic:          c.bool(),
+		startTime:            c.timestamp(),
+		time:                 c.timestamp(),
+		attributes:           c.attributes(),
+		flags:                c.uint32(),
+		valueDouble:          c.float64(),
+		valueInt:             c.int64(),
+		count:                c.uint64(),
+		sum:                  c.float64(),
+		minimum:              c.float64(),
+		maximum:              c.float64(),
+		bucketCounts:         c.list(),
+		explicitBounds:       c.list(),
+		scale:                c.int32(),
+		zeroCount:            c.uint64(),
+		positiveOffset:       c.int32(),
+		positiveBucketCounts: c.list(),
+		negativeOffset:       c.int32(),
+		negativeBucketCounts: c.list(),
+		quantiles:            c.list(),
+		quantileValues:       c.list(),
+	}
+}
+
+// appendPoint appends the columns shared by all data point types and nulls
+// for all type-specific columns. Callers overwrite the relevant columns by
+// appending them before calling appendPoint.
+func (b *metricsBuilders) appendPoint(startTime, ts pcommon.Timestamp, attrs pcommon.Map, flags pmetric.DataPointFlags) {
+	b.common.append(b.resource, b.resourceSchemaURL, b.scope)
+	b.name.Append(b.metric.Name())
+	b.description.Append(b.metric.Description())
+	b.unit.Append(b.metric.Unit())
+	b.metricType.Append(b.metric.Type().String())
+	if b.aggregationTemporality == pmetric.AggregationTemporalityUnspecified {
+		b.temporality.AppendNull()
+	} else {
+		b.temporality.Append(b.aggregationTemporality.String())
+	}
+	if b.monotonic == nil {
+		b.isMonotonic.AppendNull()
+	} else {
+		b.isMonotonic.Append(*b.monotonic)
+	}
+	appendTimestamp(b.startTime, startTime)
+	appendTimestamp(b.time, ts)
+	appendAttributes(b.attributes, attrs)
+	b.flags.Append(uint32(flags))
+}
+
+// padNulls appends nulls to all type-specific builders that are shorter than the row count.
+func (b *metricsBuilders) padNulls() {
+	rows := b.name.Len()
+	for _, builder := range []array.Builder{
+		b.valueDouble, b.valueInt, b.count, b.sum, b.minimum, b.maximum,
+		b.bucketCounts, b.explicitBounds, b.scale, b.zeroCount,
+		b.positiveOffset, b.positiveBucketCounts, b.negativeOffset, b.negativeBucketCounts,
+		b.quantiles, b.quantileValues,
+	} {
+		for builder.Len() < rows {
+			builder.AppendNull()
+		}
+	}
+}
+
+func (b *metricsBuilders) appendNumberDataPoints(dps pmetric.NumberDataPointSlice) {
+	for _, dp := range dps.All() {
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeDouble:
+			b.valueDouble.Append(dp.DoubleValue())
+		case pmetric.NumberDataPointValueTypeInt:
+			b.valueInt.Append(dp.IntValue())
+		}
+		b.appendPoint(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+		b.padNulls()
+	}
+}
+
+func (b *metricsBuilders) appendHistogramDataPoints(dps pmetric.HistogramDataPointSlice) {
+	for _, dp := range dps.All() {
+		b.count.Append(dp.Count())
+		if dp.HasSum() {
+			b.sum.Append(dp.Sum())
+		}
+		if dp.HasMin() {
+			b.minimum.Append(dp.Min())
+		}
+		if dp.HasMax() {
+			b.maximum.Append(dp.Max())
+		}
+		appendUint64List(b.bucketCounts, dp.BucketCounts().AsRaw())
+		appendFloat64List(b.explicitBounds, dp.ExplicitBounds().AsRaw())
+		b.appendPoint(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+		b.padNulls()
+	}
+}
+
+func (b *metricsBuilders) appendExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice) {
+	for _, dp := range dps.All() {
+		b.count.Append(dp.Count())
+		if dp.HasSum() {
+			b.sum.Append(dp.Sum())
+		}
+		if dp.HasMin() {
+			b.minimum.Append(dp.Min())
+		}
+		if dp.HasMax() {
+			b.maximum.Append(dp.Max())
+		}
+		b.scale.Append(dp.Scale())
+		b.zeroCount.Append(dp.ZeroCount())
+		b.positiveOffset.Append(dp.Positive().Offset())
+		appendUint64List(b.positiveBucketCounts, dp.Positive().BucketCounts().AsRaw())
+		b.negativeOffset.Append(dp.Negative().Offset())
+		appendUint64List(b.negativeBucketCounts, dp.Negative().BucketCounts().AsRaw())
+		b.appendPoint(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+		b.padNulls()
+	}
+}
+
+func (b *metricsBuilders) appendSummaryDataPoints(dps pmetric.SummaryDataPointSlice) {
+	for _, dp := range dps.All() {
+		b.count.Append(dp.Count())
+		b.sum.Append(dp.Sum())
+		quantiles := make([]float64, 0, dp.QuantileValues().Len())
+		values := make([]float64, 0, dp.QuantileValues().Len())
+		for _, qv := range dp.QuantileValues().All() {
+			quantiles = append(quantiles, qv.Quantile())
+			values = append(values, qv.Value())
+		}
+		appendFloat64List(b.quantiles, quantiles)
+		appendFloat64List(b.quantileValues, values)
+		b.appendPoint(dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+		b.padNulls()
+	}
+}
+
+func appendMetrics(rb *array.RecordBuilder, md pmetric.Metrics) {
+	b := newMetricsBuilders(rb)
+	for _, rm := range md.ResourceMetrics().All() {
+		b.resource = rm.Resource()
+		b.resourceSchemaURL = rm.SchemaUrl()
+		for _, sm := range rm.ScopeMetrics().All() {
+			b.scope = sm.Scope()
+			for _, metric := range sm.Metrics().All() {
+				b.metric = metric
+				b.aggregationTemporality = pmetric.AggregationTemporalityUnspecified
+				b.monotonic = nil
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					b.appendNumberDataPoints(metric.Gauge().DataPoints())
+				case pmetric.MetricTypeSum:
+					monotonic := metric.Sum().IsMonotonic()
+					b.aggregationTemporality = metric.Sum().AggregationTemporality()
+					b.monotonic = &monotonic
+					b.appendNumberDataPoints(metric.Sum().DataPoints())
+				case pmetric.MetricTypeHistogram:
+					b.aggregationTemporality = metric.Histogram().AggregationTemporality()
+					b.appendHistogramDataPoints(metric.Histogram().DataPoints())
+				case pmetric.MetricTypeExponentialHistogram:
+					b.aggregationTemporality = metric.ExponentialHistogram().AggregationTemporality()
+					b.appendExponentialHistogramDataPoints(metric.ExponentialHistogram().DataPoints())
+				case pmetric.MetricTypeSummary:
+					b.appendSummaryDataPoints(metric.Summary().DataPoints())
+				}
+			}
+		}
+	}
+}
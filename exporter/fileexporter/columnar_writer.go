@@ -0,0 +1,441 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxBufferedRows is the number of rows after which buffered rows are
+	// written out as a row group (Parquet) or record batch (Arrow IPC),
+	// regardless of the flush interval.
+	maxBufferedRows = 64 * 1024
+
+	// maxPendingRows is the number of rows which couldn't be written, after which
+	// new rows are refused until the pending rows are written.
+	maxPendingRows = 4 * maxBufferedRows
+
+	// backupTimeFormat matches the format used by lumberjack for rotated files.
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+
+	// defaultMaxMegabytes matches the lumberjack default file size.
+	defaultMaxMegabytes = 100
+
+	megabyte = 1024 * 1024
+)
+
+var (
+	errColumnarProfiles = errors.New("profiles are not supported by columnar formats")
+	errPendingRows      = errors.New("too many rows couldn't be written yet")
+)
+
+type signal string
+
+const (
+	signalTraces  signal = "traces"
+	signalMetrics signal = "metrics"
+	signalLogs    signal = "logs"
+)
+
+func isColumnarFormat(formatType string) bool {
+	return formatType == formatTypeParquet || formatType == formatTypeArrow
+}
+
+// recordWriter writes record batches to a columnar file. Close must be called
+// to write the file footer.
+type recordWriter interface {
+	Write(rec arrow.RecordBatch) error
+	Close() error
+}
+
+func newRecordWriter(formatType, compression string, schema *arrow.Schema, w io.Writer) (recordWriter, error) {
+	switch formatType {
+	case formatTypeParquet:
+		codec := compress.Codecs.Uncompressed
+		if compression == compressionZSTD {
+			codec = compress.Codecs.Zstd
+		}
+		props := parquet.NewWriterProperties(parquet.WithCompression(codec))
+		return pqarrow.NewFileWriter(schema, w, props, pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()))
+	case formatTypeArrow:
+		opts := []ipc.Option{ipc.WithSchema(schema)}
+		if compression == compressionZSTD {
+			opts = append(opts, ipc.WithZstd())
+		}
+		return ipc.NewFileWriter(w, opts...)
+	default:
+		return nil, fmt.Errorf("format %q is not a columnar format", formatType)
+	}
+}
+
+// columnarWriter writes telemetry in a columnar format. Every signal is
+// written to its own file, because each signal has its own schema. Rows are
+// buffered in memory and written as a row group on every flush, before a file
+// is rotated and on shutdown. Rows which couldn't be written are kept, and
+// written again by the next flush.
+type columnarWriter struct {
+	path        string
+	formatType  string
+	compression string
+	rotation    *Rotation
+	logger      *zap.Logger
+
+	mutex sync.Mutex
+	files map[signal]*columnarFile
+
+	flushInterval time.Duration
+	flushTicker   *time.Ticker
+	stopTicker    chan struct{}
+	flusherDone   chan struct{}
+}
+
+func newColumnarWriter(path string, conf *Config, rotation *Rotation, logger *zap.Logger) *columnarWriter {
+	return &columnarWriter{
+		path:          path,
+		formatType:    conf.FormatType,
+		compression:   conf.Compression,
+		rotation:      rotation,
+		logger:        logger,
+		files:         make(map[signal]*columnarFile),
+		flushInterval: conf.FlushInterval,
+	}
+}
+
+func (w *columnarWriter) writeTraces(td ptrace.Traces) error {
+	return w.write(signalTraces, tracesSchema, func(b *array.RecordBuilder) {
+		appendTraces(b, td)
+	})
+}
+
+func (w *columnarWriter) writeMetrics(md pmetric.Metrics) error {
+	return w.write(signalMetrics, metricsSchema, func(b *array.RecordBuilder) {
+		appendMetrics(b, md)
+	})
+}
+
+func (w *columnarWriter) writeLogs(ld plog.Logs) error {
+	return w.write(signalLogs, logsSchema, func(b *array.RecordBuilder) {
+		appendLogs(b, ld)
+	})
+}
+
+func (w *columnarWriter) write(s signal, schema *arrow.Schema, appendRows func(b *array.RecordBuilder)) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	f, ok := w.files[s]
+	if !ok {
+		f = newColumnarFile(signalPath(w.path, s), w.formatType, w.compression, schema, w.rotation)
+		w.files[s] = f
+	}
+	// the rows are refused, to be retried by the exporter, instead of being
+	// buffered without bounds while the file can't be written
+	if f.pendingRows >= maxPendingRows {
+		return fmt.Errorf("failed to write %s: %w", s, errPendingRows)
+	}
+	appendRows(f.builder)
+	if f.bufferedRows() >= maxBufferedRows || w.flushInterval <= 0 {
+		w.flush(s, f)
+	}
+	return nil
+}
+
+// flush writes the buffered rows of the file. The rows are accepted once
+// they are buffered, so a failure is logged rather than returned, and the
+// rows are written again by the next flush.
+func (w *columnarWriter) flush(s signal, f *columnarFile) {
+	if err := f.flush(); err != nil {
+		w.logger.Warn("Failed to write rows, they will be written again with the next flush",
+			zap.String("signal", string(s)), zap.Int64("pending_rows", f.pendingRows), zap.Error(err))
+	}
+}
+
+// start starts the flusher if a flush interval is set.
+func (w *columnarWriter) start() {
+	if w.flushInterval <= 0 {
+		return
+	}
+	w.flushTicker = time.NewTicker(w.flushInterval)
+	w.stopTicker = make(chan struct{})
+	w.flusherDone = make(chan struct{})
+	go func() {
+		defer close(w.flusherDone)
+		for {
+			select {
+			case <-w.flushTicker.C:
+				w.mutex.Lock()
+				for s, f := range w.files {
+					w.flush(s, f)
+				}
+				w.mutex.Unlock()
+			case <-w.stopTicker:
+				w.flushTicker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// shutdown stops the flusher and writes the remaining rows and footers of all files.
+func (w *columnarWriter) shutdown() error {
+	if w.stopTicker != nil {
+		close(w.stopTicker)
+		<-w.flusherDone
+		w.stopTicker = nil
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	var errs error
+	for s, f := range w.files {
+		errs = errors.Join(errs, f.close())
+		delete(w.files, s)
+	}
+	return errs
+}
+
+// signalPath inserts the signal name before the extension of path,
+// e.g. "data.parquet" becomes "data.logs.parquet".
+func signalPath(path string, s signal) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + string(s) + ext
+}
+
+// columnarFile is a single columnar file along with the rows buffered for it.
+// It is not safe for concurrent use.
+type columnarFile struct {
+	path        string
+	formatType  string
+	compression string
+	schema      *arrow.Schema
+	rotation    *Rotation
+	maxSize     int64
+
+	builder *array.RecordBuilder
+	// pending holds the record batches which couldn't be written yet, in order.
+	pending     []arrow.RecordBatch
+	pendingRows int64
+
+	file    *os.File
+	counter *countingWriter
+	writer  recordWriter
+}
+
+func newColumnarFile(path, formatType, compression string, schema *arrow.Schema, rotation *Rotation) *columnarFile {
+	f := &columnarFile{
+		path:        path,
+		formatType:  formatType,
+		compression: compression,
+		schema:      schema,
+		rotation:    rotation,
+		builder:     array.NewRecordBuilder(memory.DefaultAllocator, schema),
+	}
+	if rotation != nil {
+		maxMegabytes := rotation.MaxMegabytes
+		if maxMegabytes <= 0 {
+			maxMegabytes = defaultMaxMegabytes
+		}
+		f.maxSize = int64(maxMegabytes) * megabyte
+	}
+	return f
+}
+
+func (f *columnarFile) bufferedRows() int {
+	return f.builder.Field(0).Len()
+}
+
+// flush writes the buffered rows as a new row group, then rotates the file
+// if it grew beyond the configured size. The rows are kept when they can't be
+// written, and written before the rows buffered afterwards by the next flush.
+func (f *columnarFile) flush() error {
+	if f.bufferedRows() > 0 {
+		rec := f.builder.NewRecordBatch()
+		f.pending = append(f.pending, rec)
+		f.pendingRows += rec.NumRows()
+	}
+	for len(f.pending) > 0 {
+		if f.writer == nil {
+			if err := f.open(); err != nil {
+				return err
+			}
+		}
+		rec := f.pending[0]
+		if err := f.writer.Write(rec); err != nil {
+			// the row group may have been partially written, so the file is
+			// finalized, and the rows are written to a new file instead
+			return errors.Join(err, f.closeFile())
+		}
+		f.pending = f.pending[1:]
+		f.pendingRows -= rec.NumRows()
+		rec.Release()
+		if f.maxSize > 0 && f.counter.n >= f.maxSize {
+			if err := f.rotate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// open creates a new file. Existing files, written before a restart or before the file
+// was evicted by group_by, are never overwritten: the first free numbered name is used
+// instead, e.g. "data.logs-1.parquet".
+func (f *columnarFile) open() error {
+	file, err := createNumbered(f.path)
+	if err != nil {
+		return err
+	}
+	counter := &countingWriter{w: file}
+	writer, err := newRecordWriter(f.formatType, f.compression, f.schema, counter)
+	if err != nil {
+		return errors.Join(err, file.Close())
+	}
+	f.file = file
+	f.counter = counter
+	f.writer = writer
+	return nil
+}
+
+func createNumbered(path string) (*os.File, error) {
+	name := path
+	for i := 1; ; i++ {
+		file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o644)
+		if !errors.Is(err, fs.ErrExist) {
+			return file, err
+		}
+		name = numberedPath(path, i)
+	}
+}
+
+// numberedPath inserts the number before the extension of path,
+// e.g. "data.logs.parquet" becomes "data.logs-1.parquet".
+func numberedPath(path string, n int) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), n, ext)
+}
+
+// closeFile writes the footer and closes the current file, if any.
+func (f *columnarFile) closeFile() error {
+	if f.writer == nil {
+		return nil
+	}
+	err := errors.Join(f.writer.Close(), f.file.Close())
+	f.writer = nil
+	f.file = nil
+	f.counter = nil
+	return err
+}
+
+func (f *columnarFile) close() error {
+	err := f.flush()
+	err = errors.Join(err, f.closeFile())
+	for _, rec := range f.pending {
+		rec.Release()
+	}
+	f.pending = nil
+	f.builder.Release()
+	return err
+}
+
+// rotate finalizes the current file, renames it to a timestamped backup name
+// and removes backups that exceed the retention settings. The next flush
+// opens a new file.
+func (f *columnarFile) rotate() error {
+	name := f.file.Name()
+	if err := f.closeFile(); err != nil {
+		return err
+	}
+	now := time.Now()
+	if !f.rotation.LocalTime {
+		now = now.UTC()
+	}
+	if err := os.Rename(name, backupName(f.path, now)); err != nil {
+		return err
+	}
+	return removeOldBackups(f.path, f.rotation, now)
+}
+
+// backupName returns the name of a rotated file, using the same scheme as
+// lumberjack: "data.parquet" becomes "data-2006-01-02T15-04-05.000.parquet".
+func backupName(path string, t time.Time) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + t.Format(backupTimeFormat) + ext
+}
+
+func removeOldBackups(path string, rotation *Rotation, now time.Time) error {
+	if rotation.MaxBackups <= 0 && rotation.MaxDays <= 0 {
+		return nil
+	}
+
+	dir := filepath.Dir(path)
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(filepath.Base(path), ext) + "-"
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	type backup struct {
+		name      string
+		timestamp time.Time
+	}
+	var backups []backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		ts, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext), now.Location())
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{name: name, timestamp: ts})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+
+	var errs error
+	for i, b := range backups {
+		expired := rotation.MaxDays > 0 && now.Sub(b.timestamp) > time.Duration(rotation.MaxDays)*24*time.Hour
+		if (rotation.MaxBackups > 0 && i >= rotation.MaxBackups) || expired {
+			errs = errors.Join(errs, os.Remove(filepath.Join(dir, b.name)))
+		}
+	}
+	return errs
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,476 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+// readColumnarFile reads all rows of a Parquet or Arrow IPC file into a
+// single record and returns it along with the number of row groups or
+// record batches in the file.
+func readColumnarFile(t *testing.T, formatType, path string) (arrow.RecordBatch, int) {
+	t.Helper()
+	var records []arrow.RecordBatch
+	var schema *arrow.Schema
+
+	switch formatType {
+	case formatTypeParquet:
+		pf, err := file.OpenParquetFile(path, false)
+		require.NoError(t, err)
+		defer pf.Close()
+		reader, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+		require.NoError(t, err)
+		table, err := reader.ReadTable(t.Context())
+		require.NoError(t, err)
+		defer table.Release()
+		schema = table.Schema()
+		tr := array.NewTableReader(table, -1)
+		defer tr.Release()
+		for tr.Next() {
+			rec := tr.RecordBatch()
+			rec.Retain()
+			records = append(records, rec)
+		}
+		rec := concatRecords(t, schema, records)
+		return rec, pf.NumRowGroups()
+	case formatTypeArrow:
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+		reader, err := ipc.NewFileReader(f)
+		require.NoError(t, err)
+		defer reader.Close()
+		schema = reader.Schema()
+		for i := 0; i < reader.NumRecords(); i++ {
+			rec, err := reader.RecordBatch(i)
+			require.NoError(t, err)
+			rec.Retain()
+			records = append(records, rec)
+		}
+		return concatRecords(t, schema, records), reader.NumRecords()
+	}
+	require.Failf(t, "unexpected format", "format %q", formatType)
+	return nil, 0
+}
+
+func concatRecords(t *testing.T, schema *arrow.Schema, records []arrow.RecordBatch) arrow.RecordBatch {
+	t.Helper()
+	defer func() {
+		for _, rec := range records {
+			rec.Release()
+		}
+	}()
+	if len(records) == 0 {
+		return array.NewRecordBatch(schema, nil, 0)
+	}
+	// the table reader doesn't read across chunks, so the columns are concatenated
+	columns := make([]arrow.Array, schema.NumFields())
+	var rows int64
+	for i := range columns {
+		chunks := make([]arrow.Array, len(records))
+		for j, rec := range records {
+			chunks[j] = rec.Column(i)
+		}
+		col, err := array.Concatenate(chunks, memory.DefaultAllocator)
+		require.NoError(t, err)
+		defer col.Release()
+		columns[i] = col
+		rows = int64(col.Len())
+	}
+	return array.NewRecordBatch(schema, columns, rows)
+}
+
+func column[T arrow.Array](t *testing.T, rec arrow.RecordBatch, name string) T {
+	t.Helper()
+	indices := rec.Schema().FieldIndices(name)
+	require.Len(t, indices, 1, "column %q", name)
+	col, ok := rec.Column(indices[0]).(T)
+	require.True(t, ok, "column %q has type %T", name, rec.Column(indices[0]))
+	return col
+}
+
+func generateAllTypesMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(42)
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("sum")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sumDp := sum.Sum().DataPoints().AppendEmpty()
+	sumDp.SetDoubleValue(1.5)
+	sumDp.Attributes().PutStr("route", "/cart")
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("histogram")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	histogramDp := histogram.Histogram().DataPoints().AppendEmpty()
+	histogramDp.SetCount(3)
+	histogramDp.SetSum(6)
+	histogramDp.BucketCounts().FromRaw([]uint64{1, 2})
+	histogramDp.ExplicitBounds().FromRaw([]float64{2})
+
+	expHistogram := metrics.AppendEmpty()
+	expHistogram.SetName("exponential_histogram")
+	expHistogram.SetEmptyExponentialHistogram()
+	expHistogramDp := expHistogram.ExponentialHistogram().DataPoints().AppendEmpty()
+	expHistogramDp.SetCount(4)
+	expHistogramDp.SetScale(2)
+	expHistogramDp.Positive().SetOffset(1)
+	expHistogramDp.Positive().BucketCounts().FromRaw([]uint64{4})
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("summary")
+	summaryDp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	summaryDp.SetCount(10)
+	summaryDp.SetSum(100)
+	qv := summaryDp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.99)
+	qv.SetValue(20)
+
+	return md
+}
+
+func TestColumnarFileExporter(t *testing.T) {
+	for _, formatType := range []string{formatTypeParquet, formatTypeArrow} {
+		for _, compression := range []string{"", compressionZSTD} {
+			t.Run(formatType+"/"+compression, func(t *testing.T) {
+				conf := &Config{
+					Path:          filepath.Join(t.TempDir(), "telemetry."+formatType),
+					FormatType:    formatType,
+					Compression:   compression,
+					FlushInterval: time.Hour,
+				}
+				require.NoError(t, conf.Validate())
+				fe := &fileExporter{conf: conf, logger: zap.NewNop()}
+				require.NoError(t, fe.Start(t.Context(), componenttest.NewNopHost()))
+
+				ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+				require.NoError(t, fe.consumeLogs(t.Context(), ld))
+				require.NoError(t, fe.consumeLogs(t.Context(), ld))
+				require.NoError(t, fe.consumeTraces(t.Context(), testdata.GenerateTracesTwoSpansSameResourceOneDifferent()))
+				require.NoError(t, fe.consumeMetrics(t.Context(), generateAllTypesMetrics()))
+				err := fe.consumeProfiles(t.Context(), testdata.GenerateProfilesOneProfile())
+				require.ErrorIs(t, err, errColumnarProfiles)
+				require.True(t, consumererror.IsPermanent(err))
+				require.NoError(t, fe.Shutdown(t.Context()))
+
+				logs, groups := readColumnarFile(t, formatType, signalPath(conf.Path, signalLogs))
+				defer logs.Release()
+				assert.Equal(t, 1, groups)
+				assert.Equal(t, int64(4), logs.NumRows())
+				lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+				assert.Equal(t, lr.Body().AsString(), column[*array.String](t, logs, "body").Value(0))
+				assert.Equal(t, lr.SeverityText(), column[*array.String](t, logs, "severity_text").Value(0))
+				assert.Equal(t, int32(lr.SeverityNumber()), column[*array.Int32](t, logs, "severity_number").Value(0))
+				assert.Equal(t, arrow.Timestamp(lr.Timestamp()), column[*array.Timestamp](t, logs, "time").Value(0))
+				attrs := column[*array.Map](t, logs, "attributes")
+				start, end := attrs.ValueOffsets(0)
+				assert.Equal(t, int64(lr.Attributes().Len()), end-start)
+
+				traces, _ := readColumnarFile(t, formatType, signalPath(conf.Path, signalTraces))
+				defer traces.Release()
+				assert.Equal(t, int64(3), traces.NumRows())
+				assert.Equal(t, "operationA", column[*array.String](t, traces, "name").Value(0))
+				assert.Equal(t, 2, column[*array.List](t, traces, "events").ListValues().Len())
+				resourceAttrs := column[*array.Map](t, traces, "resource_attributes")
+				start, end = resourceAttrs.ValueOffsets(0)
+				assert.Equal(t, int64(1), end-start)
+
+				metrics, _ := readColumnarFile(t, formatType, signalPath(conf.Path, signalMetrics))
+				defer metrics.Release()
+				require.Equal(t, int64(5), metrics.NumRows())
+				names := column[*array.String](t, metrics, "metric_name")
+				types := column[*array.String](t, metrics, "metric_type")
+				for i, name := range []string{"gauge", "sum", "histogram", "exponential_histogram", "summary"} {
+					assert.Equal(t, name, names.Value(i))
+				}
+				assert.Equal(t, "ExponentialHistogram", types.Value(3))
+				valueInt := column[*array.Int64](t, metrics, "value_int")
+				assert.Equal(t, int64(42), valueInt.Value(0))
+				assert.True(t, valueInt.IsNull(1))
+				valueDouble := column[*array.Float64](t, metrics, "value_double")
+				assert.True(t, valueDouble.IsNull(0))
+				assert.Equal(t, 1.5, valueDouble.Value(1))
+				monotonic := column[*array.Boolean](t, metrics, "is_monotonic")
+				assert.True(t, monotonic.IsNull(0))
+				assert.True(t, monotonic.Value(1))
+				assert.Equal(t, "Cumulative", column[*array.String](t, metrics, "aggregation_temporality").Value(1))
+				count := column[*array.Uint64](t, metrics, "count")
+				assert.True(t, count.IsNull(1))
+				assert.Equal(t, uint64(3), count.Value(2))
+				assert.Equal(t, uint64(4), count.Value(3))
+				assert.Equal(t, uint64(10), count.Value(4))
+				assert.Equal(t, int32(2), column[*array.Int32](t, metrics, "scale").Value(3))
+				bucketCounts := column[*array.List](t, metrics, "bucket_counts")
+				assert.True(t, bucketCounts.IsNull(0))
+				start, end = bucketCounts.ValueOffsets(2)
+				assert.Equal(t, int64(2), end-start)
+				quantiles := column[*array.List](t, metrics, "quantiles")
+				start, end = quantiles.ValueOffsets(4)
+				assert.Equal(t, int64(1), end-start)
+			})
+		}
+	}
+}
+
+func TestColumnarRowGroupPerFlush(t *testing.T) {
+	conf := &Config{
+		Path:          filepath.Join(t.TempDir(), "logs.parquet"),
+		FormatType:    formatTypeParquet,
+		FlushInterval: 10 * time.Millisecond,
+	}
+	fe := &fileExporter{conf: conf, logger: zap.NewNop()}
+	require.NoError(t, fe.Start(t.Context(), componenttest.NewNopHost()))
+
+	for range 3 {
+		require.NoError(t, fe.consumeLogs(t.Context(), testdata.GenerateLogsOneLogRecord()))
+		assert.Eventually(t, func() bool {
+			fe.columnar.mutex.Lock()
+			defer fe.columnar.mutex.Unlock()
+			return fe.columnar.files[signalLogs].bufferedRows() == 0
+		}, 5*time.Second, 5*time.Millisecond)
+	}
+	require.NoError(t, fe.Shutdown(t.Context()))
+
+	rec, groups := readColumnarFile(t, formatTypeParquet, signalPath(conf.Path, signalLogs))
+	defer rec.Release()
+	assert.Equal(t, int64(3), rec.NumRows())
+	assert.Equal(t, 3, groups)
+}
+
+func TestColumnarRotation(t *testing.T) {
+	for _, formatType := range []string{formatTypeParquet, formatTypeArrow} {
+		t.Run(formatType, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "logs."+formatType)
+			f := newColumnarFile(path, formatType, "", logsSchema, &Rotation{MaxBackups: 2})
+			// Rotate after every row group.
+			f.maxSize = 1
+
+			for range 4 {
+				appendLogs(f.builder, testdata.GenerateLogsTwoLogRecordsSameResource())
+				require.NoError(t, f.flush())
+				// Backups are named with millisecond precision.
+				time.Sleep(2 * time.Millisecond)
+			}
+			require.NoError(t, f.close())
+
+			// Every flush exceeded the maximum size, so there is no current file.
+			assert.NoFileExists(t, path)
+			backups, err := filepath.Glob(filepath.Join(dir, "logs-*."+formatType))
+			require.NoError(t, err)
+			assert.Len(t, backups, 2)
+			for _, backup := range backups {
+				rec, groups := readColumnarFile(t, formatType, backup)
+				assert.Equal(t, int64(2), rec.NumRows())
+				assert.Equal(t, 1, groups)
+				rec.Release()
+			}
+		})
+	}
+}
+
+func TestColumnarGroupingFileExporter(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:          filepath.Join(dir, "*.parquet"),
+		FormatType:    formatTypeParquet,
+		FlushInterval: time.Hour,
+		GroupBy: &GroupBy{
+			Enabled:           true,
+			ResourceAttribute: defaultResourceAttribute,
+			MaxOpenFiles:      1,
+		},
+	}
+	require.NoError(t, conf.Validate())
+	gfe := &groupingFileExporter{conf: conf, logger: zap.NewNop()}
+	require.NoError(t, gfe.Start(t.Context(), componenttest.NewNopHost()))
+
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	for _, segment := range []string{"a", "b", "a"} {
+		ld.ResourceLogs().At(0).Resource().Attributes().PutStr(defaultResourceAttribute, segment)
+		require.NoError(t, gfe.consumeLogs(t.Context(), ld))
+	}
+	err := gfe.consumeProfiles(t.Context(), testdata.GenerateProfilesOneProfile())
+	require.ErrorIs(t, err, errColumnarProfiles)
+	require.True(t, consumererror.IsPermanent(err))
+	require.NoError(t, gfe.Shutdown(t.Context()))
+
+	// "a" was evicted when "b" was written, so its file was finalized and the
+	// rows written to "a" afterwards went to a new file, next to the first one.
+	for _, name := range []string{"a.logs.parquet", "a.logs-1.parquet"} {
+		rec, _ := readColumnarFile(t, formatTypeParquet, filepath.Join(dir, name))
+		assert.Equal(t, int64(2), rec.NumRows())
+		assert.Equal(t, "a", resourceAttribute(t, rec, 0, defaultResourceAttribute))
+		rec.Release()
+	}
+
+	rec, _ := readColumnarFile(t, formatTypeParquet, filepath.Join(dir, "b.logs.parquet"))
+	defer rec.Release()
+	assert.Equal(t, int64(2), rec.NumRows())
+}
+
+func TestColumnarGroupingFileExporterConcurrentEviction(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:          filepath.Join(dir, "*.parquet"),
+		FormatType:    formatTypeParquet,
+		FlushInterval: time.Hour,
+		GroupBy: &GroupBy{
+			Enabled:           true,
+			ResourceAttribute: defaultResourceAttribute,
+			MaxOpenFiles:      1,
+		},
+	}
+	require.NoError(t, conf.Validate())
+	gfe := &groupingFileExporter{conf: conf, logger: zap.NewNop()}
+	require.NoError(t, gfe.Start(t.Context(), componenttest.NewNopHost()))
+
+	logs := func(segment string) plog.Logs {
+		ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+		ld.ResourceLogs().At(0).Resource().Attributes().PutStr(defaultResourceAttribute, segment)
+		return ld
+	}
+	// a write to "b" would evict the writer of "a" while rows are written to it,
+	// so it must wait for the write to "a" to complete
+	written := make(chan struct{})
+	require.NoError(t, gfe.writeColumnar("a", func(w *columnarWriter) error {
+		go func() {
+			defer close(written)
+			assert.NoError(t, gfe.consumeLogs(context.Background(), logs("b")))
+		}()
+		select {
+		case <-written:
+		case <-time.After(100 * time.Millisecond):
+		}
+		return w.writeLogs(logs("a"))
+	}))
+	<-written
+	require.NoError(t, gfe.Shutdown(t.Context()))
+
+	for _, segment := range []string{"a", "b"} {
+		rec, _ := readColumnarFile(t, formatTypeParquet, filepath.Join(dir, segment+".logs.parquet"))
+		assert.Equal(t, int64(2), rec.NumRows(), segment)
+		rec.Release()
+	}
+}
+
+func TestColumnarWriterKeepsRowsOnFlushFailure(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	conf := &Config{
+		Path:       filepath.Join(dir, "logs.arrow"),
+		FormatType: formatTypeArrow,
+	}
+	w := newColumnarWriter(conf.Path, conf, nil, zap.NewNop())
+	w.start()
+
+	// the directory doesn't exist, so the rows can't be written, but they are kept
+	require.NoError(t, w.writeLogs(testdata.GenerateLogsOneLogRecord()))
+	f := w.files[signalLogs]
+	assert.Equal(t, int64(1), f.pendingRows)
+
+	// the rows are refused once too many of them couldn't be written
+	f.pendingRows = maxPendingRows
+	require.ErrorIs(t, w.writeLogs(testdata.GenerateLogsOneLogRecord()), errPendingRows)
+	f.pendingRows = 1
+
+	// the kept rows are written before the new ones once the file can be written
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	require.NoError(t, w.writeLogs(testdata.GenerateLogsTwoLogRecordsSameResource()))
+	assert.Equal(t, int64(0), f.pendingRows)
+	require.NoError(t, w.shutdown())
+
+	rec, batches := readColumnarFile(t, formatTypeArrow, signalPath(conf.Path, signalLogs))
+	defer rec.Release()
+	assert.Equal(t, int64(3), rec.NumRows())
+	assert.Equal(t, 2, batches)
+}
+
+func TestColumnarFileExporterRestart(t *testing.T) {
+	conf := &Config{
+		Path:       filepath.Join(t.TempDir(), "telemetry.arrow"),
+		FormatType: formatTypeArrow,
+	}
+	require.NoError(t, conf.Validate())
+
+	// every run writes its rows to a new file, instead of overwriting the file of the previous run
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	for range 3 {
+		fe := &fileExporter{conf: conf, logger: zap.NewNop()}
+		require.NoError(t, fe.Start(t.Context(), componenttest.NewNopHost()))
+		require.NoError(t, fe.consumeLogs(t.Context(), ld))
+		require.NoError(t, fe.Shutdown(t.Context()))
+	}
+
+	logsPath := signalPath(conf.Path, signalLogs)
+	for _, path := range []string{logsPath, numberedPath(logsPath, 1), numberedPath(logsPath, 2)} {
+		rec, _ := readColumnarFile(t, formatTypeArrow, path)
+		assert.Equal(t, int64(2), rec.NumRows())
+		rec.Release()
+	}
+}
+
+func resourceAttribute(t *testing.T, rec arrow.RecordBatch, row int, key string) string {
+	t.Helper()
+	attrs := column[*array.Map](t, rec, "resource_attributes")
+	keys := attrs.Keys().(*array.String)
+	items := attrs.Items().(*array.String)
+	start, end := attrs.ValueOffsets(row)
+	for i := start; i < end; i++ {
+		if keys.Value(int(i)) == key {
+			return items.Value(int(i))
+		}
+	}
+	return ""
+}
+
+func TestSignalPathAndBackupName(t *testing.T) {
+	assert.Equal(t, "/tmp/data.logs.parquet", signalPath("/tmp/data.parquet", signalLogs))
+	assert.Equal(t, "/tmp/data.traces", signalPath("/tmp/data", signalTraces))
+
+	ts := time.Date(2022, 9, 14, 5, 2, 14, 173*int(time.Millisecond), time.UTC)
+	assert.Equal(t, "/tmp/data-2022-09-14T05-02-14.173.parquet", backupName("/tmp/data.parquet", ts))
+}
+
+func TestAppendTracesEmptyIDs(t *testing.T) {
+	td := testdata.GenerateTracesOneSpan()
+	span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	span.SetTraceID(pcommon.NewTraceIDEmpty())
+
+	b := array.NewRecordBuilder(memory.DefaultAllocator, tracesSchema)
+	defer b.Release()
+	appendTraces(b, td)
+	rec := b.NewRecordBatch()
+	defer rec.Release()
+	assert.Empty(t, column[*array.String](t, rec, "trace_id").Value(0))
+}
@@ -44,6 +44,8 @@ type Config struct {
 	// Options:
 	// - json[default]:  OTLP json bytes.
 	// - proto:  OTLP binary protobuf bytes.
+	// - parquet:  Parquet files with a flattened OTLP schema, one file per signal.
+	// - arrow:  Arrow IPC files with a flattened OTLP schema, one file per signal.
 	FormatType string `mapstructure:"format"`
 
 	// Encoding defines the encoding of the telemetry data.
@@ -120,7 +122,16 @@ func (cfg *Config) Validate() error {
 	if cfg.Append && cfg.Rotation != nil {
 		return errors.New("append and rotation enabled at the same time is not supported")
 	}
-	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto {
+	switch cfg.FormatType {
+	case formatTypeJSON, formatTypeProto:
+	case formatTypeParquet, formatTypeArrow:
+		if cfg.Append {
+			return errors.New("append is not supported with columnar formats")
+		}
+		if cfg.Encoding != nil {
+			return errors.New("encoding is not supported with columnar formats")
+		}
+	default:
 		return errors.New("format type is not supported")
 	}
 	if cfg.Compression != "" && cfg.Compression != compressionZSTD {
@@ -186,6 +186,31 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "group_by_empty_resource_attribute"),
 			errorMessage: "resource_attribute must not be empty when group_by is enabled",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "parquet"),
+			expected: &Config{
+				Path:          "./telemetry.parquet",
+				FormatType:    formatTypeParquet,
+				Compression:   compressionZSTD,
+				FlushInterval: time.Second,
+				Rotation: &Rotation{
+					MaxMegabytes: 256,
+					MaxBackups:   defaultMaxBackups,
+				},
+				GroupBy: &GroupBy{
+					MaxOpenFiles:      defaultMaxOpenFiles,
+					ResourceAttribute: defaultResourceAttribute,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "parquet_append_error"),
+			errorMessage: "append is not supported with columnar formats",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "arrow_encoding_error"),
+			errorMessage: "encoding is not supported with columnar formats",
+		},
 	}
 
 	for _, tt := range tests {
@@ -31,8 +31,10 @@ const (
 	defaultMaxBackups = 100
 
 	// the format of encoded telemetry data
-	formatTypeJSON  = "json"
-	formatTypeProto = "proto"
+	formatTypeJSON    = "json"
+	formatTypeProto   = "proto"
+	formatTypeParquet = "parquet"
+	formatTypeArrow   = "arrow"
 
 	// the type of compression codec
 	compressionZSTD = "zstd"
@@ -157,7 +159,8 @@ func getOrCreateFileExporter(cfg component.Config, logger *zap.Logger) FileExpor
 func newFileExporter(conf *Config, logger *zap.Logger) FileExporter {
 	if conf.GroupBy == nil || !conf.GroupBy.Enabled {
 		return &fileExporter{
-			conf: conf,
+			conf:   conf,
+			logger: logger,
 		}
 	}
 
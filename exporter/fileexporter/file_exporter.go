@@ -9,20 +9,29 @@ import (
 	"path/filepath"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 )
 
 // fileExporter is the implementation of file exporter that writes telemetry data to a file
 type fileExporter struct {
 	conf       *Config
+	logger     *zap.Logger
 	marshaller *marshaller
 	writer     *fileWriter
+
+	// columnar is used instead of marshaller and writer for columnar formats.
+	columnar *columnarWriter
 }
 
 func (e *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	if e.columnar != nil {
+		return e.columnar.writeTraces(td)
+	}
 	buf, err := e.marshaller.marshalTraces(td)
 	if err != nil {
 		return err
@@ -31,6 +40,9 @@ func (e *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error
 }
 
 func (e *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	if e.columnar != nil {
+		return e.columnar.writeMetrics(md)
+	}
 	buf, err := e.marshaller.marshalMetrics(md)
 	if err != nil {
 		return err
@@ -39,6 +51,9 @@ func (e *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) err
 }
 
 func (e *fileExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	if e.columnar != nil {
+		return e.columnar.writeLogs(ld)
+	}
 	buf, err := e.marshaller.marshalLogs(ld)
 	if err != nil {
 		return err
@@ -47,6 +62,9 @@ func (e *fileExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
 }
 
 func (e *fileExporter) consumeProfiles(_ context.Context, pd pprofile.Profiles) error {
+	if e.columnar != nil {
+		return consumererror.NewPermanent(errColumnarProfiles)
+	}
 	buf, err := e.marshaller.marshalProfiles(pd)
 	if err != nil {
 		return err
@@ -57,11 +75,14 @@ func (e *fileExporter) consumeProfiles(_ context.Context, pd pprofile.Profiles)
 // Start starts the flush timer if set.
 func (e *fileExporter) Start(_ context.Context, host component.Host) error {
 	var err error
-	e.marshaller, err = newMarshaller(e.conf, host)
-	if err != nil {
-		return err
+	var export exportFunc
+	if !isColumnarFormat(e.conf.FormatType) {
+		e.marshaller, err = newMarshaller(e.conf, host)
+		if err != nil {
+			return err
+		}
+		export = buildExportFunc(e.conf)
 	}
-	export := buildExportFunc(e.conf)
 
 	// Optionally ensure the output directory exists.
 	if e.conf.CreateDirectory {
@@ -76,6 +97,12 @@ func (e *fileExporter) Start(_ context.Context, host component.Host) error {
 		}
 	}
 
+	if isColumnarFormat(e.conf.FormatType) {
+		e.columnar = newColumnarWriter(e.conf.Path, e.conf, e.conf.Rotation, e.logger)
+		e.columnar.start()
+		return nil
+	}
+
 	e.writer, err = newFileWriter(e.conf.Path, e.conf.Append, e.conf.Rotation, e.conf.FlushInterval, export)
 	if err != nil {
 		return err
@@ -87,6 +114,11 @@ func (e *fileExporter) Start(_ context.Context, host component.Host) error {
 // Shutdown stops the exporter and is invoked during shutdown.
 // It stops the flush ticker if set.
 func (e *fileExporter) Shutdown(context.Context) error {
+	if e.columnar != nil {
+		w := e.columnar
+		e.columnar = nil
+		return w.shutdown()
+	}
 	if e.writer == nil {
 		return nil
 	}
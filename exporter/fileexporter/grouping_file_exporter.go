@@ -23,18 +23,25 @@ import (
 	"go.uber.org/zap"
 )
 
+// groupWriter writes the data of a single group, it is either a *fileWriter
+// or a *columnarWriter.
+type groupWriter interface {
+	start()
+	shutdown() error
+}
+
 type groupingFileExporter struct {
-	conf          *Config
-	logger        *zap.Logger
-	marshaller    *marshaller
-	pathPrefix    string
-	pathSuffix    string
-	attribute     string
-	maxOpenFiles  int
-	newFileWriter func(path string) (*fileWriter, error)
+	conf         *Config
+	logger       *zap.Logger
+	marshaller   *marshaller
+	pathPrefix   string
+	pathSuffix   string
+	attribute    string
+	maxOpenFiles int
+	newWriter    func(path string) (groupWriter, error)
 
 	mutex   sync.Mutex
-	writers *simplelru.LRU[string, *fileWriter]
+	writers *simplelru.LRU[string, groupWriter]
 }
 
 func (e *groupingFileExporter) consumeTraces(ctx context.Context, td ptrace.Traces) error {
@@ -56,6 +63,16 @@ func (e *groupingFileExporter) consumeTraces(ctx context.Context, td ptrace.Trac
 			rSpans.CopyTo(traces.ResourceSpans().AppendEmpty())
 		}
 
+		if isColumnarFormat(e.conf.FormatType) {
+			err := e.writeColumnar(pathSegment, func(w *columnarWriter) error {
+				return w.writeTraces(traces)
+			})
+			if err != nil {
+				errs = errors.Join(errs, err)
+			}
+			continue
+		}
+
 		buf, err := e.marshaller.marshalTraces(traces)
 		if err != nil {
 			errs = errors.Join(errs, err)
@@ -94,6 +111,16 @@ func (e *groupingFileExporter) consumeMetrics(ctx context.Context, md pmetric.Me
 			rMetrics.CopyTo(metrics.ResourceMetrics().AppendEmpty())
 		}
 
+		if isColumnarFormat(e.conf.FormatType) {
+			err := e.writeColumnar(pathSegment, func(w *columnarWriter) error {
+				return w.writeMetrics(metrics)
+			})
+			if err != nil {
+				errs = errors.Join(errs, err)
+			}
+			continue
+		}
+
 		buf, err := e.marshaller.marshalMetrics(metrics)
 		if err != nil {
 			errs = errors.Join(errs, err)
@@ -132,6 +159,16 @@ func (e *groupingFileExporter) consumeLogs(ctx context.Context, ld plog.Logs) er
 			rlogs.CopyTo(logs.ResourceLogs().AppendEmpty())
 		}
 
+		if isColumnarFormat(e.conf.FormatType) {
+			err := e.writeColumnar(pathSegment, func(w *columnarWriter) error {
+				return w.writeLogs(logs)
+			})
+			if err != nil {
+				errs = errors.Join(errs, err)
+			}
+			continue
+		}
+
 		buf, err := e.marshaller.marshalLogs(logs)
 		if err != nil {
 			errs = errors.Join(errs, err)
@@ -156,6 +193,10 @@ func (e *groupingFileExporter) consumeProfiles(ctx context.Context, pd pprofile.
 		return nil
 	}
 
+	if isColumnarFormat(e.conf.FormatType) {
+		return consumererror.NewPermanent(errColumnarProfiles)
+	}
+
 	groups := make(map[string][]pprofile.ResourceProfiles)
 
 	for i := 0; i < pd.ResourceProfiles().Len(); i++ {
@@ -195,7 +236,7 @@ func (e *groupingFileExporter) write(_ context.Context, pathSegment string, buf
 		return err
 	}
 
-	err = writer.export(buf)
+	err = writer.(*fileWriter).export(buf)
 	if err != nil {
 		return err
 	}
@@ -203,12 +244,31 @@ func (e *groupingFileExporter) write(_ context.Context, pathSegment string, buf
 	return nil
 }
 
-func (e *groupingFileExporter) getWriter(pathSegment string) (*fileWriter, error) {
-	fullPath := e.fullPath(pathSegment)
+// writeColumnar writes to the columnar writer of the group while holding the exporter lock, so that
+// the writer can't be evicted, and its files closed, while rows are buffered in it.
+func (e *groupingFileExporter) writeColumnar(pathSegment string, write func(w *columnarWriter) error) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
 
+	writer, err := e.getWriterLocked(pathSegment)
+	if err != nil {
+		return err
+	}
+
+	return write(writer.(*columnarWriter))
+}
+
+func (e *groupingFileExporter) getWriter(pathSegment string) (groupWriter, error) {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
 
+	return e.getWriterLocked(pathSegment)
+}
+
+// getWriterLocked returns the writer of the group, creating it if needed. The exporter lock must be held.
+func (e *groupingFileExporter) getWriterLocked(pathSegment string) (groupWriter, error) {
+	fullPath := e.fullPath(pathSegment)
+
 	writer, ok := e.writers.Get(fullPath)
 	if ok {
 		return writer, nil
@@ -223,7 +283,7 @@ func (e *groupingFileExporter) getWriter(pathSegment string) (*fileWriter, error
 		return nil, err
 	}
 
-	writer, err = e.newFileWriter(fullPath)
+	writer, err = e.newWriter(fullPath)
 	if err != nil {
 		return nil, err
 	}
@@ -260,10 +320,10 @@ func (e *groupingFileExporter) fullPath(pathSegment string) string {
 	return path.Join(e.pathPrefix, path.Join("/", pathSegment+e.pathSuffix))
 }
 
-func (e *groupingFileExporter) onEvict(_ string, writer *fileWriter) {
+func (e *groupingFileExporter) onEvict(fullPath string, writer groupWriter) {
 	err := writer.shutdown()
 	if err != nil {
-		e.logger.Warn("Failed to close file", zap.Error(err), zap.String("path", writer.path))
+		e.logger.Warn("Failed to close file", zap.Error(err), zap.String("path", fullPath))
 	}
 }
 
@@ -289,11 +349,20 @@ func group[T any](e *groupingFileExporter, groups map[string][]T, resource pcomm
 // Start initializes and starts the exporter.
 func (e *groupingFileExporter) Start(_ context.Context, host component.Host) error {
 	var err error
-	e.marshaller, err = newMarshaller(e.conf, host)
-	if err != nil {
-		return err
+	if isColumnarFormat(e.conf.FormatType) {
+		e.newWriter = func(path string) (groupWriter, error) {
+			return newColumnarWriter(path, e.conf, nil, e.logger), nil
+		}
+	} else {
+		e.marshaller, err = newMarshaller(e.conf, host)
+		if err != nil {
+			return err
+		}
+		export := buildExportFunc(e.conf)
+		e.newWriter = func(path string) (groupWriter, error) {
+			return newFileWriter(path, e.conf.Append, nil, e.conf.FlushInterval, export)
+		}
 	}
-	export := buildExportFunc(e.conf)
 
 	pathParts := strings.Split(e.conf.Path, "*")
 
@@ -301,9 +370,6 @@ func (e *groupingFileExporter) Start(_ context.Context, host component.Host) err
 	e.attribute = e.conf.GroupBy.ResourceAttribute
 	e.pathSuffix = pathParts[1]
 	e.maxOpenFiles = e.conf.GroupBy.MaxOpenFiles
-	e.newFileWriter = func(path string) (*fileWriter, error) {
-		return newFileWriter(path, e.conf.Append, nil, e.conf.FlushInterval, export)
-	}
 
 	writers, err := simplelru.NewLRU(e.conf.GroupBy.MaxOpenFiles, e.onEvict)
 	if err != nil {
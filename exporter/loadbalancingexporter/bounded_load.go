@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBalanceFactor     = 1.25
+	defaultBoundedLoadWindow = 10 * time.Second
+)
+
+// boundedLoad implements consistent hashing with bounded loads, following Mirrokni et al. The load of each endpoint
+// is the number of times keys are routed to it over a sliding window, and no endpoint is assigned more than balanceFactor times
+// its share of the load, which is proportional to its weight. Keys that would be routed to an endpoint at capacity
+// are routed to the next endpoint of the ring with spare capacity instead.
+//
+// The endpoint of a key is kept for the rest of the window once assigned, so that the data of a key, such as the
+// spans of a trace, isn't spread over several endpoints. A key assigned during the previous window keeps its
+// endpoint as long as the endpoint is within capacity.
+type boundedLoad struct {
+	balanceFactor float64
+	window        time.Duration
+	// weights holds the weights of the endpoints, by endpoint with port. Endpoints without weight have a weight of 1.
+	weights map[string]float64
+	now     func() time.Time
+
+	mu sync.Mutex
+	// totalWeight is the sum of the weights of the endpoints of the ring
+	totalWeight float64
+	// windowStart is the start of the current window
+	windowStart time.Time
+	// current and previous hold the number of keys routed to each endpoint during the current and previous windows
+	current  map[string]float64
+	previous map[string]float64
+	// currentKeys and previousKeys hold the endpoint assigned to each key during the current and previous windows
+	currentKeys  map[string]string
+	previousKeys map[string]string
+	// previousWeight is the part of the previous window that is still within the sliding window
+	previousWeight float64
+}
+
+func newBoundedLoad(balanceFactor float64, window time.Duration, weights map[string]float64) *boundedLoad {
+	if balanceFactor == 0 {
+		balanceFactor = defaultBalanceFactor
+	}
+	if window == 0 {
+		window = defaultBoundedLoadWindow
+	}
+	endpointWeights := make(map[string]float64, len(weights))
+	for endpoint, weight := range weights {
+		endpointWeights[endpointWithPort(endpoint)] = weight
+	}
+	return &boundedLoad{
+		balanceFactor: balanceFactor,
+		window:        window,
+		weights:       endpointWeights,
+		now:           time.Now,
+		current:       map[string]float64{},
+		previous:      map[string]float64{},
+		currentKeys:   map[string]string{},
+		previousKeys:  map[string]string{},
+	}
+}
+
+// endpointFor returns the endpoint to route the given identifier to. When the identifier is assigned to another
+// endpoint than the one of the ring because the latter is at capacity, the endpoint of the ring is returned as
+// overflowed.
+func (b *boundedLoad) endpointFor(ring *hashRing, identifier []byte) (endpoint, overflowed string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rotate()
+	key := string(identifier)
+	if endpoint, ok := b.currentKeys[key]; ok {
+		b.current[endpoint]++
+		return endpoint, ""
+	}
+
+	total := b.total()
+	if endpoint, ok := b.previousKeys[key]; ok && b.load(endpoint)+1 <= b.capacity(endpoint, total) {
+		b.assign(key, endpoint)
+		return endpoint, ""
+	}
+
+	var preferred string
+	for candidate := range ring.endpointsFor(identifier) {
+		if preferred == "" {
+			preferred = candidate
+		}
+		if b.load(candidate)+1 <= b.capacity(candidate, total) {
+			endpoint = candidate
+			break
+		}
+	}
+	if endpoint == "" {
+		// all endpoints are at capacity, which can only happen right after the list of endpoints changed
+		endpoint = preferred
+	}
+	if endpoint == "" {
+		return "", ""
+	}
+	b.assign(key, endpoint)
+	if endpoint != preferred {
+		overflowed = preferred
+	}
+	return endpoint, overflowed
+}
+
+// assign routes the key to the endpoint for the rest of the current window.
+func (b *boundedLoad) assign(key, endpoint string) {
+	b.currentKeys[key] = endpoint
+	b.current[endpoint]++
+}
+
+// setEndpoints updates the endpoints of the ring, forgetting the load and the keys of the endpoints that were
+// removed.
+func (b *boundedLoad) setEndpoints(endpoints []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.totalWeight = 0
+	keep := make(map[string]struct{}, len(endpoints))
+	for _, endpoint := range endpoints {
+		keep[endpoint] = struct{}{}
+		b.totalWeight += b.weight(endpoint)
+	}
+	for _, loads := range []map[string]float64{b.current, b.previous} {
+		for endpoint := range loads {
+			if _, ok := keep[endpoint]; !ok {
+				delete(loads, endpoint)
+			}
+		}
+	}
+	for _, keys := range []map[string]string{b.currentKeys, b.previousKeys} {
+		for key, endpoint := range keys {
+			if _, ok := keep[endpoint]; !ok {
+				delete(keys, key)
+			}
+		}
+	}
+}
+
+// rotate starts a new window once the current one is over, and updates the weight of the previous window.
+func (b *boundedLoad) rotate() {
+	now := b.now()
+	elapsed := now.Sub(b.windowStart)
+	switch {
+	case elapsed < b.window:
+		// the current window is still open
+	case elapsed < 2*b.window:
+		b.previous, b.current = b.current, b.previous
+		clear(b.current)
+		b.previousKeys, b.currentKeys = b.currentKeys, b.previousKeys
+		clear(b.currentKeys)
+		b.windowStart = b.windowStart.Add(b.window)
+	default:
+		// no key was routed during the last window
+		clear(b.previous)
+		clear(b.current)
+		clear(b.previousKeys)
+		clear(b.currentKeys)
+		b.windowStart = now
+	}
+	b.previousWeight = 1 - float64(now.Sub(b.windowStart))/float64(b.window)
+}
+
+// load returns the load of the endpoint over the sliding window, where the keys of the previous window are weighted
+// by previousWeight.
+func (b *boundedLoad) load(endpoint string) float64 {
+	return b.current[endpoint] + b.previous[endpoint]*b.previousWeight
+}
+
+// total returns the load of all endpoints over the sliding window, including the key being routed.
+func (b *boundedLoad) total() float64 {
+	total := 1.0
+	for _, load := range b.current {
+		total += load
+	}
+	for _, load := range b.previous {
+		total += load * b.previousWeight
+	}
+	return total
+}
+
+// capacity returns the maximum load of the endpoint given the total load, including the key being routed.
+func (b *boundedLoad) capacity(endpoint string, total float64) float64 {
+	if b.totalWeight == 0 {
+		return 0
+	}
+	return math.Ceil(b.balanceFactor * total * b.weight(endpoint) / b.totalWeight)
+}
+
+// weight returns the weight of the endpoint.
+func (b *boundedLoad) weight(endpoint string) float64 {
+	if weight, ok := b.weights[endpointWithPort(endpoint)]; ok {
+		return weight
+	}
+	return 1
+}
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBoundedLoad(balanceFactor float64, endpoints []string, weights map[string]float64) (*boundedLoad, *hashRing, *time.Time) {
+	now := time.Unix(0, 0)
+	b := newBoundedLoad(balanceFactor, time.Second, weights)
+	b.now = func() time.Time { return now }
+	b.windowStart = now
+	b.setEndpoints(endpoints)
+	return b, newHashRing(endpoints), &now
+}
+
+func TestBoundedLoadDefaults(t *testing.T) {
+	b := newBoundedLoad(0, 0, nil)
+	assert.Equal(t, defaultBalanceFactor, b.balanceFactor)
+	assert.Equal(t, defaultBoundedLoadWindow, b.window)
+}
+
+func TestBoundedLoadHotKey(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}
+	b, ring, now := newTestBoundedLoad(1.25, endpoints, nil)
+	hotKey := []byte("hot-service")
+
+	// test: the hot key is routed along with other keys over several windows
+	routed := map[string]int{}
+	for window := range 8 {
+		hotEndpoint, _ := b.endpointFor(ring, hotKey)
+		for i := range 100 {
+			endpoint, _ := b.endpointFor(ring, hotKey)
+			// verify: the hot key keeps its endpoint during the window
+			require.Equal(t, hotEndpoint, endpoint)
+			b.endpointFor(ring, fmt.Appendf(nil, "key-%d-%d", window, i))
+		}
+		routed[hotEndpoint]++
+		*now = now.Add(time.Second)
+	}
+
+	// verify: the hot key moves to other endpoints once its endpoint is over capacity
+	assert.Greater(t, len(routed), 1)
+}
+
+func TestBoundedLoadPinsKeys(t *testing.T) {
+	// prepare: saturate the preferred endpoint of the trace with other keys
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}
+	b, ring, now := newTestBoundedLoad(1.25, endpoints, nil)
+	traceID := []byte("trace-id")
+	preferred := ring.endpointFor(traceID)
+	for i := range 400 {
+		b.endpointFor(ring, fmt.Appendf(nil, "key-%d", i))
+	}
+	for i := 0; b.load(preferred)+1 <= b.capacity(preferred, b.total()); i++ {
+		key := fmt.Appendf(nil, "hot-key-%d", i)
+		if ring.endpointFor(key) == preferred {
+			b.endpointFor(ring, key)
+		}
+	}
+
+	// test
+	endpoint, overflowed := b.endpointFor(ring, traceID)
+
+	// verify: the trace overflows to another endpoint
+	require.NotEqual(t, preferred, endpoint)
+	assert.Equal(t, preferred, overflowed)
+
+	// test: the trace is routed again, while its preferred endpoint is still saturated
+	for range 10 {
+		e, o := b.endpointFor(ring, traceID)
+
+		// verify: the trace stays on the same endpoint, and isn't counted as an overflow again
+		assert.Equal(t, endpoint, e)
+		assert.Empty(t, o)
+	}
+
+	// test: the trace is routed in the next window, while its endpoint is within capacity
+	*now = now.Add(time.Second)
+	e, o := b.endpointFor(ring, traceID)
+
+	// verify
+	assert.Equal(t, endpoint, e)
+	assert.Empty(t, o)
+}
+
+func TestBoundedLoadWeights(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2"}
+	b, ring, _ := newTestBoundedLoad(1.25, endpoints, map[string]float64{"endpoint-1:4317": 3})
+
+	// test
+	routed := map[string]int{}
+	for i := range 4000 {
+		endpoint, _ := b.endpointFor(ring, fmt.Appendf(nil, "key-%d", i))
+		routed[endpoint]++
+	}
+
+	// verify: the capacity of the endpoints is proportional to their weight
+	assert.Equal(t, 4.0, b.totalWeight)
+	assert.LessOrEqual(t, routed["endpoint-2"], 1250)
+	assert.Equal(t, 4000, routed["endpoint-1"]+routed["endpoint-2"])
+}
+
+func TestBoundedLoadBalancedKeys(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}
+	b, ring, _ := newTestBoundedLoad(1.25, endpoints, nil)
+
+	// test
+	routed := map[string]int{}
+	overflows := 0
+	for i := range 4000 {
+		key := fmt.Appendf(nil, "key-%d", i)
+		endpoint, overflowed := b.endpointFor(ring, key)
+		if overflowed != "" {
+			overflows++
+		}
+		routed[endpoint]++
+	}
+
+	// verify: keys stay on their endpoint unless it is over capacity
+	for endpoint, count := range routed {
+		assert.LessOrEqual(t, count, 1250, "endpoint %s is over capacity", endpoint)
+	}
+	assert.Less(t, overflows, 1000)
+}
+
+func TestBoundedLoadWindow(t *testing.T) {
+	// prepare
+	b, ring, now := newTestBoundedLoad(1.25, []string{"endpoint-1", "endpoint-2"}, nil)
+	key := []byte("hot-service")
+	for range 100 {
+		b.endpointFor(ring, key)
+	}
+	require.Equal(t, 100.0, b.current["endpoint-1"]+b.current["endpoint-2"])
+
+	// test: the keys of the previous window are weighted by the part of the window that is still in the sliding window
+	*now = now.Add(1250 * time.Millisecond)
+	b.endpointFor(ring, key)
+
+	// verify
+	assert.Equal(t, 1.0, b.current["endpoint-1"]+b.current["endpoint-2"])
+	assert.Equal(t, 100.0, b.previous["endpoint-1"]+b.previous["endpoint-2"])
+	assert.InDelta(t, 0.75, b.previousWeight, 0.0001)
+	assert.Contains(t, b.previousKeys, "hot-service")
+
+	// test: the load is forgotten after two windows
+	*now = now.Add(2 * time.Second)
+	b.endpointFor(ring, key)
+
+	// verify
+	assert.Equal(t, 1.0, b.current["endpoint-1"]+b.current["endpoint-2"])
+	assert.Empty(t, b.previous)
+	assert.Empty(t, b.previousKeys)
+	assert.Equal(t, *now, b.windowStart)
+}
+
+func TestBoundedLoadSetEndpoints(t *testing.T) {
+	// prepare
+	b, ring, _ := newTestBoundedLoad(1.25, []string{"endpoint-1", "endpoint-2"}, nil)
+	for i := range 100 {
+		b.endpointFor(ring, fmt.Appendf(nil, "key-%d", i))
+	}
+	require.Len(t, b.current, 2)
+
+	// test
+	b.setEndpoints([]string{"endpoint-2", "endpoint-3"})
+
+	// verify
+	assert.Equal(t, 2.0, b.totalWeight)
+	assert.NotContains(t, b.current, "endpoint-1")
+	assert.Contains(t, b.current, "endpoint-2")
+	assert.NotContains(t, slices.Collect(maps.Values(b.currentKeys)), "endpoint-1")
+	assert.Contains(t, slices.Collect(maps.Values(b.currentKeys)), "endpoint-2")
+}
+
+func TestBoundedLoadEmptyRing(t *testing.T) {
+	b, ring, _ := newTestBoundedLoad(1.25, nil, nil)
+	endpoint, overflowed := b.endpointFor(ring, []byte("key"))
+	assert.Empty(t, endpoint)
+	assert.Empty(t, overflowed)
+	assert.Empty(t, b.current)
+	assert.Empty(t, b.currentKeys)
+}
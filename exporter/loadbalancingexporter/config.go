@@ -4,9 +4,13 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -50,6 +54,40 @@ type Config struct {
 	// Supports all attributes available (both resource and span), as well as the pseudo attributes "span.kind" and
 	// "span.name".
 	RoutingAttributes []string `mapstructure:"routing_attributes"`
+
+	// BoundedLoad limits the load of each backend relative to the average load of all backends, by routing the keys
+	// of a backend at capacity to the next backends of the hash ring.
+	BoundedLoad configoptional.Optional[BoundedLoadConfig] `mapstructure:"bounded_load"`
+}
+
+// BoundedLoadConfig defines the configuration for consistent hashing with bounded loads
+type BoundedLoadConfig struct {
+	// BalanceFactor is the maximum load of a backend relative to the average load of all backends. It must be
+	// greater than 1.
+	BalanceFactor float64 `mapstructure:"balance_factor"`
+	// Window is the period over which the load of the backends is measured.
+	Window time.Duration `mapstructure:"window"`
+	// Weights holds the relative capacity of backends, by endpoint. The capacity of a backend is proportional to its
+	// weight, and backends without weight have a weight of 1.
+	Weights map[string]float64 `mapstructure:"weights"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the bounded load configuration is valid
+func (cfg *BoundedLoadConfig) Validate() error {
+	if cfg.BalanceFactor <= 1 {
+		return errors.New("balance_factor must be greater than 1")
+	}
+	if cfg.Window <= 0 {
+		return errors.New("window must be greater than 0")
+	}
+	for endpoint, weight := range cfg.Weights {
+		if weight <= 0 {
+			return fmt.Errorf("weight of endpoint %q must be greater than 0", endpoint)
+		}
+	}
+	return nil
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -65,6 +103,8 @@ type ResolverSettings struct {
 	DNS         configoptional.Optional[DNSResolver]         `mapstructure:"dns"`
 	K8sSvc      configoptional.Optional[K8sSvcResolver]      `mapstructure:"k8s"`
 	AWSCloudMap configoptional.Optional[AWSCloudMapResolver] `mapstructure:"aws_cloud_map"`
+	Consul      configoptional.Optional[ConsulResolver]      `mapstructure:"consul"`
+	File        configoptional.Optional[FileResolver]        `mapstructure:"file"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -104,3 +144,35 @@ type AWSCloudMapResolver struct {
 	Timeout       time.Duration            `mapstructure:"timeout"`
 	Port          *uint16                  `mapstructure:"port"`
 }
+
+// ConsulResolver defines the configuration for the resolver providing the healthy instances of a Consul service
+type ConsulResolver struct {
+	// ClientConfig configures the client of the Consul HTTP API. The endpoint defaults to http://127.0.0.1:8500, and
+	// the timeout, which also bounds each resolution, defaults to 1s.
+	confighttp.ClientConfig `mapstructure:",squash"`
+	// Service is the name of the Consul service to resolve.
+	Service string `mapstructure:"service"`
+	// Tag only resolves the instances of the service with this tag.
+	Tag string `mapstructure:"tag"`
+	// Datacenter is the datacenter to query. Defaults to the datacenter of the Consul agent.
+	Datacenter string `mapstructure:"datacenter"`
+	// Token is the ACL token used to query the Consul API.
+	Token configopaque.String `mapstructure:"token"`
+	// Port overrides the port registered for the instances of the service.
+	Port *uint16 `mapstructure:"port"`
+	// IncludeWarning also resolves the instances with checks in the warning state.
+	IncludeWarning bool          `mapstructure:"include_warning"`
+	Interval       time.Duration `mapstructure:"interval"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// FileResolver defines the configuration for the resolver reading the list of backends from a file
+type FileResolver struct {
+	// Path is the path of the YAML or JSON file holding the list of backends.
+	Path string `mapstructure:"path"`
+	// Interval is the interval at which the file is read, in addition to the reads triggered by changes of the file.
+	Interval time.Duration `mapstructure:"interval"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
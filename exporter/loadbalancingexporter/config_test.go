@@ -6,10 +6,16 @@ package loadbalancingexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
 )
@@ -25,3 +31,100 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, sub.Unmarshal(cfg))
 	require.NotNil(t, cfg)
 }
+
+func TestLoadBoundedLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+
+	for _, tt := range []struct {
+		id       component.ID
+		expected configoptional.Optional[BoundedLoadConfig]
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, ""),
+			expected: configoptional.Default(BoundedLoadConfig{
+				BalanceFactor: defaultBalanceFactor,
+				Window:        defaultBoundedLoadWindow,
+			}),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bounded_load"),
+			expected: configoptional.Some(BoundedLoadConfig{
+				BalanceFactor: 1.5,
+				Window:        defaultBoundedLoadWindow,
+				Weights:       map[string]float64{"endpoint-1": 2},
+			}),
+		},
+	} {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+			assert.Equal(t, tt.expected, cfg.(*Config).BoundedLoad)
+			assert.NoError(t, xconfmap.Validate(cfg))
+		})
+	}
+}
+
+func TestLoadConsulResolverConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "consul").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	expectedClient := confighttp.NewDefaultClientConfig()
+	expectedClient.Endpoint = "http://consul.example.com:8500"
+	expectedClient.Timeout = defaultResTimeout
+	expectedClient.Headers = configopaque.MapList{{Name: "X-Scope", Value: "collectors"}}
+	port := uint16(4317)
+	assert.Equal(t, configoptional.Some(ConsulResolver{
+		ClientConfig:   expectedClient,
+		Service:        "otelcol",
+		Tag:            "traces",
+		Datacenter:     "dc-1",
+		Port:           &port,
+		IncludeWarning: true,
+	}), cfg.(*Config).Resolver.Consul)
+}
+
+func TestBoundedLoadConfigValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		cfg         BoundedLoadConfig
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			cfg:  BoundedLoadConfig{BalanceFactor: 1.25, Window: time.Second},
+		},
+		{
+			name:        "balance factor too low",
+			cfg:         BoundedLoadConfig{BalanceFactor: 1, Window: time.Second},
+			expectedErr: "balance_factor must be greater than 1",
+		},
+		{
+			name:        "zero window",
+			cfg:         BoundedLoadConfig{BalanceFactor: 1.25},
+			expectedErr: "window must be greater than 0",
+		},
+		{
+			name:        "zero weight",
+			cfg:         BoundedLoadConfig{BalanceFactor: 1.25, Window: time.Second, Weights: map[string]float64{"endpoint-1": 0}},
+			expectedErr: `weight of endpoint "endpoint-1" must be greater than 0`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
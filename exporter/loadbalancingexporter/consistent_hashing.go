@@ -6,6 +6,8 @@ package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry
 import (
 	"encoding/binary"
 	"hash/crc32"
+	"iter"
+	"slices"
 	"sort"
 )
 
@@ -45,12 +47,40 @@ func (h *hashRing) endpointFor(identifier []byte) string {
 		// perhaps the ring itself couldn't get initialized yet?
 		return ""
 	}
+	return h.findEndpoint(positionForIdentifier(identifier))
+}
+
+// endpointsFor returns the distinct endpoints of the ring in the order they are found when walking the ring from the
+// position of the given identifier. The first endpoint is the one returned by endpointFor.
+func (h *hashRing) endpointsFor(identifier []byte) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if h == nil || len(h.items) == 0 {
+			return
+		}
+		pos := positionForIdentifier(identifier)
+		start := sort.Search(len(h.items), func(i int) bool {
+			return h.items[i].pos >= pos
+		})
+		seen := map[string]struct{}{}
+		for i := range h.items {
+			endpoint := h.items[(start+i)%len(h.items)].endpoint
+			if _, ok := seen[endpoint]; ok {
+				continue
+			}
+			if !yield(endpoint) {
+				return
+			}
+			seen[endpoint] = struct{}{}
+		}
+	}
+}
+
+// positionForIdentifier calculates the position in the ring of the given identifier
+func positionForIdentifier(identifier []byte) position {
 	hasher := crc32.NewIEEE()
 	hasher.Write(identifier)
 	hash := hasher.Sum32()
-	pos := hash % maxPositions
-
-	return h.findEndpoint(position(pos))
+	return position(hash % maxPositions)
 }
 
 // findEndpoint returns the "next" endpoint starting from the given position, or an empty string in case no endpoints are available
@@ -172,3 +202,41 @@ func (h *hashRing) equal(candidate *hashRing) bool {
 	}
 	return true
 }
+
+// endpoints returns the distinct endpoints of the ring, sorted.
+func (h *hashRing) endpoints() []string {
+	if h == nil {
+		return nil
+	}
+	endpoints := make([]string, 0, len(h.items))
+	for _, item := range h.items {
+		endpoints = append(endpoints, item.endpoint)
+	}
+	slices.Sort(endpoints)
+	return slices.Compact(endpoints)
+}
+
+// movedKeys returns the fraction of the positions of the ring that are assigned to a different endpoint in the
+// candidate ring, which is the fraction of the keys that are routed to a different endpoint after the change.
+func (h *hashRing) movedKeys(candidate *hashRing) float64 {
+	if h == nil || len(h.items) == 0 || candidate == nil || len(candidate.items) == 0 {
+		return 1
+	}
+
+	moved := 0
+	// i and j are the indexes of the items owning the current position in each ring, where the positions past the
+	// last item are owned by the first one
+	i, j := 0, 0
+	for pos := range position(maxPositions) {
+		for i < len(h.items) && h.items[i].pos < pos {
+			i++
+		}
+		for j < len(candidate.items) && candidate.items[j].pos < pos {
+			j++
+		}
+		if h.items[i%len(h.items)].endpoint != candidate.items[j%len(candidate.items)].endpoint {
+			moved++
+		}
+	}
+	return float64(moved) / float64(maxPositions)
+}
@@ -5,6 +5,7 @@ package loadbalancingexporter
 
 import (
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -225,3 +226,73 @@ func TestEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpointsFor(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}
+	ring := newHashRing(endpoints)
+
+	for _, id := range [][]byte{{1, 2, 0, 0}, {128, 128, 0, 0}, []byte("ad-service-7"), []byte("get-recommendations-1")} {
+		t.Run(fmt.Sprintf("Endpoints for id %s", string(id)), func(t *testing.T) {
+			// test
+			found := slices.Collect(ring.endpointsFor(id))
+
+			// verify
+			assert.Equal(t, ring.endpointFor(id), found[0])
+			assert.ElementsMatch(t, endpoints, found)
+		})
+	}
+}
+
+func TestEndpointsForEmptyRing(t *testing.T) {
+	var ring *hashRing
+	assert.Empty(t, slices.Collect(ring.endpointsFor([]byte{1, 2, 0, 0})))
+	assert.Empty(t, slices.Collect(newHashRing(nil).endpointsFor([]byte{1, 2, 0, 0})))
+}
+
+func TestRingEndpoints(t *testing.T) {
+	ring := newHashRing([]string{"endpoint-2", "endpoint-1", "endpoint-3"})
+	assert.Equal(t, []string{"endpoint-1", "endpoint-2", "endpoint-3"}, ring.endpoints())
+}
+
+func TestMovedKeys(t *testing.T) {
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+
+	for _, tt := range []struct {
+		name      string
+		candidate *hashRing
+		expected  float64
+		delta     float64
+	}{
+		{
+			name:      "same endpoints",
+			candidate: newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"}),
+			expected:  0,
+		},
+		{
+			name:      "added endpoint",
+			candidate: newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}),
+			expected:  0.25,
+			delta:     0.1,
+		},
+		{
+			name:      "removed endpoint",
+			candidate: newHashRing([]string{"endpoint-1", "endpoint-2"}),
+			expected:  1.0 / 3,
+			delta:     0.1,
+		},
+		{
+			name:      "different endpoints",
+			candidate: newHashRing([]string{"endpoint-4"}),
+			expected:  1,
+		},
+		{
+			name:     "nil",
+			expected: 1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, ring.movedKeys(tt.candidate), tt.delta)
+		})
+	}
+}
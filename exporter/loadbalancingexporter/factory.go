@@ -47,7 +47,14 @@ func createDefaultConfig() component.Config {
 		Protocol: Protocol{
 			OTLP: *otlpDefaultCfg,
 		},
+		Resolver: ResolverSettings{
+			Consul: configoptional.Default(ConsulResolver{ClientConfig: defaultConsulClientConfig()}),
+		},
 		QueueSettings: configoptional.Default(exporterhelper.NewDefaultQueueConfig()),
+		BoundedLoad: configoptional.Default(BoundedLoadConfig{
+			BalanceFactor: defaultBalanceFactor,
+			Window:        defaultBoundedLoadWindow,
+		}),
 	}
 }
 
@@ -6,6 +6,7 @@ require (
 	github.com/aws/aws-sdk-go-v2/config v1.32.7
 	github.com/aws/aws-sdk-go-v2/service/servicediscovery v1.39.22
 	github.com/aws/smithy-go v1.24.0
+	github.com/fsnotify/fsnotify v1.9.0
 	github.com/goccy/go-json v0.10.5
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics v0.143.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal v0.143.0
@@ -14,9 +15,13 @@ require (
 	github.com/stretchr/testify v1.11.1
 	go.opentelemetry.io/collector/component v1.49.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/component/componenttest v0.143.1-0.20260115162016-5e41fb551263
+	go.opentelemetry.io/collector/config/configauth v1.49.1-0.20260115162016-5e41fb551263
+	go.opentelemetry.io/collector/config/confighttp v0.143.1-0.20260115162016-5e41fb551263
+	go.opentelemetry.io/collector/config/configopaque v1.49.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/config/configoptional v1.49.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/config/configretry v1.49.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/confmap v1.49.1-0.20260115162016-5e41fb551263
+	go.opentelemetry.io/collector/confmap/xconfmap v0.143.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/consumer v1.49.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/consumer/consumererror v0.143.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/consumer/consumertest v0.143.1-0.20260115162016-5e41fb551263
@@ -61,8 +66,8 @@ require (
 	github.com/ebitengine/purego v0.9.1 // indirect
 	github.com/emicklei/go-restful/v3 v3.12.2 // indirect
 	github.com/evanphx/json-patch/v5 v5.9.11 // indirect
+	github.com/felixge/httpsnoop v1.0.4 // indirect
 	github.com/foxboron/go-tpm-keyfiles v0.0.0-20251226215517-609e4778396f // indirect
-	github.com/fsnotify/fsnotify v1.9.0 // indirect
 	github.com/fxamacker/cbor/v2 v2.9.0 // indirect
 	github.com/go-logr/logr v1.4.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
@@ -97,6 +102,7 @@ require (
 	github.com/mostynb/go-grpc-compression v1.2.3 // indirect
 	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil v0.143.0 // indirect
+	github.com/pierrec/lz4/v4 v4.1.23 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
 	github.com/power-devops/perfstat v0.0.0-20240221224432-82ca36839d55 // indirect
@@ -105,6 +111,7 @@ require (
 	github.com/prometheus/common v0.67.1 // indirect
 	github.com/prometheus/otlptranslator v0.0.2 // indirect
 	github.com/prometheus/procfs v0.17.0 // indirect
+	github.com/rs/cors v1.11.1 // indirect
 	github.com/shirou/gopsutil/v4 v4.25.12 // indirect
 	github.com/spf13/cobra v1.10.2 // indirect
 	github.com/spf13/pflag v1.0.10 // indirect
@@ -116,19 +123,16 @@ require (
 	go.opentelemetry.io/collector v0.143.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/client v1.49.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/component/componentstatus v0.143.1-0.20260115162016-5e41fb551263 // indirect
-	go.opentelemetry.io/collector/config/configauth v1.49.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/config/configcompression v1.49.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/config/configgrpc v0.143.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/config/configmiddleware v1.49.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/config/confignet v1.49.1-0.20260115162016-5e41fb551263 // indirect
-	go.opentelemetry.io/collector/config/configopaque v1.49.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/config/configtelemetry v0.143.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/config/configtls v1.49.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/confmap/provider/envprovider v1.49.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/confmap/provider/fileprovider v1.49.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/confmap/provider/httpprovider v1.49.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/confmap/provider/yamlprovider v1.49.1-0.20260115162016-5e41fb551263 // indirect
-	go.opentelemetry.io/collector/confmap/xconfmap v0.143.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/connector v0.143.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/connector/connectortest v0.143.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/connector/xconnector v0.143.1-0.20260115162016-5e41fb551263 // indirect
@@ -161,6 +165,7 @@ require (
 	go.opentelemetry.io/collector/service v0.143.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/collector/service/hostcapabilities v0.143.1-0.20260115162016-5e41fb551263 // indirect
 	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.63.0 // indirect
+	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.63.0 // indirect
 	go.opentelemetry.io/contrib/otelconf v0.18.0 // indirect
 	go.opentelemetry.io/contrib/propagators/b3 v1.39.0 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc v0.14.0 // indirect
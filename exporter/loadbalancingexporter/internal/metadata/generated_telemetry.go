@@ -22,14 +22,17 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                         metric.Meter
-	mu                            sync.Mutex
-	registrations                 []metric.Registration
-	LoadbalancerBackendLatency    metric.Int64Histogram
-	LoadbalancerBackendOutcome    metric.Int64Counter
-	LoadbalancerNumBackendUpdates metric.Int64Counter
-	LoadbalancerNumBackends       metric.Int64Gauge
-	LoadbalancerNumResolutions    metric.Int64Counter
+	meter                            metric.Meter
+	mu                               sync.Mutex
+	registrations                    []metric.Registration
+	LoadbalancerBackendLatency       metric.Int64Histogram
+	LoadbalancerBackendOutcome       metric.Int64Counter
+	LoadbalancerBoundedLoadOverflows metric.Int64Counter
+	LoadbalancerNumBackendUpdates    metric.Int64Counter
+	LoadbalancerNumBackends          metric.Int64Gauge
+	LoadbalancerNumResolutions       metric.Int64Counter
+	LoadbalancerRingBackendChanges   metric.Int64Counter
+	LoadbalancerRingKeyMovement      metric.Float64Histogram
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -74,6 +77,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{outcomes}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.LoadbalancerBoundedLoadOverflows, err = builder.meter.Int64Counter(
+		"otelcol_loadbalancer_bounded_load_overflows",
+		metric.WithDescription("Number of keys routed to another backend because their backend was over capacity. [Development]"),
+		metric.WithUnit("{keys}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.LoadbalancerNumBackendUpdates, err = builder.meter.Int64Counter(
 		"otelcol_loadbalancer_num_backend_updates",
 		metric.WithDescription("Number of times the list of backends was updated. [Development]"),
@@ -92,5 +101,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{resolutions}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.LoadbalancerRingBackendChanges, err = builder.meter.Int64Counter(
+		"otelcol_loadbalancer_ring_backend_changes",
+		metric.WithDescription("Number of backends added to or removed from the hash ring. [Development]"),
+		metric.WithUnit("{backends}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.LoadbalancerRingKeyMovement, err = builder.meter.Float64Histogram(
+		"otelcol_loadbalancer_ring_key_movement",
+		metric.WithDescription("Percentage of the key space assigned to a different backend when the hash ring is updated. [Development]"),
+		metric.WithUnit("%"),
+		metric.WithExplicitBucketBoundaries([]float64{1, 5, 10, 20, 30, 50, 75, 100}...),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
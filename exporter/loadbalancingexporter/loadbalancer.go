@@ -12,6 +12,8 @@ import (
 	"sync"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
@@ -24,6 +26,9 @@ const (
 var (
 	errNoResolver                = errors.New("no resolvers specified for the exporter")
 	errMultipleResolversProvided = errors.New("only one resolver should be specified")
+
+	ringBackendAddedAttrSet   = attribute.NewSet(attribute.String("change", "added"))
+	ringBackendRemovedAttrSet = attribute.NewSet(attribute.String("change", "removed"))
 )
 
 type componentFactory func(ctx context.Context, endpoint string) (component.Component, error)
@@ -34,12 +39,15 @@ type loadBalancer struct {
 
 	res  resolver
 	ring *hashRing
+	// loads is nil unless consistent hashing with bounded loads is enabled
+	loads *boundedLoad
 
 	componentFactory componentFactory
 	exporters        map[string]*wrappedExporter
 
 	stopped    bool
 	updateLock sync.RWMutex
+	telemetry  *metadata.TelemetryBuilder
 }
 
 // Create new load balancer
@@ -59,6 +67,12 @@ func newLoadBalancer(logger *zap.Logger, cfg component.Config, factory component
 	if oCfg.Resolver.K8sSvc.HasValue() {
 		count++
 	}
+	if oCfg.Resolver.Consul.HasValue() {
+		count++
+	}
+	if oCfg.Resolver.File.HasValue() {
+		count++
+	}
 	if count > 1 {
 		return nil, errMultipleResolversProvided
 	}
@@ -132,22 +146,60 @@ func newLoadBalancer(logger *zap.Logger, cfg component.Config, factory component
 		}
 	}
 
+	if oCfg.Resolver.Consul.HasValue() {
+		consulLogger := logger.With(zap.String("resolver", "consul"))
+
+		var err error
+		res, err = newConsulResolver(
+			consulLogger,
+			oCfg.Resolver.Consul.Get(),
+			telemetry,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if oCfg.Resolver.File.HasValue() {
+		fileLogger := logger.With(zap.String("resolver", "file"))
+
+		var err error
+		fileResolver := oCfg.Resolver.File.Get()
+		res, err = newFileResolver(
+			fileLogger,
+			fileResolver.Path,
+			fileResolver.Interval,
+			telemetry,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		return nil, errNoResolver
 	}
 
+	var loads *boundedLoad
+	if oCfg.BoundedLoad.HasValue() {
+		boundedLoadCfg := oCfg.BoundedLoad.Get()
+		loads = newBoundedLoad(boundedLoadCfg.BalanceFactor, boundedLoadCfg.Window, boundedLoadCfg.Weights)
+	}
+
 	return &loadBalancer{
 		logger:           logger,
 		res:              res,
+		loads:            loads,
 		componentFactory: factory,
 		exporters:        map[string]*wrappedExporter{},
+		telemetry:        telemetry,
 	}, nil
 }
 
 func (lb *loadBalancer) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
-	return lb.res.start(ctx)
+	return lb.res.start(ctx, host)
 }
 
 func (lb *loadBalancer) onBackendChanges(resolved []string) {
@@ -157,17 +209,50 @@ func (lb *loadBalancer) onBackendChanges(resolved []string) {
 		lb.updateLock.Lock()
 		defer lb.updateLock.Unlock()
 
-		lb.ring = newRing
-
 		// TODO: set a timeout?
 		ctx := context.Background()
 
+		endpoints := newRing.endpoints()
+		lb.recordRingChange(ctx, endpoints, newRing)
+		lb.ring = newRing
+		if lb.loads != nil {
+			lb.loads.setEndpoints(endpoints)
+		}
+
 		// add the missing exporters first
 		lb.addMissingExporters(ctx, resolved)
 		lb.removeExtraExporters(ctx, resolved)
 	}
 }
 
+// recordRingChange records the backends added to and removed from the ring, and the part of the keys that are
+// routed to a different backend after the change.
+func (lb *loadBalancer) recordRingChange(ctx context.Context, endpoints []string, newRing *hashRing) {
+	previous := lb.ring.endpoints()
+	added, removed := 0, 0
+	for _, endpoint := range endpoints {
+		if _, found := slices.BinarySearch(previous, endpoint); !found {
+			added++
+		}
+	}
+	for _, endpoint := range previous {
+		if _, found := slices.BinarySearch(endpoints, endpoint); !found {
+			removed++
+		}
+	}
+	if added > 0 {
+		lb.telemetry.LoadbalancerRingBackendChanges.Add(ctx, int64(added), metric.WithAttributeSet(ringBackendAddedAttrSet))
+	}
+	if removed > 0 {
+		lb.telemetry.LoadbalancerRingBackendChanges.Add(ctx, int64(removed), metric.WithAttributeSet(ringBackendRemovedAttrSet))
+	}
+
+	// the key movement is only meaningful if keys were routed before and after the change
+	if len(previous) > 0 && len(endpoints) > 0 {
+		lb.telemetry.LoadbalancerRingKeyMovement.Record(ctx, 100*lb.ring.movedKeys(newRing))
+	}
+}
+
 func (lb *loadBalancer) addMissingExporters(ctx context.Context, endpoints []string) {
 	for _, endpoint := range endpoints {
 		endpoint = endpointWithPort(endpoint)
@@ -229,7 +314,16 @@ func (lb *loadBalancer) exporterAndEndpoint(identifier []byte) (*wrappedExporter
 	// for details: https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/1690
 	lb.updateLock.RLock()
 	defer lb.updateLock.RUnlock()
-	endpoint := lb.ring.endpointFor(identifier)
+	var endpoint string
+	if lb.loads != nil {
+		var overflowed string
+		endpoint, overflowed = lb.loads.endpointFor(lb.ring, identifier)
+		if overflowed != "" {
+			lb.telemetry.LoadbalancerBoundedLoadOverflows.Add(context.Background(), 1, metric.WithAttributes(attribute.String("endpoint", overflowed)))
+		}
+	} else {
+		endpoint = lb.ring.endpointFor(identifier)
+	}
 	exp, found := lb.exporters[endpointWithPort(endpoint)]
 	if !found {
 		// something is really wrong... how come we couldn't find the exporter??
@@ -6,7 +6,9 @@ package loadbalancingexporter
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,9 +18,14 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadatatest"
 )
 
 func TestNewLoadBalancerNoResolver(t *testing.T) {
@@ -238,6 +245,92 @@ func TestOnBackendChanges(t *testing.T) {
 	assert.Len(t, p.ring.items, 2*defaultWeight)
 }
 
+func TestOnBackendChangesRecordsRingChanges(t *testing.T) {
+	// prepare
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() {
+		require.NoError(t, tt.Shutdown(context.Background())) //nolint:usetesting // Context must outlive test for cleanup
+	})
+	tb, err := metadata.NewTelemetryBuilder(tt.NewTelemetrySettings())
+	require.NoError(t, err)
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(zap.NewNop(), simpleConfig(), componentFactory, tb)
+	require.NoError(t, err)
+
+	// test
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2", "endpoint-4"})
+
+	// verify
+	metadatatest.AssertEqualLoadbalancerRingBackendChanges(t, tt, []metricdata.DataPoint[int64]{
+		{
+			Attributes: attribute.NewSet(attribute.String("change", "added")),
+			Value:      4,
+		},
+		{
+			Attributes: attribute.NewSet(attribute.String("change", "removed")),
+			Value:      1,
+		},
+	}, metricdatatest.IgnoreTimestamp())
+	got, err := tt.GetMetric("otelcol_loadbalancer_ring_key_movement")
+	require.NoError(t, err)
+	keyMovement := got.Data.(metricdata.Histogram[float64]).DataPoints
+	require.Len(t, keyMovement, 1)
+	assert.Equal(t, uint64(1), keyMovement[0].Count)
+	// the keys of the removed endpoint and about a third of the keys of the other endpoints were moved
+	assert.InDelta(t, 100*(1.0/3+2.0/9), keyMovement[0].Sum, 10)
+}
+
+func TestBoundedLoadOverflows(t *testing.T) {
+	// prepare
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() {
+		require.NoError(t, tt.Shutdown(context.Background())) //nolint:usetesting // Context must outlive test for cleanup
+	})
+	tb, err := metadata.NewTelemetryBuilder(tt.NewTelemetrySettings())
+	require.NoError(t, err)
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	cfg := simpleConfig()
+	cfg.BoundedLoad = configoptional.Some(BoundedLoadConfig{
+		BalanceFactor: 1.5,
+		Window:        time.Hour,
+	})
+	p, err := newLoadBalancer(zap.NewNop(), cfg, componentFactory, tb)
+	require.NoError(t, err)
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+	preferred := p.ring.endpointFor([]byte("trace-0"))
+	var keys [][]byte
+	for i := 0; len(keys) < 100; i++ {
+		key := fmt.Appendf(nil, "trace-%d", i)
+		if p.ring.endpointFor(key) == preferred {
+			keys = append(keys, key)
+		}
+	}
+
+	// test: the keys are routed twice, and keep their endpoint the second time
+	routed := map[string]int{}
+	for range 2 {
+		for _, key := range keys {
+			_, endpoint, err := p.exporterAndEndpoint(key)
+			require.NoError(t, err)
+			routed[endpoint]++
+		}
+	}
+
+	// verify: the preferred endpoint gets up to 1.5 times the average load of 50 keys
+	assert.Equal(t, 150, routed[preferred])
+	metadatatest.AssertEqualLoadbalancerBoundedLoadOverflows(t, tt, []metricdata.DataPoint[int64]{
+		{
+			Attributes: attribute.NewSet(attribute.String("endpoint", preferred)),
+			Value:      25,
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
+
 func TestRemoveExtraExporters(t *testing.T) {
 	// prepare
 	ts, tb := getTelemetryAssets(t)
@@ -431,6 +524,105 @@ func TestNewLoadBalancerInvalidServiceAwsResolver(t *testing.T) {
 	assert.True(t, clientcmd.IsConfigurationInvalid(err) || errors.Is(err, errNoServiceName))
 }
 
+func TestWithConsulResolver(t *testing.T) {
+	ts, tb := getTelemetryAssets(t)
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Consul: configoptional.Some(ConsulResolver{
+				Service: "otelcol",
+			}),
+		},
+	}
+
+	p, err := newLoadBalancer(ts.Logger, cfg, nil, tb)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res, ok := p.res.(*consulResolver)
+
+	// verify
+	assert.NotNil(t, res)
+	assert.True(t, ok)
+}
+
+func TestNewLoadBalancerInvalidConsulResolver(t *testing.T) {
+	// prepare
+	ts, tb := getTelemetryAssets(t)
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Consul: configoptional.Some(ConsulResolver{}),
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(ts.Logger, cfg, nil, tb)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errNoConsulService, err)
+}
+
+func TestWithFileResolver(t *testing.T) {
+	ts, tb := getTelemetryAssets(t)
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			File: configoptional.Some(FileResolver{
+				Path: "endpoints.yaml",
+			}),
+		},
+	}
+
+	p, err := newLoadBalancer(ts.Logger, cfg, nil, tb)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res, ok := p.res.(*fileResolver)
+
+	// verify
+	assert.NotNil(t, res)
+	assert.True(t, ok)
+}
+
+func TestNewLoadBalancerInvalidFileResolver(t *testing.T) {
+	// prepare
+	ts, tb := getTelemetryAssets(t)
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			File: configoptional.Some(FileResolver{}),
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(ts.Logger, cfg, nil, tb)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errNoFilePath, err)
+}
+
+func TestMultipleResolversWithConsulAndFile(t *testing.T) {
+	ts, tb := getTelemetryAssets(t)
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Consul: configoptional.Some(ConsulResolver{
+				Service: "otelcol",
+			}),
+			File: configoptional.Some(FileResolver{
+				Path: "endpoints.yaml",
+			}),
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(ts.Logger, cfg, nil, tb)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleResolversProvided, err)
+}
+
 func newNopMockExporter() *wrappedExporter {
 	return newWrappedExporter(mockComponent{}, "mock")
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/servicediscovery"
 	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
@@ -119,7 +120,7 @@ func newCloudMapResolver(
 	}, nil
 }
 
-func (r *cloudMapResolver) start(ctx context.Context) error {
+func (r *cloudMapResolver) start(ctx context.Context, _ component.Host) error {
 	if _, err := r.resolve(ctx); err != nil {
 		r.logger.Warn("failed initial resolve", zap.Error(err))
 	}
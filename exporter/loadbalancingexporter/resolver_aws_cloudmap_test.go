@@ -13,6 +13,7 @@ import (
 	"github.com/aws/smithy-go/middleware"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/zap"
 )
 
@@ -45,7 +46,7 @@ func TestInitialCloudMapResolution(t *testing.T) {
 	res.onChange(func(endpoints []string) {
 		resolved = endpoints
 	})
-	require.NoError(t, res.start(t.Context()))
+	require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
 	defer func() {
 		require.NoError(t, res.shutdown(t.Context()))
 	}()
@@ -79,7 +80,7 @@ func TestInitialCloudMapResolutionWithPort(t *testing.T) {
 	res.onChange(func(endpoints []string) {
 		resolved = endpoints
 	})
-	require.NoError(t, res.start(t.Context()))
+	require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
 	defer func() {
 		require.NoError(t, res.shutdown(t.Context()))
 	}()
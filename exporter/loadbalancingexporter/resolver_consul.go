@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+)
+
+var _ resolver = (*consulResolver)(nil)
+
+const defaultConsulEndpoint = "http://127.0.0.1:8500"
+
+// defaultConsulClientConfig returns the default configuration of the client of the Consul HTTP API.
+func defaultConsulClientConfig() confighttp.ClientConfig {
+	cfg := confighttp.NewDefaultClientConfig()
+	cfg.Endpoint = defaultConsulEndpoint
+	cfg.Timeout = defaultResTimeout
+	return cfg
+}
+
+var (
+	errNoConsulService = errors.New("no Consul service specified to resolve the backends")
+
+	consulResolverAttr           = attribute.String("resolver", "consul")
+	consulResolverAttrSet        = attribute.NewSet(consulResolverAttr)
+	consulResolverSuccessAttrSet = attribute.NewSet(consulResolverAttr, attribute.Bool("success", true))
+	consulResolverFailureAttrSet = attribute.NewSet(consulResolverAttr, attribute.Bool("success", false))
+)
+
+// consulServiceEntry is the subset of an entry returned by the Consul health
+// API that is needed to build the list of backends.
+type consulServiceEntry struct {
+	Node struct {
+		Address string `json:"Address"`
+	} `json:"Node"`
+	Service struct {
+		Address string `json:"Address"`
+		Port    int    `json:"Port"`
+	} `json:"Service"`
+	Checks []struct {
+		Status string `json:"Status"`
+	} `json:"Checks"`
+}
+
+type consulResolver struct {
+	logger *zap.Logger
+
+	clientConfig   confighttp.ClientConfig
+	client         *http.Client
+	serviceURL     string
+	token          string
+	port           *uint16
+	includeWarning bool
+	resInterval    time.Duration
+	resTimeout     time.Duration
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+	telemetry          *metadata.TelemetryBuilder
+}
+
+func newConsulResolver(
+	logger *zap.Logger,
+	cfg *ConsulResolver,
+	tb *metadata.TelemetryBuilder,
+) (*consulResolver, error) {
+	if cfg.Service == "" {
+		return nil, errNoConsulService
+	}
+
+	clientConfig := cfg.ClientConfig
+	if clientConfig.Endpoint == "" {
+		clientConfig.Endpoint = defaultConsulEndpoint
+	}
+	if clientConfig.Timeout == 0 {
+		clientConfig.Timeout = defaultResTimeout
+	}
+	endpoint := clientConfig.Endpoint
+	serviceURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Consul endpoint %q: %w", endpoint, err)
+	}
+	serviceURL = serviceURL.JoinPath("v1", "health", "service", cfg.Service)
+	query := serviceURL.Query()
+	if cfg.Tag != "" {
+		query.Set("tag", cfg.Tag)
+	}
+	if cfg.Datacenter != "" {
+		query.Set("dc", cfg.Datacenter)
+	}
+	serviceURL.RawQuery = query.Encode()
+
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaultResInterval
+	}
+	return &consulResolver{
+		logger:         logger,
+		clientConfig:   clientConfig,
+		serviceURL:     serviceURL.String(),
+		token:          string(cfg.Token),
+		port:           cfg.Port,
+		includeWarning: cfg.IncludeWarning,
+		resInterval:    interval,
+		resTimeout:     clientConfig.Timeout,
+		stopCh:         make(chan struct{}),
+		telemetry:      tb,
+	}, nil
+}
+
+func (r *consulResolver) start(ctx context.Context, host component.Host) error {
+	client, err := r.clientConfig.ToClient(ctx, host.GetExtensions(), component.TelemetrySettings{Logger: r.logger})
+	if err != nil {
+		return fmt.Errorf("failed to create the Consul client: %w", err)
+	}
+	r.client = client
+
+	resolveCtx, cancel := context.WithTimeout(ctx, r.resTimeout)
+	defer cancel()
+	if _, err := r.resolve(resolveCtx); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+
+	r.shutdownWg.Add(1)
+	go r.periodicallyResolve()
+
+	r.logger.Debug("Consul resolver started",
+		zap.String("url", r.serviceURL),
+		zap.Duration("interval", r.resInterval), zap.Duration("timeout", r.resTimeout))
+	return nil
+}
+
+func (r *consulResolver) shutdown(_ context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *consulResolver) periodicallyResolve() {
+	ticker := time.NewTicker(r.resInterval)
+	defer ticker.Stop()
+	defer r.shutdownWg.Done()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), r.resTimeout)
+			if _, err := r.resolve(ctx); err != nil {
+				r.logger.Warn("failed to resolve", zap.Error(err))
+			} else {
+				r.logger.Debug("resolved successfully")
+			}
+			cancel()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *consulResolver) resolve(ctx context.Context) ([]string, error) {
+	entries, err := r.lookupService(ctx)
+	if err != nil {
+		r.telemetry.LoadbalancerNumResolutions.Add(ctx, 1, metric.WithAttributeSet(consulResolverFailureAttrSet))
+		return nil, err
+	}
+
+	r.telemetry.LoadbalancerNumResolutions.Add(ctx, 1, metric.WithAttributeSet(consulResolverSuccessAttrSet))
+
+	backends := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !r.isHealthy(entry) {
+			continue
+		}
+
+		address := entry.Service.Address
+		if address == "" {
+			// services registered without an address use the one of their node
+			address = entry.Node.Address
+		}
+		port := entry.Service.Port
+		if r.port != nil {
+			port = int(*r.port)
+		}
+		backends = append(backends, net.JoinHostPort(address, strconv.Itoa(port)))
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	if equalStringSlice(r.endpoints, backends) {
+		return r.endpoints, nil
+	}
+
+	// the list has changed!
+	r.updateLock.Lock()
+	r.endpoints = backends
+	r.updateLock.Unlock()
+	r.telemetry.LoadbalancerNumBackends.Record(ctx, int64(len(backends)), metric.WithAttributeSet(consulResolverAttrSet))
+	r.telemetry.LoadbalancerNumBackendUpdates.Add(ctx, 1, metric.WithAttributeSet(consulResolverAttrSet))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(r.endpoints)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return r.endpoints, nil
+}
+
+// lookupService returns the instances of the service from the Consul health API.
+func (r *consulResolver) lookupService(ctx context.Context) ([]consulServiceEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.serviceURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	if r.token != "" {
+		req.Header.Set("X-Consul-Token", r.token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code from Consul: %d", resp.StatusCode)
+	}
+
+	var entries []consulServiceEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to decode Consul response: %w", err)
+	}
+	return entries, nil
+}
+
+// isHealthy returns true if none of the checks of the instance is critical,
+// and none is in warning unless instances in warning are included.
+func (r *consulResolver) isHealthy(entry consulServiceEntry) bool {
+	for _, check := range entry.Checks {
+		switch check.Status {
+		case "passing":
+		case "warning":
+			if !r.includeWarning {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (r *consulResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.uber.org/zap"
+)
+
+const consulHealthResponse = `[
+  {
+    "Node": {"Address": "10.0.0.1"},
+    "Service": {"Address": "192.168.0.1", "Port": 4317},
+    "Checks": [{"Status": "passing"}, {"Status": "passing"}]
+  },
+  {
+    "Node": {"Address": "10.0.0.2"},
+    "Service": {"Address": "", "Port": 4317},
+    "Checks": [{"Status": "passing"}]
+  },
+  {
+    "Node": {"Address": "10.0.0.3"},
+    "Service": {"Address": "192.168.0.3", "Port": 4317},
+    "Checks": [{"Status": "passing"}, {"Status": "warning"}]
+  },
+  {
+    "Node": {"Address": "10.0.0.4"},
+    "Service": {"Address": "192.168.0.4", "Port": 4317},
+    "Checks": [{"Status": "critical"}]
+  },
+  {
+    "Node": {"Address": "10.0.0.5"},
+    "Service": {"Address": "fd00::5", "Port": 4317},
+    "Checks": []
+  }
+]`
+
+// fakeConsul is a fake Consul HTTP API serving the health of a service.
+type fakeConsul struct {
+	mu       sync.Mutex
+	response string
+	status   int
+	requests []*http.Request
+}
+
+func newFakeConsul(t *testing.T, response string) (*fakeConsul, *httptest.Server) {
+	f := &fakeConsul{response: response, status: http.StatusOK}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, r)
+		w.WriteHeader(f.status)
+		_, _ = w.Write([]byte(f.response))
+	}))
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeConsul) set(status int, response string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = status
+	f.response = response
+}
+
+func (f *fakeConsul) lastRequest() *http.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[len(f.requests)-1]
+}
+
+func TestInitialConsulResolution(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		cfg      ConsulResolver
+		expected []string
+	}{
+		{
+			name:     "healthy instances",
+			cfg:      ConsulResolver{Service: "otelcol"},
+			expected: []string{"10.0.0.2:4317", "192.168.0.1:4317", "[fd00::5]:4317"},
+		},
+		{
+			name:     "include warning",
+			cfg:      ConsulResolver{Service: "otelcol", IncludeWarning: true},
+			expected: []string{"10.0.0.2:4317", "192.168.0.1:4317", "192.168.0.3:4317", "[fd00::5]:4317"},
+		},
+		{
+			name:     "port override",
+			cfg:      ConsulResolver{Service: "otelcol", Port: &port},
+			expected: []string{"10.0.0.2:1234", "192.168.0.1:1234", "[fd00::5]:1234"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			_, tb := getTelemetryAssets(t)
+			_, srv := newFakeConsul(t, consulHealthResponse)
+			tt.cfg.Endpoint = srv.URL
+			res, err := newConsulResolver(zap.NewNop(), &tt.cfg, tb)
+			require.NoError(t, err)
+
+			// test
+			var resolved []string
+			res.onChange(func(endpoints []string) {
+				resolved = endpoints
+			})
+			require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, res.shutdown(t.Context()))
+			}()
+
+			// verify
+			assert.Equal(t, tt.expected, resolved)
+		})
+	}
+}
+
+func TestConsulResolutionRequest(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	f, srv := newFakeConsul(t, "[]")
+	res, err := newConsulResolver(zap.NewNop(), &ConsulResolver{
+		ClientConfig: confighttp.ClientConfig{
+			Endpoint: srv.URL,
+			Headers:  configopaque.MapList{{Name: "X-Scope", Value: "collectors"}},
+		},
+		Service:    "otelcol",
+		Tag:        "traces",
+		Datacenter: "dc-1",
+		Token:      "secret",
+	}, tb)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, res.shutdown(t.Context()))
+	}()
+
+	// verify
+	req := f.lastRequest()
+	assert.Equal(t, "/v1/health/service/otelcol", req.URL.Path)
+	assert.Equal(t, "traces", req.URL.Query().Get("tag"))
+	assert.Equal(t, "dc-1", req.URL.Query().Get("dc"))
+	assert.Equal(t, "secret", req.Header.Get("X-Consul-Token"))
+	assert.Equal(t, "collectors", req.Header.Get("X-Scope"))
+}
+
+func TestConsulResolverClientFailure(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	cfg := &ConsulResolver{Service: "otelcol"}
+	cfg.Auth = configoptional.Some(configauth.Config{AuthenticatorID: component.MustNewID("missing")})
+	res, err := newConsulResolver(zap.NewNop(), cfg, tb)
+	require.NoError(t, err)
+
+	// test
+	err = res.start(t.Context(), componenttest.NewNopHost())
+
+	// verify
+	assert.ErrorContains(t, err, "failed to create the Consul client")
+}
+
+func TestConsulResolutionChange(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	f, srv := newFakeConsul(t, consulHealthResponse)
+	res, err := newConsulResolver(zap.NewNop(), &ConsulResolver{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Service:      "otelcol",
+		Interval:     10 * time.Millisecond,
+	}, tb)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, res.shutdown(t.Context()))
+	}()
+
+	// test
+	f.set(http.StatusOK, `[{"Node": {"Address": "10.0.0.1"}, "Service": {"Port": 4317}, "Checks": [{"Status": "passing"}]}]`)
+
+	// verify
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(c, []string{"10.0.0.1:4317"}, resolved)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestConsulResolutionFailure(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	f, srv := newFakeConsul(t, consulHealthResponse)
+	res, err := newConsulResolver(zap.NewNop(), &ConsulResolver{
+		ClientConfig: confighttp.ClientConfig{Endpoint: srv.URL},
+		Service:      "otelcol",
+	}, tb)
+	require.NoError(t, err)
+	require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, res.shutdown(t.Context()))
+	}()
+
+	for _, tt := range []struct {
+		name        string
+		status      int
+		response    string
+		expectedErr string
+	}{
+		{
+			name:        "unexpected status",
+			status:      http.StatusForbidden,
+			expectedErr: "unexpected status code from Consul: 403",
+		},
+		{
+			name:        "invalid response",
+			status:      http.StatusOK,
+			response:    "{",
+			expectedErr: "failed to decode Consul response: unexpected EOF",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// test
+			f.set(tt.status, tt.response)
+			resolved, err := res.resolve(t.Context())
+
+			// verify
+			assert.EqualError(t, err, tt.expectedErr)
+			assert.Nil(t, resolved)
+			assert.Len(t, res.endpoints, 3, "the previous backends are kept")
+		})
+	}
+}
+
+func TestConsulResolverWithoutService(t *testing.T) {
+	_, tb := getTelemetryAssets(t)
+	res, err := newConsulResolver(zap.NewNop(), &ConsulResolver{}, tb)
+	assert.Nil(t, res)
+	assert.Equal(t, errNoConsulService, err)
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
@@ -88,7 +89,7 @@ func newDNSResolver(
 	}, nil
 }
 
-func (r *dnsResolver) start(ctx context.Context) error {
+func (r *dnsResolver) start(ctx context.Context, _ component.Host) error {
 	if _, err := r.resolve(ctx); err != nil {
 		r.logger.Warn("failed to resolve", zap.Error(err))
 	}
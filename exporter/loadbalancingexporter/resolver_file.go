@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+)
+
+var _ resolver = (*fileResolver)(nil)
+
+const defaultFileResInterval = 30 * time.Second
+
+var (
+	errNoFilePath = errors.New("no file path specified to resolve the backends")
+
+	fileResolverAttr           = attribute.String("resolver", "file")
+	fileResolverAttrSet        = attribute.NewSet(fileResolverAttr)
+	fileResolverSuccessAttrSet = attribute.NewSet(fileResolverAttr, attribute.Bool("success", true))
+	fileResolverFailureAttrSet = attribute.NewSet(fileResolverAttr, attribute.Bool("success", false))
+)
+
+// fileResolver reads the list of backends from a YAML or JSON file, and
+// reloads it whenever the file changes. The file is also read periodically,
+// in case a change is not reported by the file system.
+type fileResolver struct {
+	logger *zap.Logger
+
+	path        string
+	resInterval time.Duration
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	watcher            *fsnotify.Watcher
+	stopCh             chan struct{}
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+	telemetry          *metadata.TelemetryBuilder
+}
+
+func newFileResolver(
+	logger *zap.Logger,
+	path string,
+	interval time.Duration,
+	tb *metadata.TelemetryBuilder,
+) (*fileResolver, error) {
+	if path == "" {
+		return nil, errNoFilePath
+	}
+	if interval == 0 {
+		interval = defaultFileResInterval
+	}
+
+	return &fileResolver{
+		logger:      logger,
+		path:        filepath.Clean(path),
+		resInterval: interval,
+		stopCh:      make(chan struct{}),
+		telemetry:   tb,
+	}, nil
+}
+
+func (r *fileResolver) start(ctx context.Context, _ component.Host) error {
+	if _, err := r.resolve(ctx); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	// The directory is watched rather than the file, so that files replaced
+	// by a rename, as done by editors and configuration management tools,
+	// keep being watched.
+	if err := watcher.Add(filepath.Dir(r.path)); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("failed to watch %q: %w", r.path, err)
+	}
+	r.watcher = watcher
+
+	r.shutdownWg.Add(1)
+	go r.watch()
+
+	r.logger.Debug("file resolver started",
+		zap.String("path", r.path), zap.Duration("interval", r.resInterval))
+	return nil
+}
+
+func (r *fileResolver) shutdown(_ context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	if r.watcher != nil {
+		return r.watcher.Close()
+	}
+	return nil
+}
+
+func (r *fileResolver) watch() {
+	ticker := time.NewTicker(r.resInterval)
+	defer ticker.Stop()
+	defer r.shutdownWg.Done()
+
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != r.path || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				continue
+			}
+			r.reload()
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			r.logger.Warn("failed to watch file", zap.Error(err))
+		case <-ticker.C:
+			r.reload()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *fileResolver) reload() {
+	if _, err := r.resolve(context.Background()); err != nil {
+		r.logger.Warn("failed to resolve, keeping the current backends", zap.Error(err))
+	} else {
+		r.logger.Debug("resolved successfully")
+	}
+}
+
+func (r *fileResolver) resolve(ctx context.Context) ([]string, error) {
+	backends, err := readEndpointsFile(r.path)
+	if err != nil {
+		r.telemetry.LoadbalancerNumResolutions.Add(ctx, 1, metric.WithAttributeSet(fileResolverFailureAttrSet))
+		return nil, err
+	}
+
+	r.telemetry.LoadbalancerNumResolutions.Add(ctx, 1, metric.WithAttributeSet(fileResolverSuccessAttrSet))
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	if equalStringSlice(r.endpoints, backends) {
+		return r.endpoints, nil
+	}
+
+	// the list has changed!
+	r.updateLock.Lock()
+	r.endpoints = backends
+	r.updateLock.Unlock()
+	r.telemetry.LoadbalancerNumBackends.Record(ctx, int64(len(backends)), metric.WithAttributeSet(fileResolverAttrSet))
+	r.telemetry.LoadbalancerNumBackendUpdates.Add(ctx, 1, metric.WithAttributeSet(fileResolverAttrSet))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(r.endpoints)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return r.endpoints, nil
+}
+
+func (r *fileResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
+
+// readEndpointsFile reads a list of backends from a file holding either a
+// list of endpoints or a document with an "endpoints" list. As JSON is a
+// subset of YAML, both formats are supported.
+func readEndpointsFile(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("%q is empty", path)
+	}
+
+	var endpoints []string
+	root := doc.Content[0]
+	switch root.Kind {
+	case yaml.SequenceNode:
+		err = root.Decode(&endpoints)
+	case yaml.MappingNode:
+		var list struct {
+			Endpoints []string `yaml:"endpoints"`
+		}
+		err = root.Decode(&list)
+		endpoints = list.Endpoints
+	default:
+		err = errors.New("expected a list of endpoints or an \"endpoints\" key")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", path, err)
+	}
+
+	for _, endpoint := range endpoints {
+		if endpoint == "" {
+			return nil, fmt.Errorf("%q contains an empty endpoint", path)
+		}
+	}
+	return endpoints, nil
+}
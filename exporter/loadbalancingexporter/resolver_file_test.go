@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func TestInitialFileResolution(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name:    "yaml list",
+			file:    "endpoints.yaml",
+			content: "- endpoint-2:4317\n- endpoint-1:4317\n",
+		},
+		{
+			name:    "yaml document",
+			file:    "endpoints.yaml",
+			content: "endpoints:\n  - endpoint-2:4317\n  - endpoint-1:4317\n",
+		},
+		{
+			name:    "json list",
+			file:    "endpoints.json",
+			content: `["endpoint-2:4317", "endpoint-1:4317"]`,
+		},
+		{
+			name:    "json document",
+			file:    "endpoints.json",
+			content: `{"endpoints": ["endpoint-2:4317", "endpoint-1:4317"]}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			_, tb := getTelemetryAssets(t)
+			path := filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			res, err := newFileResolver(zap.NewNop(), path, time.Minute, tb)
+			require.NoError(t, err)
+
+			// test
+			var resolved []string
+			res.onChange(func(endpoints []string) {
+				resolved = endpoints
+			})
+			require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, res.shutdown(t.Context()))
+			}()
+
+			// verify
+			assert.Equal(t, []string{"endpoint-1:4317", "endpoint-2:4317"}, resolved)
+		})
+	}
+}
+
+func TestFileResolutionChange(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "endpoints.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- endpoint-1:4317\n"), 0o600))
+	// the interval is long enough for changes to only be detected by the watcher
+	res, err := newFileResolver(zap.NewNop(), path, time.Hour, tb)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, res.shutdown(t.Context()))
+	}()
+
+	assertResolved := func(expected []string) {
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(c, expected, resolved)
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	assertResolved([]string{"endpoint-1:4317"})
+
+	// test: the file is written in place
+	require.NoError(t, os.WriteFile(path, []byte("- endpoint-1:4317\n- endpoint-2:4317\n"), 0o600))
+	assertResolved([]string{"endpoint-1:4317", "endpoint-2:4317"})
+
+	// test: the file is replaced
+	tmp := filepath.Join(dir, "endpoints.yaml.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("- endpoint-3:4317\n"), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+	assertResolved([]string{"endpoint-3:4317"})
+}
+
+func TestFileResolutionInvalidFileKeepsBackends(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	path := filepath.Join(t.TempDir(), "endpoints.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- endpoint-1:4317\n"), 0o600))
+	res, err := newFileResolver(zap.NewNop(), path, time.Minute, tb)
+	require.NoError(t, err)
+	_, err = res.resolve(t.Context())
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		{
+			name:        "invalid yaml",
+			content:     "- [",
+			expectedErr: "failed to parse",
+		},
+		{
+			name:        "empty file",
+			content:     "",
+			expectedErr: "is empty",
+		},
+		{
+			name:        "scalar",
+			content:     "endpoint-1:4317",
+			expectedErr: `expected a list of endpoints or an "endpoints" key`,
+		},
+		{
+			name:        "empty endpoint",
+			content:     "- ''\n",
+			expectedErr: "contains an empty endpoint",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// test
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			resolved, err := res.resolve(t.Context())
+
+			// verify
+			assert.ErrorContains(t, err, tt.expectedErr)
+			assert.Nil(t, resolved)
+			assert.Equal(t, []string{"endpoint-1:4317"}, res.endpoints)
+		})
+	}
+}
+
+func TestFileResolutionMissingFile(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	path := filepath.Join(t.TempDir(), "endpoints.yaml")
+	res, err := newFileResolver(zap.NewNop(), path, time.Minute, tb)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved = endpoints
+	})
+
+	// test: the resolver starts even though the file doesn't exist yet
+	require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, res.shutdown(t.Context()))
+	}()
+	require.NoError(t, os.WriteFile(path, []byte("- endpoint-1:4317\n"), 0o600))
+
+	// verify
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(c, []string{"endpoint-1:4317"}, resolved)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileResolverWithoutPath(t *testing.T) {
+	_, tb := getTelemetryAssets(t)
+	res, err := newFileResolver(zap.NewNop(), "", 0, tb)
+	assert.Nil(t, res)
+	assert.Equal(t, errNoFilePath, err)
+}
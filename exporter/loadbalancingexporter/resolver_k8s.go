@@ -15,6 +15,7 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
@@ -158,7 +159,7 @@ func newK8sResolver(clt kubernetes.Interface,
 	return r, nil
 }
 
-func (r *k8sResolver) start(_ context.Context) error {
+func (r *k8sResolver) start(_ context.Context, _ component.Host) error {
 	var initErr error
 	r.once.Do(func() {
 		if r.epsListWatcher != nil {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/zap"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -71,7 +72,7 @@ func TestK8sResolve(t *testing.T) {
 		res, err := newK8sResolver(cl, zap.NewNop(), service, ports, defaultListWatchTimeout, returnHostnames, tb)
 		require.NoError(t, err)
 
-		require.NoError(t, res.start(t.Context()))
+		require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
 		// Wait for the initial endpoints to be populated by the informer
 		// The informer cache sync only guarantees the cache is ready, but the OnAdd
 		// handler runs asynchronously and may not have completed yet
@@ -287,7 +288,7 @@ func TestK8sResolveWithServiceFQDN(t *testing.T) {
 	require.Equal(t, serviceName, res.svcName)
 	require.Equal(t, namespace, res.svcNs)
 
-	require.NoError(t, res.start(t.Context()))
+	require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
 	t.Cleanup(func() {
 		require.NoError(t, res.shutdown(t.Context()))
 	})
@@ -9,6 +9,7 @@ import (
 	"sort"
 	"sync"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
@@ -50,7 +51,7 @@ func newStaticResolver(endpoints []string, tb *metadata.TelemetryBuilder) (*stat
 	}, nil
 }
 
-func (r *staticResolver) start(ctx context.Context) error {
+func (r *staticResolver) start(ctx context.Context, _ component.Host) error {
 	_, err := r.resolve(ctx) // right now, this can't fail
 	return err
 }
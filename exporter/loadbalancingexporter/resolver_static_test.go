@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 )
 
 func TestInitialResolution(t *testing.T) {
@@ -22,7 +23,7 @@ func TestInitialResolution(t *testing.T) {
 	res.onChange(func(endpoints []string) {
 		resolved = endpoints
 	})
-	require.NoError(t, res.start(t.Context()))
+	require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
 	defer func() {
 		require.NoError(t, res.shutdown(t.Context()))
 	}()
@@ -45,7 +46,7 @@ func TestResolvedOnlyOnce(t *testing.T) {
 	})
 
 	// test
-	require.NoError(t, res.start(t.Context()))
+	require.NoError(t, res.start(t.Context(), componenttest.NewNopHost()))
 	defer func() {
 		require.NoError(t, res.shutdown(t.Context()))
 	}()
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	encodingProtobuf = "protobuf"
+	encodingJSON     = "json"
+)
+
+// defaultLabels are the labels added to every stream unless they are disabled
+var defaultLabels = []string{"exporter", "job", "instance", "level"}
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines configuration for the Loki exporter.
+type Config struct {
+	confighttp.ClientConfig `mapstructure:",squash"`
+	QueueSettings           configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+	BackOffConfig           configretry.BackOffConfig                                `mapstructure:"retry_on_failure"`
+
+	// Labels selects the attributes promoted to the labels of the streams.
+	Labels AttributesConfig `mapstructure:"labels"`
+
+	// StructuredMetadata selects the attributes attached to the log entries as structured metadata.
+	StructuredMetadata AttributesConfig `mapstructure:"structured_metadata"`
+
+	// DefaultLabelsEnabled allows disabling the labels which are added to every stream:
+	// exporter, job, instance and level.
+	DefaultLabelsEnabled map[string]bool `mapstructure:"default_labels_enabled"`
+
+	// Tenant holds the configuration of the tenant the logs are sent to.
+	Tenant TenantConfig `mapstructure:"tenant"`
+
+	// Encoding of the push requests, either protobuf or json.
+	Encoding string `mapstructure:"encoding"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AttributesConfig lists the resource attributes and the log record attributes to select.
+type AttributesConfig struct {
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+	Attributes         []string `mapstructure:"attributes"`
+}
+
+// TenantConfig holds the configuration of the tenant set in the X-Scope-OrgID header of the push requests.
+type TenantConfig struct {
+	// Attribute is the name of the resource or log record attribute holding the tenant of the logs.
+	// Resource attributes take precedence over log record attributes.
+	Attribute string `mapstructure:"attribute"`
+	// Default is the tenant of the logs which don't have the tenant attribute. No header is sent
+	// for those logs when it is empty.
+	Default string `mapstructure:"default"`
+}
+
+func (cfg *Config) Validate() error {
+	var errs []error
+	if cfg.Endpoint == "" {
+		errs = append(errs, errors.New("endpoint is required"))
+	}
+	switch cfg.Encoding {
+	case encodingProtobuf, encodingJSON:
+	default:
+		errs = append(errs, fmt.Errorf("unsupported encoding %q, must be one of %q or %q", cfg.Encoding, encodingProtobuf, encodingJSON))
+	}
+	errs = append(errs, cfg.Labels.validate("labels"), cfg.StructuredMetadata.validate("structured_metadata"))
+	for label := range cfg.DefaultLabelsEnabled {
+		if !slices.Contains(defaultLabels, label) {
+			errs = append(errs, fmt.Errorf("default_labels_enabled: unknown label %q, must be one of %s", label, strings.Join(defaultLabels, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (cfg *AttributesConfig) validate(name string) error {
+	for _, attribute := range cfg.ResourceAttributes {
+		if attribute == "" || strings.Contains(attribute, ",") {
+			return fmt.Errorf("%s::resource_attributes: invalid attribute name %q", name, attribute)
+		}
+	}
+	for _, attribute := range cfg.Attributes {
+		if attribute == "" || strings.Contains(attribute, ",") {
+			return fmt.Errorf("%s::attributes: invalid attribute name %q", name, attribute)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     func() component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewID(metadata.Type),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Endpoint = "http://localhost:3100/loki/api/v1/push"
+				return cfg
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_fields"),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Endpoint = "https://loki.example.com/loki/api/v1/push"
+				cfg.Timeout = 10 * time.Second
+				cfg.Headers = configopaque.MapList{{Name: "X-Custom", Value: "value"}}
+				cfg.QueueSettings = configoptional.None[exporterhelper.QueueBatchConfig]()
+				cfg.BackOffConfig = func() configretry.BackOffConfig {
+					backOffConfig := configretry.NewDefaultBackOffConfig()
+					backOffConfig.Enabled = false
+					return backOffConfig
+				}()
+				cfg.Labels = AttributesConfig{
+					ResourceAttributes: []string{"service.name", "k8s.namespace.name"},
+					Attributes:         []string{"http.method"},
+				}
+				cfg.StructuredMetadata = AttributesConfig{
+					ResourceAttributes: []string{"k8s.pod.uid"},
+					Attributes:         []string{"trace_id", "span_id"},
+				}
+				cfg.DefaultLabelsEnabled = map[string]bool{"exporter": false, "job": true}
+				cfg.Tenant = TenantConfig{Attribute: "tenant.id", Default: "fallback"}
+				cfg.Encoding = encodingJSON
+				return cfg
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			errorMessage: "endpoint is required",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_encoding"),
+			errorMessage: `unsupported encoding "snappy", must be one of "protobuf" or "json"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_label"),
+			errorMessage: `labels::attributes: invalid attribute name "http.method,http.route"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_structured_metadata"),
+			errorMessage: `structured_metadata::resource_attributes: invalid attribute name ""`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unknown_default_label"),
+			errorMessage: `default_labels_enabled: unknown label "service", must be one of exporter, job, instance, level`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expected == nil {
+				assert.ErrorContains(t, xconfmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected(), cfg)
+		})
+	}
+}
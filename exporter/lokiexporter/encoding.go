@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/pkg/push"
+	promql_parser "github.com/prometheus/prometheus/promql/parser"
+)
+
+const (
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeJSON     = "application/json"
+)
+
+// encodeProtobuf encodes the request in protobuf, compressed with snappy as required by Loki
+func encodeProtobuf(request *push.PushRequest) ([]byte, error) {
+	buf, err := request.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, buf), nil
+}
+
+type jsonPushRequest struct {
+	Streams []jsonStream `json:"streams"`
+}
+
+type jsonStream struct {
+	Stream map[string]string `json:"stream"`
+	// Values holds the timestamp in nanoseconds, the line and the optional structured metadata of each entry
+	Values [][]any `json:"values"`
+}
+
+// encodeJSON encodes the request in the JSON format of the push API
+func encodeJSON(request *push.PushRequest) ([]byte, error) {
+	streams := make([]jsonStream, 0, len(request.Streams))
+	for _, stream := range request.Streams {
+		labels, err := promql_parser.ParseMetric(stream.Labels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the labels %s: %w", stream.Labels, err)
+		}
+
+		values := make([][]any, 0, len(stream.Entries))
+		for _, entry := range stream.Entries {
+			value := []any{strconv.FormatInt(entry.Timestamp.UnixNano(), 10), entry.Line}
+			if len(entry.StructuredMetadata) > 0 {
+				structuredMetadata := make(map[string]string, len(entry.StructuredMetadata))
+				for _, label := range entry.StructuredMetadata {
+					structuredMetadata[label.Name] = label.Value
+				}
+				value = append(value, structuredMetadata)
+			}
+			values = append(values, value)
+		}
+		streams = append(streams, jsonStream{Stream: labels.Map(), Values: values})
+	}
+	return json.Marshal(jsonPushRequest{Streams: streams})
+}
@@ -0,0 +1,301 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/loki/pkg/push"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+const (
+	headerTenant     = "X-Scope-OrgID"
+	headerRetryAfter = "Retry-After"
+
+	// maxErrorBodySize limits the size of the response body included in errors
+	maxErrorBodySize = 1024
+)
+
+// hints read by the Loki translator to select the labels, the structured metadata and the tenant of the logs
+const (
+	hintAttributes                   = "loki.attribute.labels"
+	hintResources                    = "loki.resource.labels"
+	hintAttributesStructuredMetadata = "loki.attribute.structured_metadata"
+	hintResourcesStructuredMetadata  = "loki.resource.structured_metadata"
+	hintTenant                       = "loki.tenant"
+)
+
+type lokiExporter struct {
+	config   *Config
+	settings component.TelemetrySettings
+	client   *http.Client
+
+	encode      func(*push.PushRequest) ([]byte, error)
+	contentType string
+}
+
+func newLokiExporter(cfg *Config, settings component.TelemetrySettings) *lokiExporter {
+	e := &lokiExporter{
+		config:      cfg,
+		settings:    settings,
+		encode:      encodeProtobuf,
+		contentType: contentTypeProtobuf,
+	}
+	if cfg.Encoding == encodingJSON {
+		e.encode = encodeJSON
+		e.contentType = contentTypeJSON
+	}
+	return e
+}
+
+func (e *lokiExporter) start(ctx context.Context, host component.Host) error {
+	client, err := e.config.ToClient(ctx, host.GetExtensions(), e.settings)
+	if err != nil {
+		return err
+	}
+	e.client = client
+	return nil
+}
+
+// pushLogs sends one push request per tenant. Records which can't be converted to Loki entries
+// are dropped, the others are still sent. When the request of a tenant fails with a retryable
+// error, only the logs of that tenant are retried, so that the other tenants don't receive
+// duplicates.
+func (e *lokiExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	e.addHints(ld)
+
+	var permanentErrs, retryableErrs []error
+	failedTenants := map[string]bool{}
+	for tenant, request := range loki.LogsToLokiRequests(ld, e.config.DefaultLabelsEnabled) {
+		err := e.pushTenantRequest(ctx, tenant, request)
+		switch {
+		case err == nil:
+		case consumererror.IsPermanent(err):
+			permanentErrs = append(permanentErrs, err)
+		default:
+			retryableErrs = append(retryableErrs, err)
+			failedTenants[tenant] = true
+		}
+	}
+
+	if len(retryableErrs) == 0 {
+		return errors.Join(permanentErrs...)
+	}
+	if len(permanentErrs) > 0 {
+		// the permanent errors must not be returned along with the retryable ones,
+		// otherwise the logs of the other tenants wouldn't be retried either
+		e.settings.Logger.Error("Dropping logs refused by Loki", zap.Error(errors.Join(permanentErrs...)))
+	}
+	return consumererror.NewLogs(errors.Join(retryableErrs...), selectTenants(ld, failedTenants))
+}
+
+// pushTenantRequest sends the request converted from the logs of a single tenant
+func (e *lokiExporter) pushTenantRequest(ctx context.Context, tenant string, request loki.PushRequest) error {
+	report := request.Report
+	if report.NumSubmitted == 0 {
+		return consumererror.NewPermanent(fmt.Errorf("failed to convert logs to Loki entries: %w", errors.Join(report.Errors...)))
+	}
+	if report.NumDropped > 0 {
+		e.settings.Logger.Warn("Not all log records could be converted to Loki entries",
+			zap.Int("dropped", report.NumDropped), zap.Int("submitted", report.NumSubmitted), zap.Error(errors.Join(report.Errors...)))
+	}
+
+	if tenant == "" {
+		tenant = e.config.Tenant.Default
+	}
+	sortStreams(request.PushRequest)
+	return e.send(ctx, tenant, request.PushRequest)
+}
+
+// selectTenants copies the log records of the given tenants, as found by the Loki translator
+func selectTenants(ld plog.Logs, tenants map[string]bool) plog.Logs {
+	selected := plog.NewLogs()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		var destResource plog.ResourceLogs
+		hasResource := false
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			var destScope plog.ScopeLogs
+			hasScope := false
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				if !tenants[loki.GetTenantFromTenantHint(lr.Attributes(), rl.Resource().Attributes())] {
+					continue
+				}
+
+				if !hasScope {
+					if !hasResource {
+						destResource = selected.ResourceLogs().AppendEmpty()
+						rl.Resource().CopyTo(destResource.Resource())
+						destResource.SetSchemaUrl(rl.SchemaUrl())
+						hasResource = true
+					}
+					destScope = destResource.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(destScope.Scope())
+					destScope.SetSchemaUrl(sl.SchemaUrl())
+					hasScope = true
+				}
+				lr.CopyTo(destScope.LogRecords().AppendEmpty())
+			}
+		}
+	}
+	return selected
+}
+
+// addHints adds the hints selecting the attributes configured as labels and structured metadata, and
+// the attribute holding the tenant. Hints already present in the logs are extended.
+func (e *lokiExporter) addHints(ld plog.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		resourceAttrs := rls.At(i).Resource().Attributes()
+		appendHint(resourceAttrs, hintResources, e.config.Labels.ResourceAttributes)
+		appendHint(resourceAttrs, hintResourcesStructuredMetadata, e.config.StructuredMetadata.ResourceAttributes)
+		if e.config.Tenant.Attribute != "" {
+			resourceAttrs.PutStr(hintTenant, e.config.Tenant.Attribute)
+		}
+
+		if len(e.config.Labels.Attributes) == 0 && len(e.config.StructuredMetadata.Attributes) == 0 {
+			continue
+		}
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				attrs := lrs.At(k).Attributes()
+				appendHint(attrs, hintAttributes, e.config.Labels.Attributes)
+				appendHint(attrs, hintAttributesStructuredMetadata, e.config.StructuredMetadata.Attributes)
+			}
+		}
+	}
+}
+
+// appendHint adds the names of the attributes to the hint, which is either a comma separated list or a slice.
+// Names which are already listed aren't added again, so that the hints don't grow when logs are retried.
+func appendHint(attrs pcommon.Map, hint string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+
+	value, found := attrs.Get(hint)
+	var listed []string
+	switch {
+	case found && value.Type() == pcommon.ValueTypeSlice:
+		for _, v := range value.Slice().All() {
+			listed = append(listed, strings.TrimSpace(v.AsString()))
+		}
+	case found && value.Type() == pcommon.ValueTypeStr && value.Str() != "":
+		for _, name := range strings.Split(value.Str(), ",") {
+			listed = append(listed, strings.TrimSpace(name))
+		}
+	default:
+		attrs.PutStr(hint, strings.Join(names, ","))
+		return
+	}
+
+	var missing []string
+	for _, name := range names {
+		if !slices.Contains(listed, name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+	if value.Type() == pcommon.ValueTypeSlice {
+		for _, name := range missing {
+			value.Slice().AppendEmpty().SetStr(name)
+		}
+		return
+	}
+	value.SetStr(value.Str() + "," + strings.Join(missing, ","))
+}
+
+// sortStreams orders the entries of each stream by timestamp, since Loki rejects entries older than
+// the latest entry of their stream unless out-of-order writes are accepted, and orders the streams
+// by labels so that the requests are deterministic.
+func sortStreams(request *push.PushRequest) {
+	for i := range request.Streams {
+		slices.SortStableFunc(request.Streams[i].Entries, func(a, b push.Entry) int {
+			return a.Timestamp.Compare(b.Timestamp)
+		})
+	}
+	slices.SortFunc(request.Streams, func(a, b push.Stream) int {
+		return strings.Compare(a.Labels, b.Labels)
+	})
+}
+
+func (e *lokiExporter) send(ctx context.Context, tenant string, request *push.PushRequest) error {
+	body, err := e.encode(request)
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to encode the push request: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	req.Header.Set("Content-Type", e.contentType)
+	if tenant != "" {
+		req.Header.Set(headerTenant, tenant)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send the push request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = fmt.Errorf("push request to %s responded with HTTP status %d: %s", e.config.Endpoint, resp.StatusCode, strings.TrimSpace(string(message)))
+
+	// Loki refuses requests it can't ingest, for example because of invalid labels or out-of-order
+	// entries, with a 4xx status: only rate limited requests and server errors are retried
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < http.StatusInternalServerError {
+		return consumererror.NewPermanent(err)
+	}
+	if delay, ok := parseRetryAfter(resp.Header.Get(headerRetryAfter), time.Now()); ok {
+		return exporterhelper.NewThrottleRetry(err, delay)
+	}
+	return err
+}
+
+// parseRetryAfter returns the delay of a Retry-After header, which holds either a number of
+// seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, seconds > 0
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	delay := date.Sub(now)
+	return delay, delay > 0
+}
@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/pkg/push"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type pushRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newTestServer returns a server recording the push requests, by tenant
+func newTestServer(t *testing.T, statusCode int, header http.Header) (*httptest.Server, map[string]pushRequest) {
+	var mu sync.Mutex
+	requests := map[string]pushRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		mu.Lock()
+		requests[r.Header.Get(headerTenant)] = pushRequest{header: r.Header, body: body}
+		mu.Unlock()
+		for name, values := range header {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func newTestExporter(t *testing.T, endpoint string, modify func(*Config)) *lokiExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	if modify != nil {
+		modify(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	e := newLokiExporter(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, e.start(t.Context(), componenttest.NewNopHost()))
+	return e
+}
+
+func testLogs() plog.Logs {
+	ld := plog.NewLogs()
+
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.Resource().Attributes().PutStr("tenant.id", "team-a")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	// the records are out of order
+	for _, record := range []struct {
+		body      string
+		timestamp int64
+		traceID   string
+	}{
+		{body: "second", timestamp: 2, traceID: "b"},
+		{body: "first", timestamp: 1, traceID: "a"},
+	} {
+		lr := lrs.AppendEmpty()
+		lr.Body().SetStr(record.body)
+		lr.SetTimestamp(pcommon.Timestamp(record.timestamp))
+		lr.Attributes().PutStr("trace_id", record.traceID)
+	}
+
+	rl = ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "payment")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("third")
+	lr.SetTimestamp(pcommon.Timestamp(3))
+	return ld
+}
+
+func configureTestExporter(cfg *Config) {
+	cfg.Labels.ResourceAttributes = []string{"service.name"}
+	cfg.StructuredMetadata.Attributes = []string{"trace_id"}
+	cfg.Tenant = TenantConfig{Attribute: "tenant.id", Default: "fallback"}
+}
+
+func TestPushLogsProtobuf(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusNoContent, nil)
+	e := newTestExporter(t, server.URL, configureTestExporter)
+
+	require.NoError(t, e.pushLogs(t.Context(), testLogs()))
+	require.Len(t, requests, 2)
+
+	decode := func(t *testing.T, request pushRequest) push.PushRequest {
+		assert.Equal(t, contentTypeProtobuf, request.header.Get("Content-Type"))
+		buf, err := snappy.Decode(nil, request.body)
+		require.NoError(t, err)
+		var pr push.PushRequest
+		require.NoError(t, pr.Unmarshal(buf))
+		return pr
+	}
+
+	pr := decode(t, requests["team-a"])
+	require.Len(t, pr.Streams, 1)
+	assert.Equal(t, `{exporter="OTLP", job="checkout", service_name="checkout"}`, pr.Streams[0].Labels)
+	entries := pr.Streams[0].Entries
+	require.Len(t, entries, 2)
+	assert.Equal(t, int64(1), entries[0].Timestamp.UnixNano())
+	assert.Contains(t, entries[0].Line, `"body":"first"`)
+	assert.Equal(t, push.LabelsAdapter{{Name: "trace_id", Value: "a"}}, entries[0].StructuredMetadata)
+	assert.Equal(t, int64(2), entries[1].Timestamp.UnixNano())
+	assert.Contains(t, entries[1].Line, `"body":"second"`)
+	assert.NotContains(t, entries[1].Line, "trace_id")
+
+	pr = decode(t, requests["fallback"])
+	require.Len(t, pr.Streams, 1)
+	assert.Equal(t, `{exporter="OTLP", job="payment", service_name="payment"}`, pr.Streams[0].Labels)
+	require.Len(t, pr.Streams[0].Entries, 1)
+	assert.Contains(t, pr.Streams[0].Entries[0].Line, `"body":"third"`)
+}
+
+func TestPushLogsJSON(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusNoContent, nil)
+	e := newTestExporter(t, server.URL, func(cfg *Config) {
+		configureTestExporter(cfg)
+		cfg.Tenant = TenantConfig{}
+		cfg.DefaultLabelsEnabled = map[string]bool{"exporter": false, "job": false}
+		cfg.Encoding = encodingJSON
+	})
+
+	require.NoError(t, e.pushLogs(t.Context(), testLogs()))
+	require.Len(t, requests, 1)
+	request, ok := requests[""]
+	require.True(t, ok, "the tenant header must not be set")
+	assert.Equal(t, contentTypeJSON, request.header.Get("Content-Type"))
+
+	var got struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][]any           `json:"values"`
+		} `json:"streams"`
+	}
+	require.NoError(t, json.Unmarshal(request.body, &got))
+	require.Len(t, got.Streams, 2)
+
+	// the streams are ordered by labels
+	assert.Equal(t, map[string]string{"service_name": "checkout"}, got.Streams[0].Stream)
+	require.Len(t, got.Streams[0].Values, 2)
+	assert.Equal(t, "1", got.Streams[0].Values[0][0])
+	assert.Equal(t, map[string]any{"trace_id": "a"}, got.Streams[0].Values[0][2])
+	assert.Equal(t, "2", got.Streams[0].Values[1][0])
+
+	assert.Equal(t, map[string]string{"service_name": "payment"}, got.Streams[1].Stream)
+	require.Len(t, got.Streams[1].Values, 1)
+	assert.Equal(t, "3", got.Streams[1].Values[0][0])
+	// no structured metadata
+	assert.Len(t, got.Streams[1].Values[0], 2)
+}
+
+func TestPushLogsErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		header     http.Header
+		permanent  bool
+	}{
+		{
+			name:       "bad request",
+			statusCode: http.StatusBadRequest,
+			permanent:  true,
+		},
+		{
+			name:       "rate limited",
+			statusCode: http.StatusTooManyRequests,
+			header:     http.Header{headerRetryAfter: []string{"30"}},
+		},
+		{
+			name:       "rate limited until a date",
+			statusCode: http.StatusTooManyRequests,
+			header:     http.Header{headerRetryAfter: []string{time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}},
+		},
+		{
+			name:       "server error",
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newTestServer(t, tt.statusCode, tt.header)
+			e := newTestExporter(t, server.URL, nil)
+
+			err := e.pushLogs(t.Context(), testLogs())
+			assert.ErrorContains(t, err, "responded with HTTP status")
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, time.October, 21, 7, 28, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		delay time.Duration
+		ok    bool
+	}{
+		{name: "seconds", value: "120", delay: 2 * time.Minute, ok: true},
+		{name: "zero seconds", value: "0"},
+		{name: "date", value: "Tue, 21 Oct 2025 07:30:00 GMT", delay: 2 * time.Minute, ok: true},
+		{name: "past date", value: "Tue, 21 Oct 2025 07:00:00 GMT"},
+		{name: "empty"},
+		{name: "invalid", value: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.delay, delay)
+			}
+		})
+	}
+}
+
+func TestPushLogsPartialFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		status map[string]int
+	}{
+		{
+			name:   "one tenant rate limited",
+			status: map[string]int{"team-a": http.StatusTooManyRequests, "fallback": http.StatusNoContent},
+		},
+		{
+			name:   "one tenant rate limited, the other refused",
+			status: map[string]int{"team-a": http.StatusTooManyRequests, "fallback": http.StatusBadRequest},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var tenants []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tenant := r.Header.Get(headerTenant)
+				mu.Lock()
+				tenants = append(tenants, tenant)
+				mu.Unlock()
+				w.WriteHeader(tt.status[tenant])
+			}))
+			t.Cleanup(server.Close)
+			e := newTestExporter(t, server.URL, configureTestExporter)
+
+			err := e.pushLogs(t.Context(), testLogs())
+			require.Error(t, err)
+			assert.False(t, consumererror.IsPermanent(err), "the rate limited logs must be retried")
+			var logsErr consumererror.Logs
+			require.ErrorAs(t, err, &logsErr)
+			assert.ElementsMatch(t, []string{"team-a", "fallback"}, tenants)
+
+			// only the logs of the rate limited tenant are retried
+			failed := logsErr.Data()
+			require.Equal(t, 2, failed.LogRecordCount())
+			tenant, _ := failed.ResourceLogs().At(0).Resource().Attributes().Get("tenant.id")
+			assert.Equal(t, "team-a", tenant.Str())
+
+			tenants = nil
+			require.Error(t, e.pushLogs(t.Context(), failed))
+			assert.Equal(t, []string{"team-a"}, tenants)
+			// the hints aren't added again when the logs are retried
+			hint, _ := failed.ResourceLogs().At(0).Resource().Attributes().Get(hintResources)
+			assert.Equal(t, "service.name", hint.Str())
+			hint, _ = failed.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get(hintAttributesStructuredMetadata)
+			assert.Equal(t, "trace_id", hint.Str())
+		})
+	}
+}
+
+func TestAppendHint(t *testing.T) {
+	attrs := pcommon.NewMap()
+	appendHint(attrs, hintAttributes, nil)
+	assert.Equal(t, 0, attrs.Len())
+
+	appendHint(attrs, hintAttributes, []string{"a", "b"})
+	assert.Equal(t, map[string]any{hintAttributes: "a,b"}, attrs.AsRaw())
+	appendHint(attrs, hintAttributes, []string{"c"})
+	assert.Equal(t, map[string]any{hintAttributes: "a,b,c"}, attrs.AsRaw())
+	// the names already listed are not added again
+	appendHint(attrs, hintAttributes, []string{"a", "b", "c"})
+	assert.Equal(t, map[string]any{hintAttributes: "a,b,c"}, attrs.AsRaw())
+
+	attrs.PutEmptySlice(hintResources).AppendEmpty().SetStr("a")
+	appendHint(attrs, hintResources, []string{"b"})
+	assert.Equal(t, []any{"a", "b"}, attrs.AsRaw()[hintResources])
+	appendHint(attrs, hintResources, []string{"a", "b"})
+	assert.Equal(t, []any{"a", "b"}, attrs.AsRaw()[hintResources])
+}
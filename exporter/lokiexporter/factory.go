@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter/internal/metadata"
+)
+
+// NewFactory creates a factory for the Loki exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithLogs(createLogs, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Timeout = 30 * time.Second
+
+	// logs are batched by default, to send fewer and larger streams to Loki
+	queueConfig := exporterhelper.NewDefaultQueueConfig()
+	queueConfig.Batch = configoptional.Some(exporterhelper.BatchConfig{
+		FlushTimeout: time.Second,
+		MinSize:      8192,
+		Sizer:        exporterhelper.RequestSizerTypeItems,
+	})
+
+	return &Config{
+		ClientConfig:  clientConfig,
+		QueueSettings: configoptional.Some(queueConfig),
+		BackOffConfig: configretry.NewDefaultBackOffConfig(),
+		Encoding:      encodingProtobuf,
+	}
+}
+
+func createLogs(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	oCfg := cfg.(*Config)
+	e := newLokiExporter(oCfg, set.TelemetrySettings)
+
+	return exporterhelper.NewLogs(ctx, set, cfg,
+		e.pushLogs,
+		exporterhelper.WithStart(e.start),
+		// the hints selecting the labels are added to the logs
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
+		exporterhelper.WithTimeout(exporterhelper.TimeoutConfig{Timeout: oCfg.Timeout}),
+		exporterhelper.WithRetry(oCfg.BackOffConfig),
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+	)
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines configuration for the MQTT exporter.
+type Config struct {
+	TimeoutSettings           exporterhelper.TimeoutConfig                             `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	QueueSettings             configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+	configretry.BackOffConfig `mapstructure:"retry_on_failure"`
+	mqtt.ClientConfig         `mapstructure:",squash"`
+
+	// Logs holds configuration about how logs should be published to MQTT.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be published to MQTT.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be published to MQTT.
+	Traces SignalConfig `mapstructure:"traces"`
+}
+
+// SignalConfig holds the topic and encoding of the messages of a signal.
+type SignalConfig struct {
+	// Topic is the topic the messages are published to. It may contain
+	// %{attribute} placeholders, which are replaced with the values of resource
+	// attributes. (default = otlp/traces for traces, otlp/metrics for metrics,
+	// otlp/logs for logs)
+	Topic string `mapstructure:"topic"`
+	// QoS is the quality of service the messages are published with (default = 1).
+	QoS byte `mapstructure:"qos"`
+	// Retain asks the broker to keep the last message of each topic, and to
+	// deliver it to new subscribers.
+	Retain bool `mapstructure:"retain"`
+	// Encoding of the messages (default = otlp_proto). It may be the ID of an
+	// encoding extension.
+	Encoding string `mapstructure:"encoding"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	return errors.Join(
+		cfg.ClientConfig.Validate(),
+		cfg.Logs.validate("logs"),
+		cfg.Metrics.validate("metrics"),
+		cfg.Traces.validate("traces"),
+	)
+}
+
+func (cfg *SignalConfig) validate(signal string) error {
+	var errs []error
+	if err := validateTopicTemplate(cfg.Topic); err != nil {
+		errs = append(errs, fmt.Errorf("%s::topic: %w", signal, err))
+	}
+	if cfg.QoS > 2 {
+		errs = append(errs, fmt.Errorf("%s::qos must be 0, 1 or 2", signal))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_fields"),
+			expected: &Config{
+				TimeoutSettings: exporterhelper.TimeoutConfig{Timeout: 10 * time.Second},
+				QueueSettings:   configoptional.None[exporterhelper.QueueBatchConfig](),
+				BackOffConfig: func() configretry.BackOffConfig {
+					cfg := configretry.NewDefaultBackOffConfig()
+					cfg.Enabled = false
+					return cfg
+				}(),
+				ClientConfig: func() mqtt.ClientConfig {
+					cfg := mqtt.NewDefaultClientConfig()
+					cfg.Endpoint = "wss://broker:443/mqtt"
+					cfg.ProtocolVersion = mqtt.ProtocolVersion5
+					cfg.ClientID = "gateway"
+					cfg.Username = "user"
+					cfg.Password = "pass"
+					cfg.TLS = configoptional.Some(configtls.ClientConfig{
+						Config: configtls.Config{CAFile: "ca.pem"},
+					})
+					cfg.ConnectionTimeout = time.Second
+					cfg.KeepAlive = time.Minute
+					cfg.ReconnectWait = 3 * time.Second
+					return cfg
+				}(),
+				Logs: SignalConfig{
+					Topic:    "logs/%{service.name}",
+					QoS:      0,
+					Encoding: "raw",
+				},
+				Metrics: SignalConfig{
+					Topic:    "metrics",
+					QoS:      2,
+					Retain:   true,
+					Encoding: "otlp_json",
+				},
+				Traces: SignalConfig{
+					Topic:    "traces/%{service.namespace}/%{service.name}",
+					QoS:      1,
+					Encoding: "otlp_encoding/mqtt",
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			errorMessage: "endpoint is required",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "wildcard_topic"),
+			errorMessage: "logs::topic: must not contain wildcards or null characters",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_qos"),
+			errorMessage: "traces::qos must be 0, 1 or 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expected == nil {
+				assert.ErrorContains(t, xconfmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter"
+
+import (
+	"context"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+const (
+	defaultEncoding = "otlp_proto"
+	defaultQoS      = 1
+
+	defaultLogsTopic    = "otlp/logs"
+	defaultMetricsTopic = "otlp/metrics"
+	defaultTracesTopic  = "otlp/traces"
+)
+
+// NewFactory creates a factory for the MQTT exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithLogs(createLogsExporter, metadata.LogsStability),
+		exporter.WithMetrics(createMetricsExporter, metadata.MetricsStability),
+		exporter.WithTraces(createTracesExporter, metadata.TracesStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		TimeoutSettings: exporterhelper.NewDefaultTimeoutConfig(),
+		QueueSettings:   configoptional.Some(exporterhelper.NewDefaultQueueConfig()),
+		BackOffConfig:   configretry.NewDefaultBackOffConfig(),
+		ClientConfig:    mqtt.NewDefaultClientConfig(),
+		Logs: SignalConfig{
+			Topic:    defaultLogsTopic,
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+		Metrics: SignalConfig{
+			Topic:    defaultMetricsTopic,
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+		Traces: SignalConfig{
+			Topic:    defaultTracesTopic,
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+	}
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	config := cfg.(*Config)
+	e := &mqttLogsExporter{mqttExporter: newMQTTExporter(config, set.TelemetrySettings, "logs", config.Logs)}
+	return exporterhelper.NewLogs(
+		ctx,
+		set,
+		cfg,
+		e.publishLogs,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithShutdown(e.shutdown),
+		exporterhelper.WithTimeout(config.TimeoutSettings),
+		exporterhelper.WithQueue(config.QueueSettings),
+		exporterhelper.WithRetry(config.BackOffConfig),
+	)
+}
+
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	config := cfg.(*Config)
+	e := &mqttMetricsExporter{mqttExporter: newMQTTExporter(config, set.TelemetrySettings, "metrics", config.Metrics)}
+	return exporterhelper.NewMetrics(
+		ctx,
+		set,
+		cfg,
+		e.publishMetrics,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithShutdown(e.shutdown),
+		exporterhelper.WithTimeout(config.TimeoutSettings),
+		exporterhelper.WithQueue(config.QueueSettings),
+		exporterhelper.WithRetry(config.BackOffConfig),
+	)
+}
+
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Traces, error) {
+	config := cfg.(*Config)
+	e := &mqttTracesExporter{mqttExporter: newMQTTExporter(config, set.TelemetrySettings, "traces", config.Traces)}
+	return exporterhelper.NewTraces(
+		ctx,
+		set,
+		cfg,
+		e.publishTraces,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithShutdown(e.shutdown),
+		exporterhelper.WithTimeout(config.TimeoutSettings),
+		exporterhelper.WithQueue(config.QueueSettings),
+		exporterhelper.WithRetry(config.BackOffConfig),
+	)
+}
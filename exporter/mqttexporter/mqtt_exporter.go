@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+// mqttExporter publishes the messages of a signal to MQTT topics
+type mqttExporter struct {
+	config    *Config
+	settings  component.TelemetrySettings
+	signal    string
+	signalCfg SignalConfig
+	topic     messaging.Template
+	client    mqtt.Client
+}
+
+func newMQTTExporter(cfg *Config, set component.TelemetrySettings, signal string, signalCfg SignalConfig) *mqttExporter {
+	return &mqttExporter{
+		config:    cfg,
+		settings:  set,
+		signal:    signal,
+		signalCfg: signalCfg,
+		topic:     newTopicTemplate(signalCfg.Topic),
+	}
+}
+
+func (e *mqttExporter) start(ctx context.Context, _ component.Host) error {
+	// each signal has its own connection, which needs its own client ID
+	clientCfg := e.config.ClientConfig
+	if clientCfg.ClientID != "" {
+		clientCfg.ClientID += "-" + e.signal
+	}
+	client, err := mqtt.Connect(ctx, clientCfg, e.settings.Logger, nil, nil)
+	if err != nil {
+		return err
+	}
+	e.client = client
+	return nil
+}
+
+// publish publishes messages to a topic. QoS 1 and 2 messages are acknowledged by the
+// broker once it took ownership of them, and QoS 0 messages once they were sent.
+func (e *mqttExporter) publish(ctx context.Context, topic string, messages [][]byte) error {
+	for _, payload := range messages {
+		if err := e.client.Publish(ctx, topic, e.signalCfg.QoS, e.signalCfg.Retain, payload); err != nil {
+			return fmt.Errorf("failed to publish to %q: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+func (e *mqttExporter) shutdown(ctx context.Context) error {
+	if e.client != nil {
+		return e.client.Disconnect(ctx)
+	}
+	return nil
+}
+
+type mqttLogsExporter struct {
+	*mqttExporter
+	marshaler messaging.LogsMarshaler
+}
+
+func (e *mqttLogsExporter) start(ctx context.Context, host component.Host) error {
+	m, err := messaging.NewLogsMarshaler(e.config.Logs.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = m
+	return e.mqttExporter.start(ctx, host)
+}
+
+func (e *mqttLogsExporter) publishLogs(ctx context.Context, ld plog.Logs) error {
+	for topic, logs := range e.topic.SplitLogs(ld) {
+		messages, err := e.marshaler.MarshalLogs(logs)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal logs: %w", err))
+		}
+		if err := e.publish(ctx, topic, messages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type mqttMetricsExporter struct {
+	*mqttExporter
+	marshaler pmetric.Marshaler
+}
+
+func (e *mqttMetricsExporter) start(ctx context.Context, host component.Host) error {
+	m, err := messaging.NewMetricsMarshaler(e.config.Metrics.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = m
+	return e.mqttExporter.start(ctx, host)
+}
+
+func (e *mqttMetricsExporter) publishMetrics(ctx context.Context, md pmetric.Metrics) error {
+	for topic, metrics := range e.topic.SplitMetrics(md) {
+		data, err := e.marshaler.MarshalMetrics(metrics)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal metrics: %w", err))
+		}
+		if err := e.publish(ctx, topic, [][]byte{data}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type mqttTracesExporter struct {
+	*mqttExporter
+	marshaler ptrace.Marshaler
+}
+
+func (e *mqttTracesExporter) start(ctx context.Context, host component.Host) error {
+	m, err := messaging.NewTracesMarshaler(e.config.Traces.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = m
+	return e.mqttExporter.start(ctx, host)
+}
+
+func (e *mqttTracesExporter) publishTraces(ctx context.Context, td ptrace.Traces) error {
+	for topic, traces := range e.topic.SplitTraces(td) {
+		data, err := e.marshaler.MarshalTraces(traces)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal traces: %w", err))
+		}
+		if err := e.publish(ctx, topic, [][]byte{data}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
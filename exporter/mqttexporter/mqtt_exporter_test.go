@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	server "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt/mqtttest"
+)
+
+func newTestConfig(t *testing.T, protocolVersion string) (*server.Server, *Config) {
+	broker, clientCfg := mqtttest.NewBroker(t)
+	clientCfg.ProtocolVersion = protocolVersion
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig = clientCfg
+	return broker, cfg
+}
+
+// subscribe returns the messages published to the topics matching the filter
+func subscribe(t *testing.T, broker *server.Server, filter string) <-chan packets.Packet {
+	t.Helper()
+	published := make(chan packets.Packet, 10)
+	require.NoError(t, broker.Subscribe(filter, 1, func(_ *server.Client, _ packets.Subscription, pk packets.Packet) {
+		published <- pk
+	}))
+	return published
+}
+
+func receive(t *testing.T, published <-chan packets.Packet) packets.Packet {
+	t.Helper()
+	select {
+	case pk := <-published:
+		return pk
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "message not published")
+		return packets.Packet{}
+	}
+}
+
+func testLogs(services ...string) plog.Logs {
+	ld := plog.NewLogs()
+	for _, service := range services {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log from " + service)
+	}
+	return ld
+}
+
+func TestPublishLogsToTopicsFromResourceAttributes(t *testing.T) {
+	for _, protocolVersion := range []string{mqtt.ProtocolVersion311, mqtt.ProtocolVersion5} {
+		t.Run(protocolVersion, func(t *testing.T) {
+			broker, cfg := newTestConfig(t, protocolVersion)
+			published := subscribe(t, broker, "logs/#")
+
+			cfg.Logs.Topic = "logs/%{service.name}"
+			e := &mqttLogsExporter{mqttExporter: newMQTTExporter(cfg, componenttest.NewNopTelemetrySettings(), "logs", cfg.Logs)}
+			require.NoError(t, e.start(t.Context(), componenttest.NewNopHost()))
+			t.Cleanup(func() { assert.NoError(t, e.shutdown(context.Background())) })
+
+			require.NoError(t, e.publishLogs(t.Context(), testLogs("checkout", "payment")))
+
+			bodies := map[string]string{}
+			for range 2 {
+				pk := receive(t, published)
+				ld, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(pk.Payload)
+				require.NoError(t, err)
+				require.Equal(t, 1, ld.LogRecordCount())
+				bodies[pk.TopicName] = ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str()
+			}
+			assert.Equal(t, map[string]string{
+				"logs/checkout": "log from checkout",
+				"logs/payment":  "log from payment",
+			}, bodies)
+		})
+	}
+}
+
+func TestPublishRawLogs(t *testing.T) {
+	broker, cfg := newTestConfig(t, mqtt.ProtocolVersion311)
+	published := subscribe(t, broker, defaultLogsTopic)
+
+	cfg.Logs.Encoding = "raw"
+	cfg.Logs.QoS = 0
+	e := &mqttLogsExporter{mqttExporter: newMQTTExporter(cfg, componenttest.NewNopTelemetrySettings(), "logs", cfg.Logs)}
+	require.NoError(t, e.start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, e.shutdown(context.Background())) })
+
+	require.NoError(t, e.publishLogs(t.Context(), testLogs("a", "b")))
+	assert.Equal(t, []byte("log from a"), receive(t, published).Payload)
+	assert.Equal(t, []byte("log from b"), receive(t, published).Payload)
+}
+
+func TestPublishRetainedMetrics(t *testing.T) {
+	broker, cfg := newTestConfig(t, mqtt.ProtocolVersion5)
+	cfg.Metrics.QoS = 2
+	cfg.Metrics.Retain = true
+	cfg.Metrics.Encoding = "otlp_json"
+	e := &mqttMetricsExporter{mqttExporter: newMQTTExporter(cfg, componenttest.NewNopTelemetrySettings(), "metrics", cfg.Metrics)}
+	require.NoError(t, e.start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, e.shutdown(context.Background())) })
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	require.NoError(t, e.publishMetrics(t.Context(), md))
+
+	// the broker acknowledged the message, so it was retained
+	retained := broker.Topics.Messages(defaultMetricsTopic)
+	require.Len(t, retained, 1)
+	assert.Equal(t, defaultMetricsTopic, retained[0].TopicName)
+	got, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(retained[0].Payload)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.DataPointCount())
+}
+
+func TestStartErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Traces.Encoding = "unknown"
+	e := &mqttTracesExporter{mqttExporter: newMQTTExporter(cfg, componenttest.NewNopTelemetrySettings(), "traces", cfg.Traces)}
+	assert.EqualError(t, e.start(t.Context(), componenttest.NewNopHost()), `unrecognized traces encoding "unknown"`)
+	assert.NoError(t, e.shutdown(t.Context()))
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Endpoint = "tcp://127.0.0.1:1"
+	cfg.ConnectionTimeout = 100 * time.Millisecond
+	e = &mqttTracesExporter{mqttExporter: newMQTTExporter(cfg, componenttest.NewNopTelemetrySettings(), "traces", cfg.Traces)}
+	assert.ErrorContains(t, e.start(t.Context(), componenttest.NewNopHost()), "failed to connect to the MQTT broker")
+	assert.NoError(t, e.shutdown(t.Context()))
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/mqttexporter"
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+)
+
+// invalidLevelCharacters can't be part of a topic level: slashes separate levels,
+// and wildcards and null characters are not allowed in the topics messages are published to.
+var invalidLevelCharacters = strings.NewReplacer("/", "_", "+", "_", "#", "_", "\x00", "_")
+
+// newTopicTemplate returns the template of the topics messages are published to.
+// Each placeholder is replaced with a single topic level at most, so the values of the
+// attributes can't change the structure of the topic.
+func newTopicTemplate(template string) messaging.Template {
+	return messaging.NewTemplate(template, invalidLevelCharacters)
+}
+
+// validateTopicTemplate checks that the topics resolved from a template are valid topic names
+func validateTopicTemplate(template string) error {
+	return messaging.ValidateTemplate(template, func(topic string) error {
+		switch {
+		case strings.ContainsAny(topic, "+#\x00"):
+			return errors.New("must not contain wildcards or null characters")
+		case strings.HasPrefix(topic, "$"):
+			return errors.New("must not start with $, which is reserved for the topics of the broker")
+		}
+		return nil
+	})
+}
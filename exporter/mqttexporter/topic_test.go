@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestValidateTopicTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		wantErr  string
+	}{
+		{template: "otlp/logs"},
+		{template: "logs/%{service.name}"},
+		{template: "%{service.namespace}/%{service.name}/logs"},
+		{template: "/logs//errors"},
+		{template: "my logs"},
+		{template: "", wantErr: "must not be empty"},
+		{template: "logs/+", wantErr: "must not contain wildcards or null characters"},
+		{template: "logs/#", wantErr: "must not contain wildcards or null characters"},
+		{template: "$SYS/logs", wantErr: "must not start with $, which is reserved for the topics of the broker"},
+		{template: "logs/%{service.name", wantErr: "contains an unterminated placeholder"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			err := validateTopicTemplate(tt.template)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTopicTemplateResolve(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	resource.Attributes().PutStr("host.name", "edge node.1")
+	resource.Attributes().PutStr("levels", "a/b+c#d")
+	resource.Attributes().PutInt("shard", 3)
+	resource.Attributes().PutStr("empty", "")
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{template: "otlp/logs", want: "otlp/logs"},
+		{template: "logs/%{service.name}", want: "logs/checkout"},
+		{template: "logs/%{service.name}/%{shard}", want: "logs/checkout/3"},
+		{template: "logs/%{host.name}", want: "logs/edge node.1"},
+		{template: "logs/%{levels}", want: "logs/a_b_c_d"},
+		{template: "logs/%{missing}", want: "logs/unknown"},
+		{template: "logs/%{empty}", want: "logs/unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			assert.Equal(t, tt.want, newTopicTemplate(tt.template).Resolve(resource))
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsclient"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines configuration for the NATS exporter.
+type Config struct {
+	TimeoutSettings           exporterhelper.TimeoutConfig                             `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	QueueSettings             configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+	configretry.BackOffConfig `mapstructure:"retry_on_failure"`
+	natsclient.ClientConfig   `mapstructure:",squash"`
+
+	// Logs holds configuration about how logs should be published to NATS.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be published to NATS.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be published to NATS.
+	Traces SignalConfig `mapstructure:"traces"`
+
+	// JetStream publishes messages to a JetStream stream and waits for the server
+	// to acknowledge that they were persisted. Messages are published with core NATS
+	// when JetStream is not configured.
+	JetStream configoptional.Optional[JetStreamConfig] `mapstructure:"jetstream"`
+}
+
+// SignalConfig holds the subject and encoding of the messages of a signal.
+type SignalConfig struct {
+	// Subject is the subject the messages are published to. It may contain
+	// %{attribute} placeholders, which are replaced with the values of resource
+	// attributes. (default = otlp.traces for traces, otlp.metrics for metrics,
+	// otlp.logs for logs)
+	Subject string `mapstructure:"subject"`
+	// Encoding of the messages (default = otlp_proto). It may be the ID of an
+	// encoding extension.
+	Encoding string `mapstructure:"encoding"`
+}
+
+// JetStreamConfig holds the configuration for publishing messages to JetStream.
+type JetStreamConfig struct {
+	// Stream is the name of the stream the messages are expected to be persisted in.
+	// Messages published to subjects bound to a different stream are rejected.
+	Stream string `mapstructure:"stream"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	var errs []error
+	if err := cfg.ClientConfig.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateSubjectTemplate(cfg.Logs.Subject); err != nil {
+		errs = append(errs, fmt.Errorf("logs::subject: %w", err))
+	}
+	if err := validateSubjectTemplate(cfg.Metrics.Subject); err != nil {
+		errs = append(errs, fmt.Errorf("metrics::subject: %w", err))
+	}
+	if err := validateSubjectTemplate(cfg.Traces.Subject); err != nil {
+		errs = append(errs, fmt.Errorf("traces::subject: %w", err))
+	}
+	return errors.Join(errs...)
+}
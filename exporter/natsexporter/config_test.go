@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsclient"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_fields"),
+			expected: &Config{
+				TimeoutSettings: exporterhelper.TimeoutConfig{Timeout: 10 * time.Second},
+				QueueSettings:   configoptional.None[exporterhelper.QueueBatchConfig](),
+				BackOffConfig: func() configretry.BackOffConfig {
+					cfg := configretry.NewDefaultBackOffConfig()
+					cfg.Enabled = false
+					return cfg
+				}(),
+				ClientConfig: natsclient.ClientConfig{
+					Endpoint:          "nats://nats1:4222,nats://nats2:4222",
+					Name:              "otelcol",
+					ConnectionTimeout: time.Second,
+					ReconnectWait:     3 * time.Second,
+					TLS: configoptional.Some(configtls.ClientConfig{
+						Config: configtls.Config{CAFile: "ca.pem"},
+					}),
+					Auth: natsclient.AuthConfig{
+						Username: "user",
+						Password: "pass",
+					},
+				},
+				Logs: SignalConfig{
+					Subject:  "logs.%{service.name}",
+					Encoding: "raw",
+				},
+				Metrics: SignalConfig{
+					Subject:  "metrics",
+					Encoding: "otlp_json",
+				},
+				Traces: SignalConfig{
+					Subject:  "traces.%{service.namespace}.%{service.name}",
+					Encoding: "otlp_encoding/nats",
+				},
+				JetStream: configoptional.Some(JetStreamConfig{Stream: "OTLP"}),
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			errorMessage: "endpoint is required",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "multiple_auth_methods"),
+			errorMessage: "only one of auth::token, auth::username, auth::credentials_file and auth::nkey_file can be configured",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "password_without_username"),
+			errorMessage: "auth::username is required when auth::password is configured",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "wildcard_subject"),
+			errorMessage: "logs::subject: must not contain wildcards or whitespace",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "empty_subject_token"),
+			errorMessage: "metrics::subject: must not contain empty tokens",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expected == nil {
+				assert.ErrorContains(t, xconfmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsclient"
+)
+
+const (
+	defaultEncoding = "otlp_proto"
+
+	defaultLogsSubject    = "otlp.logs"
+	defaultMetricsSubject = "otlp.metrics"
+	defaultTracesSubject  = "otlp.traces"
+)
+
+// NewFactory creates a factory for the NATS exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithLogs(createLogsExporter, metadata.LogsStability),
+		exporter.WithMetrics(createMetricsExporter, metadata.MetricsStability),
+		exporter.WithTraces(createTracesExporter, metadata.TracesStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		TimeoutSettings: exporterhelper.NewDefaultTimeoutConfig(),
+		QueueSettings:   configoptional.Some(exporterhelper.NewDefaultQueueConfig()),
+		BackOffConfig:   configretry.NewDefaultBackOffConfig(),
+		ClientConfig:    natsclient.NewDefaultClientConfig(),
+		Logs: SignalConfig{
+			Subject:  defaultLogsSubject,
+			Encoding: defaultEncoding,
+		},
+		Metrics: SignalConfig{
+			Subject:  defaultMetricsSubject,
+			Encoding: defaultEncoding,
+		},
+		Traces: SignalConfig{
+			Subject:  defaultTracesSubject,
+			Encoding: defaultEncoding,
+		},
+		JetStream: configoptional.Default(JetStreamConfig{}),
+	}
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	config := cfg.(*Config)
+	e := &natsLogsExporter{natsExporter: newNatsExporter(config, set.TelemetrySettings, config.Logs.Subject)}
+	return exporterhelper.NewLogs(
+		ctx,
+		set,
+		cfg,
+		e.publishLogs,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithShutdown(e.shutdown),
+		exporterhelper.WithTimeout(config.TimeoutSettings),
+		exporterhelper.WithQueue(config.QueueSettings),
+		exporterhelper.WithRetry(config.BackOffConfig),
+	)
+}
+
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	config := cfg.(*Config)
+	e := &natsMetricsExporter{natsExporter: newNatsExporter(config, set.TelemetrySettings, config.Metrics.Subject)}
+	return exporterhelper.NewMetrics(
+		ctx,
+		set,
+		cfg,
+		e.publishMetrics,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithShutdown(e.shutdown),
+		exporterhelper.WithTimeout(config.TimeoutSettings),
+		exporterhelper.WithQueue(config.QueueSettings),
+		exporterhelper.WithRetry(config.BackOffConfig),
+	)
+}
+
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Traces, error) {
+	config := cfg.(*Config)
+	e := &natsTracesExporter{natsExporter: newNatsExporter(config, set.TelemetrySettings, config.Traces.Subject)}
+	return exporterhelper.NewTraces(
+		ctx,
+		set,
+		cfg,
+		e.publishTraces,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithShutdown(e.shutdown),
+		exporterhelper.WithTimeout(config.TimeoutSettings),
+		exporterhelper.WithQueue(config.QueueSettings),
+		exporterhelper.WithRetry(config.BackOffConfig),
+	)
+}
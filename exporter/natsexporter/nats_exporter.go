@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsclient"
+)
+
+// natsExporter publishes messages to NATS subjects, or to JetStream when it's configured
+type natsExporter struct {
+	config     *Config
+	settings   component.TelemetrySettings
+	subject    messaging.Template
+	conn       *nats.Conn
+	js         jetstream.JetStream
+	publishOpt []jetstream.PublishOpt
+}
+
+func newNatsExporter(cfg *Config, set component.TelemetrySettings, subject string) *natsExporter {
+	return &natsExporter{
+		config:   cfg,
+		settings: set,
+		subject:  newSubjectTemplate(subject),
+	}
+}
+
+func (e *natsExporter) start(ctx context.Context, _ component.Host) error {
+	conn, err := natsclient.Connect(ctx, e.config.ClientConfig, e.settings.Logger)
+	if err != nil {
+		return err
+	}
+	e.conn = conn
+
+	if e.config.JetStream.HasValue() {
+		e.js, err = jetstream.New(conn)
+		if err != nil {
+			return fmt.Errorf("failed to create JetStream context: %w", err)
+		}
+		if stream := e.config.JetStream.Get().Stream; stream != "" {
+			e.publishOpt = append(e.publishOpt, jetstream.WithExpectStream(stream))
+		}
+	}
+	return nil
+}
+
+// publish publishes messages to a subject. Messages published to JetStream are acknowledged
+// once they were persisted, and messages published with core NATS once the server processed them.
+func (e *natsExporter) publish(ctx context.Context, subject string, messages [][]byte) error {
+	if e.js != nil {
+		for _, data := range messages {
+			if _, err := e.js.Publish(ctx, subject, data, e.publishOpt...); err != nil {
+				return publishError(subject, err)
+			}
+		}
+		return nil
+	}
+
+	for _, data := range messages {
+		if err := e.conn.Publish(subject, data); err != nil {
+			return publishError(subject, err)
+		}
+	}
+	var err error
+	if _, ok := ctx.Deadline(); ok {
+		err = e.conn.FlushWithContext(ctx)
+	} else {
+		err = e.conn.Flush()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to flush messages published to %q: %w", subject, err)
+	}
+	return nil
+}
+
+func publishError(subject string, err error) error {
+	err = fmt.Errorf("failed to publish to %q: %w", subject, err)
+	if errors.Is(err, nats.ErrMaxPayload) || errors.Is(err, nats.ErrBadSubject) {
+		// the message would be rejected again if it was retried
+		return consumererror.NewPermanent(err)
+	}
+	return err
+}
+
+// publishGroups publishes the groups of data split by subject, in the order of the subjects. Groups
+// which can't be published because of a permanent error are dropped, and the others are still
+// published. The publication stops at the first retryable error: the failed group and the groups
+// which weren't published yet are returned, so that only they are retried.
+func publishGroups[T any](logger *zap.Logger, groups map[string]T, publish func(subject string, data T) error) ([]T, error) {
+	subjects := slices.Sorted(maps.Keys(groups))
+	var permanentErrs []error
+	for i, subject := range subjects {
+		err := publish(subject, groups[subject])
+		switch {
+		case err == nil:
+		case consumererror.IsPermanent(err):
+			permanentErrs = append(permanentErrs, err)
+		default:
+			if len(permanentErrs) > 0 {
+				// the permanent errors must not be returned along with the retryable one,
+				// otherwise the unpublished groups wouldn't be retried
+				logger.Error("Dropping data which can't be published", zap.Error(errors.Join(permanentErrs...)))
+			}
+			unpublished := make([]T, 0, len(subjects)-i)
+			for _, s := range subjects[i:] {
+				unpublished = append(unpublished, groups[s])
+			}
+			return unpublished, err
+		}
+	}
+	return nil, errors.Join(permanentErrs...)
+}
+
+func (e *natsExporter) shutdown(context.Context) error {
+	if e.conn != nil {
+		e.conn.Close()
+	}
+	return nil
+}
+
+type natsLogsExporter struct {
+	*natsExporter
+	marshaler messaging.LogsMarshaler
+}
+
+func (e *natsLogsExporter) start(ctx context.Context, host component.Host) error {
+	m, err := messaging.NewLogsMarshaler(e.config.Logs.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = m
+	return e.natsExporter.start(ctx, host)
+}
+
+func (e *natsLogsExporter) publishLogs(ctx context.Context, ld plog.Logs) error {
+	unpublished, err := publishGroups(e.settings.Logger, e.subject.SplitLogs(ld), func(subject string, logs plog.Logs) error {
+		messages, err := e.marshaler.MarshalLogs(logs)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal logs: %w", err))
+		}
+		return e.publish(ctx, subject, messages)
+	})
+	if len(unpublished) == 0 {
+		return err
+	}
+	remainder := plog.NewLogs()
+	for _, logs := range unpublished {
+		for _, rl := range logs.ResourceLogs().All() {
+			rl.CopyTo(remainder.ResourceLogs().AppendEmpty())
+		}
+	}
+	return consumererror.NewLogs(err, remainder)
+}
+
+type natsMetricsExporter struct {
+	*natsExporter
+	marshaler pmetric.Marshaler
+}
+
+func (e *natsMetricsExporter) start(ctx context.Context, host component.Host) error {
+	m, err := messaging.NewMetricsMarshaler(e.config.Metrics.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = m
+	return e.natsExporter.start(ctx, host)
+}
+
+func (e *natsMetricsExporter) publishMetrics(ctx context.Context, md pmetric.Metrics) error {
+	unpublished, err := publishGroups(e.settings.Logger, e.subject.SplitMetrics(md), func(subject string, metrics pmetric.Metrics) error {
+		data, err := e.marshaler.MarshalMetrics(metrics)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal metrics: %w", err))
+		}
+		return e.publish(ctx, subject, [][]byte{data})
+	})
+	if len(unpublished) == 0 {
+		return err
+	}
+	remainder := pmetric.NewMetrics()
+	for _, metrics := range unpublished {
+		for _, rm := range metrics.ResourceMetrics().All() {
+			rm.CopyTo(remainder.ResourceMetrics().AppendEmpty())
+		}
+	}
+	return consumererror.NewMetrics(err, remainder)
+}
+
+type natsTracesExporter struct {
+	*natsExporter
+	marshaler ptrace.Marshaler
+}
+
+func (e *natsTracesExporter) start(ctx context.Context, host component.Host) error {
+	m, err := messaging.NewTracesMarshaler(e.config.Traces.Encoding, host)
+	if err != nil {
+		return err
+	}
+	e.marshaler = m
+	return e.natsExporter.start(ctx, host)
+}
+
+func (e *natsTracesExporter) publishTraces(ctx context.Context, td ptrace.Traces) error {
+	unpublished, err := publishGroups(e.settings.Logger, e.subject.SplitTraces(td), func(subject string, traces ptrace.Traces) error {
+		data, err := e.marshaler.MarshalTraces(traces)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to marshal traces: %w", err))
+		}
+		return e.publish(ctx, subject, [][]byte{data})
+	})
+	if len(unpublished) == 0 {
+		return err
+	}
+	remainder := ptrace.NewTraces()
+	for _, traces := range unpublished {
+		for _, rs := range traces.ResourceSpans().All() {
+			rs.CopyTo(remainder.ResourceSpans().AppendEmpty())
+		}
+	}
+	return consumererror.NewTraces(err, remainder)
+}
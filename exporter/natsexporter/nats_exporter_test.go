@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newTestServer(t *testing.T, configure func(*server.Options)) *server.Server {
+	t.Helper()
+	opts := &server.Options{
+		Host:     "127.0.0.1",
+		Port:     server.RANDOM_PORT,
+		NoLog:    true,
+		NoSigs:   true,
+		StoreDir: t.TempDir(),
+	}
+	if configure != nil {
+		configure(opts)
+	}
+	s, err := server.NewServer(opts)
+	require.NoError(t, err)
+	go s.Start()
+	require.True(t, s.ReadyForConnections(10*time.Second), "NATS server not ready")
+	t.Cleanup(func() {
+		s.Shutdown()
+		s.WaitForShutdown()
+	})
+	return s
+}
+
+func newTestConfig(s *server.Server) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = s.ClientURL()
+	return cfg
+}
+
+func subscribe(t *testing.T, s *server.Server, subject string) *nats.Subscription {
+	t.Helper()
+	conn, err := nats.Connect(s.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	sub, err := conn.SubscribeSync(subject)
+	require.NoError(t, err)
+	require.NoError(t, conn.Flush())
+	return sub
+}
+
+func testLogs(services ...string) plog.Logs {
+	ld := plog.NewLogs()
+	for _, service := range services {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log from " + service)
+	}
+	return ld
+}
+
+func TestPublishLogsToSubjectsFromResourceAttributes(t *testing.T) {
+	s := newTestServer(t, nil)
+	sub := subscribe(t, s, "logs.>")
+
+	cfg := newTestConfig(s)
+	cfg.Logs.Subject = "logs.%{service.name}"
+	e := &natsLogsExporter{natsExporter: newNatsExporter(cfg, componenttest.NewNopTelemetrySettings(), cfg.Logs.Subject)}
+	require.NoError(t, e.start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, e.shutdown(t.Context())) }()
+
+	require.NoError(t, e.publishLogs(t.Context(), testLogs("a", "b", "a")))
+
+	received := map[string]plog.Logs{}
+	for range 2 {
+		msg, err := sub.NextMsg(5 * time.Second)
+		require.NoError(t, err)
+		logs, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(msg.Data)
+		require.NoError(t, err)
+		received[msg.Subject] = logs
+	}
+	require.Len(t, received, 2)
+	assert.Equal(t, 2, received["logs.a"].LogRecordCount())
+	assert.Equal(t, 1, received["logs.b"].LogRecordCount())
+}
+
+func TestPublishRawLogs(t *testing.T) {
+	s := newTestServer(t, nil)
+	sub := subscribe(t, s, defaultLogsSubject)
+
+	cfg := newTestConfig(s)
+	cfg.Logs.Encoding = "raw"
+	e := &natsLogsExporter{natsExporter: newNatsExporter(cfg, componenttest.NewNopTelemetrySettings(), cfg.Logs.Subject)}
+	require.NoError(t, e.start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, e.shutdown(t.Context())) }()
+
+	require.NoError(t, e.publishLogs(t.Context(), testLogs("a", "b")))
+
+	for _, want := range []string{"log from a", "log from b"} {
+		msg, err := sub.NextMsg(5 * time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(msg.Data))
+	}
+}
+
+func TestPublishMetricsToJetStream(t *testing.T) {
+	s := newTestServer(t, func(opts *server.Options) { opts.JetStream = true })
+
+	conn, err := nats.Connect(s.ClientURL())
+	require.NoError(t, err)
+	defer conn.Close()
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	stream, err := js.CreateStream(t.Context(), jetstream.StreamConfig{Name: "OTLP", Subjects: []string{"otlp.>"}})
+	require.NoError(t, err)
+
+	cfg := newTestConfig(s)
+	cfg.Metrics.Encoding = "otlp_json"
+	cfg.JetStream = configoptional.Some(JetStreamConfig{Stream: "OTLP"})
+	e := &natsMetricsExporter{natsExporter: newNatsExporter(cfg, componenttest.NewNopTelemetrySettings(), cfg.Metrics.Subject)}
+	require.NoError(t, e.start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, e.shutdown(t.Context())) }()
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("test_metric")
+	require.NoError(t, e.publishMetrics(t.Context(), md))
+
+	// the message was persisted before the publication returned
+	msg, err := stream.GetLastMsgForSubject(t.Context(), defaultMetricsSubject)
+	require.NoError(t, err)
+	received, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(msg.Data)
+	require.NoError(t, err)
+	assert.Equal(t, md, received)
+}
+
+func TestPublishTracesToUnexpectedStream(t *testing.T) {
+	s := newTestServer(t, func(opts *server.Options) { opts.JetStream = true })
+
+	conn, err := nats.Connect(s.ClientURL())
+	require.NoError(t, err)
+	defer conn.Close()
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	_, err = js.CreateStream(t.Context(), jetstream.StreamConfig{Name: "OTHER", Subjects: []string{"otlp.>"}})
+	require.NoError(t, err)
+
+	cfg := newTestConfig(s)
+	cfg.JetStream = configoptional.Some(JetStreamConfig{Stream: "OTLP"})
+	e := &natsTracesExporter{natsExporter: newNatsExporter(cfg, componenttest.NewNopTelemetrySettings(), cfg.Traces.Subject)}
+	require.NoError(t, e.start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, e.shutdown(t.Context())) }()
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("test_span")
+	err = e.publishTraces(t.Context(), td)
+	assert.ErrorContains(t, err, `failed to publish to "otlp.traces"`)
+}
+
+func TestPublishLogsRetriesUnpublishedSubjects(t *testing.T) {
+	s := newTestServer(t, func(opts *server.Options) { opts.JetStream = true })
+
+	conn, err := nats.Connect(s.ClientURL())
+	require.NoError(t, err)
+	defer conn.Close()
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	// only the logs of service a can be persisted
+	stream, err := js.CreateStream(t.Context(), jetstream.StreamConfig{Name: "OTLP", Subjects: []string{"logs.a"}})
+	require.NoError(t, err)
+
+	cfg := newTestConfig(s)
+	cfg.Logs.Subject = "logs.%{service.name}"
+	cfg.JetStream = configoptional.Some(JetStreamConfig{})
+	e := &natsLogsExporter{natsExporter: newNatsExporter(cfg, componenttest.NewNopTelemetrySettings(), cfg.Logs.Subject)}
+	require.NoError(t, e.start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, e.shutdown(t.Context())) }()
+
+	err = e.publishLogs(t.Context(), testLogs("a", "b", "c"))
+	require.ErrorContains(t, err, `failed to publish to "logs.b"`)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	// the logs published to logs.a are not retried
+	var logsErr consumererror.Logs
+	require.ErrorAs(t, err, &logsErr)
+	assert.Equal(t, testLogs("b", "c"), logsErr.Data())
+
+	info, err := stream.Info(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), info.State.Msgs)
+
+	_, err = js.UpdateStream(t.Context(), jetstream.StreamConfig{Name: "OTLP", Subjects: []string{"logs.>"}})
+	require.NoError(t, err)
+	require.NoError(t, e.publishLogs(t.Context(), logsErr.Data()))
+	info, err = stream.Info(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), info.State.Msgs)
+}
+
+func TestPublishMetricsDropsOnlyRejectedSubjects(t *testing.T) {
+	s := newTestServer(t, func(opts *server.Options) { opts.MaxPayload = 256 })
+	sub := subscribe(t, s, "metrics.>")
+
+	cfg := newTestConfig(s)
+	cfg.Metrics.Subject = "metrics.%{service.name}"
+	e := &natsMetricsExporter{natsExporter: newNatsExporter(cfg, componenttest.NewNopTelemetrySettings(), cfg.Metrics.Subject)}
+	require.NoError(t, e.start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, e.shutdown(t.Context())) }()
+
+	md := pmetric.NewMetrics()
+	for _, service := range []string{"a", "b"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", service)
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("test_metric")
+	}
+	// the metrics of service a are too large to be published
+	md.ResourceMetrics().At(0).Resource().Attributes().PutStr("padding", strings.Repeat("x", 512))
+
+	err := e.publishMetrics(t.Context(), md)
+	require.ErrorContains(t, err, `failed to publish to "metrics.a"`)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	msg, err := sub.NextMsg(5 * time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "metrics.b", msg.Subject)
+}
+
+func TestPublishMessageLargerThanMaxPayload(t *testing.T) {
+	s := newTestServer(t, func(opts *server.Options) { opts.MaxPayload = 64 })
+
+	cfg := newTestConfig(s)
+	cfg.Logs.Encoding = "raw"
+	e := &natsLogsExporter{natsExporter: newNatsExporter(cfg, componenttest.NewNopTelemetrySettings(), cfg.Logs.Subject)}
+	require.NoError(t, e.start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, e.shutdown(t.Context())) }()
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(strings.Repeat("x", 128))
+	err := e.publishLogs(t.Context(), ld)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
+func TestStartConnectionError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "nats://127.0.0.1:1"
+	cfg.ConnectionTimeout = 100 * time.Millisecond
+	e := &natsLogsExporter{natsExporter: newNatsExporter(cfg, componenttest.NewNopTelemetrySettings(), cfg.Logs.Subject)}
+	err := e.start(t.Context(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, "failed to connect to the NATS server")
+	assert.NoError(t, e.shutdown(t.Context()))
+}
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/natsexporter"
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+)
+
+// invalidTokenCharacters can't be part of a subject token: dots separate tokens,
+// and wildcards and whitespace are not allowed in the subjects messages are published to.
+var invalidTokenCharacters = strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_", "\t", "_", "\r", "_", "\n", "_")
+
+// newSubjectTemplate returns the template of the subjects messages are published to.
+// Each placeholder is replaced with a single subject token, so the values of the attributes
+// can't change the structure of the subject.
+func newSubjectTemplate(template string) messaging.Template {
+	return messaging.NewTemplate(template, invalidTokenCharacters)
+}
+
+// validateSubjectTemplate checks that the subjects resolved from a template are valid publish subjects
+func validateSubjectTemplate(template string) error {
+	return messaging.ValidateTemplate(template, func(subject string) error {
+		if strings.ContainsAny(subject, "*> \t\r\n") {
+			return errors.New("must not contain wildcards or whitespace")
+		}
+		for _, token := range strings.Split(subject, ".") {
+			if token == "" {
+				return errors.New("must not contain empty tokens")
+			}
+		}
+		return nil
+	})
+}
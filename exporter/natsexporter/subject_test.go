@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestValidateSubjectTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		wantErr  string
+	}{
+		{template: "otlp.logs"},
+		{template: "logs.%{service.name}"},
+		{template: "%{service.namespace}.%{service.name}.logs"},
+		{template: "logs-%{service.name}"},
+		{template: "", wantErr: "must not be empty"},
+		{template: "logs.*", wantErr: "must not contain wildcards or whitespace"},
+		{template: "logs.>", wantErr: "must not contain wildcards or whitespace"},
+		{template: "my logs", wantErr: "must not contain wildcards or whitespace"},
+		{template: "logs.%{service.name", wantErr: "contains an unterminated placeholder"},
+		{template: ".logs", wantErr: "must not contain empty tokens"},
+		{template: "logs.", wantErr: "must not contain empty tokens"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			err := validateSubjectTemplate(tt.template)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSubjectTemplateResolve(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	resource.Attributes().PutStr("host.name", "edge.node 1")
+	resource.Attributes().PutStr("wildcards", "a*b>c")
+	resource.Attributes().PutInt("shard", 3)
+	resource.Attributes().PutStr("empty", "")
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{template: "otlp.logs", want: "otlp.logs"},
+		{template: "logs.%{service.name}", want: "logs.checkout"},
+		{template: "logs.%{service.name}.%{shard}", want: "logs.checkout.3"},
+		{template: "logs.%{host.name}", want: "logs.edge_node_1"},
+		{template: "logs.%{wildcards}", want: "logs.a_b_c"},
+		{template: "logs.%{missing}", want: "logs.unknown"},
+		{template: "logs.%{empty}", want: "logs.unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			assert.Equal(t, tt.want, newSubjectTemplate(tt.template).Resolve(resource))
+		})
+	}
+}
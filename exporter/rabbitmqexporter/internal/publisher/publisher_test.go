@@ -361,6 +361,19 @@ func (m *mockChannel) PublishWithDeferredConfirmWithContext(ctx context.Context,
 	return nil, args.Error(1)
 }
 
+func (m *mockChannel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	args := m.Called(prefetchCount, prefetchSize, global)
+	return args.Error(0)
+}
+
+func (m *mockChannel) ConsumeWithContext(ctx context.Context, queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	called := m.Called(ctx, queue, consumer, autoAck, exclusive, noLocal, noWait, args)
+	if deliveries := called.Get(0); deliveries != nil {
+		return deliveries.(<-chan amqp.Delivery), called.Error(1)
+	}
+	return nil, called.Error(1)
+}
+
 func (m *mockChannel) IsClosed() bool {
 	args := m.Called()
 	return args.Bool(0)
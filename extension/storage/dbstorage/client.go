@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	_ "github.com/go-sql-driver/mysql" // MySQL/MariaDB driver
 	_ "github.com/jackc/pgx/v5/stdlib" // Postgres driver
 	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.uber.org/zap"
@@ -171,7 +172,7 @@ func (c *dbStorageClient) aggregatedBatch(ctx context.Context, tx *sql.Tx, squas
 func (c *dbStorageClient) batchGet(ctx context.Context, tx *sql.Tx, ops ...*storage.Operation) error {
 	opsCount := len(ops)
 	// Form a multi-row SELECT Query
-	placeholders := generatePlaceholders(opsCount, 0)
+	placeholders := c.dialect.Placeholders(opsCount, 0)
 	query := strings.Replace(c.dialect.Queries.QueryGetMultiRows, "$1", placeholders, 1)
 
 	// Create helper structs for passing data to query and getting result back
@@ -215,7 +216,7 @@ func (c *dbStorageClient) batchGet(ctx context.Context, tx *sql.Tx, ops ...*stor
 func (c *dbStorageClient) batchSet(ctx context.Context, tx *sql.Tx, ops ...*storage.Operation) error {
 	opsCount := len(ops)
 	// Form a multi-row INSERT Query
-	placeholders := generatePlaceholders(opsCount, 2)
+	placeholders := c.dialect.Placeholders(opsCount, 2)
 
 	vals := make([]any, opsCount*2)
 	idx := 0
@@ -235,7 +236,7 @@ func (c *dbStorageClient) batchSet(ctx context.Context, tx *sql.Tx, ops ...*stor
 func (c *dbStorageClient) batchDelete(ctx context.Context, tx *sql.Tx, ops ...*storage.Operation) error {
 	opsCount := len(ops)
 	// Form a multi-row DELETE Query
-	placeholders := generatePlaceholders(opsCount, 0)
+	placeholders := c.dialect.Placeholders(opsCount, 0)
 	query := strings.Replace(c.dialect.Queries.QueryDeleteMultiRows, "$1", placeholders, 1)
 
 	vals := make([]any, opsCount)
@@ -285,11 +286,28 @@ func canAggregate(ops ...*storage.Operation) (storage.OpType, bool) {
 }
 
 // generatePlaceholders creates SQL placeholder for parametrized queries
-// Positional placeholders, like "$N" is used as they are supported by all used SQL drivers
+// Positional placeholders, like "$N" is used as they are supported by all used SQL drivers except MySQL
 // By default, when `groupSize = 0` will generate N monotonic placeholders, i.e. "$1, $2, ... $n"
 // If `groupSize > 0` - will generate placeholders groups with size = `groupSize`,
 // i.e for `groupSize = 2` - "($1, $2), ($3, $4), ... ($n*groupSize-1, $n*groupSize)"
 func generatePlaceholders(n, groupSize int) string {
+	return buildPlaceholders(n, groupSize, func(sb *strings.Builder, i int) {
+		sb.WriteByte('$')
+		sb.WriteString(strconv.Itoa(i))
+	})
+}
+
+// generateMySQLPlaceholders works as generatePlaceholders, but uses "?" placeholders,
+// the only ones supported by MySQL, i.e. "?, ?, ... ?" or "(?, ?), (?, ?), ... (?, ?)"
+func generateMySQLPlaceholders(n, groupSize int) string {
+	return buildPlaceholders(n, groupSize, func(sb *strings.Builder, _ int) {
+		sb.WriteByte('?')
+	})
+}
+
+// buildPlaceholders lays out placeholders as described in generatePlaceholders,
+// writing each one with its 1-based position using `write`
+func buildPlaceholders(n, groupSize int, write func(sb *strings.Builder, i int)) string {
 	if n <= 0 {
 		return ""
 	}
@@ -298,8 +316,7 @@ func generatePlaceholders(n, groupSize int) string {
 	// Simple case when we don'e need to group placeholders
 	if groupSize == 0 {
 		for i := range n {
-			sb.WriteByte('$')
-			sb.WriteString(strconv.Itoa(i + 1))
+			write(sb, i+1)
 			if i != n-1 {
 				sb.WriteByte(',')
 			}
@@ -314,8 +331,7 @@ func generatePlaceholders(n, groupSize int) string {
 		sb.WriteByte('(')
 		for range groupSize {
 			i++
-			sb.WriteByte('$')
-			sb.WriteString(strconv.Itoa(i))
+			write(sb, i)
 
 			if i%groupSize != 0 {
 				sb.WriteByte(',')
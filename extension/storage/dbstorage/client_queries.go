@@ -13,7 +13,7 @@ const (
 	// Generic set of queries
 	// Will NOT work on all most popular SQL DB's, see DB-specific override queries below
 	// Tested to be working at least on PostgreSQL and SQLite
-	// Not all queries are working on MSSQL, MySQL/MqriaDB, Oracle, etc.
+	// Not all queries are working on MSSQL, MySQL/MariaDB, Oracle, etc.
 	sqlGenericCreateTableQuery = "CREATE TABLE IF NOT EXISTS %s (key TEXT PRIMARY KEY, value TEXT)"
 	sqlGenericGetQuery         = "SELECT value FROM %s WHERE key=$1"
 	sqlGenericMultiGetQuery    = "SELECT key, value FROM %s WHERE key IN ($1)"
@@ -26,6 +26,16 @@ const (
 	sqlSQLiteCreateTableQuery = "CREATE TABLE IF NOT EXISTS %s (key TEXT PRIMARY KEY, value BLOB)"
 	// PostgreSQL
 	sqlPostgreSQLCreateTableQuery = "CREATE TABLE IF NOT EXISTS %s (key TEXT PRIMARY KEY, value bytea)"
+	// MySQL/MariaDB
+	// "key" is a reserved word and TEXT columns can't be a primary key, "$1" in multi-row queries is
+	// still substituted by the list of placeholders
+	sqlMySQLCreateTableQuery = "CREATE TABLE IF NOT EXISTS %s (`key` VARCHAR(512) PRIMARY KEY, `value` LONGBLOB)"
+	sqlMySQLGetQuery         = "SELECT `value` FROM %s WHERE `key`=?"
+	sqlMySQLMultiGetQuery    = "SELECT `key`, `value` FROM %s WHERE `key` IN ($1)"
+	sqlMySQLInsertQuery      = "INSERT INTO %s(`key`, `value`) VALUES(?, ?) ON DUPLICATE KEY UPDATE `value`=VALUES(`value`)"
+	sqlMySQLMultiInsertQuery = "INSERT INTO %s(`key`, `value`) VALUES $1 ON DUPLICATE KEY UPDATE `value`=VALUES(`value`)"
+	sqlMySQLDeleteQuery      = "DELETE FROM %s WHERE `key`=?"
+	sqlMySQLMultiDeleteQuery = "DELETE FROM %s WHERE `key` IN ($1)"
 	// Other to be added later...
 
 	// Max amount of similar queries that can be aggregated into single query, driver-specific
@@ -63,6 +73,16 @@ func getDialectQueries(driverName string) sqlQuerySet {
 		set.QueryCreateTable = sqlSQLiteCreateTableQuery
 	case driverPostgreSQL:
 		set.QueryCreateTable = sqlPostgreSQLCreateTableQuery
+	case driverMySQL:
+		set = sqlQuerySet{
+			QueryCreateTable:     sqlMySQLCreateTableQuery,
+			QueryGetRow:          sqlMySQLGetQuery,
+			QuerySetRow:          sqlMySQLInsertQuery,
+			QueryDeleteRow:       sqlMySQLDeleteQuery,
+			QueryGetMultiRows:    sqlMySQLMultiGetQuery,
+			QuerySetMultiRows:    sqlMySQLMultiInsertQuery,
+			QueryDeleteMultiRows: sqlMySQLMultiDeleteQuery,
+		}
 	}
 
 	return set
@@ -79,6 +99,8 @@ type dbDialect struct {
 	// Reasonable size of Operations that could be aggregated into single batch query
 	// This limit is based on benchmark tests for each specific SQL driver
 	MaxAggregationSize int
+	// Generates the placeholders substituted into multi-row queries, driver-specific
+	Placeholders func(n, groupSize int) string
 }
 
 // Prepare will compile some regularly used queries into Prepared Statements
@@ -127,6 +149,11 @@ func newDBDialect(driverName, tableName string) *dbDialect {
 	default:
 		aggSize = maxAggregatedOpsGeneric
 	}
+
+	placeholders := generatePlaceholders
+	if driverName == driverMySQL {
+		placeholders = generateMySQLPlaceholders
+	}
 	return &dbDialect{
 		Queries: sqlQuerySet{
 			QueryCreateTable:     fmt.Sprintf(queries.QueryCreateTable, tableName),
@@ -138,5 +165,6 @@ func newDBDialect(driverName, tableName string) *dbDialect {
 			QueryDeleteMultiRows: fmt.Sprintf(queries.QueryDeleteMultiRows, tableName),
 		},
 		MaxAggregationSize: aggSize,
+		Placeholders:       placeholders,
 	}
 }
@@ -27,6 +27,16 @@ func Test_getDialectQueries(t *testing.T) {
 		got := getDialectQueries(driverSQLite)
 		assert.NotEqual(t, sqlGenericCreateTableQuery, got.QueryCreateTable)
 	})
+	t.Run("Should return MySQL queries", func(t *testing.T) {
+		got := getDialectQueries(driverMySQL)
+		assert.Equal(t, sqlMySQLCreateTableQuery, got.QueryCreateTable)
+		assert.Equal(t, sqlMySQLGetQuery, got.QueryGetRow)
+		assert.Equal(t, sqlMySQLInsertQuery, got.QuerySetRow)
+		assert.Equal(t, sqlMySQLDeleteQuery, got.QueryDeleteRow)
+		assert.Equal(t, sqlMySQLMultiGetQuery, got.QueryGetMultiRows)
+		assert.Equal(t, sqlMySQLMultiInsertQuery, got.QuerySetMultiRows)
+		assert.Equal(t, sqlMySQLMultiDeleteQuery, got.QueryDeleteMultiRows)
+	})
 }
 
 func Test_dbDialect_Prepare(t *testing.T) {
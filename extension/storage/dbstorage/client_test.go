@@ -325,6 +325,28 @@ func Test_dbStorageClient_Batch(t *testing.T) {
 	})
 }
 
+func Test_dbStorageClient_BatchMySQL(t *testing.T) {
+	queries := getDialectQueries(driverMySQL)
+
+	t.Run("Should use MySQL placeholders in aggregated queries", func(t *testing.T) {
+		client, mock := newTestClient(t, driverMySQL)
+		defer client.db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(strings.Replace(fmt.Sprintf(queries.QuerySetMultiRows, testTableName), "$1", "(?,?),(?,?)", 1))).
+			WithArgs("foo", []byte("bar"), "baz", []byte("qux")).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		err := client.Batch(t.Context(),
+			&storage.Operation{Type: storage.Set, Key: "foo", Value: []byte("bar")},
+			&storage.Operation{Type: storage.Set, Key: "baz", Value: []byte("qux")},
+		)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func Test_dbStorageClient_Close(t *testing.T) {
 	t.Run("Shouldn't fail on already closed connection", func(t *testing.T) {
 		client, mock := newTestClient(t, driverSQLite)
@@ -420,6 +442,12 @@ func Test_generatePlaceholders(t *testing.T) {
 	}
 }
 
+func Test_generateMySQLPlaceholders(t *testing.T) {
+	assert.Empty(t, generateMySQLPlaceholders(0, 0))
+	assert.Equal(t, "?,?,?", generateMySQLPlaceholders(3, 0))
+	assert.Equal(t, "(?,?),(?,?)", generateMySQLPlaceholders(2, 2))
+}
+
 func newTestClient(t *testing.T, driverName string) (*dbStorageClient, sqlmock.Sqlmock) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
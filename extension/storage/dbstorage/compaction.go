@@ -0,0 +1,299 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage"
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage/internal/metadata"
+)
+
+const (
+	// registryTableName is the table that records when the table of each component was last used
+	registryTableName = "otelcol_db_storage_registry"
+
+	// Generic set of queries
+	// Tested to be working at least on PostgreSQL and SQLite
+	sqlGenericCreateRegistryQuery  = "CREATE TABLE IF NOT EXISTS " + registryTableName + " (table_name VARCHAR(255) PRIMARY KEY, last_used BIGINT)"
+	sqlGenericTouchTableQuery      = "INSERT INTO " + registryTableName + "(table_name, last_used) VALUES($1, $2) ON CONFLICT(table_name) DO UPDATE SET last_used=excluded.last_used"
+	sqlGenericStaleTablesQuery     = "SELECT table_name FROM " + registryTableName + " WHERE last_used < $1"
+	sqlGenericUnregisterTableQuery = "DELETE FROM " + registryTableName + " WHERE table_name=$1"
+	sqlGenericDropTableQuery       = "DROP TABLE IF EXISTS %s"
+	sqlGenericCountRowsQuery       = "SELECT COUNT(*) FROM %s"
+	// DB-specific queries
+	// SQLite, the primary key index is stored separately from the table
+	sqlSQLiteTableSizeQuery = "SELECT COALESCE(SUM(pgsize), 0) FROM dbstat WHERE name IN (SELECT name FROM sqlite_schema WHERE tbl_name=$1)"
+	sqlSQLiteVacuumQuery    = "VACUUM"
+	// PostgreSQL
+	sqlPostgreSQLTableSizeQuery = "SELECT pg_total_relation_size($1::regclass)"
+	// MySQL/MariaDB, table statistics are estimates that might be cached by the server
+	sqlMySQLTouchTableQuery      = "INSERT INTO " + registryTableName + "(table_name, last_used) VALUES(?, ?) ON DUPLICATE KEY UPDATE last_used=VALUES(last_used)"
+	sqlMySQLStaleTablesQuery     = "SELECT table_name FROM " + registryTableName + " WHERE last_used < ?"
+	sqlMySQLUnregisterTableQuery = "DELETE FROM " + registryTableName + " WHERE table_name=?"
+	sqlMySQLTableSizeQuery       = "SELECT COALESCE(data_length + index_length, 0) FROM information_schema.tables WHERE table_schema=DATABASE() AND table_name=?"
+)
+
+type compactionQuerySet struct {
+	QueryCreateRegistry  string
+	QueryTouchTable      string
+	QueryStaleTables     string
+	QueryUnregisterTable string
+	QueryDropTable       string
+	QueryCountRows       string
+	// Empty if the driver can't report the size of a table
+	QueryTableSize string
+	// Empty if the driver doesn't need to be vacuumed
+	QueryVacuum string
+}
+
+// getCompactionQueries returns set of queries used by the compaction job, driver-specific
+func getCompactionQueries(driverName string) compactionQuerySet {
+	set := compactionQuerySet{
+		QueryCreateRegistry:  sqlGenericCreateRegistryQuery,
+		QueryTouchTable:      sqlGenericTouchTableQuery,
+		QueryStaleTables:     sqlGenericStaleTablesQuery,
+		QueryUnregisterTable: sqlGenericUnregisterTableQuery,
+		QueryDropTable:       sqlGenericDropTableQuery,
+		QueryCountRows:       sqlGenericCountRowsQuery,
+	}
+
+	switch driverName {
+	case driverSQLite:
+		set.QueryTableSize = sqlSQLiteTableSizeQuery
+		set.QueryVacuum = sqlSQLiteVacuumQuery
+	case driverPostgreSQL:
+		set.QueryTableSize = sqlPostgreSQLTableSizeQuery
+	case driverMySQL:
+		set.QueryTouchTable = sqlMySQLTouchTableQuery
+		set.QueryStaleTables = sqlMySQLStaleTablesQuery
+		set.QueryUnregisterTable = sqlMySQLUnregisterTableQuery
+		set.QueryTableSize = sqlMySQLTableSizeQuery
+	}
+
+	return set
+}
+
+// compactor keeps track of the tables used by components and periodically drops
+// the tables of components that were not used within the configured TTL.
+// The last time a table was used is stored in the database, so that tables of
+// components that were removed from the configuration are dropped as well.
+type compactor struct {
+	cfg       CompactionConfig
+	logger    *zap.Logger
+	db        *sql.DB
+	queries   compactionQuerySet
+	telemetry *metadata.TelemetryBuilder
+	now       func() time.Time
+
+	// mu is held while tables are created or dropped, so a table can't be dropped
+	// while a client is being created for it
+	mu sync.Mutex
+	// tables holds the number of open clients for each table
+	tables map[string]int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newCompactor(cfg CompactionConfig, logger *zap.Logger, db *sql.DB, driverName string, telemetry *metadata.TelemetryBuilder) *compactor {
+	return &compactor{
+		cfg:       cfg,
+		logger:    logger,
+		db:        db,
+		queries:   getCompactionQueries(driverName),
+		telemetry: telemetry,
+		now:       time.Now,
+		tables:    make(map[string]int),
+	}
+}
+
+// Start creates the registry table if needed and starts the compaction job
+func (c *compactor) Start(ctx context.Context) error {
+	if _, err := c.db.ExecContext(ctx, c.queries.QueryCreateRegistry); err != nil {
+		return fmt.Errorf("failed to create registry table: %w", err)
+	}
+
+	var loopCtx context.Context
+	loopCtx, c.cancel = context.WithCancel(context.Background())
+	c.done = make(chan struct{})
+	go c.loop(loopCtx)
+	return nil
+}
+
+// Shutdown stops the compaction job and waits for a running compaction to finish
+func (c *compactor) Shutdown() {
+	if c.cancel == nil {
+		return
+	}
+	c.cancel()
+	<-c.done
+	c.cancel = nil
+}
+
+func (c *compactor) loop(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Compact(ctx); err != nil {
+				c.logger.Warn("Failed to compact storage", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Acquire marks table as used and calls create while no compaction can drop the table
+func (c *compactor) Acquire(ctx context.Context, table string, create func() error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.db.ExecContext(ctx, c.queries.QueryTouchTable, table, c.now().Unix()); err != nil {
+		return fmt.Errorf("failed to register table %s: %w", table, err)
+	}
+	if err := create(); err != nil {
+		return err
+	}
+	c.tables[table]++
+	return nil
+}
+
+// Release marks table as no longer used by one of its clients
+func (c *compactor) Release(table string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tables[table]--
+	if c.tables[table] <= 0 {
+		delete(c.tables, table)
+	}
+}
+
+// Compact drops stale tables, vacuums the database if configured and records
+// the size of all tables that are currently in use
+func (c *compactor) Compact(ctx context.Context) error {
+	openTables, errs := c.dropStaleTables(ctx)
+
+	if c.cfg.Vacuum && c.queries.QueryVacuum != "" {
+		if _, err := c.db.ExecContext(ctx, c.queries.QueryVacuum); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to vacuum database: %w", err))
+		}
+	}
+
+	for _, table := range openTables {
+		errs = errors.Join(errs, c.recordTableSize(ctx, table))
+	}
+	return errs
+}
+
+// dropStaleTables refreshes the registry entries of all open tables and drops the
+// tables that were not used within the TTL. It returns the tables that are open.
+func (c *compactor) dropStaleTables(ctx context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	openTables := make([]string, 0, len(c.tables))
+	for table := range c.tables {
+		if _, err := c.db.ExecContext(ctx, c.queries.QueryTouchTable, table, now.Unix()); err != nil {
+			return nil, fmt.Errorf("failed to register table %s: %w", table, err)
+		}
+		openTables = append(openTables, table)
+	}
+
+	if c.cfg.TTL <= 0 {
+		return openTables, nil
+	}
+
+	staleTables, err := c.staleTables(ctx, now.Add(-c.cfg.TTL))
+	if err != nil {
+		return nil, err
+	}
+
+	var errs error
+	for _, table := range staleTables {
+		if _, open := c.tables[table]; open {
+			continue
+		}
+		if err := c.dropTable(ctx, table); err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		c.logger.Info("Dropped table of unused component", zap.String("table", table))
+		c.telemetry.DbStorageCompactionDroppedTables.Add(ctx, 1)
+	}
+	return openTables, errs
+}
+
+func (c *compactor) staleTables(ctx context.Context, usedBefore time.Time) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx, c.queries.QueryStaleTables, usedBefore.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
+
+func (c *compactor) dropTable(ctx context.Context, table string) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); !errors.Is(rollbackErr, sql.ErrTxDone) {
+			c.logger.Error("Failed to rollback transaction", zap.Error(rollbackErr))
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(c.queries.QueryDropTable, table)); err != nil {
+		return fmt.Errorf("failed to drop table %s: %w", table, err)
+	}
+	if _, err := tx.ExecContext(ctx, c.queries.QueryUnregisterTable, table); err != nil {
+		return fmt.Errorf("failed to unregister table %s: %w", table, err)
+	}
+	return tx.Commit()
+}
+
+func (c *compactor) recordTableSize(ctx context.Context, table string) error {
+	attrs := metric.WithAttributeSet(attribute.NewSet(attribute.String("table", table)))
+
+	var rows int64
+	if err := c.db.QueryRowContext(ctx, fmt.Sprintf(c.queries.QueryCountRows, table)).Scan(&rows); err != nil {
+		return fmt.Errorf("failed to count rows of table %s: %w", table, err)
+	}
+	c.telemetry.DbStorageTableRows.Record(ctx, rows, attrs)
+
+	if c.queries.QueryTableSize == "" {
+		return nil
+	}
+	var size int64
+	if err := c.db.QueryRowContext(ctx, c.queries.QueryTableSize, table).Scan(&size); err != nil {
+		return fmt.Errorf("failed to get size of table %s: %w", table, err)
+	}
+	c.telemetry.DbStorageTableSize.Record(ctx, size, attrs)
+	return nil
+}
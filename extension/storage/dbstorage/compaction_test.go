@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dbstorage
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage/internal/metadatatest"
+)
+
+func Test_getCompactionQueries(t *testing.T) {
+	t.Run("Should return generic queries without size for unknown driver", func(t *testing.T) {
+		got := getCompactionQueries("unknown")
+		assert.Equal(t, sqlGenericTouchTableQuery, got.QueryTouchTable)
+		assert.Empty(t, got.QueryTableSize)
+		assert.Empty(t, got.QueryVacuum)
+	})
+	t.Run("Should only vacuum SQLite", func(t *testing.T) {
+		assert.Equal(t, sqlSQLiteVacuumQuery, getCompactionQueries(driverSQLite).QueryVacuum)
+		assert.Empty(t, getCompactionQueries(driverPostgreSQL).QueryVacuum)
+		assert.Empty(t, getCompactionQueries(driverMySQL).QueryVacuum)
+	})
+	t.Run("Should return MySQL queries", func(t *testing.T) {
+		got := getCompactionQueries(driverMySQL)
+		assert.Equal(t, sqlMySQLTouchTableQuery, got.QueryTouchTable)
+		assert.Equal(t, sqlMySQLStaleTablesQuery, got.QueryStaleTables)
+		assert.Equal(t, sqlMySQLUnregisterTableQuery, got.QueryUnregisterTable)
+		assert.Equal(t, sqlMySQLTableSizeQuery, got.QueryTableSize)
+	})
+}
+
+func TestCompactionWithSqlite(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) }) //nolint:usetesting
+
+	dbPath := filepath.Join(t.TempDir(), "foo.db")
+	cfg := &Config{
+		DriverName: driverSQLite,
+		DataSource: dbPath,
+		Compaction: CompactionConfig{
+			// The job is triggered manually below
+			Interval: time.Hour,
+			TTL:      24 * time.Hour,
+			Vacuum:   true,
+		},
+	}
+	ext, err := newDBStorage(metadatatest.NewSettings(tt), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, ext.Shutdown(t.Context()))
+	}()
+
+	ds := ext.(*databaseStorage)
+	now := time.Now()
+	ds.compactor.now = func() time.Time { return now }
+
+	used, err := ds.GetClient(t.Context(), component.KindReceiver, newTestEntity("used"), "")
+	require.NoError(t, err)
+	defer used.Close(t.Context())
+	require.NoError(t, used.Set(t.Context(), "foo", []byte("bar")))
+	require.NoError(t, used.Set(t.Context(), "baz", []byte("qux")))
+
+	removed, err := ds.GetClient(t.Context(), component.KindReceiver, newTestEntity("removed"), "")
+	require.NoError(t, err)
+	require.NoError(t, removed.Set(t.Context(), "foo", []byte("bar")))
+	require.NoError(t, removed.Close(t.Context()))
+
+	// The removed component is not stale yet
+	now = now.Add(time.Hour)
+	require.NoError(t, ds.compactor.Compact(t.Context()))
+	assert.ElementsMatch(t, []string{"receiver_nop_used", "receiver_nop_removed"}, listTables(t, ds.db))
+
+	now = now.Add(24 * time.Hour)
+	require.NoError(t, ds.compactor.Compact(t.Context()))
+	assert.ElementsMatch(t, []string{"receiver_nop_used"}, listTables(t, ds.db))
+
+	// The state of the used component was kept
+	val, err := used.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bar"), val)
+
+	usedAttrs := attribute.NewSet(attribute.String("table", "receiver_nop_used"))
+	metadatatest.AssertEqualDbStorageCompactionDroppedTables(t, tt, []metricdata.DataPoint[int64]{
+		{Value: 1},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualDbStorageTableRows(t, tt, []metricdata.DataPoint[int64]{
+		{Attributes: usedAttrs, Value: 2},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualDbStorageTableSize(t, tt, []metricdata.DataPoint[int64]{
+		{Attributes: usedAttrs},
+	}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+}
+
+func TestCompactionDropsTablesOfPreviousRuns(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "foo.db")
+	cfg := &Config{
+		DriverName: driverSQLite,
+		DataSource: dbPath,
+		Compaction: CompactionConfig{Interval: time.Hour, TTL: 24 * time.Hour},
+	}
+	set := metadatatest.NewSettings(componenttest.NewTelemetry())
+
+	// The component used in the first run is removed from the configuration of the second run
+	first, err := newDBStorage(set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, first.Start(t.Context(), componenttest.NewNopHost()))
+	client, err := first.(*databaseStorage).GetClient(t.Context(), component.KindExporter, newTestEntity("removed"), "queue")
+	require.NoError(t, err)
+	require.NoError(t, client.Close(t.Context()))
+	require.NoError(t, first.Shutdown(t.Context()))
+
+	second, err := newDBStorage(set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, second.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, second.Shutdown(t.Context()))
+	}()
+	ds := second.(*databaseStorage)
+	assert.ElementsMatch(t, []string{"exporter_nop_removed_queue"}, listTables(t, ds.db))
+
+	ds.compactor.now = func() time.Time { return time.Now().Add(25 * time.Hour) }
+	require.NoError(t, ds.compactor.Compact(t.Context()))
+	assert.Empty(t, listTables(t, ds.db))
+}
+
+func TestCompactionDisabled(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "foo.db")
+	ext, err := newDBStorage(metadatatest.NewSettings(componenttest.NewTelemetry()), &Config{
+		DriverName: driverSQLite,
+		DataSource: dbPath,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, ext.Shutdown(t.Context()))
+	}()
+
+	ds := ext.(*databaseStorage)
+	assert.Nil(t, ds.compactor)
+
+	var count int
+	require.NoError(t, ds.db.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM sqlite_schema WHERE name=$1", registryTableName).Scan(&count))
+	assert.Zero(t, count, "registry table should not be created when compaction is disabled")
+}
+
+// listTables returns the tables of components, without the registry table
+func listTables(t *testing.T, db *sql.DB) []string {
+	rows, err := db.QueryContext(t.Context(), "SELECT name FROM sqlite_schema WHERE type='table' AND name<>$1", registryTableName)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		tables = append(tables, name)
+	}
+	require.NoError(t, rows.Err())
+	return tables
+}
@@ -6,18 +6,38 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 const (
 	driverPostgreSQL   = "pgx"
 	driverSQLite       = "sqlite"
 	driverSQLiteLegacy = "sqlite3"
+	driverMySQL        = "mysql"
 )
 
 // Config defines configuration for dbstorage extension.
 type Config struct {
 	DriverName string `mapstructure:"driver,omitempty"`
 	DataSource string `mapstructure:"datasource,omitempty"`
+
+	// Compaction configures the background job that removes the state of
+	// components that are no longer used and reports the size of tables.
+	Compaction CompactionConfig `mapstructure:"compaction"`
+}
+
+// CompactionConfig defines configuration for the compaction job.
+type CompactionConfig struct {
+	// Interval is the time between two compaction runs. Compaction is disabled when zero.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// TTL is the time after which the table of a component that was not used
+	// is dropped. Tables are never dropped when zero.
+	TTL time.Duration `mapstructure:"ttl"`
+
+	// Vacuum rebuilds the database file on every compaction run to return
+	// unused space to the file system. Only supported by the sqlite driver.
+	Vacuum bool `mapstructure:"vacuum"`
 }
 
 func (cfg *Config) Validate() error {
@@ -30,9 +50,29 @@ func (cfg *Config) Validate() error {
 
 	if cfg.DriverName != driverPostgreSQL &&
 		cfg.DriverName != driverSQLite &&
-		cfg.DriverName != driverSQLiteLegacy {
+		cfg.DriverName != driverSQLiteLegacy &&
+		cfg.DriverName != driverMySQL {
 		return fmt.Errorf("unsupported driver %s", cfg.DriverName)
 	}
 
+	return cfg.Compaction.validate(cfg.DriverName)
+}
+
+func (cfg *CompactionConfig) validate(driverName string) error {
+	if cfg.Interval < 0 {
+		return errors.New("compaction interval must not be negative")
+	}
+	if cfg.TTL < 0 {
+		return errors.New("compaction ttl must not be negative")
+	}
+	if cfg.Interval == 0 && (cfg.TTL > 0 || cfg.Vacuum) {
+		return errors.New("compaction interval must be set when compaction ttl or vacuum is set")
+	}
+	if cfg.TTL > 0 && cfg.TTL < cfg.Interval {
+		return errors.New("compaction ttl must not be lower than compaction interval")
+	}
+	if cfg.Vacuum && driverName != driverSQLite && driverName != driverSQLiteLegacy {
+		return fmt.Errorf("compaction vacuum is not supported by driver %s", driverName)
+	}
 	return nil
 }
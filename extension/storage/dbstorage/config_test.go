@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -37,6 +38,41 @@ func TestConfigValidate(t *testing.T) {
 			Config{DriverName: driverSQLite, DataSource: "bar"},
 			nil,
 		},
+		{
+			"Valid MySQL",
+			Config{DriverName: driverMySQL, DataSource: "bar"},
+			nil,
+		},
+		{
+			"Valid compaction",
+			Config{DriverName: driverSQLite, DataSource: "bar", Compaction: CompactionConfig{Interval: time.Hour, TTL: 24 * time.Hour, Vacuum: true}},
+			nil,
+		},
+		{
+			"Negative compaction interval",
+			Config{DriverName: driverSQLite, DataSource: "bar", Compaction: CompactionConfig{Interval: -time.Hour}},
+			errors.New("compaction interval must not be negative"),
+		},
+		{
+			"Negative compaction ttl",
+			Config{DriverName: driverSQLite, DataSource: "bar", Compaction: CompactionConfig{Interval: time.Hour, TTL: -time.Hour}},
+			errors.New("compaction ttl must not be negative"),
+		},
+		{
+			"Compaction ttl without interval",
+			Config{DriverName: driverSQLite, DataSource: "bar", Compaction: CompactionConfig{TTL: time.Hour}},
+			errors.New("compaction interval must be set when compaction ttl or vacuum is set"),
+		},
+		{
+			"Compaction ttl lower than interval",
+			Config{DriverName: driverSQLite, DataSource: "bar", Compaction: CompactionConfig{Interval: time.Hour, TTL: time.Minute}},
+			errors.New("compaction ttl must not be lower than compaction interval"),
+		},
+		{
+			"Vacuum not supported",
+			Config{DriverName: driverMySQL, DataSource: "bar", Compaction: CompactionConfig{Interval: time.Hour, Vacuum: true}},
+			fmt.Errorf("compaction vacuum is not supported by driver %s", driverMySQL),
+		},
 	}
 
 	for _, test := range tests {
@@ -13,28 +13,40 @@ import (
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage/internal/metadata"
 )
 
 type databaseStorage struct {
 	driverName     string
 	datasourceName string
+	compaction     CompactionConfig
 	logger         *zap.Logger
+	telemetry      *metadata.TelemetryBuilder
 	db             *sql.DB
+	// compactor is only set if compaction is enabled
+	compactor *compactor
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*databaseStorage)(nil)
 
-func newDBStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+func newDBStorage(set extension.Settings, config *Config) (extension.Extension, error) {
+	telemetry, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
 	return &databaseStorage{
 		driverName:     config.DriverName,
 		datasourceName: config.DataSource,
-		logger:         logger,
+		compaction:     config.Compaction,
+		logger:         set.Logger,
+		telemetry:      telemetry,
 	}, nil
 }
 
 // Start opens a connection to the database
-func (ds *databaseStorage) Start(context.Context, component.Host) error {
+func (ds *databaseStorage) Start(ctx context.Context, _ component.Host) error {
 	if ds.driverName == driverSQLiteLegacy {
 		// Log warning about legacy driver usage
 		ds.logger.Warn("Legacy driver 'sqlite3' is used, please review documentation to update your configuration")
@@ -57,11 +69,23 @@ func (ds *databaseStorage) Start(context.Context, component.Host) error {
 		return err
 	}
 	ds.db = db
+
+	if ds.compaction.Interval > 0 {
+		ds.compactor = newCompactor(ds.compaction, ds.logger, db, ds.driverName, ds.telemetry)
+		if err := ds.compactor.Start(ctx); err != nil {
+			ds.compactor = nil
+			return err
+		}
+	}
 	return nil
 }
 
-// Shutdown closes the connection to the database
+// Shutdown stops the compaction job and closes the connection to the database
 func (ds *databaseStorage) Shutdown(context.Context) error {
+	if ds.compactor != nil {
+		ds.compactor.Shutdown()
+	}
+	ds.telemetry.Shutdown()
 	if ds.db == nil {
 		return nil
 	}
@@ -77,7 +101,34 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.logger, ds.db, ds.driverName, fullName)
+	if ds.compactor == nil {
+		return newClient(ctx, ds.logger, ds.db, ds.driverName, fullName)
+	}
+
+	var client *dbStorageClient
+	err := ds.compactor.Acquire(ctx, fullName, func() error {
+		var err error
+		client, err = newClient(ctx, ds.logger, ds.db, ds.driverName, fullName)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &compactedClient{
+		dbStorageClient: client,
+		release:         func() { ds.compactor.Release(fullName) },
+	}, nil
+}
+
+// compactedClient marks its table as no longer used by the compactor when closed
+type compactedClient struct {
+	*dbStorageClient
+	release func()
+}
+
+func (c *compactedClient) Close(ctx context.Context) error {
+	c.release()
+	return c.dbStorageClient.Close(ctx)
 }
 
 func kindString(k component.Kind) string {
@@ -53,6 +53,22 @@ func TestExtensionIntegrityWithPostgres(t *testing.T) {
 	testExtensionIntegrity(t, se)
 }
 
+func TestExtensionIntegrityWithMySQL(t *testing.T) {
+	if runtime.GOOS == "windows" && os.Getenv("GITHUB_ACTIONS") == "true" {
+		t.Skip("Skipping test on Windows GH runners: test requires Docker to be running Linux containers")
+	}
+
+	se, ctr, err := newMySQLTestExtension()
+	t.Cleanup(func() {
+		if ctr != nil {
+			require.NoError(t, ctr.Terminate(context.Background())) //nolint:usetesting
+		}
+	})
+	require.NoError(t, err)
+
+	testExtensionIntegrity(t, se)
+}
+
 func testExtensionIntegrity(t *testing.T, se storage.Extension) {
 	ctx := t.Context()
 
@@ -252,6 +268,47 @@ func newPostgresTestExtension() (storage.Extension, testcontainers.Container, er
 	return se, ctr, nil
 }
 
+func newMySQLTestExtension() (storage.Extension, testcontainers.Container, error) {
+	req := testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "mariadb:11",
+			ExposedPorts: []string{"3306/tcp"},
+			Env: map[string]string{
+				"MARIADB_ROOT_PASSWORD": "passwd",
+				"MARIADB_DATABASE":      "db",
+			},
+			WaitingFor: wait.ForListeningPort("3306"),
+		},
+		Started: true,
+	}
+
+	ctr, err := testcontainers.GenericContainer(context.Background(), req)
+	if err != nil {
+		return nil, nil, err
+	}
+	port, err := ctr.MappedPort(context.Background(), "3306")
+	if err != nil {
+		return nil, ctr, err
+	}
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.DriverName = driverMySQL
+	cfg.DataSource = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", "root", "passwd", "127.0.0.1", port.Port(), "db")
+	cfg.Compaction = CompactionConfig{Interval: time.Second, TTL: time.Hour}
+
+	extension, err := f.Create(context.Background(), extensiontest.NewNopSettings(f.Type()), cfg)
+	if err != nil {
+		return nil, ctr, err
+	}
+
+	se, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, ctr, errors.New("created extension is not a storage extension")
+	}
+
+	return se, ctr, nil
+}
+
 func newTestEntity(name string) component.ID {
 	return component.MustNewIDWithName("nop", name)
 }
@@ -33,5 +33,5 @@ func createExtension(
 	params extension.Settings,
 	cfg component.Config,
 ) (extension.Extension, error) {
-	return newDBStorage(params.Logger, cfg.(*Config))
+	return newDBStorage(params, cfg.(*Config))
 }
@@ -0,0 +1,81 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Meter(settings component.TelemetrySettings) metric.Meter {
+	return settings.MeterProvider.Meter("github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage")
+}
+
+func Tracer(settings component.TelemetrySettings) trace.Tracer {
+	return settings.TracerProvider.Tracer("github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage")
+}
+
+// TelemetryBuilder provides an interface for components to report telemetry
+// as defined in metadata and user config.
+type TelemetryBuilder struct {
+	meter                            metric.Meter
+	mu                               sync.Mutex
+	registrations                    []metric.Registration
+	DbStorageCompactionDroppedTables metric.Int64Counter
+	DbStorageTableRows               metric.Int64Gauge
+	DbStorageTableSize               metric.Int64Gauge
+}
+
+// TelemetryBuilderOption applies changes to default builder.
+type TelemetryBuilderOption interface {
+	apply(*TelemetryBuilder)
+}
+
+type telemetryBuilderOptionFunc func(mb *TelemetryBuilder)
+
+func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
+	tbof(mb)
+}
+
+// Shutdown unregister all registered callbacks for async instruments.
+func (builder *TelemetryBuilder) Shutdown() {
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	for _, reg := range builder.registrations {
+		reg.Unregister()
+	}
+}
+
+// NewTelemetryBuilder provides a struct with methods to update all internal telemetry
+// for a component
+func NewTelemetryBuilder(settings component.TelemetrySettings, options ...TelemetryBuilderOption) (*TelemetryBuilder, error) {
+	builder := TelemetryBuilder{}
+	for _, op := range options {
+		op.apply(&builder)
+	}
+	builder.meter = Meter(settings)
+	var err, errs error
+	builder.DbStorageCompactionDroppedTables, err = builder.meter.Int64Counter(
+		"otelcol_db_storage_compaction_dropped_tables",
+		metric.WithDescription("Number of tables dropped because their component was not used within the configured TTL. [Development]"),
+		metric.WithUnit("{tables}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.DbStorageTableRows, err = builder.meter.Int64Gauge(
+		"otelcol_db_storage_table_rows",
+		metric.WithDescription("Number of keys stored in the table of a component, recorded on every compaction run. [Development]"),
+		metric.WithUnit("{keys}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.DbStorageTableSize, err = builder.meter.Int64Gauge(
+		"otelcol_db_storage_table_size",
+		metric.WithDescription("Size of the table of a component including its indexes, recorded on every compaction run. [Development]"),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	return &builder, errs
+}
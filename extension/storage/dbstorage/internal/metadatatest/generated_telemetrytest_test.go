@@ -0,0 +1,36 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadatatest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/dbstorage/internal/metadata"
+)
+
+func TestSetupTelemetry(t *testing.T) {
+	testTel := componenttest.NewTelemetry()
+	tb, err := metadata.NewTelemetryBuilder(testTel.NewTelemetrySettings())
+	require.NoError(t, err)
+	defer tb.Shutdown()
+	tb.DbStorageCompactionDroppedTables.Add(context.Background(), 1)
+	tb.DbStorageTableRows.Record(context.Background(), 1)
+	tb.DbStorageTableSize.Record(context.Background(), 1)
+	AssertEqualDbStorageCompactionDroppedTables(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualDbStorageTableRows(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualDbStorageTableSize(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+
+	require.NoError(t, testTel.Shutdown(context.Background()))
+}
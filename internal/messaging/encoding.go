@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var errUnknownEncodingExtension = errors.New("unknown encoding extension")
+
+// loadEncodingExtension tries to load an available extension for the given encoding.
+// kind describes the interface the extension must implement, e.g. "logs marshaler".
+func loadEncodingExtension[T any](host component.Host, encoding, kind string) (T, error) {
+	var zero T
+	var id component.ID
+	if err := id.UnmarshalText([]byte(encoding)); err != nil {
+		return zero, fmt.Errorf("invalid component ID: %w", err)
+	}
+	encodingExtension, ok := host.GetExtensions()[id]
+	if !ok {
+		return zero, fmt.Errorf("invalid encoding %q: %w", encoding, errUnknownEncodingExtension)
+	}
+	extension, ok := encodingExtension.(T)
+	if !ok {
+		return zero, fmt.Errorf("extension %q is not a %s", encoding, kind)
+	}
+	return extension, nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+// Handler unmarshals the payload of a message, sets the given attributes on the resources of the
+// resulting data, and passes the data to the next consumer. Payloads which can't be unmarshaled fail
+// with a permanent error, since they would fail again if the message was redelivered.
+type Handler func(ctx context.Context, payload []byte, resourceAttributes map[string]string) error
+
+// NewLogsHandler returns a Handler passing logs to the next consumer. The operations are reported
+// to obsrecv with the given format.
+func NewLogsHandler(obsrecv *receiverhelper.ObsReport, format string, unmarshaler plog.Unmarshaler, next consumer.Logs) Handler {
+	return func(ctx context.Context, payload []byte, resourceAttributes map[string]string) error {
+		ctx = obsrecv.StartLogsOp(ctx)
+		logs, err := unmarshaler.UnmarshalLogs(payload)
+		if err != nil {
+			obsrecv.EndLogsOp(ctx, format, 0, err)
+			return consumererror.NewPermanent(fmt.Errorf("failed to unmarshal logs: %w", err))
+		}
+		for _, rl := range logs.ResourceLogs().All() {
+			putAttributes(rl.Resource(), resourceAttributes)
+		}
+		err = next.ConsumeLogs(ctx, logs)
+		obsrecv.EndLogsOp(ctx, format, logs.LogRecordCount(), err)
+		return err
+	}
+}
+
+// NewMetricsHandler returns a Handler passing metrics to the next consumer. The operations are reported
+// to obsrecv with the given format.
+func NewMetricsHandler(obsrecv *receiverhelper.ObsReport, format string, unmarshaler pmetric.Unmarshaler, next consumer.Metrics) Handler {
+	return func(ctx context.Context, payload []byte, resourceAttributes map[string]string) error {
+		ctx = obsrecv.StartMetricsOp(ctx)
+		metrics, err := unmarshaler.UnmarshalMetrics(payload)
+		if err != nil {
+			obsrecv.EndMetricsOp(ctx, format, 0, err)
+			return consumererror.NewPermanent(fmt.Errorf("failed to unmarshal metrics: %w", err))
+		}
+		for _, rm := range metrics.ResourceMetrics().All() {
+			putAttributes(rm.Resource(), resourceAttributes)
+		}
+		err = next.ConsumeMetrics(ctx, metrics)
+		obsrecv.EndMetricsOp(ctx, format, metrics.DataPointCount(), err)
+		return err
+	}
+}
+
+// NewTracesHandler returns a Handler passing traces to the next consumer. The operations are reported
+// to obsrecv with the given format.
+func NewTracesHandler(obsrecv *receiverhelper.ObsReport, format string, unmarshaler ptrace.Unmarshaler, next consumer.Traces) Handler {
+	return func(ctx context.Context, payload []byte, resourceAttributes map[string]string) error {
+		ctx = obsrecv.StartTracesOp(ctx)
+		traces, err := unmarshaler.UnmarshalTraces(payload)
+		if err != nil {
+			obsrecv.EndTracesOp(ctx, format, 0, err)
+			return consumererror.NewPermanent(fmt.Errorf("failed to unmarshal traces: %w", err))
+		}
+		for _, rs := range traces.ResourceSpans().All() {
+			putAttributes(rs.Resource(), resourceAttributes)
+		}
+		err = next.ConsumeTraces(ctx, traces)
+		obsrecv.EndTracesOp(ctx, format, traces.SpanCount(), err)
+		return err
+	}
+}
+
+func putAttributes(resource pcommon.Resource, attributes map[string]string) {
+	for key, value := range attributes {
+		resource.Attributes().PutStr(key, value)
+	}
+}
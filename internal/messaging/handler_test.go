@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func newObsReport(t *testing.T) *receiverhelper.ObsReport {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             component.MustNewID("test"),
+		Transport:              "test",
+		ReceiverCreateSettings: receivertest.NewNopSettings(component.MustNewType("test")),
+	})
+	require.NoError(t, err)
+	return obsrecv
+}
+
+func TestLogsHandler(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	handler := NewLogsHandler(newObsReport(t), "text", rawLogsUnmarshaler{text: true}, sink)
+
+	require.NoError(t, handler(t.Context(), []byte("hello"), map[string]string{"site": "paris"}))
+	require.Len(t, sink.AllLogs(), 1)
+	rl := sink.AllLogs()[0].ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{"site": "paris"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, "hello", rl.ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+
+	err := NewLogsHandler(newObsReport(t), "otlp_proto", &plog.ProtoUnmarshaler{}, sink)(t.Context(), []byte("invalid"), nil)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	// errors of the next consumer are returned as they are
+	consumerErr := errors.New("refused")
+	err = NewLogsHandler(newObsReport(t), "text", rawLogsUnmarshaler{text: true}, consumertest.NewErr(consumerErr))(t.Context(), []byte("hello"), nil)
+	assert.ErrorIs(t, err, consumerErr)
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
+func TestMetricsHandler(t *testing.T) {
+	metrics := pmetric.NewMetrics()
+	metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	payload, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(metrics)
+	require.NoError(t, err)
+
+	sink := new(consumertest.MetricsSink)
+	handler := NewMetricsHandler(newObsReport(t), "otlp_proto", &pmetric.ProtoUnmarshaler{}, sink)
+	require.NoError(t, handler(t.Context(), payload, map[string]string{"site": "paris"}))
+	require.Equal(t, 1, sink.DataPointCount())
+	assert.Equal(t, map[string]any{"site": "paris"}, sink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+
+	err = handler(t.Context(), []byte("invalid"), nil)
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
+func TestTracesHandler(t *testing.T) {
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	payload, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+	require.NoError(t, err)
+
+	sink := new(consumertest.TracesSink)
+	handler := NewTracesHandler(newObsReport(t), "otlp_proto", &ptrace.ProtoUnmarshaler{}, sink)
+	require.NoError(t, handler(t.Context(), payload, nil))
+	require.Equal(t, 1, sink.SpanCount())
+	assert.Equal(t, 0, sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().Len())
+
+	err = handler(t.Context(), []byte("invalid"), nil)
+	assert.True(t, consumererror.IsPermanent(err))
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// LogsMarshaler marshals logs into the payloads of one or more messages
+type LogsMarshaler interface {
+	MarshalLogs(plog.Logs) ([][]byte, error)
+}
+
+type pdataLogsMarshaler struct {
+	marshaler plog.Marshaler
+}
+
+func (m pdataLogsMarshaler) MarshalLogs(ld plog.Logs) ([][]byte, error) {
+	data, err := m.marshaler.MarshalLogs(ld)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{data}, nil
+}
+
+// rawLogsMarshaler publishes the body of each log record as a separate message.
+// String and bytes bodies are published as they are, other bodies are encoded as JSON.
+type rawLogsMarshaler struct{}
+
+func (rawLogsMarshaler) MarshalLogs(ld plog.Logs) ([][]byte, error) {
+	var messages [][]byte
+	for _, rl := range ld.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				data, err := rawBody(lr.Body())
+				if err != nil {
+					return nil, err
+				}
+				if len(data) == 0 {
+					continue
+				}
+				messages = append(messages, data)
+			}
+		}
+	}
+	return messages, nil
+}
+
+func rawBody(body pcommon.Value) ([]byte, error) {
+	switch body.Type() {
+	case pcommon.ValueTypeEmpty:
+		return nil, nil
+	case pcommon.ValueTypeStr:
+		return []byte(body.Str()), nil
+	case pcommon.ValueTypeBytes:
+		return body.Bytes().AsRaw(), nil
+	default:
+		return json.Marshal(body.AsRaw())
+	}
+}
+
+// NewLogsMarshaler returns the marshaler of the given logs encoding: otlp_proto, otlp_json, raw,
+// or the ID of an encoding extension, which takes precedence.
+func NewLogsMarshaler(encoding string, host component.Host) (LogsMarshaler, error) {
+	if m, err := loadEncodingExtension[plog.Marshaler](host, encoding, "logs marshaler"); err != nil {
+		if !errors.Is(err, errUnknownEncodingExtension) {
+			return nil, err
+		}
+	} else {
+		return pdataLogsMarshaler{m}, nil
+	}
+	switch encoding {
+	case "otlp_proto":
+		return pdataLogsMarshaler{&plog.ProtoMarshaler{}}, nil
+	case "otlp_json":
+		return pdataLogsMarshaler{&plog.JSONMarshaler{}}, nil
+	case "raw":
+		return rawLogsMarshaler{}, nil
+	}
+	return nil, fmt.Errorf("unrecognized logs encoding %q", encoding)
+}
+
+// NewMetricsMarshaler returns the marshaler of the given metrics encoding: otlp_proto, otlp_json,
+// or the ID of an encoding extension, which takes precedence.
+func NewMetricsMarshaler(encoding string, host component.Host) (pmetric.Marshaler, error) {
+	if m, err := loadEncodingExtension[pmetric.Marshaler](host, encoding, "metrics marshaler"); err != nil {
+		if !errors.Is(err, errUnknownEncodingExtension) {
+			return nil, err
+		}
+	} else {
+		return m, nil
+	}
+	switch encoding {
+	case "otlp_proto":
+		return &pmetric.ProtoMarshaler{}, nil
+	case "otlp_json":
+		return &pmetric.JSONMarshaler{}, nil
+	}
+	return nil, fmt.Errorf("unrecognized metrics encoding %q", encoding)
+}
+
+// NewTracesMarshaler returns the marshaler of the given traces encoding: otlp_proto, otlp_json,
+// or the ID of an encoding extension, which takes precedence.
+func NewTracesMarshaler(encoding string, host component.Host) (ptrace.Marshaler, error) {
+	if m, err := loadEncodingExtension[ptrace.Marshaler](host, encoding, "traces marshaler"); err != nil {
+		if !errors.Is(err, errUnknownEncodingExtension) {
+			return nil, err
+		}
+	} else {
+		return m, nil
+	}
+	switch encoding {
+	case "otlp_proto":
+		return &ptrace.ProtoMarshaler{}, nil
+	case "otlp_json":
+		return &ptrace.JSONMarshaler{}, nil
+	}
+	return nil, fmt.Errorf("unrecognized traces encoding %q", encoding)
+}
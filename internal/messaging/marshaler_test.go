@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestNewLogsMarshaler(t *testing.T) {
+	for _, encoding := range []string{"otlp_proto", "otlp_json", "raw"} {
+		m, err := NewLogsMarshaler(encoding, componenttest.NewNopHost())
+		require.NoError(t, err, encoding)
+		assert.NotNil(t, m, encoding)
+	}
+
+	// Verify extensions take precedence over built-in marshalers.
+	m, err := NewLogsMarshaler("otlp_proto", extensionsHost{
+		component.MustNewID("otlp_proto"): plogMarshalerFuncExtension(func(plog.Logs) ([]byte, error) {
+			return []byte("overridden"), nil
+		}),
+	})
+	require.NoError(t, err)
+	messages, err := m.MarshalLogs(plog.NewLogs())
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("overridden")}, messages)
+
+	// Specifying an extension for a different type should fail fast.
+	_, err = NewLogsMarshaler("otlp_proto", extensionsHost{
+		component.MustNewID("otlp_proto"): struct{ component.Component }{},
+	})
+	assert.EqualError(t, err, `extension "otlp_proto" is not a logs marshaler`)
+
+	_, err = NewLogsMarshaler("unknown", componenttest.NewNopHost())
+	assert.EqualError(t, err, `unrecognized logs encoding "unknown"`)
+}
+
+func TestNewMetricsAndTracesMarshaler(t *testing.T) {
+	for _, encoding := range []string{"otlp_proto", "otlp_json"} {
+		_, err := NewMetricsMarshaler(encoding, componenttest.NewNopHost())
+		assert.NoError(t, err, encoding)
+		_, err = NewTracesMarshaler(encoding, componenttest.NewNopHost())
+		assert.NoError(t, err, encoding)
+	}
+
+	_, err := NewMetricsMarshaler("raw", componenttest.NewNopHost())
+	assert.EqualError(t, err, `unrecognized metrics encoding "raw"`)
+	_, err = NewTracesMarshaler("raw", componenttest.NewNopHost())
+	assert.EqualError(t, err, `unrecognized traces encoding "raw"`)
+}
+
+func TestRawLogsMarshaler(t *testing.T) {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Body().SetStr("plain text")
+	records.AppendEmpty().Body().SetEmptyBytes().FromRaw([]byte{0x01, 0x02})
+	records.AppendEmpty()
+	records.AppendEmpty().Body().SetEmptyMap().PutStr("key", "value")
+	records.AppendEmpty().Body().SetInt(42)
+
+	messages, err := rawLogsMarshaler{}.MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{
+		[]byte("plain text"),
+		{0x01, 0x02},
+		[]byte(`{"key":"value"}`),
+		[]byte("42"),
+	}, messages)
+}
+
+type extensionsHost map[component.ID]component.Component
+
+func (m extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return m
+}
+
+type plogMarshalerFuncExtension func(plog.Logs) ([]byte, error)
+
+func (f plogMarshalerFuncExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	return f(ld)
+}
+
+func (plogMarshalerFuncExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (plogMarshalerFuncExtension) Shutdown(context.Context) error {
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// missingAttributeValue replaces the placeholders of resource attributes which aren't set
+const missingAttributeValue = "unknown"
+
+var placeholderPattern = regexp.MustCompile(`%\{([^}]+)\}`)
+
+// Template resolves the subject or topic of the data of a resource, replacing the
+// %{attribute} placeholders of the template with the values of its attributes.
+type Template struct {
+	template string
+	// invalidCharacters replaces the characters which can't be part of the values of the
+	// placeholders, like the separators of the tokens or levels of the subject or topic.
+	invalidCharacters *strings.Replacer
+}
+
+// NewTemplate returns a template whose placeholders are replaced with the values of the
+// attributes, where the invalid characters are replaced.
+func NewTemplate(template string, invalidCharacters *strings.Replacer) Template {
+	return Template{template: template, invalidCharacters: invalidCharacters}
+}
+
+// ValidateTemplate checks that the template isn't empty and its placeholders are terminated,
+// then checks the name resolved from the template with validate.
+func ValidateTemplate(template string, validate func(name string) error) error {
+	name := placeholderPattern.ReplaceAllString(template, missingAttributeValue)
+	switch {
+	case name == "":
+		return errors.New("must not be empty")
+	case strings.Contains(name, "%{"):
+		return errors.New("contains an unterminated placeholder")
+	}
+	return validate(name)
+}
+
+func (t Template) static() bool {
+	return !placeholderPattern.MatchString(t.template)
+}
+
+// Resolve returns the name of the data of the given resource. The invalid characters of the
+// values of the attributes are replaced, so they can't change the structure of the name.
+// Placeholders of attributes which aren't set, or are empty, are replaced with "unknown".
+func (t Template) Resolve(resource pcommon.Resource) string {
+	return placeholderPattern.ReplaceAllStringFunc(t.template, func(match string) string {
+		key := placeholderPattern.FindStringSubmatch(match)[1]
+		v, ok := resource.Attributes().Get(key)
+		if !ok || v.AsString() == "" {
+			return missingAttributeValue
+		}
+		return t.invalidCharacters.Replace(v.AsString())
+	})
+}
+
+// SplitLogs groups the resource logs by the name they are published to
+func (t Template) SplitLogs(ld plog.Logs) map[string]plog.Logs {
+	if t.static() {
+		return map[string]plog.Logs{t.template: ld}
+	}
+	groups := map[string]plog.Logs{}
+	for _, rl := range ld.ResourceLogs().All() {
+		name := t.Resolve(rl.Resource())
+		group, ok := groups[name]
+		if !ok {
+			group = plog.NewLogs()
+			groups[name] = group
+		}
+		rl.CopyTo(group.ResourceLogs().AppendEmpty())
+	}
+	return groups
+}
+
+// SplitMetrics groups the resource metrics by the name they are published to
+func (t Template) SplitMetrics(md pmetric.Metrics) map[string]pmetric.Metrics {
+	if t.static() {
+		return map[string]pmetric.Metrics{t.template: md}
+	}
+	groups := map[string]pmetric.Metrics{}
+	for _, rm := range md.ResourceMetrics().All() {
+		name := t.Resolve(rm.Resource())
+		group, ok := groups[name]
+		if !ok {
+			group = pmetric.NewMetrics()
+			groups[name] = group
+		}
+		rm.CopyTo(group.ResourceMetrics().AppendEmpty())
+	}
+	return groups
+}
+
+// SplitTraces groups the resource spans by the name they are published to
+func (t Template) SplitTraces(td ptrace.Traces) map[string]ptrace.Traces {
+	if t.static() {
+		return map[string]ptrace.Traces{t.template: td}
+	}
+	groups := map[string]ptrace.Traces{}
+	for _, rs := range td.ResourceSpans().All() {
+		name := t.Resolve(rs.Resource())
+		group, ok := groups[name]
+		if !ok {
+			group = ptrace.NewTraces()
+			groups[name] = group
+		}
+		rs.CopyTo(group.ResourceSpans().AppendEmpty())
+	}
+	return groups
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var testInvalidCharacters = strings.NewReplacer(".", "_", "*", "_")
+
+func TestValidateTemplate(t *testing.T) {
+	noWildcards := func(name string) error {
+		if strings.Contains(name, "*") {
+			return errors.New("must not contain wildcards")
+		}
+		return nil
+	}
+	tests := []struct {
+		template string
+		wantErr  string
+	}{
+		{template: "otlp.logs"},
+		{template: "logs.%{service.name}"},
+		{template: "%{service.namespace}.%{service.name}.logs"},
+		{template: "", wantErr: "must not be empty"},
+		{template: "logs.%{service.name", wantErr: "contains an unterminated placeholder"},
+		{template: "logs.*", wantErr: "must not contain wildcards"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			err := ValidateTemplate(tt.template, noWildcards)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTemplateResolve(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	resource.Attributes().PutStr("host.name", "edge.node*1")
+	resource.Attributes().PutInt("shard", 3)
+	resource.Attributes().PutStr("empty", "")
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{template: "otlp.logs", want: "otlp.logs"},
+		{template: "logs.%{service.name}", want: "logs.checkout"},
+		{template: "logs.%{service.name}.%{shard}", want: "logs.checkout.3"},
+		{template: "logs.%{host.name}", want: "logs.edge_node_1"},
+		{template: "logs.%{missing}", want: "logs.unknown"},
+		{template: "logs.%{empty}", want: "logs.unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewTemplate(tt.template, testInvalidCharacters).Resolve(resource))
+		})
+	}
+}
+
+func TestTemplateSplitLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	for _, service := range []string{"a", "b", "a"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log from " + service)
+	}
+
+	groups := NewTemplate("otlp.logs", testInvalidCharacters).SplitLogs(ld)
+	require.Len(t, groups, 1)
+	assert.Equal(t, ld, groups["otlp.logs"])
+
+	groups = NewTemplate("logs.%{service.name}", testInvalidCharacters).SplitLogs(ld)
+	require.Len(t, groups, 2)
+	assert.Equal(t, 2, groups["logs.a"].ResourceLogs().Len())
+	assert.Equal(t, 2, groups["logs.a"].LogRecordCount())
+	assert.Equal(t, 1, groups["logs.b"].ResourceLogs().Len())
+	assert.Equal(t, "log from b", groups["logs.b"].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	// the exported data isn't modified
+	assert.Equal(t, 3, ld.ResourceLogs().Len())
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// rawLogsUnmarshaler creates a log record for each message, with the payload of the message as its body
+type rawLogsUnmarshaler struct {
+	// text sets the body to a string instead of bytes
+	text bool
+}
+
+func (u rawLogsUnmarshaler) UnmarshalLogs(data []byte) (plog.Logs, error) {
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	if u.text {
+		lr.Body().SetStr(string(data))
+	} else {
+		lr.Body().SetEmptyBytes().FromRaw(data)
+	}
+	return ld, nil
+}
+
+// jsonLogsUnmarshaler creates a log record for each message with a JSON payload, with the
+// parsed payload as its body. A log record is created for each element of JSON arrays.
+type jsonLogsUnmarshaler struct{}
+
+func (jsonLogsUnmarshaler) UnmarshalLogs(data []byte) (plog.Logs, error) {
+	var body any
+	if err := json.Unmarshal(data, &body); err != nil {
+		return plog.Logs{}, err
+	}
+	bodies, ok := body.([]any)
+	if !ok {
+		bodies = []any{body}
+	}
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for _, b := range bodies {
+		lr := lrs.AppendEmpty()
+		lr.SetObservedTimestamp(now)
+		if err := lr.Body().FromRaw(b); err != nil {
+			return plog.Logs{}, err
+		}
+	}
+	return ld, nil
+}
+
+// NewLogsUnmarshaler returns the unmarshaler of the given logs encoding: otlp_proto, otlp_json, raw,
+// text, json, or the ID of an encoding extension, which takes precedence.
+func NewLogsUnmarshaler(encoding string, host component.Host) (plog.Unmarshaler, error) {
+	if u, err := loadEncodingExtension[plog.Unmarshaler](host, encoding, "logs unmarshaler"); err != nil {
+		if !errors.Is(err, errUnknownEncodingExtension) {
+			return nil, err
+		}
+	} else {
+		return u, nil
+	}
+	switch encoding {
+	case "otlp_proto":
+		return &plog.ProtoUnmarshaler{}, nil
+	case "otlp_json":
+		return &plog.JSONUnmarshaler{}, nil
+	case "raw":
+		return rawLogsUnmarshaler{}, nil
+	case "text":
+		return rawLogsUnmarshaler{text: true}, nil
+	case "json":
+		return jsonLogsUnmarshaler{}, nil
+	}
+	return nil, fmt.Errorf("unrecognized logs encoding %q", encoding)
+}
+
+// NewMetricsUnmarshaler returns the unmarshaler of the given metrics encoding: otlp_proto, otlp_json,
+// or the ID of an encoding extension, which takes precedence.
+func NewMetricsUnmarshaler(encoding string, host component.Host) (pmetric.Unmarshaler, error) {
+	if u, err := loadEncodingExtension[pmetric.Unmarshaler](host, encoding, "metrics unmarshaler"); err != nil {
+		if !errors.Is(err, errUnknownEncodingExtension) {
+			return nil, err
+		}
+	} else {
+		return u, nil
+	}
+	switch encoding {
+	case "otlp_proto":
+		return &pmetric.ProtoUnmarshaler{}, nil
+	case "otlp_json":
+		return &pmetric.JSONUnmarshaler{}, nil
+	}
+	return nil, fmt.Errorf("unrecognized metrics encoding %q", encoding)
+}
+
+// NewTracesUnmarshaler returns the unmarshaler of the given traces encoding: otlp_proto, otlp_json,
+// or the ID of an encoding extension, which takes precedence.
+func NewTracesUnmarshaler(encoding string, host component.Host) (ptrace.Unmarshaler, error) {
+	if u, err := loadEncodingExtension[ptrace.Unmarshaler](host, encoding, "traces unmarshaler"); err != nil {
+		if !errors.Is(err, errUnknownEncodingExtension) {
+			return nil, err
+		}
+	} else {
+		return u, nil
+	}
+	switch encoding {
+	case "otlp_proto":
+		return &ptrace.ProtoUnmarshaler{}, nil
+	case "otlp_json":
+		return &ptrace.JSONUnmarshaler{}, nil
+	}
+	return nil, fmt.Errorf("unrecognized traces encoding %q", encoding)
+}
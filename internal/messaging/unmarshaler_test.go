@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestNewLogsUnmarshaler(t *testing.T) {
+	for _, encoding := range []string{"otlp_proto", "otlp_json", "raw", "text", "json"} {
+		u, err := NewLogsUnmarshaler(encoding, componenttest.NewNopHost())
+		require.NoError(t, err, encoding)
+		assert.NotNil(t, u, encoding)
+	}
+
+	// Verify extensions take precedence over built-in unmarshalers.
+	u, err := NewLogsUnmarshaler("otlp_proto", extensionsHost{
+		component.MustNewID("otlp_proto"): plogUnmarshalerFuncExtension(func([]byte) (plog.Logs, error) {
+			ld := plog.NewLogs()
+			ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("overridden")
+			return ld, nil
+		}),
+	})
+	require.NoError(t, err)
+	ld, err := u.UnmarshalLogs(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "overridden", ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+
+	// Specifying an extension for a different type should fail fast.
+	_, err = NewLogsUnmarshaler("otlp_proto", extensionsHost{
+		component.MustNewID("otlp_proto"): struct{ component.Component }{},
+	})
+	assert.EqualError(t, err, `extension "otlp_proto" is not a logs unmarshaler`)
+
+	_, err = NewLogsUnmarshaler("unknown", componenttest.NewNopHost())
+	assert.EqualError(t, err, `unrecognized logs encoding "unknown"`)
+}
+
+func TestNewMetricsAndTracesUnmarshaler(t *testing.T) {
+	for _, encoding := range []string{"otlp_proto", "otlp_json"} {
+		_, err := NewMetricsUnmarshaler(encoding, componenttest.NewNopHost())
+		assert.NoError(t, err, encoding)
+		_, err = NewTracesUnmarshaler(encoding, componenttest.NewNopHost())
+		assert.NoError(t, err, encoding)
+	}
+
+	_, err := NewMetricsUnmarshaler("raw", componenttest.NewNopHost())
+	assert.EqualError(t, err, `unrecognized metrics encoding "raw"`)
+	_, err = NewTracesUnmarshaler("raw", componenttest.NewNopHost())
+	assert.EqualError(t, err, `unrecognized traces encoding "raw"`)
+}
+
+func TestRawLogsUnmarshaler(t *testing.T) {
+	ld, err := rawLogsUnmarshaler{}.UnmarshalLogs([]byte("payload"))
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.LogRecordCount())
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, pcommon.ValueTypeBytes, lr.Body().Type())
+	assert.Equal(t, []byte("payload"), lr.Body().Bytes().AsRaw())
+	assert.NotZero(t, lr.ObservedTimestamp())
+
+	ld, err = rawLogsUnmarshaler{text: true}.UnmarshalLogs([]byte("payload"))
+	require.NoError(t, err)
+	lr = ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, pcommon.ValueTypeStr, lr.Body().Type())
+	assert.Equal(t, "payload", lr.Body().Str())
+}
+
+type plogUnmarshalerFuncExtension func([]byte) (plog.Logs, error)
+
+func (f plogUnmarshalerFuncExtension) UnmarshalLogs(data []byte) (plog.Logs, error) {
+	return f(data)
+}
+
+func (plogUnmarshalerFuncExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (plogUnmarshalerFuncExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestJSONLogsUnmarshaler(t *testing.T) {
+	ld, err := jsonLogsUnmarshaler{}.UnmarshalLogs([]byte(`{"level":"info","temperature":21.5}`))
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.LogRecordCount())
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]any{"level": "info", "temperature": 21.5}, lr.Body().Map().AsRaw())
+	assert.NotZero(t, lr.ObservedTimestamp())
+
+	// each element of arrays is a log record
+	ld, err = jsonLogsUnmarshaler{}.UnmarshalLogs([]byte(`[{"id":1},"text",true]`))
+	require.NoError(t, err)
+	lrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, lrs.Len())
+	assert.Equal(t, map[string]any{"id": float64(1)}, lrs.At(0).Body().Map().AsRaw())
+	assert.Equal(t, "text", lrs.At(1).Body().Str())
+	assert.True(t, lrs.At(2).Body().Bool())
+
+	_, err = jsonLogsUnmarshaler{}.UnmarshalLogs([]byte("not json"))
+	assert.Error(t, err)
+}
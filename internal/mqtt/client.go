@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Subscription is a topic filter the client subscribes to, with the maximum QoS of the messages delivered to the client.
+type Subscription struct {
+	TopicFilter string
+	QoS         byte
+}
+
+// Message is a message received from the broker.
+type Message struct {
+	Topic   string
+	Payload []byte
+}
+
+// MessageHandler handles the messages received from the broker. It is called for a single message
+// at a time, and QoS 1 and 2 messages are only acknowledged once it returns.
+type MessageHandler func(msg Message)
+
+// Client is a connection to an MQTT broker, which is re-established when lost.
+type Client interface {
+	// Publish publishes a message, and waits for the broker to acknowledge it for QoS 1 and 2.
+	Publish(ctx context.Context, topic string, qos byte, retain bool, payload []byte) error
+	// Disconnect closes the connection to the broker.
+	Disconnect(ctx context.Context) error
+}
+
+// Connect connects to the broker, and subscribes to the given topic filters each time the
+// connection is established. It returns once the first connection and subscription succeeded.
+func Connect(ctx context.Context, cfg ClientConfig, logger *zap.Logger, subscriptions []Subscription, handler MessageHandler) (Client, error) {
+	var tlsConfig *tls.Config
+	if cfg.TLS.HasValue() {
+		var err error
+		if tlsConfig, err = cfg.TLS.Get().LoadTLSConfig(ctx); err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+	}
+	if cfg.ProtocolVersion == ProtocolVersion5 {
+		return connectV5(ctx, cfg, tlsConfig, logger, subscriptions, handler)
+	}
+	return connectV311(ctx, cfg, tlsConfig, logger, subscriptions, handler)
+}
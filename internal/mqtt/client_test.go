@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt_test
+
+import (
+	"testing"
+	"time"
+
+	server "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt/mqtttest"
+)
+
+func TestClient(t *testing.T) {
+	for _, protocolVersion := range []string{mqtt.ProtocolVersion311, mqtt.ProtocolVersion5} {
+		t.Run(protocolVersion, func(t *testing.T) {
+			broker, cfg := mqtttest.NewBroker(t)
+			cfg.ProtocolVersion = protocolVersion
+
+			published := make(chan packets.Packet, 10)
+			require.NoError(t, broker.Subscribe("devices/#", 1, func(_ *server.Client, _ packets.Subscription, pk packets.Packet) {
+				published <- pk
+			}))
+
+			received := make(chan mqtt.Message, 10)
+			client, err := mqtt.Connect(t.Context(), cfg, zap.NewNop(), []mqtt.Subscription{
+				{TopicFilter: "commands/+", QoS: 1},
+				{TopicFilter: "config", QoS: 2},
+			}, func(msg mqtt.Message) {
+				received <- msg
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, client.Publish(t.Context(), "devices/sensor", 1, false, []byte("telemetry")))
+			select {
+			case pk := <-published:
+				assert.Equal(t, "devices/sensor", pk.TopicName)
+				assert.Equal(t, []byte("telemetry"), pk.Payload)
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "message not published")
+			}
+
+			require.NoError(t, broker.Publish("commands/reboot", []byte("now"), false, 1))
+			require.NoError(t, broker.Publish("config", []byte("{}"), false, 2))
+			for _, expected := range []mqtt.Message{
+				{Topic: "commands/reboot", Payload: []byte("now")},
+				{Topic: "config", Payload: []byte("{}")},
+			} {
+				select {
+				case msg := <-received:
+					assert.Equal(t, expected, msg)
+				case <-time.After(5 * time.Second):
+					require.Fail(t, "message not received")
+				}
+			}
+
+			require.NoError(t, client.Disconnect(t.Context()))
+		})
+	}
+}
+
+func TestConnectError(t *testing.T) {
+	for _, protocolVersion := range []string{mqtt.ProtocolVersion311, mqtt.ProtocolVersion5} {
+		t.Run(protocolVersion, func(t *testing.T) {
+			cfg := mqtt.NewDefaultClientConfig()
+			cfg.Endpoint = "tcp://127.0.0.1:1"
+			cfg.ProtocolVersion = protocolVersion
+			cfg.ConnectionTimeout = 100 * time.Millisecond
+			_, err := mqtt.Connect(t.Context(), cfg, zap.NewNop(), nil, nil)
+			assert.ErrorContains(t, err, "failed to connect to the MQTT broker")
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sync/atomic"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"go.uber.org/zap"
+)
+
+// disconnectQuiesce is the time in milliseconds given to the client to complete the work in progress when disconnecting.
+const disconnectQuiesce = 250
+
+// failedSubscription is the return code of the subscriptions refused by the broker.
+const failedSubscription = 0x80
+
+// clientV311 is a client using the MQTT 3.1.1 protocol.
+type clientV311 struct {
+	client paho.Client
+}
+
+func connectV311(ctx context.Context, cfg ClientConfig, tlsConfig *tls.Config, logger *zap.Logger, subscriptions []Subscription, handler MessageHandler) (Client, error) {
+	filters := make(map[string]byte, len(subscriptions))
+	for _, subscription := range subscriptions {
+		filters[subscription.TopicFilter] = subscription.QoS
+	}
+	onMessage := func(_ paho.Client, msg paho.Message) {
+		handler(Message{Topic: msg.Topic(), Payload: msg.Payload()})
+		msg.Ack()
+	}
+
+	// the subscriptions of the first connection are made by Connect, so that it can report errors
+	var resubscribe atomic.Bool
+	opts := paho.NewClientOptions().
+		AddBroker(cfg.Endpoint).
+		SetProtocolVersion(4).
+		SetClientID(cfg.ClientID).
+		SetUsername(cfg.Username).
+		SetPassword(string(cfg.Password)).
+		SetTLSConfig(tlsConfig).
+		SetCleanSession(cfg.CleanSession).
+		SetConnectTimeout(cfg.ConnectionTimeout).
+		SetKeepAlive(cfg.KeepAlive).
+		SetAutoReconnect(true).
+		SetMaxReconnectInterval(cfg.ReconnectWait).
+		SetAutoAckDisabled(true).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			logger.Warn("Lost the connection to the MQTT broker", zap.Error(err))
+		}).
+		SetOnConnectHandler(func(client paho.Client) {
+			if !resubscribe.Load() {
+				return
+			}
+			logger.Info("Reconnected to the MQTT broker")
+			subscribeCtx, cancel := context.WithTimeout(context.Background(), cfg.ConnectionTimeout)
+			defer cancel()
+			if err := subscribeV311(subscribeCtx, client, filters, onMessage); err != nil {
+				logger.Error("Failed to subscribe after reconnecting to the MQTT broker", zap.Error(err))
+			}
+		})
+
+	client := paho.NewClient(opts)
+	if err := waitToken(ctx, client.Connect()); err != nil {
+		return nil, fmt.Errorf("failed to connect to the MQTT broker: %w", err)
+	}
+	if err := subscribeV311(ctx, client, filters, onMessage); err != nil {
+		client.Disconnect(0)
+		return nil, err
+	}
+	resubscribe.Store(true)
+	return &clientV311{client: client}, nil
+}
+
+func subscribeV311(ctx context.Context, client paho.Client, filters map[string]byte, onMessage paho.MessageHandler) error {
+	if len(filters) == 0 {
+		return nil
+	}
+	token := client.SubscribeMultiple(filters, onMessage)
+	if err := waitToken(ctx, token); err != nil {
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+	for topicFilter, code := range token.(*paho.SubscribeToken).Result() {
+		if code == failedSubscription {
+			return fmt.Errorf("failed to subscribe: the broker refused the subscription to %q", topicFilter)
+		}
+	}
+	return nil
+}
+
+func (c *clientV311) Publish(ctx context.Context, topic string, qos byte, retain bool, payload []byte) error {
+	return waitToken(ctx, c.client.Publish(topic, qos, retain, payload))
+}
+
+func (c *clientV311) Disconnect(context.Context) error {
+	c.client.Disconnect(disconnectQuiesce)
+	return nil
+}
+
+func waitToken(ctx context.Context, token paho.Token) error {
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"go.uber.org/zap"
+)
+
+// clientV5 is a client using the MQTT 5 protocol.
+type clientV5 struct {
+	manager *autopaho.ConnectionManager
+}
+
+func connectV5(ctx context.Context, cfg ClientConfig, tlsConfig *tls.Config, logger *zap.Logger, subscriptions []Subscription, handler MessageHandler) (Client, error) {
+	serverURL, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+
+	// the subscriptions of the first connection are made by Connect, so that it can report errors
+	var resubscribe atomic.Bool
+	var connectErr atomic.Pointer[error]
+	manager, err := autopaho.NewConnection(context.Background(), autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{serverURL},
+		TlsCfg:                        tlsConfig,
+		KeepAlive:                     uint16(cfg.KeepAlive / time.Second),
+		CleanStartOnInitialConnection: cfg.CleanSession,
+		SessionExpiryInterval:         uint32(cfg.SessionExpiryInterval / time.Second),
+		ReconnectBackoff:              autopaho.NewConstantBackoff(cfg.ReconnectWait),
+		ConnectTimeout:                cfg.ConnectionTimeout,
+		ConnectUsername:               cfg.Username,
+		ConnectPassword:               []byte(cfg.Password),
+		OnConnectionUp: func(manager *autopaho.ConnectionManager, _ *paho.Connack) {
+			if !resubscribe.Load() {
+				return
+			}
+			logger.Info("Reconnected to the MQTT broker")
+			// the callback must not block
+			go func() {
+				subscribeCtx, cancel := context.WithTimeout(context.Background(), cfg.ConnectionTimeout)
+				defer cancel()
+				if err := subscribeV5(subscribeCtx, manager, subscriptions); err != nil {
+					logger.Error("Failed to subscribe after reconnecting to the MQTT broker", zap.Error(err))
+				}
+			}()
+		},
+		OnConnectionDown: func() bool {
+			logger.Warn("Lost the connection to the MQTT broker")
+			return true
+		},
+		OnConnectError: func(err error) {
+			connectErr.Store(&err)
+			if resubscribe.Load() {
+				logger.Warn("Failed to reconnect to the MQTT broker", zap.Error(err))
+			}
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID:                   cfg.ClientID,
+			EnableManualAcknowledgment: true,
+			OnPublishReceived: []func(paho.PublishReceived) (bool, error){
+				func(received paho.PublishReceived) (bool, error) {
+					handler(Message{Topic: received.Packet.Topic, Payload: received.Packet.Payload})
+					if err := received.Client.Ack(received.Packet); err != nil {
+						logger.Warn("Failed to acknowledge message", zap.String("topic", received.Packet.Topic), zap.Error(err))
+					}
+					return true, nil
+				},
+			},
+			OnClientError: func(err error) {
+				logger.Warn("MQTT client error", zap.Error(err))
+			},
+			OnServerDisconnect: func(disconnect *paho.Disconnect) {
+				logger.Warn("Disconnected by the MQTT broker", zap.Uint8("reason_code", disconnect.ReasonCode))
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the MQTT broker: %w", err)
+	}
+	c := &clientV5{manager: manager}
+
+	awaitCtx, cancel := context.WithTimeout(ctx, cfg.ConnectionTimeout)
+	defer cancel()
+	if err := manager.AwaitConnection(awaitCtx); err != nil {
+		if lastErr := connectErr.Load(); lastErr != nil {
+			err = *lastErr
+		}
+		_ = c.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to connect to the MQTT broker: %w", err)
+	}
+	if err := subscribeV5(ctx, manager, subscriptions); err != nil {
+		_ = c.Disconnect(ctx)
+		return nil, err
+	}
+	resubscribe.Store(true)
+	return c, nil
+}
+
+func subscribeV5(ctx context.Context, manager *autopaho.ConnectionManager, subscriptions []Subscription) error {
+	if len(subscriptions) == 0 {
+		return nil
+	}
+	subscribe := &paho.Subscribe{Subscriptions: make([]paho.SubscribeOptions, 0, len(subscriptions))}
+	for _, subscription := range subscriptions {
+		subscribe.Subscriptions = append(subscribe.Subscriptions, paho.SubscribeOptions{
+			Topic: subscription.TopicFilter,
+			QoS:   subscription.QoS,
+		})
+	}
+	suback, err := manager.Subscribe(ctx, subscribe)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+	var errs []error
+	for i, code := range suback.Reasons {
+		if code >= failedSubscription && i < len(subscriptions) {
+			errs = append(errs, fmt.Errorf("the broker refused the subscription to %q with reason code %d", subscriptions[i].TopicFilter, code))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to subscribe: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func (c *clientV5) Publish(ctx context.Context, topic string, qos byte, retain bool, payload []byte) error {
+	_, err := c.manager.Publish(ctx, &paho.Publish{
+		Topic:   topic,
+		QoS:     qos,
+		Retain:  retain,
+		Payload: payload,
+	})
+	return err
+}
+
+func (c *clientV5) Disconnect(ctx context.Context) error {
+	return c.manager.Disconnect(ctx)
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const (
+	// ProtocolVersion311 is the MQTT 3.1.1 protocol version.
+	ProtocolVersion311 = "3.1.1"
+	// ProtocolVersion5 is the MQTT 5 protocol version.
+	ProtocolVersion5 = "5"
+)
+
+// ClientConfig holds the settings of the connection to an MQTT broker.
+type ClientConfig struct {
+	// Endpoint is the URL of the broker, e.g. tcp://localhost:1883, ssl://localhost:8883 or ws://localhost:8080/mqtt.
+	Endpoint string `mapstructure:"endpoint"`
+	// ProtocolVersion is the version of the MQTT protocol, either 3.1.1 or 5.
+	ProtocolVersion string `mapstructure:"protocol_version"`
+	// ClientID identifies the client to the broker. The broker assigns one when it's empty.
+	ClientID string `mapstructure:"client_id"`
+	// Username and Password are used to authenticate to the broker.
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+	// TLS configures the TLS connection to the broker, used with the ssl, tls, mqtts and wss schemes.
+	TLS configoptional.Optional[configtls.ClientConfig] `mapstructure:"tls"`
+	// ConnectionTimeout is the timeout of each attempt to connect to the broker.
+	ConnectionTimeout time.Duration `mapstructure:"connection_timeout"`
+	// KeepAlive is the interval at which the client pings the broker when no other packets are sent.
+	KeepAlive time.Duration `mapstructure:"keep_alive"`
+	// ReconnectWait is the maximum time to wait between attempts to reconnect to the broker.
+	ReconnectWait time.Duration `mapstructure:"reconnect_wait"`
+	// CleanSession discards the session state kept by the broker when connecting.
+	// With persistent sessions, QoS 1 and 2 messages published while the client is disconnected are
+	// delivered when it reconnects.
+	CleanSession bool `mapstructure:"clean_session"`
+	// SessionExpiryInterval is how long the broker keeps the session state after the client
+	// disconnected, with MQTT 5 and persistent sessions.
+	SessionExpiryInterval time.Duration `mapstructure:"session_expiry_interval"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// NewDefaultClientConfig returns the default settings of the connection to an MQTT broker.
+func NewDefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		Endpoint:          "tcp://localhost:1883",
+		ProtocolVersion:   ProtocolVersion311,
+		ConnectionTimeout: 10 * time.Second,
+		KeepAlive:         30 * time.Second,
+		ReconnectWait:     5 * time.Second,
+		CleanSession:      true,
+	}
+}
+
+func (cfg *ClientConfig) Validate() error {
+	var errs []error
+	if cfg.Endpoint == "" {
+		errs = append(errs, errors.New("endpoint is required"))
+	} else if u, err := url.Parse(cfg.Endpoint); err != nil {
+		errs = append(errs, fmt.Errorf("endpoint: %w", err))
+	} else if !isSupportedScheme(u.Scheme) {
+		errs = append(errs, fmt.Errorf("endpoint: unsupported scheme %q, must be one of tcp, mqtt, ssl, tls, mqtts, ws or wss", u.Scheme))
+	}
+	if cfg.ProtocolVersion != ProtocolVersion311 && cfg.ProtocolVersion != ProtocolVersion5 {
+		errs = append(errs, fmt.Errorf("protocol_version %q must be one of %q or %q", cfg.ProtocolVersion, ProtocolVersion311, ProtocolVersion5))
+	}
+	if !cfg.CleanSession && cfg.ClientID == "" {
+		errs = append(errs, errors.New("client_id is required when clean_session is disabled"))
+	}
+	if !cfg.CleanSession && cfg.ProtocolVersion == ProtocolVersion5 && cfg.SessionExpiryInterval == 0 {
+		errs = append(errs, errors.New("session_expiry_interval is required when clean_session is disabled with MQTT 5"))
+	}
+	if cfg.Password != "" && cfg.Username == "" {
+		errs = append(errs, errors.New("username is required when password is configured"))
+	}
+	if cfg.ConnectionTimeout <= 0 {
+		errs = append(errs, errors.New("connection_timeout must be positive"))
+	}
+	if cfg.KeepAlive < time.Second || cfg.KeepAlive > 65535*time.Second {
+		errs = append(errs, errors.New("keep_alive must be between 1s and 65535s"))
+	}
+	if cfg.ReconnectWait <= 0 {
+		errs = append(errs, errors.New("reconnect_wait must be positive"))
+	}
+	if cfg.SessionExpiryInterval < 0 || cfg.SessionExpiryInterval > (1<<32-1)*time.Second {
+		errs = append(errs, errors.New("session_expiry_interval must be between 0s and 4294967295s"))
+	}
+	return errors.Join(errs...)
+}
+
+func isSupportedScheme(scheme string) bool {
+	switch strings.ToLower(scheme) {
+	case "tcp", "mqtt", "ssl", "tls", "mqtts", "ws", "wss":
+		return true
+	}
+	return false
+}
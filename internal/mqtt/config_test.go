@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientConfigValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *ClientConfig)
+		expected string
+	}{
+		{
+			name:   "default",
+			modify: func(*ClientConfig) {},
+		},
+		{
+			name: "persistent_session",
+			modify: func(cfg *ClientConfig) {
+				cfg.ProtocolVersion = ProtocolVersion5
+				cfg.ClientID = "otelcol"
+				cfg.CleanSession = false
+				cfg.SessionExpiryInterval = time.Hour
+			},
+		},
+		{
+			name:     "missing_endpoint",
+			modify:   func(cfg *ClientConfig) { cfg.Endpoint = "" },
+			expected: "endpoint is required",
+		},
+		{
+			name:     "unsupported_scheme",
+			modify:   func(cfg *ClientConfig) { cfg.Endpoint = "http://localhost:1883" },
+			expected: `endpoint: unsupported scheme "http", must be one of tcp, mqtt, ssl, tls, mqtts, ws or wss`,
+		},
+		{
+			name:     "invalid_protocol_version",
+			modify:   func(cfg *ClientConfig) { cfg.ProtocolVersion = "3.1" },
+			expected: `protocol_version "3.1" must be one of "3.1.1" or "5"`,
+		},
+		{
+			name:     "persistent_session_without_client_id",
+			modify:   func(cfg *ClientConfig) { cfg.CleanSession = false },
+			expected: "client_id is required when clean_session is disabled",
+		},
+		{
+			name: "persistent_session_without_expiry",
+			modify: func(cfg *ClientConfig) {
+				cfg.ProtocolVersion = ProtocolVersion5
+				cfg.ClientID = "otelcol"
+				cfg.CleanSession = false
+			},
+			expected: "session_expiry_interval is required when clean_session is disabled with MQTT 5",
+		},
+		{
+			name:     "password_without_username",
+			modify:   func(cfg *ClientConfig) { cfg.Password = "secret" },
+			expected: "username is required when password is configured",
+		},
+		{
+			name: "invalid_durations",
+			modify: func(cfg *ClientConfig) {
+				cfg.ConnectionTimeout = 0
+				cfg.KeepAlive = time.Millisecond
+				cfg.ReconnectWait = -time.Second
+				cfg.SessionExpiryInterval = -time.Second
+			},
+			expected: "connection_timeout must be positive\n" +
+				"keep_alive must be between 1s and 65535s\n" +
+				"reconnect_wait must be positive\n" +
+				"session_expiry_interval must be between 0s and 4294967295s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultClientConfig()
+			tt.modify(&cfg)
+			if tt.expected == "" {
+				assert.NoError(t, cfg.Validate())
+			} else {
+				assert.EqualError(t, cfg.Validate(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtttest // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt/mqtttest"
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	server "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+// NewBroker returns an embedded MQTT broker allowing all clients, with an inline client to publish
+// and subscribe from tests, and mqtt.ClientConfig with the default configuration and the endpoint
+// set to the address of the broker.
+func NewBroker(tb testing.TB) (*server.Server, mqtt.ClientConfig) {
+	broker := server.New(&server.Options{
+		InlineClient: true,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	require.NoError(tb, broker.AddHook(new(auth.AllowHook), nil))
+	listener := listeners.NewTCP(listeners.Config{ID: "tcp", Address: "127.0.0.1:0"})
+	require.NoError(tb, broker.AddListener(listener))
+	require.NoError(tb, broker.Serve())
+	tb.Cleanup(func() { require.NoError(tb, broker.Close()) })
+
+	cfg := mqtt.NewDefaultClientConfig()
+	cfg.Endpoint = "tcp://" + listener.Address()
+	return broker, cfg
+}
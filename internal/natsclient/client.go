@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsclient // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsclient"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+// Connect opens a connection to the NATS server, which is reestablished whenever it's interrupted
+func Connect(ctx context.Context, cfg ClientConfig, logger *zap.Logger) (*nats.Conn, error) {
+	opts := []nats.Option{
+		nats.Name(cfg.Name),
+		nats.Timeout(cfg.ConnectionTimeout),
+		nats.ReconnectWait(cfg.ReconnectWait),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				logger.Warn("Disconnected from the NATS server", zap.Error(err))
+			}
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			logger.Info("Reconnected to the NATS server", zap.String("url", conn.ConnectedUrlRedacted()))
+		}),
+		nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
+			logger.Error("Asynchronous NATS error", zap.Error(err))
+		}),
+	}
+
+	if cfg.TLS.HasValue() {
+		tlsConfig, err := cfg.TLS.Get().LoadTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		opts = append(opts, nats.Secure(tlsConfig))
+	}
+
+	switch auth := cfg.Auth; {
+	case auth.Token != "":
+		opts = append(opts, nats.Token(string(auth.Token)))
+	case auth.Username != "":
+		opts = append(opts, nats.UserInfo(auth.Username, string(auth.Password)))
+	case auth.CredentialsFile != "":
+		opts = append(opts, nats.UserCredentials(auth.CredentialsFile))
+	case auth.NKeyFile != "":
+		opt, err := nats.NkeyOptionFromSeed(auth.NKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load NKey seed: %w", err)
+		}
+		opts = append(opts, opt)
+	}
+
+	conn, err := nats.Connect(cfg.Endpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the NATS server: %w", err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestServer(t *testing.T, opts *server.Options) *server.Server {
+	t.Helper()
+	opts.Host = "127.0.0.1"
+	opts.Port = server.RANDOM_PORT
+	opts.NoLog = true
+	opts.NoSigs = true
+	s, err := server.NewServer(opts)
+	require.NoError(t, err)
+	go s.Start()
+	require.True(t, s.ReadyForConnections(10*time.Second), "NATS server not ready")
+	t.Cleanup(func() {
+		s.Shutdown()
+		s.WaitForShutdown()
+	})
+	return s
+}
+
+func TestConnect(t *testing.T) {
+	s := newTestServer(t, &server.Options{})
+
+	cfg := NewDefaultClientConfig()
+	cfg.Endpoint = s.ClientURL()
+	cfg.Name = "otelcol"
+	conn, err := Connect(t.Context(), cfg, zap.NewNop())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	assert.True(t, conn.IsConnected())
+	assert.Equal(t, "otelcol", conn.Opts.Name)
+}
+
+func TestConnectWithAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *server.Options
+		auth    AuthConfig
+		wantErr bool
+	}{
+		{
+			name: "token",
+			opts: &server.Options{Authorization: "secret"},
+			auth: AuthConfig{Token: "secret"},
+		},
+		{
+			name:    "invalid_token",
+			opts:    &server.Options{Authorization: "secret"},
+			auth:    AuthConfig{Token: "invalid"},
+			wantErr: true,
+		},
+		{
+			name: "username_and_password",
+			opts: &server.Options{Username: "user", Password: "pass"},
+			auth: AuthConfig{Username: "user", Password: "pass"},
+		},
+		{
+			name:    "missing_credentials",
+			opts:    &server.Options{Username: "user", Password: "pass"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t, tt.opts)
+
+			cfg := NewDefaultClientConfig()
+			cfg.Endpoint = s.ClientURL()
+			cfg.Auth = tt.auth
+			conn, err := Connect(t.Context(), cfg, zap.NewNop())
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "failed to connect to the NATS server")
+				return
+			}
+			require.NoError(t, err)
+			conn.Close()
+		})
+	}
+}
+
+func TestConnectWithMissingNKeyFile(t *testing.T) {
+	cfg := NewDefaultClientConfig()
+	cfg.Auth.NKeyFile = "testdata/missing.nk"
+	_, err := Connect(t.Context(), cfg, zap.NewNop())
+	assert.ErrorContains(t, err, "failed to load NKey seed")
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsclient // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsclient"
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// ClientConfig holds the configuration of the connection to the NATS server.
+type ClientConfig struct {
+	// Endpoint is the URL of the NATS server, or a comma separated list of URLs
+	// of the servers of a cluster.
+	Endpoint string `mapstructure:"endpoint"`
+	// Name of the connection, visible in the monitoring endpoints of the NATS server.
+	Name string `mapstructure:"name"`
+	// ConnectionTimeout is the timeout of the initial connection to the NATS server.
+	ConnectionTimeout time.Duration `mapstructure:"connection_timeout"`
+	// ReconnectWait is the time to wait between attempts to reconnect to the NATS server.
+	ReconnectWait time.Duration `mapstructure:"reconnect_wait"`
+
+	TLS  configoptional.Optional[configtls.ClientConfig] `mapstructure:"tls"`
+	Auth AuthConfig                                      `mapstructure:"auth"`
+}
+
+// AuthConfig holds the credentials used to connect to the NATS server.
+// At most one authentication method can be configured.
+type AuthConfig struct {
+	// Token authenticates with a token.
+	Token configopaque.String `mapstructure:"token"`
+	// Username and Password authenticate with a username and password.
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+	// CredentialsFile is the path of a credentials file holding a user JWT and NKey seed.
+	CredentialsFile string `mapstructure:"credentials_file"`
+	// NKeyFile is the path of a file holding an NKey seed.
+	NKeyFile string `mapstructure:"nkey_file"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// NewDefaultClientConfig returns the default settings of the connection to the NATS server.
+func NewDefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		Endpoint:          nats.DefaultURL,
+		ConnectionTimeout: 10 * time.Second,
+		ReconnectWait:     2 * time.Second,
+	}
+}
+
+func (cfg *ClientConfig) Validate() error {
+	var errs []error
+	if cfg.Endpoint == "" {
+		errs = append(errs, errors.New("endpoint is required"))
+	}
+	if err := cfg.Auth.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (cfg *AuthConfig) validate() error {
+	methods := 0
+	if cfg.Token != "" {
+		methods++
+	}
+	if cfg.Username != "" {
+		methods++
+	}
+	if cfg.CredentialsFile != "" {
+		methods++
+	}
+	if cfg.NKeyFile != "" {
+		methods++
+	}
+	if methods > 1 {
+		return errors.New("only one of auth::token, auth::username, auth::credentials_file and auth::nkey_file can be configured")
+	}
+	if cfg.Password != "" && cfg.Username == "" {
+		return errors.New("auth::username is required when auth::password is configured")
+	}
+	return nil
+}
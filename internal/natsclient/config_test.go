@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientConfigValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *ClientConfig)
+		expected string
+	}{
+		{
+			name:   "default",
+			modify: func(*ClientConfig) {},
+		},
+		{
+			name: "username_and_password",
+			modify: func(cfg *ClientConfig) {
+				cfg.Auth.Username = "user"
+				cfg.Auth.Password = "pass"
+			},
+		},
+		{
+			name:     "missing_endpoint",
+			modify:   func(cfg *ClientConfig) { cfg.Endpoint = "" },
+			expected: "endpoint is required",
+		},
+		{
+			name: "multiple_auth_methods",
+			modify: func(cfg *ClientConfig) {
+				cfg.Auth.Token = "token"
+				cfg.Auth.NKeyFile = "user.nk"
+			},
+			expected: "only one of auth::token, auth::username, auth::credentials_file and auth::nkey_file can be configured",
+		},
+		{
+			name:     "password_without_username",
+			modify:   func(cfg *ClientConfig) { cfg.Auth.Password = "pass" },
+			expected: "auth::username is required when auth::password is configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultClientConfig()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
@@ -60,6 +60,16 @@ func (m *MockChannel) PublishWithDeferredConfirmWithContext(ctx context.Context,
 	return args.Get(0).(DeferredConfirmation), args.Error(1)
 }
 
+func (m *MockChannel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	args := m.Called(prefetchCount, prefetchSize, global)
+	return args.Error(0)
+}
+
+func (m *MockChannel) ConsumeWithContext(ctx context.Context, queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	called := m.Called(ctx, queue, consumer, autoAck, exclusive, noLocal, noWait, args)
+	return called.Get(0).(<-chan amqp.Delivery), called.Error(1)
+}
+
 func (m *MockChannel) IsClosed() bool {
 	args := m.Called()
 	return args.Bool(0)
@@ -150,6 +160,8 @@ func TestChannel(t *testing.T) {
 	mockConn.On("Channel").Return(mockChan, nil)
 	mockChan.On("Confirm", false).Return(nil)
 	mockChan.On("PublishWithDeferredConfirmWithContext", mock.Anything, "exchange", "key", false, false, mock.Anything).Return(new(MockDeferredConfirmation), nil)
+	mockChan.On("Qos", 10, 0, false).Return(nil)
+	mockChan.On("ConsumeWithContext", mock.Anything, "queue", "consumer", false, false, false, false, amqp.Table(nil)).Return((<-chan amqp.Delivery)(make(chan amqp.Delivery)), nil)
 	mockChan.On("IsClosed").Return(false)
 	mockChan.On("Close").Return(nil)
 
@@ -165,6 +177,13 @@ func TestChannel(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, deferredConf)
 
+	err = channel.Qos(10, 0, false)
+	assert.NoError(t, err)
+
+	deliveries, err := channel.ConsumeWithContext(ctx, "queue", "consumer", false, false, false, false, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, deliveries)
+
 	assert.False(t, channel.IsClosed())
 
 	err = channel.Close()
@@ -20,6 +20,7 @@ var defaultContextInferPriority = []string{
 	"datapoint",
 	"metric",
 	"spanevent",
+	"spanlink",
 	"span",
 	"profile",
 	"scope",
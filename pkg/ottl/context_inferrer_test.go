@@ -295,6 +295,7 @@ func Test_NewPriorityContextInferrer_DefaultPriorityList(t *testing.T) {
 		"datapoint",
 		"metric",
 		"spanevent",
+		"spanlink",
 		"span",
 		"profile",
 		"scope",
@@ -315,8 +316,9 @@ func Test_NewPriorityContextInferrer_InferStatements_DefaultContextsOrder(t *tes
 		"log":                   newDummyPriorityContextInferrerCandidate(true, true, []string{"scope", "instrumentation_scope", "resource"}),
 		"metric":                newDummyPriorityContextInferrerCandidate(true, true, []string{"datapoint", "scope", "instrumentation_scope", "resource"}),
 		"datapoint":             newDummyPriorityContextInferrerCandidate(true, true, []string{"scope", "instrumentation_scope", "resource"}),
-		"span":                  newDummyPriorityContextInferrerCandidate(true, true, []string{"spanevent", "scope", "instrumentation_scope", "resource"}),
+		"span":                  newDummyPriorityContextInferrerCandidate(true, true, []string{"spanevent", "spanlink", "scope", "instrumentation_scope", "resource"}),
 		"spanevent":             newDummyPriorityContextInferrerCandidate(true, true, []string{"scope", "instrumentation_scope", "resource"}),
+		"spanlink":              newDummyPriorityContextInferrerCandidate(true, true, []string{"scope", "instrumentation_scope", "resource"}),
 		"profile":               newDummyPriorityContextInferrerCandidate(true, true, []string{"profile", "scope", "instrumentation_scope", "resource"}),
 		"scope":                 newDummyPriorityContextInferrerCandidate(true, true, []string{"resource"}),
 		"instrumentation_scope": newDummyPriorityContextInferrerCandidate(true, true, []string{"resource"}),
@@ -388,6 +390,11 @@ func Test_NewPriorityContextInferrer_InferStatements_DefaultContextsOrder(t *tes
 			statement: `set(span.name, "foo") where spanevent.name != nil and scope.name != nil and resource.attributes["foo"] != nil`,
 			expected:  "spanevent",
 		},
+		{
+			name:      "spanlink,span,scope,resource",
+			statement: `set(span.name, "foo") where spanlink.trace_id != nil and scope.name != nil and resource.attributes["foo"] != nil`,
+			expected:  "spanlink",
+		},
 		{
 			name:      "profile,instrumentation_scope,resource",
 			statement: `set(profile.name, "foo") where profile.name != nil and instrumentation_scope.name != nil and resource.attributes["foo"] != nil`,
@@ -419,8 +426,9 @@ func Test_NewPriorityContextInferrer_InferConditions_DefaultContextsOrder(t *tes
 		"log":                   newDummyPriorityContextInferrerCandidate(true, true, []string{"scope", "instrumentation_scope", "resource"}),
 		"metric":                newDummyPriorityContextInferrerCandidate(true, true, []string{"datapoint", "scope", "instrumentation_scope", "resource"}),
 		"datapoint":             newDummyPriorityContextInferrerCandidate(true, true, []string{"scope", "instrumentation_scope", "resource"}),
-		"span":                  newDummyPriorityContextInferrerCandidate(true, true, []string{"spanevent", "scope", "instrumentation_scope", "resource"}),
+		"span":                  newDummyPriorityContextInferrerCandidate(true, true, []string{"spanevent", "spanlink", "scope", "instrumentation_scope", "resource"}),
 		"spanevent":             newDummyPriorityContextInferrerCandidate(true, true, []string{"scope", "instrumentation_scope", "resource"}),
+		"spanlink":              newDummyPriorityContextInferrerCandidate(true, true, []string{"scope", "instrumentation_scope", "resource"}),
 		"profile":               newDummyPriorityContextInferrerCandidate(true, true, []string{"profile", "scope", "instrumentation_scope", "resource"}),
 		"scope":                 newDummyPriorityContextInferrerCandidate(true, true, []string{"resource"}),
 		"instrumentation_scope": newDummyPriorityContextInferrerCandidate(true, true, []string{"resource"}),
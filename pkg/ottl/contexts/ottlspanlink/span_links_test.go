@@ -0,0 +1,506 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlspanlink
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ctxspanlink"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/pathtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+var (
+	traceID  = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	traceID2 = [16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	spanID   = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	spanID2  = [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+)
+
+func Test_newPathGetSetter(t *testing.T) {
+	_, _, _, refSpanLink := createTelemetry()
+
+	newAttrs := pcommon.NewMap()
+	newAttrs.PutStr("hello", "world")
+
+	newCache := pcommon.NewMap()
+	newCache.PutStr("temp", "value")
+
+	tests := []struct {
+		name              string
+		path              ottl.Path[*TransformContext]
+		orig              any
+		newVal            any
+		expectSetterError bool
+		modified          func(spanLink ptrace.SpanLink, cache pcommon.Map)
+	}{
+		{
+			name: "cache",
+			path: &pathtest.Path[*TransformContext]{
+				N: "cache",
+			},
+			orig:   pcommon.NewMap(),
+			newVal: newCache,
+			modified: func(_ ptrace.SpanLink, cache pcommon.Map) {
+				newCache.CopyTo(cache)
+			},
+		},
+		{
+			name: "cache access",
+			path: &pathtest.Path[*TransformContext]{
+				N: "cache",
+				KeySlice: []ottl.Key[*TransformContext]{
+					&pathtest.Key[*TransformContext]{
+						S: ottltest.Strp("temp"),
+					},
+				},
+			},
+			orig:   nil,
+			newVal: "new value",
+			modified: func(_ ptrace.SpanLink, cache pcommon.Map) {
+				cache.PutStr("temp", "new value")
+			},
+		},
+		{
+			name: "trace_id",
+			path: &pathtest.Path[*TransformContext]{
+				N: "trace_id",
+			},
+			orig:   pcommon.TraceID(traceID),
+			newVal: pcommon.TraceID(traceID2),
+			modified: func(spanLink ptrace.SpanLink, _ pcommon.Map) {
+				spanLink.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "trace_id string",
+			path: &pathtest.Path[*TransformContext]{
+				N: "trace_id",
+				NextPath: &pathtest.Path[*TransformContext]{
+					N: "string",
+				},
+			},
+			orig:   "0102030405060708090a0b0c0d0e0f10",
+			newVal: "100f0e0d0c0b0a090807060504030201",
+			modified: func(spanLink ptrace.SpanLink, _ pcommon.Map) {
+				spanLink.SetTraceID(traceID2)
+			},
+		},
+		{
+			name: "span_id",
+			path: &pathtest.Path[*TransformContext]{
+				N: "span_id",
+			},
+			orig:   pcommon.SpanID(spanID),
+			newVal: pcommon.SpanID(spanID2),
+			modified: func(spanLink ptrace.SpanLink, _ pcommon.Map) {
+				spanLink.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "span_id string",
+			path: &pathtest.Path[*TransformContext]{
+				N: "span_id",
+				NextPath: &pathtest.Path[*TransformContext]{
+					N: "string",
+				},
+			},
+			orig:   "0102030405060708",
+			newVal: "0807060504030201",
+			modified: func(spanLink ptrace.SpanLink, _ pcommon.Map) {
+				spanLink.SetSpanID(spanID2)
+			},
+		},
+		{
+			name: "trace_state",
+			path: &pathtest.Path[*TransformContext]{
+				N: "trace_state",
+			},
+			orig:   "key1=val1,key2=val2",
+			newVal: "key=newVal",
+			modified: func(spanLink ptrace.SpanLink, _ pcommon.Map) {
+				spanLink.TraceState().FromRaw("key=newVal")
+			},
+		},
+		{
+			name: "trace_state key",
+			path: &pathtest.Path[*TransformContext]{
+				N: "trace_state",
+				KeySlice: []ottl.Key[*TransformContext]{
+					&pathtest.Key[*TransformContext]{
+						S: ottltest.Strp("key1"),
+					},
+				},
+			},
+			orig:   "val1",
+			newVal: "newVal",
+			modified: func(spanLink ptrace.SpanLink, _ pcommon.Map) {
+				spanLink.TraceState().FromRaw("key1=newVal,key2=val2")
+			},
+		},
+		{
+			name: "flags",
+			path: &pathtest.Path[*TransformContext]{
+				N: "flags",
+			},
+			orig:   int64(1),
+			newVal: int64(0),
+			modified: func(spanLink ptrace.SpanLink, _ pcommon.Map) {
+				spanLink.SetFlags(0)
+			},
+		},
+		{
+			name: "attributes",
+			path: &pathtest.Path[*TransformContext]{
+				N: "attributes",
+			},
+			orig:   refSpanLink.Attributes(),
+			newVal: newAttrs,
+			modified: func(spanLink ptrace.SpanLink, _ pcommon.Map) {
+				newAttrs.CopyTo(spanLink.Attributes())
+			},
+		},
+		{
+			name: "attributes string",
+			path: &pathtest.Path[*TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[*TransformContext]{
+					&pathtest.Key[*TransformContext]{
+						S: ottltest.Strp("str"),
+					},
+				},
+			},
+			orig:   "val",
+			newVal: "newVal",
+			modified: func(spanLink ptrace.SpanLink, _ pcommon.Map) {
+				spanLink.Attributes().PutStr("str", "newVal")
+			},
+		},
+		{
+			name: "attributes nested",
+			path: &pathtest.Path[*TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[*TransformContext]{
+					&pathtest.Key[*TransformContext]{
+						S: ottltest.Strp("slice"),
+					},
+					&pathtest.Key[*TransformContext]{
+						I: ottltest.Intp(0),
+					},
+					&pathtest.Key[*TransformContext]{
+						S: ottltest.Strp("map"),
+					},
+				},
+			},
+			orig:   "pass",
+			newVal: "new",
+			modified: func(spanLink ptrace.SpanLink, _ pcommon.Map) {
+				spanLink.Attributes().PutEmptySlice("slice").AppendEmpty().SetEmptyMap().PutStr("map", "new")
+			},
+		},
+		{
+			name: "dropped_attributes_count",
+			path: &pathtest.Path[*TransformContext]{
+				N: "dropped_attributes_count",
+			},
+			orig:   int64(10),
+			newVal: int64(20),
+			modified: func(spanLink ptrace.SpanLink, _ pcommon.Map) {
+				spanLink.SetDroppedAttributesCount(20)
+			},
+		},
+		{
+			name: "link_index",
+			path: &pathtest.Path[*TransformContext]{
+				N: "link_index",
+			},
+			orig:              int64(1),
+			newVal:            int64(1),
+			expectSetterError: true,
+		},
+	}
+	// Copy all tests cases and sets the path.Context value to the generated ones.
+	// It ensures all exiting field access also work when the path context is set.
+	for _, tt := range slices.Clone(tests) {
+		testWithContext := tt
+		testWithContext.name = "with_path_context:" + tt.name
+		pathWithContext := *tt.path.(*pathtest.Path[*TransformContext])
+		pathWithContext.C = ctxspanlink.Name
+		testWithContext.path = ottl.Path[*TransformContext](&pathWithContext)
+		tests = append(tests, testWithContext)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCache := pcommon.NewMap()
+			cacheGetter := func(*TransformContext) pcommon.Map {
+				return testCache
+			}
+
+			accessor, err := pathExpressionParser(cacheGetter)(tt.path)
+			require.NoError(t, err)
+
+			rs, ss, span, spanLink := createTelemetry()
+
+			tCtx := NewTransformContextPtr(rs, ss, span, spanLink, WithLinkIndex(1))
+			defer tCtx.Close()
+
+			got, err := accessor.Get(t.Context(), tCtx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.orig, got)
+
+			err = accessor.Set(t.Context(), tCtx, tt.newVal)
+			if tt.expectSetterError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			exRS, _, _, exSpanLink := createTelemetry()
+			exCache := pcommon.NewMap()
+			tt.modified(exSpanLink, exCache)
+
+			assert.Equal(t, exRS, rs)
+			assert.Equal(t, exCache, testCache)
+		})
+	}
+}
+
+func Test_newPathGetSetter_higherContextPath(t *testing.T) {
+	rs := ptrace.NewResourceSpans()
+	rs.Resource().Attributes().PutStr("foo", "bar")
+
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("scope")
+
+	span := ss.Spans().AppendEmpty()
+	span.SetName("span")
+
+	ctx := NewTransformContextPtr(rs, ss, span, ptrace.NewSpanLink())
+	defer ctx.Close()
+
+	tests := []struct {
+		name     string
+		path     ottl.Path[*TransformContext]
+		expected any
+	}{
+		{
+			name: "resource",
+			path: &pathtest.Path[*TransformContext]{C: "", N: "resource", NextPath: &pathtest.Path[*TransformContext]{
+				N: "attributes",
+				KeySlice: []ottl.Key[*TransformContext]{
+					&pathtest.Key[*TransformContext]{
+						S: ottltest.Strp("foo"),
+					},
+				},
+			}},
+			expected: "bar",
+		},
+		{
+			name: "resource with context",
+			path: &pathtest.Path[*TransformContext]{C: "resource", N: "attributes", KeySlice: []ottl.Key[*TransformContext]{
+				&pathtest.Key[*TransformContext]{
+					S: ottltest.Strp("foo"),
+				},
+			}},
+			expected: "bar",
+		},
+		{
+			name:     "instrumentation_scope",
+			path:     &pathtest.Path[*TransformContext]{N: "instrumentation_scope", NextPath: &pathtest.Path[*TransformContext]{N: "name"}},
+			expected: "scope",
+		},
+		{
+			name:     "scope with context",
+			path:     &pathtest.Path[*TransformContext]{C: "scope", N: "name"},
+			expected: "scope",
+		},
+		{
+			name:     "span",
+			path:     &pathtest.Path[*TransformContext]{N: "span", NextPath: &pathtest.Path[*TransformContext]{N: "name"}},
+			expected: span.Name(),
+		},
+		{
+			name:     "span with context",
+			path:     &pathtest.Path[*TransformContext]{C: "span", N: "name"},
+			expected: span.Name(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessor, err := pathExpressionParser(getCache)(tt.path)
+			require.NoError(t, err)
+
+			got, err := accessor.Get(t.Context(), ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_setAndGetLinkIndex(t *testing.T) {
+	tests := []struct {
+		name             string
+		setLinkIndex     bool
+		linkIndexValue   int64
+		expected         any
+		expectedErrorMsg string
+	}{
+		{
+			name:           "link index set",
+			setLinkIndex:   true,
+			linkIndexValue: 1,
+			expected:       int64(1),
+		},
+		{
+			name:             "invalid value for link index",
+			setLinkIndex:     true,
+			linkIndexValue:   -1,
+			expectedErrorMsg: "found invalid value for 'link_index'",
+		},
+		{
+			name:             "no value for link index",
+			setLinkIndex:     false,
+			expectedErrorMsg: "no 'link_index' property has been set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, ss, span, spanLink := createTelemetry()
+
+			var tCtx *TransformContext
+			if tt.setLinkIndex {
+				tCtx = NewTransformContextPtr(rs, ss, span, spanLink, WithLinkIndex(tt.linkIndexValue))
+			} else {
+				tCtx = NewTransformContextPtr(rs, ss, span, spanLink)
+			}
+			defer tCtx.Close()
+
+			accessor, err := pathExpressionParser(getCache)(&pathtest.Path[*TransformContext]{
+				N: "link_index",
+			})
+			require.NoError(t, err)
+
+			got, err := accessor.Get(t.Context(), tCtx)
+			if tt.expectedErrorMsg != "" {
+				assert.ErrorContains(t, err, tt.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestHigherContextCacheAccessError(t *testing.T) {
+	higherContexts := []string{
+		ctxresource.Name,
+		ctxscope.Name,
+		ctxscope.LegacyName,
+		ctxspan.Name,
+	}
+	for _, higherContext := range higherContexts {
+		t.Run(higherContext, func(t *testing.T) {
+			path := &pathtest.Path[*TransformContext]{
+				N: "cache",
+				C: higherContext,
+				KeySlice: []ottl.Key[*TransformContext]{
+					&pathtest.Key[*TransformContext]{
+						S: ottltest.Strp("key"),
+					},
+				},
+				FullPath: fmt.Sprintf("%s.cache[key]", higherContext),
+			}
+
+			_, err := pathExpressionParser(getCache)(path)
+			require.Error(t, err)
+			expectError := fmt.Sprintf(`replace "%s.cache[key]" with "spanlink.cache[key]"`, higherContext)
+			require.ErrorContains(t, err, expectError)
+		})
+	}
+}
+
+func createTelemetry() (ptrace.ResourceSpans, ptrace.ScopeSpans, ptrace.Span, ptrace.SpanLink) {
+	rs := ptrace.NewResourceSpans()
+	ss := rs.ScopeSpans().AppendEmpty()
+	span := ss.Spans().AppendEmpty()
+	span.SetName("test")
+
+	spanLink := span.Links().AppendEmpty()
+
+	spanLink.SetTraceID(traceID)
+	spanLink.SetSpanID(spanID)
+	spanLink.TraceState().FromRaw("key1=val1,key2=val2")
+	spanLink.SetFlags(1)
+	spanLink.SetDroppedAttributesCount(10)
+
+	spanLink.Attributes().PutStr("str", "val")
+	spanLink.Attributes().PutInt("int", 10)
+
+	s := spanLink.Attributes().PutEmptySlice("slice")
+	s.AppendEmpty().SetEmptyMap().PutStr("map", "pass")
+
+	ss.Scope().SetName("library")
+	ss.Scope().SetVersion("version")
+
+	return rs, ss, span, spanLink
+}
+
+func Test_ParseEnum(t *testing.T) {
+	tests := []struct {
+		name string
+		want ottl.Enum
+	}{
+		{
+			name: "SPAN_KIND_SERVER",
+			want: ottl.Enum(ptrace.SpanKindServer),
+		},
+		{
+			name: "STATUS_CODE_ERROR",
+			want: ottl.Enum(ptrace.StatusCodeError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum((*ottl.EnumSymbol)(ottltest.Strp(tt.name)))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *actual)
+		})
+	}
+}
+
+func Test_ParseEnum_False(t *testing.T) {
+	tests := []struct {
+		name       string
+		enumSymbol *ottl.EnumSymbol
+	}{
+		{
+			name:       "unknown enum symbol",
+			enumSymbol: (*ottl.EnumSymbol)(ottltest.Strp("not an enum")),
+		},
+		{
+			name:       "nil enum symbol",
+			enumSymbol: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEnum(tt.enumSymbol)
+			assert.Error(t, err)
+			assert.Nil(t, actual)
+		})
+	}
+}
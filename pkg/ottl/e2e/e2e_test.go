@@ -537,12 +537,82 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("decoded_base64", "pass")
 			},
 		},
+		{
+			statement: `set(attributes["test"], Encode("pass", "base64"))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "cGFzcw==")
+			},
+		},
 		{
 			statement: `set(attributes["test"], Concat(["A","B"], ":"))`,
 			want: func(tCtx *ottllog.TransformContext) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "A:B")
 			},
 		},
+		{
+			statement: `set(attributes["test"], Map(["A", "B"], x => ToLowerCase(x)))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("a")
+				s.AppendEmpty().SetStr("b")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Map(attributes["things"], thing => thing["name"]))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("foo")
+				s.AppendEmpty().SetStr("bar")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Map(["a", "b"], x => Filter(["a", "b", "c"], y => y != x)))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s0 := s.AppendEmpty().SetEmptySlice()
+				s0.AppendEmpty().SetStr("b")
+				s0.AppendEmpty().SetStr("c")
+				s1 := s.AppendEmpty().SetEmptySlice()
+				s1.AppendEmpty().SetStr("a")
+				s1.AppendEmpty().SetStr("c")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Filter(["a", "", "b"], x => x != ""))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("a")
+				s.AppendEmpty().SetStr("b")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Filter(["val1", "val2"], x => x == attributes["val"]))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("val2")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Filter(attributes["things"], thing => thing["value"] > 3 and not IsMatch(thing["name"], "^f")))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				m := s.AppendEmpty().SetEmptyMap()
+				m.PutStr("name", "bar")
+				m.PutInt("value", 5)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Reduce(attributes["things"], (total, thing) => total + thing["value"], 0))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutInt("test", 7)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Reduce(["a", "b", "c"], (acc, x) => Concat([acc, x], "-")))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "a-b-c")
+			},
+		},
 		{
 			statement: `set(attributes["test"], Concat(["A","B"], attributes["val"]))`,
 			want: func(tCtx *ottllog.TransformContext) {
@@ -934,6 +1004,52 @@ func Test_e2e_converters(t *testing.T) {
 				m.PutStr("k2", "v2")
 			},
 		},
+		{
+			statement: `set(attributes["test"], ParseCEF("CEF:0|Vendor|Product|1.0|100|Name|5|src=10.0.0.1 msg=threat detected"))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutInt("version", 0)
+				m.PutStr("device_vendor", "Vendor")
+				m.PutStr("device_product", "Product")
+				m.PutStr("device_version", "1.0")
+				m.PutStr("device_event_class_id", "100")
+				m.PutStr("name", "Name")
+				m.PutStr("severity", "5")
+				ext := m.PutEmptyMap("extensions")
+				ext.PutStr("src", "10.0.0.1")
+				ext.PutStr("msg", "threat detected")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseLEEF("LEEF:2.0|Vendor|Product|1.0|42|^|src=10.0.0.1^dst=10.0.0.2"))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("version", "2.0")
+				m.PutStr("vendor", "Vendor")
+				m.PutStr("product", "Product")
+				m.PutStr("product_version", "1.0")
+				m.PutStr("event_id", "42")
+				attrs := m.PutEmptyMap("attributes")
+				attrs.PutStr("src", "10.0.0.1")
+				attrs.PutStr("dst", "10.0.0.2")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseSyslog("<34>1 2015-08-05T21:58:59.693Z host app 23108 ID52020 - message"))`,
+			want: func(tCtx *ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutInt("timestamp", 1438811939693000000)
+				m.PutStr("hostname", "host")
+				m.PutStr("appname", "app")
+				m.PutStr("proc_id", "23108")
+				m.PutStr("msg_id", "ID52020")
+				m.PutStr("message", "message")
+				m.PutInt("version", 1)
+				m.PutInt("priority", 34)
+				m.PutInt("severity", 2)
+				m.PutInt("facility", 4)
+			},
+		},
 		{
 			statement: `set(attributes["test"], ParseKeyValue("k1!v1_k2!v2", "!", "_"))`,
 			want: func(tCtx *ottllog.TransformContext) {
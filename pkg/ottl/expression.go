@@ -1119,6 +1119,9 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 		if i := eL.Int; i != nil {
 			return newLiteral[K, any](*i), nil
 		}
+		if eL.Variable != nil {
+			return newVariableGetter[K](eL.Variable), nil
+		}
 		if eL.Path != nil {
 			np, err := p.newPath(eL.Path)
 			if err != nil {
@@ -0,0 +1,536 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"unicode"
+)
+
+// FunctionDefinition declares an OTTL function composed of other OTTL functions, so the same
+// statements don't need to be repeated in every configuration using them.
+//
+// Editors, whose name starts with a lowercase letter, execute their Statements in order.
+// Converters, whose name starts with an uppercase letter, return the value of their Expression,
+// or the result of their Condition.
+// The parameters of the function are referenced as paths without context in its body, and are
+// replaced by the arguments of each call when the calling statement is parsed, like macros.
+// The other paths of the body must include their context when the parser requires one.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type FunctionDefinition struct {
+	// Name is the name used to call the function.
+	Name string `mapstructure:"name"`
+	// Params are the names of the parameters of the function, in the order of its arguments.
+	Params []string `mapstructure:"params"`
+	// Statements are the statements executed by an editor.
+	Statements []string `mapstructure:"statements"`
+	// Expression is the value expression returned by a converter.
+	Expression string `mapstructure:"expression"`
+	// Condition is the boolean expression returned by a converter, instead of an Expression.
+	Condition string `mapstructure:"condition"`
+}
+
+func (d FunctionDefinition) isConverter() bool {
+	return d.Name != "" && unicode.IsUpper(rune(d.Name[0]))
+}
+
+// Validate checks the name and parameters of the function definition, and the syntax of its body.
+func (d FunctionDefinition) Validate() error {
+	if d.isConverter() {
+		parsed, err := parseValueExpression(d.Name + "()")
+		if err != nil || parsed.Literal == nil || parsed.Literal.Converter == nil || parsed.Literal.Converter.Function != d.Name {
+			return fmt.Errorf("invalid function name %q", d.Name)
+		}
+		if len(d.Statements) > 0 {
+			return fmt.Errorf("converter %q must define an expression or a condition, not statements", d.Name)
+		}
+		switch {
+		case d.Expression != "" && d.Condition != "":
+			return fmt.Errorf("converter %q must define either an expression or a condition", d.Name)
+		case d.Expression != "":
+			if _, err = parseValueExpression(d.Expression); err != nil {
+				return fmt.Errorf("invalid expression of function %q: %w", d.Name, err)
+			}
+		case d.Condition != "":
+			if _, err = parseCondition(d.Condition); err != nil {
+				return fmt.Errorf("invalid condition of function %q: %w", d.Name, err)
+			}
+		default:
+			return fmt.Errorf("converter %q must define an expression or a condition", d.Name)
+		}
+	} else {
+		parsed, err := parseStatement(d.Name + "()")
+		if err != nil || parsed.Editor.Function != d.Name {
+			return fmt.Errorf("invalid function name %q", d.Name)
+		}
+		if d.Expression != "" || d.Condition != "" {
+			return fmt.Errorf("editor %q must define statements, not an expression or a condition", d.Name)
+		}
+		if len(d.Statements) == 0 {
+			return fmt.Errorf("editor %q must define at least one statement", d.Name)
+		}
+		for _, statement := range d.Statements {
+			if _, err = parseStatement(statement); err != nil {
+				return fmt.Errorf("invalid statement %q of function %q: %w", statement, d.Name, err)
+			}
+		}
+	}
+
+	for i, param := range d.Params {
+		parsed, err := parseValueExpression(param)
+		if err != nil || parameterName(parsed.Literal) != param {
+			return fmt.Errorf("invalid parameter name %q of function %q", param, d.Name)
+		}
+		if slices.Contains(d.Params[:i], param) {
+			return fmt.Errorf("duplicate parameter %q of function %q", param, d.Name)
+		}
+	}
+	return nil
+}
+
+// AddFunctionDefinitions returns a copy of the functions including the functions declared by the definitions.
+// It returns an error if a definition is invalid or if its name is already used by another function.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func AddFunctionDefinitions[K any](functions map[string]Factory[K], definitions []FunctionDefinition) (map[string]Factory[K], error) {
+	result := make(map[string]Factory[K], len(functions)+len(definitions))
+	maps.Copy(result, functions)
+	for _, definition := range definitions {
+		if err := definition.Validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := result[definition.Name]; ok {
+			return nil, fmt.Errorf("function %q is already defined", definition.Name)
+		}
+		result[definition.Name] = &functionDefinitionFactory[K]{definition: definition}
+	}
+	if err := checkRecursiveDefinitions(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkRecursiveDefinitions returns an error if a defined function calls itself, directly or
+// through other defined functions, since its calls could not be expanded.
+func checkRecursiveDefinitions[K any](functions map[string]Factory[K]) error {
+	calls := map[string][]string{}
+	for name, f := range functions {
+		if definition, ok := f.(*functionDefinitionFactory[K]); ok {
+			calls[name] = definition.definition.calledFunctions()
+		}
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := map[string]int{}
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("function %q cannot be called recursively", name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, called := range calls[name] {
+			if _, ok := calls[called]; !ok {
+				continue
+			}
+			if err := visit(called); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(calls)) {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// calledFunctions returns the names of the functions called in the body of the definition.
+func (d FunctionDefinition) calledFunctions() []string {
+	visitor := &functionCallsVisitor{}
+	if d.isConverter() {
+		if parsed, err := parseValueExpression(d.Expression); d.Expression != "" && err == nil {
+			parsed.accept(visitor)
+		}
+		if parsed, err := parseCondition(d.Condition); d.Condition != "" && err == nil {
+			parsed.accept(visitor)
+		}
+		return visitor.names
+	}
+	for _, statement := range d.Statements {
+		parsed, err := parseStatement(statement)
+		if err != nil {
+			continue
+		}
+		parsed.Editor.accept(visitor)
+		if parsed.WhereClause != nil {
+			parsed.WhereClause.accept(visitor)
+		}
+	}
+	return visitor.names
+}
+
+// functionCallsVisitor collects the names of the called functions.
+type functionCallsVisitor struct {
+	names []string
+}
+
+func (*functionCallsVisitor) visitPath(*path) {}
+
+func (v *functionCallsVisitor) visitEditor(e *editor) {
+	v.names = append(v.names, e.Function)
+}
+
+func (v *functionCallsVisitor) visitConverter(c *converter) {
+	v.names = append(v.names, c.Function)
+	// the grammar doesn't visit the keys of converters
+	for i := range c.Keys {
+		c.Keys[i].accept(v)
+	}
+}
+
+func (*functionCallsVisitor) visitValue(*value) {}
+
+func (*functionCallsVisitor) visitMathExprLiteral(*mathExprLiteral) {}
+
+// functionDefinitionFactory is the Factory of a FunctionDefinition. Its calls are expanded by the Parser
+// rather than created with CreateFunction, since the parameters are replaced in the body of the function.
+type functionDefinitionFactory[K any] struct {
+	definition FunctionDefinition
+}
+
+//nolint:unused
+func (*functionDefinitionFactory[K]) unexportedFactoryFunc() {}
+
+func (f *functionDefinitionFactory[K]) Name() string {
+	return f.definition.Name
+}
+
+func (*functionDefinitionFactory[K]) CreateDefaultArguments() Arguments {
+	return nil
+}
+
+func (f *functionDefinitionFactory[K]) CreateFunction(FunctionContext, Arguments) (ExprFunc[K], error) {
+	return nil, fmt.Errorf("function %q is defined by OTTL statements and can only be called within parsed statements", f.definition.Name)
+}
+
+func (p *Parser[K]) addFunctionDefinitions(definitions []FunctionDefinition) error {
+	functions, err := AddFunctionDefinitions(p.functions, definitions)
+	if err != nil {
+		return err
+	}
+	p.functions = functions
+	return nil
+}
+
+// newDefinedFunctionCall parses the body of the function definition, with its parameters replaced by the
+// arguments of the call.
+func (p *Parser[K]) newDefinedFunctionCall(definition FunctionDefinition, ed editor) (Expr[K], error) {
+	args, err := bindDefinedFunctionArgs(definition, ed.Arguments)
+	if err != nil {
+		return Expr[K]{}, fmt.Errorf("error while parsing arguments for call to %q: %w", ed.Function, err)
+	}
+
+	if definition.isConverter() && definition.Condition != "" {
+		condition, err := p.newDefinedFunctionCondition(definition.Condition, args)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("unable to parse the condition of function %q: %w", definition.Name, err)
+		}
+		return Expr[K]{exprFunc: func(ctx context.Context, tCtx K) (any, error) {
+			return condition.Eval(ctx, tCtx)
+		}}, nil
+	}
+	if definition.isConverter() {
+		getter, err := p.newDefinedFunctionGetter(definition.Expression, args)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("unable to parse the expression of function %q: %w", definition.Name, err)
+		}
+		return Expr[K]{exprFunc: getter.Get}, nil
+	}
+
+	statements := make([]*Statement[K], 0, len(definition.Statements))
+	for _, statement := range definition.Statements {
+		s, err := p.newDefinedFunctionStatement(statement, args)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("unable to parse statement %q of function %q: %w", statement, definition.Name, err)
+		}
+		statements = append(statements, s)
+	}
+	return Expr[K]{exprFunc: func(ctx context.Context, tCtx K) (any, error) {
+		for _, s := range statements {
+			if _, _, err := s.Execute(ctx, tCtx); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}}, nil
+}
+
+func (p *Parser[K]) newDefinedFunctionStatement(statement string, args map[string]value) (*Statement[K], error) {
+	parsed, err := parseStatement(statement)
+	if err != nil {
+		return nil, err
+	}
+	substitution := newParametersSubstitution(args)
+	parsed.Editor.accept(substitution)
+	if parsed.WhereClause != nil {
+		parsed.WhereClause.accept(substitution)
+	}
+	if err = substitution.apply(); err != nil {
+		return nil, err
+	}
+
+	function, err := p.newFunctionCall(parsed.Editor)
+	if err != nil {
+		return nil, err
+	}
+	expression, err := p.newBoolExpr(parsed.WhereClause)
+	if err != nil {
+		return nil, err
+	}
+	return &Statement[K]{
+		function:          function,
+		condition:         expression,
+		origText:          statement,
+		telemetrySettings: p.telemetrySettings,
+	}, nil
+}
+
+func (p *Parser[K]) newDefinedFunctionGetter(expression string, args map[string]value) (Getter[K], error) {
+	parsed, err := parseValueExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	substitution := newParametersSubstitution(args)
+	parsed.accept(substitution)
+	if err = substitution.apply(); err != nil {
+		return nil, err
+	}
+	return p.newGetter(*parsed)
+}
+
+func (p *Parser[K]) newDefinedFunctionCondition(condition string, args map[string]value) (boolExpr[K], error) {
+	parsed, err := parseCondition(condition)
+	if err != nil {
+		return nil, err
+	}
+	substitution := newParametersSubstitution(args)
+	parsed.accept(substitution)
+	if err = substitution.apply(); err != nil {
+		return nil, err
+	}
+	return p.newBoolExpr(parsed)
+}
+
+// bindDefinedFunctionArgs maps the parameters of the function definition to the arguments of the call,
+// which can be given by position or by name.
+func bindDefinedFunctionArgs(definition FunctionDefinition, arguments []argument) (map[string]value, error) {
+	if len(arguments) != len(definition.Params) {
+		return nil, fmt.Errorf("incorrect number of arguments. Expected: %d Received: %d", len(definition.Params), len(arguments))
+	}
+
+	args := make(map[string]value, len(arguments))
+	seenNamed := false
+	for i, arg := range arguments {
+		if arg.FunctionName != nil {
+			return nil, fmt.Errorf("invalid argument at position %v: function names cannot be passed to defined functions", i)
+		}
+		if arg.Lambda != nil {
+			return nil, fmt.Errorf("invalid argument at position %v: lambda expressions cannot be passed to defined functions", i)
+		}
+		name := arg.Name
+		switch {
+		case name != "":
+			seenNamed = true
+			if !slices.Contains(definition.Params, name) {
+				return nil, fmt.Errorf("no such parameter: %s", name)
+			}
+		case seenNamed:
+			return nil, errors.New("unnamed argument used after named argument")
+		default:
+			name = definition.Params[i]
+		}
+		if _, ok := args[name]; ok {
+			return nil, fmt.Errorf("duplicate argument for parameter: %s", name)
+		}
+		args[name] = arg.Value
+	}
+	return args, nil
+}
+
+// parameterName returns the name of the parameter referenced by the literal,
+// which is a path without context made of a single field.
+func parameterName(literal *mathExprLiteral) string {
+	if literal == nil || literal.Path == nil || literal.Path.Context != "" || len(literal.Path.Fields) != 1 {
+		return ""
+	}
+	return literal.Path.Fields[0].Name
+}
+
+// parametersSubstitution replaces the parameters referenced in the body of a function definition by
+// the arguments of its call. The nodes referencing the parameters are collected before being replaced,
+// so the paths of the arguments are never mistaken for parameters. Values are collected from their
+// parent nodes, since the grammar visits the arguments and list items as copies.
+type parametersSubstitution struct {
+	args     map[string]value
+	values   []*value
+	keys     []*key
+	literals []*mathExprLiteral
+	// collected holds the literals already collected as a value or a key
+	collected map[*mathExprLiteral]struct{}
+}
+
+func newParametersSubstitution(args map[string]value) *parametersSubstitution {
+	return &parametersSubstitution{
+		args:      args,
+		collected: map[*mathExprLiteral]struct{}{},
+	}
+}
+
+// parameter returns the literal referencing a parameter without keys, or nil.
+func (s *parametersSubstitution) parameter(literal *mathExprLiteral) *mathExprLiteral {
+	if _, ok := s.args[parameterName(literal)]; !ok || len(literal.Path.Fields[0].Keys) > 0 {
+		return nil
+	}
+	if _, ok := s.collected[literal]; ok {
+		return nil
+	}
+	return literal
+}
+
+// keyLiteral returns the literal of a key made of a single path or converter,
+// which is parsed as a math expression without operators.
+func keyLiteral(k *key) *mathExprLiteral {
+	if k.Expression != nil {
+		return k.Expression
+	}
+	m := k.MathExpression
+	if m == nil || len(m.Right) > 0 || m.Left == nil || len(m.Left.Right) > 0 || m.Left.Left == nil || m.Left.Left.UnaryOp != nil {
+		return nil
+	}
+	return m.Left.Left.Literal
+}
+
+func (s *parametersSubstitution) collectKeys(keys []key) {
+	for i := range keys {
+		if literal := s.parameter(keyLiteral(&keys[i])); literal != nil {
+			s.keys = append(s.keys, &keys[i])
+			s.collected[literal] = struct{}{}
+		}
+	}
+}
+
+func (s *parametersSubstitution) collectValue(v *value) {
+	if literal := s.parameter(v.Literal); literal != nil {
+		s.values = append(s.values, v)
+		s.collected[literal] = struct{}{}
+	}
+}
+
+func (s *parametersSubstitution) collectArguments(arguments []argument) {
+	for i := range arguments {
+		s.collectValue(&arguments[i].Value)
+	}
+}
+
+func (s *parametersSubstitution) visitPath(p *path) {
+	for _, f := range p.Fields {
+		s.collectKeys(f.Keys)
+	}
+}
+
+func (s *parametersSubstitution) visitEditor(e *editor) {
+	s.collectArguments(e.Arguments)
+}
+
+func (s *parametersSubstitution) visitConverter(c *converter) {
+	s.collectArguments(c.Arguments)
+	s.collectKeys(c.Keys)
+	// the grammar doesn't visit the keys of converters
+	for i := range c.Keys {
+		c.Keys[i].accept(s)
+	}
+}
+
+func (s *parametersSubstitution) visitValue(v *value) {
+	s.collectValue(v)
+	if v.List != nil {
+		for i := range v.List.Values {
+			s.collectValue(&v.List.Values[i])
+		}
+	}
+}
+
+func (s *parametersSubstitution) visitMathExprLiteral(m *mathExprLiteral) {
+	if _, ok := s.collected[m]; !ok && parameterName(m) != "" {
+		if _, ok = s.args[parameterName(m)]; ok {
+			s.literals = append(s.literals, m)
+		}
+	}
+}
+
+// apply replaces the collected nodes. The keys are replaced first, since the keys of an indexed
+// parameter are appended to its argument.
+func (s *parametersSubstitution) apply() error {
+	for _, k := range s.keys {
+		name := parameterName(keyLiteral(k))
+		arg := s.args[name]
+		switch {
+		case arg.String != nil:
+			*k = key{String: arg.String}
+		case arg.Literal != nil && arg.Literal.Int != nil:
+			*k = key{Int: arg.Literal.Int}
+		case arg.Literal != nil && (arg.Literal.Path != nil || arg.Literal.Converter != nil):
+			*k = key{Expression: arg.Literal}
+		case arg.MathExpression != nil:
+			*k = key{MathExpression: arg.MathExpression}
+		default:
+			return fmt.Errorf("parameter %q is used as a key, so its argument must be a string, an int, a path, a converter or a math expression", name)
+		}
+	}
+
+	for _, v := range s.values {
+		*v = s.args[parameterName(v.Literal)]
+	}
+
+	for _, m := range s.literals {
+		param := m.Path.Fields[0]
+		arg := s.args[param.Name]
+		if arg.Literal == nil {
+			return fmt.Errorf("parameter %q is used in a math expression or indexed, so its argument must be a path, a converter or a number", param.Name)
+		}
+		literal := *arg.Literal
+		if len(param.Keys) > 0 {
+			switch {
+			case literal.Path != nil:
+				argPath := *literal.Path
+				argPath.Fields = slices.Clone(argPath.Fields)
+				last := &argPath.Fields[len(argPath.Fields)-1]
+				last.Keys = append(slices.Clone(last.Keys), param.Keys...)
+				literal.Path = &argPath
+			case literal.Converter != nil:
+				argConverter := *literal.Converter
+				argConverter.Keys = append(slices.Clone(argConverter.Keys), param.Keys...)
+				literal.Converter = &argConverter
+			default:
+				return fmt.Errorf("parameter %q is indexed, so its argument must be a path or a converter", param.Name)
+			}
+		}
+		*m = literal
+	}
+	return nil
+}
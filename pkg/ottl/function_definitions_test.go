@@ -0,0 +1,434 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// testAttributesPath parses the attributes["key"] paths of a pcommon.Map transform context
+func testAttributesPath(p Path[pcommon.Map]) (GetSetter[pcommon.Map], error) {
+	if p.Name() != "attributes" || len(p.Keys()) != 1 {
+		return nil, fmt.Errorf("invalid path %q", p.String())
+	}
+	key := func(ctx context.Context, tCtx pcommon.Map) (string, error) {
+		k := p.Keys()[0]
+		if s, err := k.String(ctx, tCtx); err != nil || s != nil {
+			if s == nil {
+				return "", err
+			}
+			return *s, nil
+		}
+		getter, err := k.ExpressionGetter(ctx, tCtx)
+		if err != nil {
+			return "", err
+		}
+		val, err := getter.Get(ctx, tCtx)
+		if err != nil {
+			return "", err
+		}
+		s, ok := val.(string)
+		if !ok {
+			return "", errors.New("key must be a string")
+		}
+		return s, nil
+	}
+	return &StandardGetSetter[pcommon.Map]{
+		Getter: func(ctx context.Context, tCtx pcommon.Map) (any, error) {
+			k, err := key(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			if v, ok := tCtx.Get(k); ok {
+				return v.AsRaw(), nil
+			}
+			return nil, nil
+		},
+		Setter: func(ctx context.Context, tCtx pcommon.Map, val any) error {
+			k, err := key(ctx, tCtx)
+			if err != nil {
+				return err
+			}
+			return tCtx.PutEmpty(k).FromRaw(val)
+		},
+	}, nil
+}
+
+type testSetArguments struct {
+	Target GetSetter[pcommon.Map]
+	Value  Getter[pcommon.Map]
+}
+
+func testSetFactory() Factory[pcommon.Map] {
+	return NewFactory("set", &testSetArguments{}, func(_ FunctionContext, args Arguments) (ExprFunc[pcommon.Map], error) {
+		a := args.(*testSetArguments)
+		return func(ctx context.Context, tCtx pcommon.Map) (any, error) {
+			val, err := a.Value.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			return nil, a.Target.Set(ctx, tCtx, val)
+		}, nil
+	})
+}
+
+type testSuffixArguments struct {
+	Value  StringGetter[pcommon.Map]
+	Suffix string
+}
+
+func testSuffixFactory() Factory[pcommon.Map] {
+	return NewFactory("Suffix", &testSuffixArguments{}, func(_ FunctionContext, args Arguments) (ExprFunc[pcommon.Map], error) {
+		a := args.(*testSuffixArguments)
+		return func(ctx context.Context, tCtx pcommon.Map) (any, error) {
+			val, err := a.Value.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			return val + a.Suffix, nil
+		}, nil
+	})
+}
+
+func newTestDefinitionsParser(t *testing.T, definitions ...FunctionDefinition) Parser[pcommon.Map] {
+	t.Helper()
+	functions, err := AddFunctionDefinitions(CreateFactoryMap(testSetFactory(), testSuffixFactory()), definitions)
+	require.NoError(t, err)
+	p, err := NewParser[pcommon.Map](functions, testAttributesPath, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return p
+}
+
+func Test_FunctionDefinitions(t *testing.T) {
+	definitions := []FunctionDefinition{
+		{
+			Name:       "set_default",
+			Params:     []string{"target", "value"},
+			Statements: []string{`set(target, value) where target == nil`},
+		},
+		{
+			Name:       "copy_to",
+			Params:     []string{"key"},
+			Statements: []string{`set(attributes[key], attributes["source"])`},
+		},
+		{
+			Name:       "mark",
+			Params:     []string{"target"},
+			Statements: []string{`set(target["marked"], true)`, `set(attributes["count"], Double(attributes["count"]))`},
+		},
+		{
+			Name:       "Double",
+			Params:     []string{"x"},
+			Expression: `x * 2`,
+		},
+		{
+			Name:       "Quadruple",
+			Params:     []string{"x"},
+			Expression: `Double(Double(x))`,
+		},
+		{
+			Name:       "Tag",
+			Params:     []string{"value", "tag"},
+			Expression: `Suffix(value, tag)`,
+		},
+		{
+			Name:      "IsBetween",
+			Params:    []string{"x", "low", "high"},
+			Condition: `x >= low and x <= high`,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		statement string
+		want      func(pcommon.Map)
+	}{
+		{
+			name:      "editor",
+			statement: `set_default(attributes["missing"], "default")`,
+			want: func(m pcommon.Map) {
+				m.PutStr("missing", "default")
+			},
+		},
+		{
+			name:      "editor with a where clause",
+			statement: `set_default(attributes["source"], "default")`,
+			want:      func(pcommon.Map) {},
+		},
+		{
+			name:      "parameter used as a key",
+			statement: `copy_to("destination")`,
+			want: func(m pcommon.Map) {
+				m.PutStr("destination", "value")
+			},
+		},
+		{
+			name:      "path parameter used as a key",
+			statement: `copy_to(attributes["name"])`,
+			want: func(m pcommon.Map) {
+				m.PutStr("destination", "value")
+			},
+		},
+		{
+			name:      "indexed parameter",
+			statement: `mark(attributes)`,
+			want: func(m pcommon.Map) {
+				m.PutBool("marked", true)
+				m.PutInt("count", 6)
+			},
+		},
+		{
+			name:      "converter",
+			statement: `set(attributes["result"], Double(attributes["count"]))`,
+			want: func(m pcommon.Map) {
+				m.PutInt("result", 6)
+			},
+		},
+		{
+			name:      "nested converters",
+			statement: `set(attributes["result"], Quadruple(attributes["count"]))`,
+			want: func(m pcommon.Map) {
+				m.PutInt("result", 12)
+			},
+		},
+		{
+			name:      "named arguments",
+			statement: `set(attributes["result"], Tag(tag = "-tagged", value = attributes["source"]))`,
+			want: func(m pcommon.Map) {
+				m.PutStr("result", "value-tagged")
+			},
+		},
+		{
+			name:      "condition",
+			statement: `set(attributes["result"], IsBetween(attributes["count"], 1, 5))`,
+			want: func(m pcommon.Map) {
+				m.PutBool("result", true)
+			},
+		},
+		{
+			name:      "condition within a where clause",
+			statement: `set(attributes["result"], true) where IsBetween(high = 2, low = 1, x = attributes["count"])`,
+			want:      func(pcommon.Map) {},
+		},
+		{
+			name:      "called within a where clause",
+			statement: `set(attributes["result"], true) where Double(attributes["count"]) == 6`,
+			want: func(m pcommon.Map) {
+				m.PutBool("result", true)
+			},
+		},
+	}
+
+	newMap := func() pcommon.Map {
+		m := pcommon.NewMap()
+		m.PutStr("source", "value")
+		m.PutStr("name", "destination")
+		m.PutInt("count", 3)
+		return m
+	}
+
+	p := newTestDefinitionsParser(t, definitions...)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			m := newMap()
+			_, _, err = statement.Execute(t.Context(), m)
+			require.NoError(t, err)
+
+			want := newMap()
+			tt.want(want)
+			assert.Equal(t, want.AsRaw(), m.AsRaw())
+		})
+	}
+}
+
+func Test_FunctionDefinitions_ParseErrors(t *testing.T) {
+	definitions := []FunctionDefinition{
+		{
+			Name:       "copy_to",
+			Params:     []string{"key"},
+			Statements: []string{`set(attributes[key], attributes["source"])`},
+		},
+		{
+			Name:       "Double",
+			Params:     []string{"x"},
+			Expression: `x * 2`,
+		},
+		{
+			Name:       "Invalid",
+			Expression: `Suffix(attributes["source"])`,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		statement string
+		wantErr   string
+	}{
+		{
+			name:      "missing argument",
+			statement: `copy_to()`,
+			wantErr:   `error while parsing arguments for call to "copy_to": incorrect number of arguments. Expected: 1 Received: 0`,
+		},
+		{
+			name:      "unknown named argument",
+			statement: `copy_to(name = "destination")`,
+			wantErr:   "no such parameter: name",
+		},
+		{
+			name:      "invalid key argument",
+			statement: `copy_to(["destination"])`,
+			wantErr:   `parameter "key" is used as a key`,
+		},
+		{
+			name:      "invalid math expression argument",
+			statement: `set(attributes["result"], Double("value"))`,
+			wantErr:   `parameter "x" is used in a math expression or indexed`,
+		},
+		{
+			name:      "invalid body",
+			statement: `set(attributes["result"], Invalid())`,
+			wantErr:   `unable to parse the expression of function "Invalid"`,
+		},
+	}
+
+	p := newTestDefinitionsParser(t, definitions...)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseStatement(tt.statement)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_FunctionDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition FunctionDefinition
+		wantErr    string
+	}{
+		{
+			name:       "valid editor",
+			definition: FunctionDefinition{Name: "normalize_http", Params: []string{"url"}, Statements: []string{`set(url, url)`}},
+		},
+		{
+			name:       "valid converter",
+			definition: FunctionDefinition{Name: "Normalize", Params: []string{"url"}, Expression: `url`},
+		},
+		{
+			name:       "valid condition",
+			definition: FunctionDefinition{Name: "IsURL", Params: []string{"url"}, Condition: `url != nil and IsString(url)`},
+		},
+		{
+			name:       "missing name",
+			definition: FunctionDefinition{Statements: []string{`set(url, url)`}},
+			wantErr:    `invalid function name ""`,
+		},
+		{
+			name:       "invalid name",
+			definition: FunctionDefinition{Name: "normalize-http", Statements: []string{`set(url, url)`}},
+			wantErr:    `invalid function name "normalize-http"`,
+		},
+		{
+			name:       "editor with an expression",
+			definition: FunctionDefinition{Name: "normalize", Expression: `url`},
+			wantErr:    `editor "normalize" must define statements, not an expression or a condition`,
+		},
+		{
+			name:       "editor without statements",
+			definition: FunctionDefinition{Name: "normalize"},
+			wantErr:    `editor "normalize" must define at least one statement`,
+		},
+		{
+			name:       "converter with statements",
+			definition: FunctionDefinition{Name: "Normalize", Statements: []string{`set(url, url)`}},
+			wantErr:    `converter "Normalize" must define an expression or a condition, not statements`,
+		},
+		{
+			name:       "converter without expression",
+			definition: FunctionDefinition{Name: "Normalize"},
+			wantErr:    `converter "Normalize" must define an expression or a condition`,
+		},
+		{
+			name:       "converter with an expression and a condition",
+			definition: FunctionDefinition{Name: "IsURL", Params: []string{"url"}, Expression: `url`, Condition: `url != nil`},
+			wantErr:    `converter "IsURL" must define either an expression or a condition`,
+		},
+		{
+			name:       "invalid condition",
+			definition: FunctionDefinition{Name: "IsURL", Params: []string{"url"}, Condition: `url !=`},
+			wantErr:    `invalid condition of function "IsURL"`,
+		},
+		{
+			name:       "invalid statement",
+			definition: FunctionDefinition{Name: "normalize", Statements: []string{`set(url`}},
+			wantErr:    `invalid statement "set(url" of function "normalize"`,
+		},
+		{
+			name:       "invalid expression",
+			definition: FunctionDefinition{Name: "Normalize", Expression: `url +`},
+			wantErr:    `invalid expression of function "Normalize"`,
+		},
+		{
+			name:       "invalid parameter",
+			definition: FunctionDefinition{Name: "Normalize", Params: []string{"span.url"}, Expression: `url`},
+			wantErr:    `invalid parameter name "span.url" of function "Normalize"`,
+		},
+		{
+			name:       "duplicate parameter",
+			definition: FunctionDefinition{Name: "Normalize", Params: []string{"url", "url"}, Expression: `url`},
+			wantErr:    `duplicate parameter "url" of function "Normalize"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.definition.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_AddFunctionDefinitions(t *testing.T) {
+	functions := CreateFactoryMap(testSetFactory())
+	definition := FunctionDefinition{Name: "Identity", Params: []string{"x"}, Expression: `x`}
+
+	result, err := AddFunctionDefinitions(functions, []FunctionDefinition{definition})
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Len(t, functions, 1)
+
+	_, err = result["Identity"].CreateFunction(FunctionContext{}, nil)
+	assert.ErrorContains(t, err, `function "Identity" is defined by OTTL statements`)
+
+	_, err = AddFunctionDefinitions(result, []FunctionDefinition{definition})
+	assert.EqualError(t, err, `function "Identity" is already defined`)
+
+	_, err = AddFunctionDefinitions(functions, []FunctionDefinition{{Name: "Invalid"}})
+	assert.EqualError(t, err, `converter "Invalid" must define an expression or a condition`)
+
+	_, err = AddFunctionDefinitions(functions, []FunctionDefinition{{Name: "Loop", Params: []string{"x"}, Expression: `Loop(x)`}})
+	assert.EqualError(t, err, `function "Loop" cannot be called recursively`)
+
+	_, err = AddFunctionDefinitions(result, []FunctionDefinition{
+		{Name: "loop", Statements: []string{`set(attributes["a"], Loop())`}},
+		{Name: "Loop", Expression: `Identity(attributes["a"])[Wrap()]`},
+		{Name: "Wrap", Expression: `Loop()`},
+	})
+	assert.EqualError(t, err, `function "Loop" cannot be called recursively`)
+}
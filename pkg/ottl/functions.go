@@ -237,6 +237,9 @@ func (p *Parser[K]) newKeys(keys []key) ([]Key[K], error) {
 				}
 				getter = g
 			}
+			if keys[i].Expression.Variable != nil {
+				getter = newVariableGetter[K](keys[i].Expression.Variable)
+			}
 			if keys[i].Expression.Converter != nil {
 				g, err := p.newGetterFromConverter(*keys[i].Expression.Converter)
 				if err != nil {
@@ -319,6 +322,9 @@ func (p *Parser[K]) newFunctionCall(ed editor) (Expr[K], error) {
 	if !ok {
 		return Expr[K]{}, fmt.Errorf("undefined function %q", ed.Function)
 	}
+	if definition, ok := f.(*functionDefinitionFactory[K]); ok {
+		return p.newDefinedFunctionCall(definition.definition, ed)
+	}
 	defaultArgs := f.CreateDefaultArguments()
 	var args Arguments
 
@@ -404,7 +410,17 @@ func (p *Parser[K]) buildArgs(ed editor, argsVal reflect.Value) error {
 			fieldType = manager.get().Type()
 		}
 
+		isLambda := strings.HasPrefix(fieldType.Name(), "LambdaGetter")
+		if arg.Lambda != nil && !isLambda {
+			return fmt.Errorf("invalid argument at position %v: lambda expressions are not supported by this parameter", i)
+		}
+
 		switch {
+		case isLambda:
+			if arg.Lambda == nil {
+				return fmt.Errorf("invalid argument at position %v: must be a lambda expression", i)
+			}
+			val, err = p.newLambdaGetter(arg.Lambda)
 		case strings.HasPrefix(fieldType.Name(), "FunctionGetter"):
 			var name string
 			switch {
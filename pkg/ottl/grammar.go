@@ -33,6 +33,13 @@ func (p *parsedStatement) checkForCustomError() error {
 	return validator.join()
 }
 
+func (p *parsedStatement) accept(v grammarVisitor) {
+	p.Editor.accept(v)
+	if p.WhereClause != nil {
+		p.WhereClause.accept(v)
+	}
+}
+
 type constExpr struct {
 	Boolean   *boolean   `parser:"( @Boolean"`
 	Converter *converter `parser:"| @@ )"`
@@ -223,14 +230,37 @@ func (c *converter) accept(v grammarVisitor) {
 
 type argument struct {
 	Name         string  `parser:"(@(Lowercase(Uppercase | Lowercase)*) Equal)?"`
-	Value        value   `parser:"( @@"`
+	Lambda       *lambda `parser:"( @@"`
+	Value        value   `parser:"| @@"`
 	FunctionName *string `parser:"| @(Uppercase(Uppercase | Lowercase)*) )"`
 }
 
 func (a *argument) accept(v grammarVisitor) {
+	if a.Lambda != nil {
+		a.Lambda.accept(v)
+		return
+	}
 	a.Value.accept(v)
 }
 
+// lambda represents an anonymous function passed as an argument, such as `x => ToLowerCase(x)`
+// or `(acc, x) => acc + x`. Its body is either a value or, if it contains comparisons or logical
+// operators, a boolean expression.
+type lambda struct {
+	Params    []string           `parser:"( @Lowercase | '(' @Lowercase ( ',' @Lowercase )* ')' ) Arrow"`
+	Value     *value             `parser:"( @@ (?! OpComparison | OpAnd | OpOr)"`
+	Condition *booleanExpression `parser:"| @@ )"`
+}
+
+func (l *lambda) accept(v grammarVisitor) {
+	if l.Value != nil {
+		l.Value.accept(v)
+	}
+	if l.Condition != nil {
+		l.Condition.accept(v)
+	}
+}
+
 // value represents a part of a parsed statement which is resolved to a value of some sort. This can be a telemetry path
 // mathExpression, function call, or literal.
 type value struct {
@@ -368,6 +398,9 @@ type mathExprLiteral struct {
 	Float     *float64   `parser:"| @Float"`
 	Int       *int64     `parser:"| @Int"`
 	Path      *path      `parser:"| @@ )"`
+	// Variable is not parsed, it replaces the Path referencing a lambda parameter once
+	// the lambda variables are resolved.
+	Variable *variable
 }
 
 func (m *mathExprLiteral) accept(v grammarVisitor) {
@@ -375,6 +408,11 @@ func (m *mathExprLiteral) accept(v grammarVisitor) {
 	if m.Path != nil {
 		m.Path.accept(v)
 	}
+	if m.Variable != nil {
+		for _, k := range m.Variable.Keys {
+			k.accept(v)
+		}
+	}
 	if m.Editor != nil {
 		m.Editor.accept(v)
 	}
@@ -383,6 +421,12 @@ func (m *mathExprLiteral) accept(v grammarVisitor) {
 	}
 }
 
+// variable represents a reference to a lambda parameter, such as `x` or `x["key"]`.
+type variable struct {
+	Name string
+	Keys []key
+}
+
 type mathValue struct {
 	UnaryOp       *mathOp          `parser:"@OpAddSub?"`
 	Literal       *mathExprLiteral `parser:"( @@"`
@@ -509,6 +553,7 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `OpNot`, Pattern: `\b(not)\b`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
+		{Name: `Arrow`, Pattern: `=>`},
 		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
 		{Name: `OpAddSub`, Pattern: `\+|\-`},
 		{Name: `OpMultDiv`, Pattern: `\/|\*`},
@@ -583,16 +628,42 @@ func (*grammarCustomErrorsVisitor) visitPath(*path) {}
 
 func (*grammarCustomErrorsVisitor) visitValue(*value) {}
 
-func (*grammarCustomErrorsVisitor) visitConverter(*converter) {}
+func (g *grammarCustomErrorsVisitor) visitConverter(v *converter) {
+	g.checkLambdaParams(v.Arguments)
+}
 
 func (g *grammarCustomErrorsVisitor) visitEditor(v *editor) {
 	if v.Keys != nil {
 		g.add(fmt.Errorf("only paths and converters may be indexed, not editors, but got %s%s", v.Function, buildOriginalKeysText(v.Keys)))
 	}
+	g.checkLambdaParams(v.Arguments)
+}
+
+func (g *grammarCustomErrorsVisitor) checkLambdaParams(arguments []argument) {
+	for _, arg := range arguments {
+		if arg.Lambda == nil {
+			continue
+		}
+		seen := make(map[string]struct{}, len(arg.Lambda.Params))
+		for _, param := range arg.Lambda.Params {
+			if _, ok := seen[param]; ok {
+				g.add(fmt.Errorf("duplicate lambda parameter %q", param))
+			}
+			seen[param] = struct{}{}
+		}
+	}
 }
 
 func (g *grammarCustomErrorsVisitor) visitMathExprLiteral(v *mathExprLiteral) {
 	if v.Editor != nil {
 		g.add(fmt.Errorf("converter names must start with an uppercase letter but got '%v'", v.Editor.Function))
 	}
+	if v.Variable != nil {
+		for _, k := range v.Variable.Keys {
+			if k.String == nil && k.Int == nil {
+				g.add(fmt.Errorf("lambda parameter %q can only be indexed by string or int literals", v.Variable.Name))
+				break
+			}
+		}
+	}
 }
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// LambdaGetter is a lambda expression passed as an argument to a function, such as `x => ToLowerCase(x)`.
+// Its body is evaluated with values bound to its parameters, which makes it possible to implement
+// higher-order functions operating on each element of a list.
+type LambdaGetter[K any] interface {
+	// NumParams returns the number of parameters of the lambda expression.
+	NumParams() int
+	// Call evaluates the lambda expression binding the given arguments to its parameters, in order.
+	Call(ctx context.Context, tCtx K, args ...any) (any, error)
+}
+
+// StandardLambdaGetter is a basic implementation of LambdaGetter.
+type StandardLambdaGetter[K any] struct {
+	// Params are the names of the lambda parameters.
+	Params []string
+	// Getter evaluates the lambda body. The parameters values are available through the context.Context.
+	Getter func(ctx context.Context, tCtx K) (any, error)
+}
+
+// NumParams returns the number of parameters of the lambda expression.
+func (l StandardLambdaGetter[K]) NumParams() int {
+	return len(l.Params)
+}
+
+// Call evaluates the lambda expression binding the given arguments to its parameters, in order.
+// If the number of arguments doesn't match the number of parameters, an error is returned.
+func (l StandardLambdaGetter[K]) Call(ctx context.Context, tCtx K, args ...any) (any, error) {
+	if len(args) != len(l.Params) {
+		return nil, fmt.Errorf("lambda expects %d arguments but got %d", len(l.Params), len(args))
+	}
+	for i, param := range l.Params {
+		ctx = context.WithValue(ctx, lambdaVariableKey(param), lambdaVariableValue{value: args[i]})
+	}
+	return l.Getter(ctx, tCtx)
+}
+
+type lambdaVariableKey string
+
+// lambdaVariableValue wraps the value bound to a lambda parameter, so a nil value can be
+// distinguished from an unbound parameter.
+type lambdaVariableValue struct {
+	value any
+}
+
+func (p *Parser[K]) newLambdaGetter(l *lambda) (LambdaGetter[K], error) {
+	if l.Condition != nil {
+		expr, err := p.newBoolExpr(l.Condition)
+		if err != nil {
+			return nil, err
+		}
+		return StandardLambdaGetter[K]{
+			Params: l.Params,
+			Getter: func(ctx context.Context, tCtx K) (any, error) {
+				return expr.Eval(ctx, tCtx)
+			},
+		}, nil
+	}
+	if l.Value == nil {
+		// In practice, can't happen since the DSL grammar guarantees one is set
+		return nil, errors.New("no lambda body set. This is a bug in the OpenTelemetry Transformation Language")
+	}
+	getter, err := p.newGetter(*l.Value)
+	if err != nil {
+		return nil, err
+	}
+	return StandardLambdaGetter[K]{
+		Params: l.Params,
+		Getter: getter.Get,
+	}, nil
+}
+
+func newVariableGetter[K any](v *variable) Getter[K] {
+	return &exprGetter[K]{
+		expr: Expr[K]{exprFunc: func(ctx context.Context, _ K) (any, error) {
+			bound, ok := ctx.Value(lambdaVariableKey(v.Name)).(lambdaVariableValue)
+			if !ok {
+				return nil, fmt.Errorf("lambda parameter %q is not bound", v.Name)
+			}
+			return bound.value, nil
+		}},
+		keys: v.Keys,
+	}
+}
+
+// lambdaVariablesResolver finds the lambda expressions of a parsed statement, condition or value,
+// and replaces the context-less paths referencing their parameters by variables.
+type lambdaVariablesResolver struct{}
+
+func resolveLambdaVariables(node interface{ accept(grammarVisitor) }) {
+	node.accept(&lambdaVariablesResolver{})
+}
+
+func (*lambdaVariablesResolver) visitPath(*path)                       {}
+func (*lambdaVariablesResolver) visitValue(*value)                     {}
+func (*lambdaVariablesResolver) visitMathExprLiteral(*mathExprLiteral) {}
+
+func (r *lambdaVariablesResolver) visitEditor(v *editor) {
+	r.resolve(v.Arguments)
+}
+
+func (r *lambdaVariablesResolver) visitConverter(v *converter) {
+	r.resolve(v.Arguments)
+}
+
+func (*lambdaVariablesResolver) resolve(arguments []argument) {
+	for _, arg := range arguments {
+		if arg.Lambda == nil {
+			continue
+		}
+		params := make(map[string]struct{}, len(arg.Lambda.Params))
+		for _, param := range arg.Lambda.Params {
+			params[param] = struct{}{}
+		}
+		// nested lambda expressions are visited as well, so their bodies can reference the outer
+		// parameters. Parameters shadowing outer ones are resolved to the innermost binding at runtime.
+		arg.Lambda.accept(&lambdaParamsVisitor{params: params})
+	}
+}
+
+// lambdaParamsVisitor replaces the paths referencing the given lambda parameters by variables.
+type lambdaParamsVisitor struct {
+	params map[string]struct{}
+}
+
+func (*lambdaParamsVisitor) visitPath(*path)           {}
+func (*lambdaParamsVisitor) visitValue(*value)         {}
+func (*lambdaParamsVisitor) visitEditor(*editor)       {}
+func (*lambdaParamsVisitor) visitConverter(*converter) {}
+
+func (v *lambdaParamsVisitor) visitMathExprLiteral(m *mathExprLiteral) {
+	if m.Path == nil || m.Path.Context != "" || len(m.Path.Fields) != 1 {
+		return
+	}
+	field := m.Path.Fields[0]
+	if _, ok := v.params[field.Name]; !ok {
+		return
+	}
+	m.Variable = &variable{Name: field.Name, Keys: field.Keys}
+	m.Path = nil
+}
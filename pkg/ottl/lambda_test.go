@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+type testApplyArguments struct {
+	Values   Getter[pcommon.Map]
+	Function LambdaGetter[pcommon.Map]
+}
+
+// testApplyFactory returns a converter calling the given lambda on each element of a list.
+func testApplyFactory() Factory[pcommon.Map] {
+	return NewFactory("Apply", &testApplyArguments{}, func(_ FunctionContext, args Arguments) (ExprFunc[pcommon.Map], error) {
+		a := args.(*testApplyArguments)
+		if a.Function.NumParams() != 1 {
+			return nil, fmt.Errorf("the function of Apply must have exactly one parameter, got %d", a.Function.NumParams())
+		}
+		return func(ctx context.Context, tCtx pcommon.Map) (any, error) {
+			val, err := a.Values.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			values, ok := val.([]any)
+			if !ok {
+				return nil, errors.New("values must be a list")
+			}
+			result := make([]any, 0, len(values))
+			for _, v := range values {
+				r, err := a.Function.Call(ctx, tCtx, v)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, r)
+			}
+			return result, nil
+		}, nil
+	})
+}
+
+func newTestLambdaParser(t *testing.T, definitions []FunctionDefinition, options ...Option[pcommon.Map]) Parser[pcommon.Map] {
+	t.Helper()
+	functions, err := AddFunctionDefinitions(CreateFactoryMap(testSetFactory(), testSuffixFactory(), testApplyFactory()), definitions)
+	require.NoError(t, err)
+	p, err := NewParser[pcommon.Map](functions, testAttributesPath, componenttest.NewNopTelemetrySettings(), options...)
+	require.NoError(t, err)
+	return p
+}
+
+func Test_parseStatement_lambda(t *testing.T) {
+	parsed, err := parseStatement(`set(attributes["a"], Apply(attributes["list"], x => Suffix(x["name"], attributes["suffix"])))`)
+	require.NoError(t, err)
+
+	resolveLambdaVariables(parsed)
+
+	apply := parsed.Editor.Arguments[1].Value.Literal.Converter
+	require.NotNil(t, apply)
+	lambda := apply.Arguments[1].Lambda
+	require.NotNil(t, lambda)
+	assert.Equal(t, []string{"x"}, lambda.Params)
+
+	suffix := lambda.Value.Literal.Converter
+	require.NotNil(t, suffix)
+	assert.Nil(t, suffix.Arguments[0].Value.Literal.Path)
+	assert.Equal(t, &variable{
+		Name: "x",
+		Keys: []key{{String: ottltest.Strp("name")}},
+	}, suffix.Arguments[0].Value.Literal.Variable)
+	assert.NotNil(t, suffix.Arguments[1].Value.Literal.Path)
+	assert.Nil(t, suffix.Arguments[1].Value.Literal.Variable)
+}
+
+func Test_Lambda(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      func(pcommon.Map)
+	}{
+		{
+			name:      "single parameter",
+			statement: `set(attributes["result"], Apply(attributes["list"], x => Suffix(x, "!")))`,
+			want: func(m pcommon.Map) {
+				s := m.PutEmptySlice("result")
+				s.AppendEmpty().SetStr("a!")
+				s.AppendEmpty().SetStr("b!")
+			},
+		},
+		{
+			name:      "parenthesized parameter",
+			statement: `set(attributes["result"], Apply(attributes["list"], (x) => x))`,
+			want: func(m pcommon.Map) {
+				s := m.PutEmptySlice("result")
+				s.AppendEmpty().SetStr("a")
+				s.AppendEmpty().SetStr("b")
+			},
+		},
+		{
+			name:      "condition body",
+			statement: `set(attributes["result"], Apply(attributes["list"], x => x != "a" and x == attributes["other"]))`,
+			want: func(m pcommon.Map) {
+				s := m.PutEmptySlice("result")
+				s.AppendEmpty().SetBool(false)
+				s.AppendEmpty().SetBool(true)
+			},
+		},
+		{
+			name:      "math expression body",
+			statement: `set(attributes["result"], Apply(attributes["numbers"], n => n * 2 + 1))`,
+			want: func(m pcommon.Map) {
+				s := m.PutEmptySlice("result")
+				s.AppendEmpty().SetInt(3)
+				s.AppendEmpty().SetInt(5)
+			},
+		},
+		{
+			name:      "indexed parameter",
+			statement: `set(attributes["result"], Apply(attributes["maps"], m => m["name"]))`,
+			want: func(m pcommon.Map) {
+				s := m.PutEmptySlice("result")
+				s.AppendEmpty().SetStr("first")
+				s.AppendEmpty().SetStr("second")
+			},
+		},
+		{
+			name:      "nested lambda using the outer parameter",
+			statement: `set(attributes["result"], Apply(attributes["list"], x => Apply(attributes["numbers"], n => Suffix(x, "-"))))`,
+			want: func(m pcommon.Map) {
+				s := m.PutEmptySlice("result")
+				a := s.AppendEmpty().SetEmptySlice()
+				a.AppendEmpty().SetStr("a-")
+				a.AppendEmpty().SetStr("a-")
+				b := s.AppendEmpty().SetEmptySlice()
+				b.AppendEmpty().SetStr("b-")
+				b.AppendEmpty().SetStr("b-")
+			},
+		},
+	}
+
+	p := newTestLambdaParser(t, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			tCtx := newTestLambdaMap()
+			_, _, err = statement.Execute(t.Context(), tCtx)
+			require.NoError(t, err)
+
+			expected := newTestLambdaMap()
+			tt.want(expected)
+			assert.Equal(t, expected.AsRaw(), tCtx.AsRaw())
+		})
+	}
+}
+
+func newTestLambdaMap() pcommon.Map {
+	m := pcommon.NewMap()
+	m.PutStr("other", "b")
+	list := m.PutEmptySlice("list")
+	list.AppendEmpty().SetStr("a")
+	list.AppendEmpty().SetStr("b")
+	numbers := m.PutEmptySlice("numbers")
+	numbers.AppendEmpty().SetInt(1)
+	numbers.AppendEmpty().SetInt(2)
+	maps := m.PutEmptySlice("maps")
+	maps.AppendEmpty().SetEmptyMap().PutStr("name", "first")
+	maps.AppendEmpty().SetEmptyMap().PutStr("name", "second")
+	return m
+}
+
+func Test_Lambda_ParseErrors(t *testing.T) {
+	definitions := []FunctionDefinition{
+		{
+			Name:       "Double",
+			Params:     []string{"x"},
+			Expression: `x * 2`,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		statement string
+		wantErr   string
+	}{
+		{
+			name:      "lambda passed to a non-lambda parameter",
+			statement: `set(attributes["result"], x => x)`,
+			wantErr:   "invalid argument at position 1: lambda expressions are not supported by this parameter",
+		},
+		{
+			name:      "non-lambda passed to a lambda parameter",
+			statement: `set(attributes["result"], Apply(attributes["list"], "x"))`,
+			wantErr:   "invalid argument at position 1: must be a lambda expression",
+		},
+		{
+			name:      "duplicate parameters",
+			statement: `set(attributes["result"], Apply(attributes["list"], (x, x) => x))`,
+			wantErr:   `duplicate lambda parameter "x"`,
+		},
+		{
+			name:      "parameter indexed by a path",
+			statement: `set(attributes["result"], Apply(attributes["list"], x => x[attributes["key"]]))`,
+			wantErr:   `lambda parameter "x" can only be indexed by string or int literals`,
+		},
+		{
+			name:      "lambda passed to a defined function",
+			statement: `set(attributes["result"], Double(x => x))`,
+			wantErr:   "invalid argument at position 0: lambda expressions cannot be passed to defined functions",
+		},
+		{
+			name:      "wrong number of parameters",
+			statement: `set(attributes["result"], Apply(attributes["list"], (x, y) => x))`,
+			wantErr:   "the function of Apply must have exactly one parameter, got 2",
+		},
+	}
+
+	p := newTestLambdaParser(t, definitions)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseStatement(tt.statement)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_Lambda_prependContextToStatementPaths(t *testing.T) {
+	p := newTestLambdaParser(t, nil, WithPathContextNames[pcommon.Map]([]string{"log"}))
+	got, err := p.prependContextToStatementPaths("log", `set(attributes["result"], Apply(attributes["list"], x => Suffix(x["name"], attributes["suffix"])))`)
+	require.NoError(t, err)
+	assert.Equal(t, `set(log.attributes["result"], Apply(log.attributes["list"], x => Suffix(x["name"], log.attributes["suffix"])))`, got)
+
+	got, err = p.prependContextToStatementPaths("log", `set(attributes["result"], Apply(attributes["list"], attributes => Suffix(attributes, log.attributes["suffix"])))`)
+	require.NoError(t, err)
+	assert.Equal(t, `set(log.attributes["result"], Apply(log.attributes["list"], attributes => Suffix(attributes, log.attributes["suffix"])))`, got)
+}
+
+func Test_StandardLambdaGetter_Call(t *testing.T) {
+	lambda := StandardLambdaGetter[any]{
+		Params: []string{"x", "y"},
+		Getter: newVariableGetter[any](&variable{Name: "y"}).Get,
+	}
+	assert.Equal(t, 2, lambda.NumParams())
+
+	got, err := lambda.Call(t.Context(), nil, "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, "b", got)
+
+	_, err = lambda.Call(t.Context(), nil, "a")
+	assert.EqualError(t, err, "lambda expects 2 arguments but got 1")
+
+	_, err = newVariableGetter[any](&variable{Name: "z"}).Get(t.Context(), nil)
+	assert.EqualError(t, err, `lambda parameter "z" is not bound`)
+}
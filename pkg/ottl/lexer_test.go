@@ -62,6 +62,13 @@ func Test_lexer(t *testing.T) {
 			{"OpComparison", "!="},
 			{"Float", "4.9"},
 		}},
+		{"lambda_arrow", "x => x>=1", false, []result{
+			{"Lowercase", "x"},
+			{"Arrow", "=>"},
+			{"Lowercase", "x"},
+			{"OpComparison", ">="},
+			{"Int", "1"},
+		}},
 		{"unambiguous_names", "foo bar BAZZ", false, []result{
 			{"Lowercase", "foo"},
 			{"Lowercase", "bar"},
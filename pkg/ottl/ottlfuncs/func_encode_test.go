@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func TestEncode(t *testing.T) {
+	testByteSlice := pcommon.NewByteSlice()
+	testByteSlice.FromRaw([]byte("hello world"))
+
+	testValue := pcommon.NewValueEmpty()
+	_ = testValue.FromRaw("hello world")
+
+	type testCase struct {
+		name          string
+		value         any
+		encoding      string
+		want          any
+		expectedError string
+	}
+	tests := []testCase{
+		{
+			name:     "encode string as base64",
+			value:    "hello world",
+			encoding: "base64",
+			want:     "aGVsbG8gd29ybGQ=",
+		},
+		{
+			name:     "encode byte array as base64",
+			value:    []byte("test\n"),
+			encoding: "base64",
+			want:     "dGVzdAo=",
+		},
+		{
+			name:     "encode ByteSlice as base64",
+			value:    testByteSlice,
+			encoding: "base64",
+			want:     "aGVsbG8gd29ybGQ=",
+		},
+		{
+			name:     "encode ByteSlice pointer as base64",
+			value:    &testByteSlice,
+			encoding: "base64",
+			want:     "aGVsbG8gd29ybGQ=",
+		},
+		{
+			name:     "encode Value as base64",
+			value:    testValue,
+			encoding: "base64",
+			want:     "aGVsbG8gd29ybGQ=",
+		},
+		{
+			name:     "encode Value pointer as base64",
+			value:    &testValue,
+			encoding: "base64",
+			want:     "aGVsbG8gd29ybGQ=",
+		},
+		{
+			name:     "base64 with url-safe sensitive characters",
+			value:    "Go?/Z~x",
+			encoding: "base64",
+			want:     "R28/L1p+eA==",
+		},
+		{
+			name:     "base64-raw with url-safe sensitive characters",
+			value:    "Go?/Z~x",
+			encoding: "base64-raw",
+			want:     "R28/L1p+eA",
+		},
+		{
+			name:     "base64-url with url-safe sensitive characters",
+			value:    "Go?/Z~x",
+			encoding: "base64-url",
+			want:     "R28_L1p-eA==",
+		},
+		{
+			name:     "base64-raw-url with url-safe sensitive characters",
+			value:    "Go?/Z~x",
+			encoding: "base64-raw-url",
+			want:     "R28_L1p-eA",
+		},
+		{
+			name:     "encode us-ascii string",
+			value:    "test string",
+			encoding: "us-ascii",
+			want:     []byte("test string"),
+		},
+		{
+			name:     "encode ISO-8859-1 string",
+			value:    "café",
+			encoding: "ISO-8859-1",
+			want:     []byte{99, 97, 102, 233},
+		},
+		{
+			name:     "encode UTF-16 string",
+			value:    "test string",
+			encoding: "UTF-16",
+			want:     []byte{116, 0, 101, 0, 115, 0, 116, 0, 32, 0, 115, 0, 116, 0, 114, 0, 105, 0, 110, 0, 103, 0},
+		},
+		{
+			name:          "character not representable in ISO-8859-1",
+			value:         "日本",
+			encoding:      "ISO-8859-1",
+			expectedError: "could not encode",
+		},
+		{
+			name:          "encode GB2312 string; no encoder available",
+			value:         "test string",
+			encoding:      "GB2312",
+			expectedError: "no charmap defined for encoding 'GB2312'",
+		},
+		{
+			name:          "unknown encoding",
+			value:         "test string",
+			encoding:      "invalid",
+			expectedError: "unsupported encoding 'invalid'",
+		},
+		{
+			name:          "non-string",
+			value:         10,
+			encoding:      "base64",
+			expectedError: "unsupported type provided to Encode function: int",
+		},
+		{
+			name:          "nil",
+			value:         nil,
+			encoding:      "base64",
+			expectedError: "unsupported type provided to Encode function: <nil>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expressionFunc, err := createEncodeFunction[any](ottl.FunctionContext{}, &EncodeArguments[any]{
+				Target: &ottl.StandardGetSetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.value, nil
+					},
+				},
+				Encoding: ottl.StandardStringGetter[any]{
+					Getter: func(_ context.Context, _ any) (any, error) {
+						return tt.encoding, nil
+					},
+				},
+			})
+
+			require.NoError(t, err)
+
+			result, err := expressionFunc(nil, nil)
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, result)
+		})
+	}
+}
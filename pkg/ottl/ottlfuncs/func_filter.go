@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type FilterArguments[K any] struct {
+	Target   ottl.PSliceGetter[K]
+	Function ottl.LambdaGetter[K]
+}
+
+func NewFilterFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Filter", &FilterArguments[K]{}, createFilterFunction[K])
+}
+
+func createFilterFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*FilterArguments[K])
+	if !ok {
+		return nil, errors.New("FilterFactory args must be of type *FilterArguments[K]")
+	}
+
+	return filterSlice(args.Target, args.Function)
+}
+
+func filterSlice[K any](target ottl.PSliceGetter[K], function ottl.LambdaGetter[K]) (ottl.ExprFunc[K], error) {
+	if function.NumParams() != 1 {
+		return nil, fmt.Errorf("the function of Filter must have exactly one parameter, got %d", function.NumParams())
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		list, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewSlice()
+		for i, elem := range list.All() {
+			val, err := function.Call(ctx, tCtx, ottlcommon.GetValue(elem))
+			if err != nil {
+				return nil, fmt.Errorf("failed to filter the element at index %d: %w", i, err)
+			}
+			keep, ok := val.(bool)
+			if !ok {
+				return nil, fmt.Errorf("the function of Filter must return a bool, got %T for the element at index %d", val, i)
+			}
+			if keep {
+				elem.CopyTo(result.AppendEmpty())
+			}
+		}
+		return result, nil
+	}, nil
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func Test_filterSlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []any
+		function testLambda
+		expected []any
+	}{
+		{
+			name:   "strings",
+			values: []any{"a", "", "b"},
+			function: testLambda{params: 1, fn: func(args ...any) (any, error) {
+				return args[0] != "", nil
+			}},
+			expected: []any{"a", "b"},
+		},
+		{
+			name:   "maps",
+			values: []any{map[string]any{"keep": true}, map[string]any{"keep": false}},
+			function: testLambda{params: 1, fn: func(args ...any) (any, error) {
+				v, _ := args[0].(pcommon.Map).Get("keep")
+				return v.Bool(), nil
+			}},
+			expected: []any{map[string]any{"keep": true}},
+		},
+		{
+			name:   "none kept",
+			values: []any{int64(1), int64(2)},
+			function: testLambda{params: 1, fn: func(...any) (any, error) {
+				return false, nil
+			}},
+			expected: []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := filterSlice[any](testSliceGetter(tt.values...), tt.function)
+			require.NoError(t, err)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(pcommon.Slice).AsRaw())
+		})
+	}
+}
+
+func Test_filterSlice_error(t *testing.T) {
+	_, err := filterSlice[any](testSliceGetter("a"), testLambda{params: 0})
+	assert.EqualError(t, err, "the function of Filter must have exactly one parameter, got 0")
+
+	exprFunc, err := filterSlice[any](testSliceGetter("a"), testLambda{params: 1, fn: func(...any) (any, error) {
+		return nil, errors.New("failed")
+	}})
+	require.NoError(t, err)
+	_, err = exprFunc(t.Context(), nil)
+	assert.EqualError(t, err, "failed to filter the element at index 0: failed")
+
+	exprFunc, err = filterSlice[any](testSliceGetter("a"), testLambda{params: 1, fn: func(...any) (any, error) {
+		return "true", nil
+	}})
+	require.NoError(t, err)
+	_, err = exprFunc(t.Context(), nil)
+	assert.EqualError(t, err, "the function of Filter must return a bool, got string for the element at index 0")
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type MapArguments[K any] struct {
+	Target   ottl.PSliceGetter[K]
+	Function ottl.LambdaGetter[K]
+}
+
+func NewMapFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Map", &MapArguments[K]{}, createMapFunction[K])
+}
+
+func createMapFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*MapArguments[K])
+	if !ok {
+		return nil, errors.New("MapFactory args must be of type *MapArguments[K]")
+	}
+
+	return mapSlice(args.Target, args.Function)
+}
+
+func mapSlice[K any](target ottl.PSliceGetter[K], function ottl.LambdaGetter[K]) (ottl.ExprFunc[K], error) {
+	if function.NumParams() != 1 {
+		return nil, fmt.Errorf("the function of Map must have exactly one parameter, got %d", function.NumParams())
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		list, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewSlice()
+		result.EnsureCapacity(list.Len())
+		for i, elem := range list.All() {
+			val, err := function.Call(ctx, tCtx, ottlcommon.GetValue(elem))
+			if err != nil {
+				return nil, fmt.Errorf("failed to map the element at index %d: %w", i, err)
+			}
+			if err := setValue(result.AppendEmpty(), val); err != nil {
+				return nil, fmt.Errorf("failed to map the element at index %d: %w", i, err)
+			}
+		}
+		return result, nil
+	}, nil
+}
+
+// setValue sets the given value returned by a getter into dest.
+func setValue(dest pcommon.Value, val any) error {
+	switch v := val.(type) {
+	case pcommon.Value:
+		v.CopyTo(dest)
+	case pcommon.Map:
+		v.CopyTo(dest.SetEmptyMap())
+	case pcommon.Slice:
+		v.CopyTo(dest.SetEmptySlice())
+	case []string:
+		s := dest.SetEmptySlice()
+		s.EnsureCapacity(len(v))
+		for _, str := range v {
+			s.AppendEmpty().SetStr(str)
+		}
+	default:
+		return dest.FromRaw(v)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// testLambda is an ottl.LambdaGetter calling fn with the arguments passed to the lambda.
+type testLambda struct {
+	params int
+	fn     func(args ...any) (any, error)
+}
+
+func (l testLambda) NumParams() int {
+	return l.params
+}
+
+func (l testLambda) Call(_ context.Context, _ any, args ...any) (any, error) {
+	return l.fn(args...)
+}
+
+func testSliceGetter(values ...any) ottl.StandardPSliceGetter[any] {
+	return ottl.StandardPSliceGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			s := pcommon.NewSlice()
+			err := s.FromRaw(values)
+			return s, err
+		},
+	}
+}
+
+func Test_mapSlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   ottl.PSliceGetter[any]
+		function testLambda
+		expected []any
+	}{
+		{
+			name:   "strings",
+			target: testSliceGetter("A", "B"),
+			function: testLambda{params: 1, fn: func(args ...any) (any, error) {
+				return strings.ToLower(args[0].(string)), nil
+			}},
+			expected: []any{"a", "b"},
+		},
+		{
+			name:   "maps to values",
+			target: testSliceGetter(map[string]any{"name": "first"}, map[string]any{"name": "second"}),
+			function: testLambda{params: 1, fn: func(args ...any) (any, error) {
+				v, _ := args[0].(pcommon.Map).Get("name")
+				return v, nil
+			}},
+			expected: []any{"first", "second"},
+		},
+		{
+			name:   "values to maps",
+			target: testSliceGetter(int64(1), int64(2)),
+			function: testLambda{params: 1, fn: func(args ...any) (any, error) {
+				m := pcommon.NewMap()
+				m.PutInt("value", args[0].(int64))
+				return m, nil
+			}},
+			expected: []any{map[string]any{"value": int64(1)}, map[string]any{"value": int64(2)}},
+		},
+		{
+			name:   "string slices",
+			target: testSliceGetter("a,b", "c"),
+			function: testLambda{params: 1, fn: func(args ...any) (any, error) {
+				return strings.Split(args[0].(string), ","), nil
+			}},
+			expected: []any{[]any{"a", "b"}, []any{"c"}},
+		},
+		{
+			name:   "empty",
+			target: testSliceGetter(),
+			function: testLambda{params: 1, fn: func(...any) (any, error) {
+				return nil, errors.New("must not be called")
+			}},
+			expected: []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := mapSlice[any](tt.target, tt.function)
+			require.NoError(t, err)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.(pcommon.Slice).AsRaw())
+		})
+	}
+}
+
+func Test_mapSlice_error(t *testing.T) {
+	_, err := mapSlice[any](testSliceGetter("a"), testLambda{params: 2})
+	assert.EqualError(t, err, "the function of Map must have exactly one parameter, got 2")
+
+	exprFunc, err := mapSlice[any](testSliceGetter("a"), testLambda{params: 1, fn: func(...any) (any, error) {
+		return nil, errors.New("failed")
+	}})
+	require.NoError(t, err)
+	_, err = exprFunc(t.Context(), nil)
+	assert.EqualError(t, err, "failed to map the element at index 0: failed")
+
+	exprFunc, err = mapSlice[any](testSliceGetter("a"), testLambda{params: 1, fn: func(...any) (any, error) {
+		return struct{}{}, nil
+	}})
+	require.NoError(t, err)
+	_, err = exprFunc(t.Context(), nil)
+	assert.ErrorContains(t, err, "failed to map the element at index 0")
+}
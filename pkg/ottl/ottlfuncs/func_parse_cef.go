@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	cefPrefix      = "CEF:"
+	cefHeaderCount = 7
+)
+
+var cefHeaderKeys = [cefHeaderCount]string{
+	"version",
+	"device_vendor",
+	"device_product",
+	"device_version",
+	"device_event_class_id",
+	"name",
+	"severity",
+}
+
+type ParseCEFArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewParseCEFFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseCEF", &ParseCEFArguments[K]{}, createParseCEFFunction[K])
+}
+
+func createParseCEFFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseCEFArguments[K])
+	if !ok {
+		return nil, errors.New("ParseCEFFactory args must be of type *ParseCEFArguments[K]")
+	}
+
+	return parseCEF(args.Target), nil
+}
+
+func parseCEF[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if source == "" {
+			return nil, errors.New("cannot parse from empty target")
+		}
+
+		// CEF messages are usually prefixed by a syslog header, which is ignored.
+		start := strings.Index(source, cefPrefix)
+		if start == -1 {
+			return nil, fmt.Errorf("target is not a CEF message, %q prefix not found", cefPrefix)
+		}
+
+		headers, extension, err := splitEscapedHeaders(source[start+len(cefPrefix):], cefHeaderCount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CEF header: %w", err)
+		}
+
+		version, err := strconv.ParseInt(strings.TrimSpace(headers[0]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CEF version %q", headers[0])
+		}
+
+		extensions, err := parseCEFExtension(extension)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CEF extension: %w", err)
+		}
+
+		result := pcommon.NewMap()
+		result.PutInt(cefHeaderKeys[0], version)
+		for i := 1; i < cefHeaderCount; i++ {
+			result.PutStr(cefHeaderKeys[i], headers[i])
+		}
+		ext := result.PutEmptyMap("extensions")
+		for _, pair := range extensions {
+			ext.PutStr(pair[0], pair[1])
+		}
+		return result, nil
+	}
+}
+
+// splitEscapedHeaders splits the first count pipe-delimited header fields of source, unescaping
+// `\|` and `\\` within them. The remainder after the last delimiter is returned as-is.
+func splitEscapedHeaders(source string, count int) ([]string, string, error) {
+	headers := make([]string, 0, count)
+	var current strings.Builder
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case c == '\\' && i+1 < len(source) && (source[i+1] == '|' || source[i+1] == '\\'):
+			current.WriteByte(source[i+1])
+			i++
+		case c == '|':
+			headers = append(headers, current.String())
+			current.Reset()
+			if len(headers) == count {
+				return headers, source[i+1:], nil
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+	return nil, "", fmt.Errorf("expected %d header fields, got %d", count, len(headers))
+}
+
+// parseCEFExtension parses the space separated key=value pairs of a CEF extension.
+// Values may contain spaces, so a value ends where the next key begins. Within values,
+// `\=`, `\\`, `\n` and `\r` are unescaped.
+func parseCEFExtension(extension string) ([][2]string, error) {
+	extension = strings.TrimSpace(extension)
+	if extension == "" {
+		return nil, nil
+	}
+
+	type keyPos struct {
+		start, eq int
+	}
+	var keys []keyPos
+	for i := 0; i < len(extension); i++ {
+		switch extension[i] {
+		case '\\':
+			// skip the escaped character
+			i++
+		case '=':
+			start := i
+			for start > 0 && isCEFKeyChar(extension[start-1]) {
+				start--
+			}
+			if start == i || (start > 0 && extension[start-1] != ' ') {
+				// not preceded by a key, the equal sign is part of the value
+				continue
+			}
+			if len(keys) == 0 && start != 0 {
+				return nil, fmt.Errorf("unexpected value %q before the first key", extension[:start])
+			}
+			keys = append(keys, keyPos{start: start, eq: i})
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no key-value pairs found in %q", extension)
+	}
+
+	pairs := make([][2]string, 0, len(keys))
+	for i, k := range keys {
+		end := len(extension)
+		if i+1 < len(keys) {
+			end = keys[i+1].start
+		}
+		value := strings.TrimRight(extension[k.eq+1:end], " ")
+		pairs = append(pairs, [2]string{extension[k.start:k.eq], unescapeCEFValue(value)})
+	}
+	return pairs, nil
+}
+
+func isCEFKeyChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '_' || c == '.' || c == '-' || c == '[' || c == ']'
+}
+
+func unescapeCEFValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var sb strings.Builder
+	sb.Grow(len(value))
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c != '\\' || i+1 == len(value) {
+			sb.WriteByte(c)
+			continue
+		}
+		switch value[i+1] {
+		case '=', '\\':
+			sb.WriteByte(value[i+1])
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		default:
+			// unknown escape sequences are kept as they are
+			sb.WriteByte(c)
+			sb.WriteByte(value[i+1])
+		}
+		i++
+	}
+	return sb.String()
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseCEF(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected map[string]any
+	}{
+		{
+			name:   "header and extensions",
+			target: `CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232`,
+			expected: map[string]any{
+				"version":               int64(0),
+				"device_vendor":         "Security",
+				"device_product":        "threatmanager",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "worm successfully stopped",
+				"severity":              "10",
+				"extensions": map[string]any{
+					"src": "10.0.0.1",
+					"dst": "2.1.2.2",
+					"spt": "1232",
+				},
+			},
+		},
+		{
+			name:   "syslog prefix",
+			target: `Sep 19 08:26:10 host CEF:1|Vendor|Product|2.0|signature|Name|High|act=blocked`,
+			expected: map[string]any{
+				"version":               int64(1),
+				"device_vendor":         "Vendor",
+				"device_product":        "Product",
+				"device_version":        "2.0",
+				"device_event_class_id": "signature",
+				"name":                  "Name",
+				"severity":              "High",
+				"extensions": map[string]any{
+					"act": "blocked",
+				},
+			},
+		},
+		{
+			name:   "escaped header",
+			target: `CEF:0|security|threat\|manager|1.0|100|detected a \\ in packet|10|`,
+			expected: map[string]any{
+				"version":               int64(0),
+				"device_vendor":         "security",
+				"device_product":        "threat|manager",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  `detected a \ in packet`,
+				"severity":              "10",
+				"extensions":            map[string]any{},
+			},
+		},
+		{
+			name:   "extension values with spaces and escapes",
+			target: `CEF:0|Vendor|Product|1.0|100|Name|5|msg=Detected a threat. No action needed cs1Label=a\=b file\\path fname=line1\nline2 request=https://example.com/?a=b|c`,
+			expected: map[string]any{
+				"version":               int64(0),
+				"device_vendor":         "Vendor",
+				"device_product":        "Product",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "Name",
+				"severity":              "5",
+				"extensions": map[string]any{
+					"msg":      "Detected a threat. No action needed",
+					"cs1Label": `a=b file\path`,
+					"fname":    "line1\nline2",
+					"request":  "https://example.com/?a=b|c",
+				},
+			},
+		},
+		{
+			name:   "empty extension value",
+			target: `CEF:0|Vendor|Product|1.0|100|Name|5|suser= duser=admin`,
+			expected: map[string]any{
+				"version":               int64(0),
+				"device_vendor":         "Vendor",
+				"device_product":        "Product",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "Name",
+				"severity":              "5",
+				"extensions": map[string]any{
+					"suser": "",
+					"duser": "admin",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseCEF[any](ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			})
+
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+
+			actual, ok := result.(pcommon.Map)
+			require.True(t, ok)
+
+			expected := pcommon.NewMap()
+			require.NoError(t, expected.FromRaw(tt.expected))
+			assert.Equal(t, expected.AsRaw(), actual.AsRaw())
+		})
+	}
+}
+
+func Test_parseCEF_error(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		expectedError string
+	}{
+		{
+			name:          "empty target",
+			target:        "",
+			expectedError: "cannot parse from empty target",
+		},
+		{
+			name:          "missing prefix",
+			target:        "LEEF:1.0|Vendor|Product|1.0|100|src=10.0.0.1",
+			expectedError: `target is not a CEF message, "CEF:" prefix not found`,
+		},
+		{
+			name:          "missing header fields",
+			target:        "CEF:0|Vendor|Product|1.0|100",
+			expectedError: "invalid CEF header: expected 7 header fields, got 4",
+		},
+		{
+			name:          "invalid version",
+			target:        "CEF:x|Vendor|Product|1.0|100|Name|5|",
+			expectedError: `invalid CEF version "x"`,
+		},
+		{
+			name:          "extension without keys",
+			target:        "CEF:0|Vendor|Product|1.0|100|Name|5|not an extension",
+			expectedError: `invalid CEF extension: no key-value pairs found in "not an extension"`,
+		},
+		{
+			name:          "extension starting with a value",
+			target:        "CEF:0|Vendor|Product|1.0|100|Name|5|value src=10.0.0.1",
+			expectedError: `invalid CEF extension: unexpected value "value " before the first key`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseCEF[any](ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			})
+
+			_, err := exprFunc(t.Context(), nil)
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
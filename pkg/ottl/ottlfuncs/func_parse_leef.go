@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	leefPrefix           = "LEEF:"
+	leefHeaderCount      = 5
+	leefDefaultDelimiter = "\t"
+)
+
+var leefHeaderKeys = [leefHeaderCount]string{
+	"version",
+	"vendor",
+	"product",
+	"product_version",
+	"event_id",
+}
+
+type ParseLEEFArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewParseLEEFFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseLEEF", &ParseLEEFArguments[K]{}, createParseLEEFFunction[K])
+}
+
+func createParseLEEFFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseLEEFArguments[K])
+	if !ok {
+		return nil, errors.New("ParseLEEFFactory args must be of type *ParseLEEFArguments[K]")
+	}
+
+	return parseLEEF(args.Target), nil
+}
+
+func parseLEEF[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if source == "" {
+			return nil, errors.New("cannot parse from empty target")
+		}
+
+		// LEEF messages are usually prefixed by a syslog header, which is ignored.
+		start := strings.Index(source, leefPrefix)
+		if start == -1 {
+			return nil, fmt.Errorf("target is not a LEEF message, %q prefix not found", leefPrefix)
+		}
+
+		headers, attributes, err := splitEscapedHeaders(source[start+len(leefPrefix):], leefHeaderCount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LEEF header: %w", err)
+		}
+
+		delimiter := leefDefaultDelimiter
+		switch version := strings.TrimSpace(headers[0]); version {
+		case "1.0":
+		case "2.0":
+			// LEEF 2.0 has an optional header field defining the attributes delimiter
+			if field, rest, found := strings.Cut(attributes, "|"); found {
+				if d, ok := parseLEEFDelimiter(field); ok {
+					delimiter = d
+					attributes = rest
+				}
+			}
+		default:
+			return nil, fmt.Errorf("unsupported LEEF version %q", version)
+		}
+
+		parsed, err := parseLEEFAttributes(attributes, delimiter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LEEF attributes: %w", err)
+		}
+
+		result := pcommon.NewMap()
+		result.PutStr(leefHeaderKeys[0], strings.TrimSpace(headers[0]))
+		for i := 1; i < leefHeaderCount; i++ {
+			result.PutStr(leefHeaderKeys[i], headers[i])
+		}
+		attrs := result.PutEmptyMap("attributes")
+		for _, pair := range parsed {
+			attrs.PutStr(pair[0], pair[1])
+		}
+		return result, nil
+	}
+}
+
+// parseLEEFDelimiter parses the delimiter header field of LEEF 2.0, which is either
+// empty, a single character, or a hex value prefixed by `x` or `0x`.
+func parseLEEFDelimiter(field string) (string, bool) {
+	switch {
+	case field == "":
+		return leefDefaultDelimiter, true
+	case len(field) == 1:
+		return field, true
+	}
+	hex, ok := strings.CutPrefix(strings.ToLower(field), "0x")
+	if !ok {
+		hex, ok = strings.CutPrefix(strings.ToLower(field), "x")
+	}
+	if !ok || len(hex) == 0 || len(hex) > 4 {
+		return "", false
+	}
+	code, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || code == 0 {
+		return "", false
+	}
+	return string(rune(code)), true
+}
+
+// parseLEEFAttributes parses delimiter separated key=value pairs. Values may contain
+// the delimiter, so a segment without an equal sign is joined to the preceding value.
+func parseLEEFAttributes(attributes, delimiter string) ([][2]string, error) {
+	var pairs [][2]string
+	for _, segment := range strings.Split(attributes, delimiter) {
+		key, value, found := strings.Cut(segment, "=")
+		if !found || key == "" || strings.ContainsAny(key, " \t") {
+			if strings.TrimSpace(segment) == "" {
+				continue
+			}
+			if len(pairs) == 0 {
+				return nil, fmt.Errorf("unexpected value %q before the first key", segment)
+			}
+			pairs[len(pairs)-1][1] += delimiter + segment
+			continue
+		}
+		pairs = append(pairs, [2]string{key, value})
+	}
+	return pairs, nil
+}
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseLEEF(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected map[string]any
+	}{
+		{
+			name:   "LEEF 1.0",
+			target: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tusrName=joe.black",
+			expected: map[string]any{
+				"version":         "1.0",
+				"vendor":          "Microsoft",
+				"product":         "MSExchange",
+				"product_version": "4.0 SP1",
+				"event_id":        "15345",
+				"attributes": map[string]any{
+					"src":     "192.0.2.0",
+					"dst":     "172.50.123.1",
+					"sev":     "5",
+					"usrName": "joe.black",
+				},
+			},
+		},
+		{
+			name:   "LEEF 2.0 with character delimiter and syslog prefix",
+			target: "Jan 18 11:07:53 host LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=192.0.2.0^dst=172.50.123.1^msg=a=b",
+			expected: map[string]any{
+				"version":         "2.0",
+				"vendor":          "Lancope",
+				"product":         "StealthWatch",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"attributes": map[string]any{
+					"src": "192.0.2.0",
+					"dst": "172.50.123.1",
+					"msg": "a=b",
+				},
+			},
+		},
+		{
+			name:   "LEEF 2.0 with hex delimiter",
+			target: "LEEF:2.0|Vendor|Product|1.0|42|0x7c|src=192.0.2.0|dst=172.50.123.1",
+			expected: map[string]any{
+				"version":         "2.0",
+				"vendor":          "Vendor",
+				"product":         "Product",
+				"product_version": "1.0",
+				"event_id":        "42",
+				"attributes": map[string]any{
+					"src": "192.0.2.0",
+					"dst": "172.50.123.1",
+				},
+			},
+		},
+		{
+			name:   "LEEF 2.0 with short hex delimiter",
+			target: "LEEF:2.0|Vendor|Product|1.0|42|x5E|src=192.0.2.0^dst=172.50.123.1",
+			expected: map[string]any{
+				"version":         "2.0",
+				"vendor":          "Vendor",
+				"product":         "Product",
+				"product_version": "1.0",
+				"event_id":        "42",
+				"attributes": map[string]any{
+					"src": "192.0.2.0",
+					"dst": "172.50.123.1",
+				},
+			},
+		},
+		{
+			name:   "LEEF 2.0 without delimiter field",
+			target: "LEEF:2.0|Vendor|Product|1.0|42|src=192.0.2.0\tdst=172.50.123.1",
+			expected: map[string]any{
+				"version":         "2.0",
+				"vendor":          "Vendor",
+				"product":         "Product",
+				"product_version": "1.0",
+				"event_id":        "42",
+				"attributes": map[string]any{
+					"src": "192.0.2.0",
+					"dst": "172.50.123.1",
+				},
+			},
+		},
+		{
+			name:   "space delimiter with values containing spaces",
+			target: "LEEF:2.0|Vendor|Prod\\|uct|1.0|42| |src=192.0.2.0 msg=user logged in cat=auth",
+			expected: map[string]any{
+				"version":         "2.0",
+				"vendor":          "Vendor",
+				"product":         "Prod|uct",
+				"product_version": "1.0",
+				"event_id":        "42",
+				"attributes": map[string]any{
+					"src": "192.0.2.0",
+					"msg": "user logged in",
+					"cat": "auth",
+				},
+			},
+		},
+		{
+			name:   "no attributes",
+			target: "LEEF:1.0|Vendor|Product|1.0|42|",
+			expected: map[string]any{
+				"version":         "1.0",
+				"vendor":          "Vendor",
+				"product":         "Product",
+				"product_version": "1.0",
+				"event_id":        "42",
+				"attributes":      map[string]any{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseLEEF[any](ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			})
+
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+
+			actual, ok := result.(pcommon.Map)
+			require.True(t, ok)
+
+			expected := pcommon.NewMap()
+			require.NoError(t, expected.FromRaw(tt.expected))
+			assert.Equal(t, expected.AsRaw(), actual.AsRaw())
+		})
+	}
+}
+
+func Test_parseLEEF_error(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		expectedError string
+	}{
+		{
+			name:          "empty target",
+			target:        "",
+			expectedError: "cannot parse from empty target",
+		},
+		{
+			name:          "missing prefix",
+			target:        "CEF:0|Vendor|Product|1.0|100|Name|5|",
+			expectedError: `target is not a LEEF message, "LEEF:" prefix not found`,
+		},
+		{
+			name:          "missing header fields",
+			target:        "LEEF:1.0|Vendor|Product",
+			expectedError: "invalid LEEF header: expected 5 header fields, got 2",
+		},
+		{
+			name:          "unsupported version",
+			target:        "LEEF:3.0|Vendor|Product|1.0|42|src=192.0.2.0",
+			expectedError: `unsupported LEEF version "3.0"`,
+		},
+		{
+			name:          "attributes starting with a value",
+			target:        "LEEF:1.0|Vendor|Product|1.0|42|value\tsrc=192.0.2.0",
+			expectedError: `invalid LEEF attributes: unexpected value "value" before the first key`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseLEEF[any](ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			})
+
+			_, err := exprFunc(t.Context(), nil)
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	sl "github.com/leodido/go-syslog/v4"
+	"github.com/leodido/go-syslog/v4/rfc3164"
+	"github.com/leodido/go-syslog/v4/rfc5424"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	syslogProtocolRFC3164 = "rfc3164"
+	syslogProtocolRFC5424 = "rfc5424"
+)
+
+var (
+	syslogPriRegex     = regexp.MustCompile(`^<\d{1,3}>`)
+	syslogRFC5424Regex = regexp.MustCompile(`^<\d{1,3}>\d{1,2} `)
+)
+
+type ParseSyslogArguments[K any] struct {
+	Target   ottl.StringGetter[K]
+	Protocol ottl.Optional[string]
+	Location ottl.Optional[string]
+}
+
+func NewParseSyslogFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseSyslog", &ParseSyslogArguments[K]{}, createParseSyslogFunction[K])
+}
+
+func createParseSyslogFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseSyslogArguments[K])
+	if !ok {
+		return nil, errors.New("ParseSyslogFactory args must be of type *ParseSyslogArguments[K]")
+	}
+
+	return parseSyslog(args.Target, args.Protocol, args.Location)
+}
+
+func parseSyslog[K any](target ottl.StringGetter[K], p, l ottl.Optional[string]) (ottl.ExprFunc[K], error) {
+	protocol := ""
+	if !p.IsEmpty() {
+		protocol = p.Get()
+		if protocol != syslogProtocolRFC3164 && protocol != syslogProtocolRFC5424 {
+			return nil, fmt.Errorf("unsupported syslog protocol %q, must be one of %q or %q", protocol, syslogProtocolRFC3164, syslogProtocolRFC5424)
+		}
+	}
+
+	location := time.UTC
+	if !l.IsEmpty() {
+		loc, err := time.LoadLocation(l.Get())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load location %q: %w", l.Get(), err)
+		}
+		location = loc
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if source == "" {
+			return nil, errors.New("cannot parse from empty target")
+		}
+
+		// messages embedded in other payloads frequently lack the PRI header,
+		// in which case a placeholder is added and the priority values are omitted.
+		input := source
+		hasPri := syslogPriRegex.MatchString(source)
+		if !hasPri {
+			input = "<0>" + source
+		}
+
+		msgProtocol := protocol
+		if msgProtocol == "" {
+			msgProtocol = syslogProtocolRFC3164
+			if syslogRFC5424Regex.MatchString(input) {
+				msgProtocol = syslogProtocolRFC5424
+			}
+		}
+
+		var machine sl.Machine
+		if msgProtocol == syslogProtocolRFC5424 {
+			machine = rfc5424.NewMachine()
+		} else {
+			machine = rfc3164.NewMachine(rfc3164.WithYear(rfc3164.CurrentYear{}), rfc3164.WithLocaleTimezone(location))
+		}
+
+		message, err := machine.Parse([]byte(input))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s syslog message: %w", msgProtocol, err)
+		}
+
+		result := pcommon.NewMap()
+		switch m := message.(type) {
+		case *rfc3164.SyslogMessage:
+			putSyslogBase(result, &m.Base, hasPri)
+		case *rfc5424.SyslogMessage:
+			putSyslogBase(result, &m.Base, hasPri)
+			result.PutInt("version", int64(m.Version))
+			if m.StructuredData != nil {
+				structuredData := result.PutEmptyMap("structured_data")
+				for id, params := range *m.StructuredData {
+					element := structuredData.PutEmptyMap(id)
+					for name, value := range params {
+						element.PutStr(name, value)
+					}
+				}
+			}
+		default:
+			return nil, errors.New("parsed value was not rfc3164 or rfc5424 compliant")
+		}
+		return result, nil
+	}, nil
+}
+
+// putSyslogBase adds the fields shared by RFC3164 and RFC5424 messages to the given map,
+// using the same names as the syslog parser of pkg/stanza.
+func putSyslogBase(dest pcommon.Map, base *sl.Base, withPriority bool) {
+	if base.Timestamp != nil {
+		dest.PutInt("timestamp", base.Timestamp.UnixNano())
+	}
+	putSyslogString(dest, "hostname", base.Hostname)
+	putSyslogString(dest, "appname", base.Appname)
+	putSyslogString(dest, "proc_id", base.ProcID)
+	putSyslogString(dest, "msg_id", base.MsgID)
+	putSyslogString(dest, "message", base.Message)
+	if withPriority {
+		putSyslogInt(dest, "priority", base.Priority)
+		putSyslogInt(dest, "severity", base.Severity)
+		putSyslogInt(dest, "facility", base.Facility)
+	}
+}
+
+func putSyslogString(dest pcommon.Map, key string, value *string) {
+	if value != nil {
+		dest.PutStr(key, *value)
+	}
+}
+
+func putSyslogInt(dest pcommon.Map, key string, value *uint8) {
+	if value != nil {
+		dest.PutInt(key, int64(*value))
+	}
+}
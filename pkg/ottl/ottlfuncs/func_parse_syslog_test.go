@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseSyslog(t *testing.T) {
+	year := time.Now().Year()
+	est, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		target   string
+		protocol ottl.Optional[string]
+		location ottl.Optional[string]
+		expected map[string]any
+	}{
+		{
+			name:   "detect rfc3164",
+			target: "<34>Jan 12 06:30:00 1.2.3.4 apache_server: test message",
+			expected: map[string]any{
+				"timestamp": time.Date(year, time.January, 12, 6, 30, 0, 0, time.UTC).UnixNano(),
+				"hostname":  "1.2.3.4",
+				"appname":   "apache_server",
+				"message":   "test message",
+				"priority":  int64(34),
+				"severity":  int64(2),
+				"facility":  int64(4),
+			},
+		},
+		{
+			name:     "rfc3164 with location and process id",
+			target:   "<86>Mar  4 09:12:45 myhost sshd[1234]: Accepted publickey for user",
+			protocol: ottl.NewTestingOptional[string]("rfc3164"),
+			location: ottl.NewTestingOptional[string]("America/New_York"),
+			expected: map[string]any{
+				"timestamp": time.Date(year, time.March, 4, 9, 12, 45, 0, est).UnixNano(),
+				"hostname":  "myhost",
+				"appname":   "sshd",
+				"proc_id":   "1234",
+				"message":   "Accepted publickey for user",
+				"priority":  int64(86),
+				"severity":  int64(6),
+				"facility":  int64(10),
+			},
+		},
+		{
+			name:   "detect rfc5424",
+			target: `<86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 [SecureAuth@27389 UserHostAddress="192.168.2.132" Realm="SecureAuth0"] Found the user for retrieving user's profile`,
+			expected: map[string]any{
+				"timestamp": time.Date(2015, time.August, 5, 21, 58, 59, 693000000, time.UTC).UnixNano(),
+				"hostname":  "192.168.2.132",
+				"appname":   "SecureAuth0",
+				"proc_id":   "23108",
+				"msg_id":    "ID52020",
+				"message":   "Found the user for retrieving user's profile",
+				"version":   int64(1),
+				"priority":  int64(86),
+				"severity":  int64(6),
+				"facility":  int64(10),
+				"structured_data": map[string]any{
+					"SecureAuth@27389": map[string]any{
+						"UserHostAddress": "192.168.2.132",
+						"Realm":           "SecureAuth0",
+					},
+				},
+			},
+		},
+		{
+			name:     "rfc5424 without structured data",
+			target:   "<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 - An application event",
+			protocol: ottl.NewTestingOptional[string]("rfc5424"),
+			expected: map[string]any{
+				"timestamp": time.Date(2003, time.October, 11, 22, 14, 15, 3000000, time.UTC).UnixNano(),
+				"hostname":  "mymachine.example.com",
+				"appname":   "evntslog",
+				"msg_id":    "ID47",
+				"message":   "An application event",
+				"version":   int64(1),
+				"priority":  int64(165),
+				"severity":  int64(5),
+				"facility":  int64(20),
+			},
+		},
+		{
+			name:   "missing priority",
+			target: "1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 - Found the user",
+			expected: map[string]any{
+				"timestamp": time.Date(2015, time.August, 5, 21, 58, 59, 693000000, time.UTC).UnixNano(),
+				"hostname":  "192.168.2.132",
+				"appname":   "SecureAuth0",
+				"proc_id":   "23108",
+				"msg_id":    "ID52020",
+				"message":   "Found the user",
+				"version":   int64(1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := parseSyslog[any](ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}, tt.protocol, tt.location)
+			require.NoError(t, err)
+
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+
+			actual, ok := result.(pcommon.Map)
+			require.True(t, ok)
+
+			expected := pcommon.NewMap()
+			require.NoError(t, expected.FromRaw(tt.expected))
+			assert.Equal(t, expected.AsRaw(), actual.AsRaw())
+		})
+	}
+}
+
+func Test_parseSyslog_error(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		protocol      ottl.Optional[string]
+		expectedError string
+	}{
+		{
+			name:          "empty target",
+			target:        "",
+			expectedError: "cannot parse from empty target",
+		},
+		{
+			name:          "invalid rfc5424 message",
+			target:        "<34>Jan 12 06:30:00 1.2.3.4 apache_server: test message",
+			protocol:      ottl.NewTestingOptional[string]("rfc5424"),
+			expectedError: "failed to parse rfc5424 syslog message",
+		},
+		{
+			name:          "invalid priority",
+			target:        "<999>Jan 12 06:30:00 1.2.3.4 apache_server: test message",
+			expectedError: "failed to parse rfc3164 syslog message",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := parseSyslog[any](ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}, tt.protocol, ottl.Optional[string]{})
+			require.NoError(t, err)
+
+			_, err = exprFunc(t.Context(), nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func Test_parseSyslog_bad_arguments(t *testing.T) {
+	target := ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "<34>Jan 12 06:30:00 1.2.3.4 apache_server: test message", nil
+		},
+	}
+
+	_, err := parseSyslog[any](target, ottl.NewTestingOptional[string]("rfc1234"), ottl.Optional[string]{})
+	assert.EqualError(t, err, `unsupported syslog protocol "rfc1234", must be one of "rfc3164" or "rfc5424"`)
+
+	_, err = parseSyslog[any](target, ottl.Optional[string]{}, ottl.NewTestingOptional[string]("Invalid/Location"))
+	assert.ErrorContains(t, err, `failed to load location "Invalid/Location"`)
+}
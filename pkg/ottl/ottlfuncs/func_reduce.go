@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type ReduceArguments[K any] struct {
+	Target   ottl.PSliceGetter[K]
+	Function ottl.LambdaGetter[K]
+	Initial  ottl.Optional[ottl.Getter[K]]
+}
+
+func NewReduceFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Reduce", &ReduceArguments[K]{}, createReduceFunction[K])
+}
+
+func createReduceFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ReduceArguments[K])
+	if !ok {
+		return nil, errors.New("ReduceFactory args must be of type *ReduceArguments[K]")
+	}
+
+	return reduceSlice(args.Target, args.Function, args.Initial)
+}
+
+func reduceSlice[K any](target ottl.PSliceGetter[K], function ottl.LambdaGetter[K], initial ottl.Optional[ottl.Getter[K]]) (ottl.ExprFunc[K], error) {
+	if function.NumParams() != 2 {
+		return nil, fmt.Errorf("the function of Reduce must have exactly two parameters, got %d", function.NumParams())
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		list, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		start := 0
+		var acc any
+		switch {
+		case !initial.IsEmpty():
+			acc, err = initial.Get().Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+		case list.Len() == 0:
+			return nil, nil
+		default:
+			// without an initial value, the first element is the initial accumulator
+			acc = ottlcommon.GetValue(list.At(0))
+			start = 1
+		}
+
+		for i := start; i < list.Len(); i++ {
+			acc, err = function.Call(ctx, tCtx, acc, ottlcommon.GetValue(list.At(i)))
+			if err != nil {
+				return nil, fmt.Errorf("failed to reduce the element at index %d: %w", i, err)
+			}
+		}
+		return acc, nil
+	}, nil
+}
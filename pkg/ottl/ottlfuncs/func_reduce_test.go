@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_reduceSlice(t *testing.T) {
+	sum := testLambda{params: 2, fn: func(args ...any) (any, error) {
+		return args[0].(int64) + args[1].(int64), nil
+	}}
+
+	tests := []struct {
+		name     string
+		values   []any
+		function testLambda
+		initial  ottl.Optional[ottl.Getter[any]]
+		expected any
+	}{
+		{
+			name:     "without initial value",
+			values:   []any{int64(1), int64(2), int64(4)},
+			function: sum,
+			expected: int64(7),
+		},
+		{
+			name:     "with initial value",
+			values:   []any{int64(1), int64(2), int64(4)},
+			function: sum,
+			initial: ottl.NewTestingOptional[ottl.Getter[any]](ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return int64(10), nil
+				},
+			}),
+			expected: int64(17),
+		},
+		{
+			name:   "different accumulator type",
+			values: []any{"a", "b"},
+			function: testLambda{params: 2, fn: func(args ...any) (any, error) {
+				return append(args[0].([]string), args[1].(string)), nil
+			}},
+			initial: ottl.NewTestingOptional[ottl.Getter[any]](ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return []string{}, nil
+				},
+			}),
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "single element",
+			values:   []any{int64(3)},
+			function: sum,
+			expected: int64(3),
+		},
+		{
+			name:     "empty without initial value",
+			function: sum,
+			expected: nil,
+		},
+		{
+			name:     "empty with initial value",
+			function: sum,
+			initial: ottl.NewTestingOptional[ottl.Getter[any]](ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return int64(0), nil
+				},
+			}),
+			expected: int64(0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := reduceSlice[any](testSliceGetter(tt.values...), tt.function, tt.initial)
+			require.NoError(t, err)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_reduceSlice_error(t *testing.T) {
+	_, err := reduceSlice[any](testSliceGetter("a"), testLambda{params: 1}, ottl.Optional[ottl.Getter[any]]{})
+	assert.EqualError(t, err, "the function of Reduce must have exactly two parameters, got 1")
+
+	exprFunc, err := reduceSlice[any](testSliceGetter("a", "b"), testLambda{params: 2, fn: func(...any) (any, error) {
+		return nil, errors.New("failed")
+	}}, ottl.Optional[ottl.Getter[any]]{})
+	require.NoError(t, err)
+	_, err = exprFunc(t.Context(), nil)
+	assert.EqualError(t, err, "failed to reduce the element at index 1: failed")
+}
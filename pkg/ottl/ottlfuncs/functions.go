@@ -41,6 +41,7 @@ func converters[K any]() []ottl.Factory[K] {
 		NewBase64DecodeFactory[K](),
 		NewBoolFactory[K](),
 		NewDecodeFactory[K](),
+		NewEncodeFactory[K](),
 		NewCommunityIDFactory[K](),
 		NewConcatFactory[K](),
 		NewContainsValueFactory[K](),
@@ -52,6 +53,7 @@ func converters[K any]() []ottl.Factory[K] {
 		NewDurationFactory[K](),
 		NewExtractPatternsFactory[K](),
 		NewExtractGrokPatternsFactory[K](),
+		NewFilterFactory[K](),
 		NewFnvFactory[K](),
 		NewGetXMLFactory[K](),
 		NewHasPrefixFactory[K](),
@@ -70,6 +72,7 @@ func converters[K any]() []ottl.Factory[K] {
 		NewIsStringFactory[K](),
 		NewLenFactory[K](),
 		NewLogFactory[K](),
+		NewMapFactory[K](),
 		NewIsValidLuhnFactory[K](),
 		NewMD5Factory[K](),
 		NewMicrosecondsFactory[K](),
@@ -82,11 +85,15 @@ func converters[K any]() []ottl.Factory[K] {
 		NewNanosecondFactory[K](),
 		NewNanosecondsFactory[K](),
 		NewNowFactory[K](),
+		NewParseCEFFactory[K](),
 		NewParseCSVFactory[K](),
 		NewParseJSONFactory[K](),
 		NewParseKeyValueFactory[K](),
+		NewParseLEEFFactory[K](),
 		NewParseSimplifiedXMLFactory[K](),
+		NewParseSyslogFactory[K](),
 		NewParseXMLFactory[K](),
+		NewReduceFactory[K](),
 		NewRemoveXMLFactory[K](),
 		NewSecondFactory[K](),
 		NewSecondsFactory[K](),
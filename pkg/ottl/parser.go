@@ -284,6 +284,7 @@ func parseStatement(raw string) (*parsedStatement, error) {
 	if err != nil {
 		return nil, fmt.Errorf("statement has invalid syntax: %w", err)
 	}
+	resolveLambdaVariables(parsed)
 	err = parsed.checkForCustomError()
 	if err != nil {
 		return nil, err
@@ -297,6 +298,7 @@ func parseCondition(raw string) (*booleanExpression, error) {
 	if err != nil {
 		return nil, fmt.Errorf("condition has invalid syntax: %w", err)
 	}
+	resolveLambdaVariables(parsed)
 	err = parsed.checkForCustomError()
 	if err != nil {
 		return nil, err
@@ -310,6 +312,7 @@ func parseValueExpression(raw string) (*value, error) {
 	if err != nil {
 		return nil, fmt.Errorf("expression has invalid syntax: %w", err)
 	}
+	resolveLambdaVariables(parsed)
 	err = parsed.checkForCustomError()
 	if err != nil {
 		return nil, err
@@ -80,6 +80,8 @@ type ParserCollection[R any] struct {
 	contextInferrerCandidates map[string]*priorityContextInferrerCandidate
 	candidatesLowerContexts   map[string][]string
 	modifiedLogging           bool
+	functionDefinitions       []FunctionDefinition
+	contextFunctionDefiners   []func([]FunctionDefinition) error
 	Settings                  component.TelemetrySettings
 	ErrorMode                 ErrorMode
 }
@@ -353,6 +355,19 @@ func WithParserCollectionContext[K, R any](
 		if _, ok := parser.pathContextNames[context]; !ok {
 			return fmt.Errorf(`context "%s" must be a valid "%T" path context name`, context, parser)
 		}
+		defineFunctions := func(definitions []FunctionDefinition) error {
+			if err := parser.addFunctionDefinitions(definitions); err != nil {
+				return fmt.Errorf(`failed to define functions for the "%s" context: %w`, context, err)
+			}
+			return nil
+		}
+		if len(mp.functionDefinitions) > 0 {
+			if err := defineFunctions(mp.functionDefinitions); err != nil {
+				return err
+			}
+		}
+		mp.contextFunctionDefiners = append(mp.contextFunctionDefiners, defineFunctions)
+
 		pcp := &ParserCollectionContextParser[R]{}
 		for _, o := range opts {
 			o(pcp, parser)
@@ -395,6 +410,22 @@ func WithParserCollectionErrorMode[R any](errorMode ErrorMode) ParserCollectionO
 	}
 }
 
+// WithParserCollectionFunctionDefinitions declares the given functions in the parsers of all
+// contexts, including the contexts configured after this option. See FunctionDefinition.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func WithParserCollectionFunctionDefinitions[R any](definitions []FunctionDefinition) ParserCollectionOption[R] {
+	return func(pc *ParserCollection[R]) error {
+		for _, defineFunctions := range pc.contextFunctionDefiners {
+			if err := defineFunctions(definitions); err != nil {
+				return err
+			}
+		}
+		pc.functionDefinitions = append(pc.functionDefinitions, definitions...)
+		return nil
+	}
+}
+
 // EnableParserCollectionModifiedPathsLogging controls the modification logs.
 // When enabled, it logs any modifications performed by the parsing operations,
 // instructing users to rewrite the statements accordingly.
@@ -191,6 +191,50 @@ func Test_WithParserCollectionErrorMode(t *testing.T) {
 	require.Equal(t, PropagateError, pc.ErrorMode)
 }
 
+func Test_WithParserCollectionFunctionDefinitions(t *testing.T) {
+	fooParser := mockParser(t, WithPathContextNames[any]([]string{"foo"}))
+	barParser := mockParser(t, WithPathContextNames[any]([]string{"bar"}))
+	definitions := []FunctionDefinition{
+		{
+			Name:       "set_bar",
+			Params:     []string{"target"},
+			Statements: []string{`set(target["bar"], "bar")`},
+		},
+	}
+
+	pc, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("foo", fooParser, WithStatementConverter(newNopParsedStatementsConverter[any]())),
+		WithParserCollectionFunctionDefinitions[any](definitions),
+		WithParserCollectionContext("bar", barParser, WithStatementConverter(newNopParsedStatementsConverter[any]())),
+	)
+	require.NoError(t, err)
+
+	for _, statement := range []string{`set_bar(foo.attributes)`, `set_bar(bar.attributes)`} {
+		result, err := pc.ParseStatements(mockGetter{values: []string{statement}})
+		require.NoError(t, err)
+		assert.Len(t, result.([]*Statement[any]), 1)
+	}
+}
+
+func Test_WithParserCollectionFunctionDefinitions_Error(t *testing.T) {
+	ps := mockParser(t, WithPathContextNames[any]([]string{"foo"}))
+	definitions := []FunctionDefinition{
+		{
+			Name:       "set",
+			Params:     []string{"target"},
+			Statements: []string{`set(target, "bar")`},
+		},
+	}
+
+	_, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("foo", ps, WithStatementConverter(newNopParsedStatementsConverter[any]())),
+		WithParserCollectionFunctionDefinitions[any](definitions),
+	)
+	assert.EqualError(t, err, `failed to define functions for the "foo" context: function "set" is already defined`)
+}
+
 func Test_EnableParserCollectionModifiedPathsLogging_True(t *testing.T) {
 	ps := mockParser(t, WithPathContextNames[any]([]string{"dummy"}))
 	core, observedLogs := observer.New(zap.InfoLevel)
@@ -19,6 +19,7 @@ const (
 	LogFileOwnerGroupName = "log.file.owner.group.name"
 	LogFileRecordNumber   = "log.file.record_number"
 	LogFileRecordOffset   = "log.file.record_offset"
+	LogFileArchiveMember  = "log.file.archive_member"
 )
 
 type Resolver struct {
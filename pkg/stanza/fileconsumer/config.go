@@ -20,6 +20,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/textutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/metadata"
@@ -89,6 +90,7 @@ type Config struct {
 	IncludeFileRecordNumber bool            `mapstructure:"include_file_record_number,omitempty"`
 	IncludeFileRecordOffset bool            `mapstructure:"include_file_record_offset,omitempty"`
 	Compression             string          `mapstructure:"compression,omitempty"`
+	ArchiveFormat           string          `mapstructure:"archive_format,omitempty"`
 	PollsToArchive          int             `mapstructure:"polls_to_archive,omitempty"`
 	AcquireFSLock           bool            `mapstructure:"acquire_fs_lock,omitempty"`
 }
@@ -169,6 +171,7 @@ func (c Config) Build(set component.TelemetrySettings, emit emit.Callback, opts
 		DeleteAtEOF:             c.DeleteAfterRead,
 		IncludeFileRecordNumber: c.IncludeFileRecordNumber,
 		Compression:             c.Compression,
+		ArchiveFormat:           c.ArchiveFormat,
 		AcquireFSLock:           c.AcquireFSLock,
 	}
 
@@ -243,6 +246,16 @@ func (c Config) validate() error {
 		}
 	}
 
+	if c.Compression != "" && !compression.IsSupported(c.Compression) {
+		return fmt.Errorf("invalid 'compression' %q, must be one of 'gzip', 'zstd', 'xz', 'bzip2' or 'auto'", c.Compression)
+	}
+
+	switch c.ArchiveFormat {
+	case "", reader.TarArchive, reader.ZipArchive, reader.AutoArchive:
+	default:
+		return fmt.Errorf("invalid 'archive_format' %q, must be one of 'tar', 'zip' or 'auto'", c.ArchiveFormat)
+	}
+
 	if runtime.GOOS == "windows" && (c.IncludeFileOwnerName || c.IncludeFileOwnerGroupName) {
 		return errors.New("'include_file_owner_name' or 'include_file_owner_group_name' it's not supported on Windows")
 	}
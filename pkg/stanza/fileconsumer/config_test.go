@@ -582,6 +582,43 @@ func TestBuild(t *testing.T) {
 				require.Equal(t, 6, m.maxBatches)
 			},
 		},
+		{
+			"ValidCompression",
+			func(cfg *Config) {
+				cfg.Compression = "zstd"
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, "zstd", m.readerFactory.Compression)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(cfg *Config) {
+				cfg.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"ValidArchiveFormat",
+			func(cfg *Config) {
+				cfg.Compression = "auto"
+				cfg.ArchiveFormat = "auto"
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, "auto", m.readerFactory.ArchiveFormat)
+			},
+		},
+		{
+			"InvalidArchiveFormat",
+			func(cfg *Config) {
+				cfg.ArchiveFormat = "rar"
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"HeaderConfigNoFlag",
 			func(cfg *Config) {
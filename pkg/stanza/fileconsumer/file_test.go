@@ -4,6 +4,7 @@
 package fileconsumer
 
 import (
+	"archive/tar"
 	"compress/gzip"
 	"context"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/featuregate"
@@ -1613,6 +1615,88 @@ func TestReadGzipCompressedLogsFromEnd(t *testing.T) {
 	sink.ExpectToken(t, []byte("testlog4"))
 }
 
+// TestReadZstdCompressedLogsAutoDetected tests that zstd compressed files are detected and that the frames
+// appended to them are read
+func TestReadZstdCompressedLogsAutoDetected(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = "auto"
+	cfg.StartAt = "beginning"
+	operator, sink := testManager(t, cfg)
+
+	temp := filetest.OpenTempWithPattern(t, tempDir, "*.zst")
+
+	appendToLog := func(t *testing.T, content string) {
+		writer, err := zstd.NewWriter(temp)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+	}
+
+	appendToLog(t, "testlog1\ntestlog2\n")
+	operator.poll(t.Context())
+	sink.ExpectTokens(t, []byte("testlog1"), []byte("testlog2"))
+
+	appendToLog(t, "testlog3\n")
+	operator.poll(t.Context())
+	sink.ExpectToken(t, []byte("testlog3"))
+	sink.ExpectNoCalls(t)
+}
+
+// TestReadArchiveMembersAfterRestart tests that the members of an archive are only read once,
+// even when the archive is updated while the operator is stopped
+func TestReadArchiveMembersAfterRestart(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = "auto"
+	cfg.ArchiveFormat = "auto"
+	cfg.StartAt = "beginning"
+	persister := testutil.NewUnscopedMockPersister()
+
+	writeArchive := func(t *testing.T, members ...string) {
+		temp := filetest.OpenFile(t, filepath.Join(tempDir, "logs.tar.gz"))
+		require.NoError(t, temp.Truncate(0))
+		gw := gzip.NewWriter(temp)
+		tw := tar.NewWriter(gw)
+		for _, member := range members {
+			content := member + "_log1\n" + member + "_log2\n"
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: member + ".log", Mode: 0o600, Size: int64(len(content))}))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		require.NoError(t, gw.Close())
+		require.NoError(t, temp.Close())
+	}
+
+	writeArchive(t, "a", "b")
+	operatorOne, sink1 := testManager(t, cfg)
+	require.NoError(t, operatorOne.Start(persister))
+	sink1.ExpectCalls(t,
+		emit.NewToken([]byte("a_log1"), map[string]any{attrs.LogFileName: "logs.tar.gz", attrs.LogFileArchiveMember: "a.log"}),
+		emit.NewToken([]byte("a_log2"), map[string]any{attrs.LogFileName: "logs.tar.gz", attrs.LogFileArchiveMember: "a.log"}),
+		emit.NewToken([]byte("b_log1"), map[string]any{attrs.LogFileName: "logs.tar.gz", attrs.LogFileArchiveMember: "b.log"}),
+		emit.NewToken([]byte("b_log2"), map[string]any{attrs.LogFileName: "logs.tar.gz", attrs.LogFileArchiveMember: "b.log"}),
+	)
+	sink1.ExpectNoCallsUntil(t, 200*time.Millisecond)
+	require.NoError(t, operatorOne.Stop())
+
+	writeArchive(t, "a", "b", "c")
+	operatorTwo, sink2 := testManager(t, cfg)
+	require.NoError(t, operatorTwo.Start(persister))
+	sink2.ExpectCalls(t,
+		emit.NewToken([]byte("c_log1"), map[string]any{attrs.LogFileName: "logs.tar.gz", attrs.LogFileArchiveMember: "c.log"}),
+		emit.NewToken([]byte("c_log2"), map[string]any{attrs.LogFileName: "logs.tar.gz", attrs.LogFileArchiveMember: "c.log"}),
+	)
+	sink2.ExpectNoCallsUntil(t, 200*time.Millisecond)
+	require.NoError(t, operatorTwo.Stop())
+}
+
 func TestArchive(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("Time sensitive tests disabled for now on Windows. See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/32715#issuecomment-2107737828")
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compression // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+	"go.uber.org/zap"
+)
+
+// Supported compression formats
+const (
+	Gzip  = "gzip"
+	Zstd  = "zstd"
+	Xz    = "xz"
+	Bzip2 = "bzip2"
+	// Auto detects the compression format of each file based on its magic bytes
+	Auto = "auto"
+)
+
+var magicBytes = []struct {
+	format string
+	magic  []byte
+}{
+	{Gzip, []byte(gzipHeader)},
+	{Zstd, []byte("\x28\xb5\x2f\xfd")}, // RFC 8878 magic number
+	{Xz, []byte("\xfd7zXZ\x00")},       // xz file format header magic bytes
+	{Bzip2, []byte("BZh")},             // bzip2 stream header, followed by the block size
+}
+
+// maxMagicLen is the length of the longest magic bytes sequence
+const maxMagicLen = 6
+
+// IsSupported returns true if the given format is a supported compression format, or Auto.
+func IsSupported(format string) bool {
+	switch format {
+	case Gzip, Zstd, Xz, Bzip2, Auto:
+		return true
+	}
+	return false
+}
+
+// Detect returns the compression format of a file by reading its header, or an
+// empty string if the file isn't compressed with a supported format.
+func Detect(f *os.File, logger *zap.Logger) string {
+	header := make([]byte, maxMagicLen)
+	n, err := f.ReadAt(header, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		logger.Error(fmt.Sprintf("error reading file: %s: %s", f.Name(), err))
+		return ""
+	}
+	header = header[:n]
+
+	for _, m := range magicBytes {
+		if !bytes.HasPrefix(header, m.magic) {
+			continue
+		}
+		if m.format == Bzip2 && (len(header) <= len(m.magic) || header[len(m.magic)] < '1' || header[len(m.magic)] > '9') {
+			continue
+		}
+		return m.format
+	}
+	return ""
+}
+
+// NewReader returns a reader decompressing the data read from r using the given format.
+// Concatenated streams are decompressed as a single stream.
+func NewReader(format string, r io.Reader) (io.ReadCloser, error) {
+	switch format {
+	case Gzip:
+		return gzip.NewReader(r)
+	case Zstd:
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	case Xz:
+		xr, err := xz.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(xr), nil
+	case Bzip2:
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression format %q", format)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
+	"go.uber.org/zap"
+)
+
+func compress(t *testing.T, format string, w io.Writer, content string) {
+	t.Helper()
+	var cw io.WriteCloser
+	var err error
+	switch format {
+	case Gzip:
+		cw = gzip.NewWriter(w)
+	case Zstd:
+		cw, err = zstd.NewWriter(w)
+	case Xz:
+		cw, err = xz.NewWriter(w)
+	default:
+		t.Fatalf("no writer for format %q", format)
+	}
+	require.NoError(t, err)
+	_, err = cw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, cw.Close())
+}
+
+func TestDetectAndNewReader(t *testing.T) {
+	for _, format := range []string{Gzip, Zstd, Xz} {
+		t.Run(format, func(t *testing.T) {
+			f, err := os.Create(filepath.Join(t.TempDir(), "test.log"))
+			require.NoError(t, err)
+			defer f.Close()
+
+			// concatenated streams, as produced when compressed data is appended to a file
+			compress(t, format, f, "testlog1\ntestlog2\n")
+			compress(t, format, f, "testlog3\n")
+
+			require.Equal(t, format, Detect(f, zap.NewNop()))
+
+			r, err := NewReader(format, io.NewSectionReader(f, 0, 1<<20))
+			require.NoError(t, err)
+			defer r.Close()
+			content, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, "testlog1\ntestlog2\ntestlog3\n", string(content))
+		})
+	}
+
+	t.Run(Bzip2, func(t *testing.T) {
+		// bzip2 streams can't be written with the standard library
+		f, err := os.Open(filepath.Join("testdata", "test.log.bz2"))
+		require.NoError(t, err)
+		defer f.Close()
+
+		require.Equal(t, Bzip2, Detect(f, zap.NewNop()))
+
+		r, err := NewReader(Bzip2, f)
+		require.NoError(t, err)
+		defer r.Close()
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "testlog1\ntestlog2\ntestlog3\n", string(content))
+	})
+}
+
+func TestDetectUncompressed(t *testing.T) {
+	for name, content := range map[string]string{
+		"empty":           "",
+		"short":           "B",
+		"text":            "this is test data and the header should prove this is not compressed",
+		"bzip2 lookalike": "BZhello",
+	} {
+		t.Run(name, func(t *testing.T) {
+			f, err := os.Create(filepath.Join(t.TempDir(), "test.log"))
+			require.NoError(t, err)
+			defer f.Close()
+			_, err = f.WriteString(content)
+			require.NoError(t, err)
+
+			assert.Empty(t, Detect(f, zap.NewNop()))
+		})
+	}
+}
+
+func TestNewReaderUnsupported(t *testing.T) {
+	_, err := NewReader("lz4", nil)
+	assert.EqualError(t, err, `unsupported compression format "lz4"`)
+}
+
+func TestIsSupported(t *testing.T) {
+	for _, format := range []string{Gzip, Zstd, Xz, Bzip2, Auto} {
+		assert.True(t, IsSupported(format), format)
+	}
+	assert.False(t, IsSupported(""))
+	assert.False(t, IsSupported("lz4"))
+}
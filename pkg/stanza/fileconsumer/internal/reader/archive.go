@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/reader"
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+)
+
+// Supported archive formats
+const (
+	TarArchive = "tar"
+	ZipArchive = "zip"
+	// AutoArchive detects the archive format of each file based on its magic bytes
+	AutoArchive = "auto"
+)
+
+const (
+	zipMagic       = "PK\x03\x04" // local file header signature
+	tarMagic       = "ustar"      // POSIX and GNU tar magic, at tarMagicOffset in the first header
+	tarMagicOffset = 257
+)
+
+// ArchiveMember tracks the progress of reading a file contained in an archive.
+// Members are identified by their name and the fingerprint of their content, so that each one
+// is only read once, even when several members start with the same bytes.
+type ArchiveMember struct {
+	Name        string
+	Fingerprint *fingerprint.Fingerprint
+	Offset      int64
+	RecordNum   int64
+}
+
+// detectArchive returns the archive format of the file, or an empty string if it isn't an archive.
+// The file is decompressed using the given compression format, if any, before looking for a tar header.
+func (f *Factory) detectArchive(file *os.File, compressionFormat string) string {
+	if f.ArchiveFormat != AutoArchive {
+		return f.ArchiveFormat
+	}
+
+	var src io.Reader = io.NewSectionReader(file, 0, math.MaxInt64)
+	if compressionFormat != "" {
+		decompressed, err := compression.NewReader(compressionFormat, src)
+		if err != nil {
+			return ""
+		}
+		defer decompressed.Close()
+		src = decompressed
+	}
+
+	header := make([]byte, tarMagicOffset+len(tarMagic))
+	n, err := io.ReadFull(src, header)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		f.Logger.Debug("failed to read archive header", zap.String("path", file.Name()), zap.Error(err))
+		return ""
+	}
+	header = header[:n]
+
+	switch {
+	case compressionFormat == "" && bytes.HasPrefix(header, []byte(zipMagic)):
+		// zip archives need random access, so they can't be read from a compressed stream
+		return ZipArchive
+	case n > tarMagicOffset && bytes.Equal(header[tarMagicOffset:], []byte(tarMagic)):
+		return TarArchive
+	}
+	return ""
+}
+
+// readArchive reads the members of an archive which haven't been read yet
+func (r *Reader) readArchive(ctx context.Context) {
+	info, err := r.file.Stat()
+	if err != nil {
+		r.set.Logger.Error("failed to stat", zap.Error(err))
+		return
+	}
+	if info.Size() <= r.Offset {
+		// the archive hasn't changed since all of its members were read
+		return
+	}
+
+	// The offset and record number of the archive itself are used to read each member,
+	// and are restored once all the members were read.
+	offset, recordNum := r.Offset, r.RecordNum
+	defer func() {
+		r.Offset, r.RecordNum = offset, recordNum
+	}()
+
+	switch r.ArchiveFormat {
+	case TarArchive:
+		err = r.readTar(ctx)
+	case ZipArchive:
+		err = r.readZip(ctx, info.Size())
+	default:
+		err = fmt.Errorf("unsupported archive format %q", r.ArchiveFormat)
+	}
+	if err != nil {
+		r.set.Logger.Error("failed to read archive", zap.String("archive_format", r.ArchiveFormat), zap.Error(err))
+		return
+	}
+	if ctx.Err() != nil {
+		// the remaining members will be read during the next poll
+		return
+	}
+
+	offset = info.Size()
+	if r.deleteAtEOF {
+		r.delete()
+	}
+}
+
+func (r *Reader) readTar(ctx context.Context) error {
+	var src io.Reader = io.NewSectionReader(r.file, 0, math.MaxInt64)
+	if format := r.compressionFormat(); format != "" {
+		decompressed, err := compression.NewReader(format, src)
+		if err != nil {
+			return err
+		}
+		defer decompressed.Close()
+		src = decompressed
+	}
+
+	tr := tar.NewReader(src)
+	for ctx.Err() == nil {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := r.readArchiveMember(ctx, hdr.Name, hdr.Size, tr); err != nil {
+			return fmt.Errorf("read member %q: %w", hdr.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Reader) readZip(ctx context.Context, size int64) error {
+	zr, err := zip.NewReader(r.file, size)
+	if err != nil {
+		return err
+	}
+	for _, f := range zr.File {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		if err := r.readZipMember(ctx, f); err != nil {
+			return fmt.Errorf("read member %q: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Reader) readZipMember(ctx context.Context, f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return r.readArchiveMember(ctx, f.Name, int64(f.UncompressedSize64), rc)
+}
+
+// readArchiveMember reads the content of an archive member from the offset recorded for it
+func (r *Reader) readArchiveMember(ctx context.Context, name string, size int64, src io.Reader) error {
+	br := bufio.NewReaderSize(src, r.fingerprintSize)
+	first, err := br.Peek(r.fingerprintSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if len(first) == 0 {
+		return nil
+	}
+
+	fp := fingerprint.New(first).Copy()
+	member := r.findArchiveMember(name, fp)
+	if member == nil {
+		member = &ArchiveMember{Name: name, Fingerprint: fp}
+		r.ArchiveMembers = append(r.ArchiveMembers, member)
+	}
+	// the fingerprint of a member shorter than the fingerprint size grows with its content
+	member.Fingerprint = fp
+	if member.Offset >= size {
+		return nil
+	}
+
+	if member.Offset > 0 {
+		if _, err := io.CopyN(io.Discard, br, member.Offset); err != nil {
+			return err
+		}
+	}
+
+	// the tokens of each member are emitted with the attributes of the archive and the name of the member
+	fileAttributes := r.FileAttributes
+	defer func() {
+		r.FileAttributes = fileAttributes
+	}()
+	r.FileAttributes = maps.Clone(fileAttributes)
+	r.FileAttributes[attrs.LogFileArchiveMember] = name
+
+	r.reader = br
+	r.Offset, r.RecordNum = member.Offset, member.RecordNum
+	r.readContents(ctx, r.archiveSplitFunc)
+	member.Offset, member.RecordNum = r.Offset, r.RecordNum
+	return nil
+}
+
+func (r *Reader) findArchiveMember(name string, fp *fingerprint.Fingerprint) *ArchiveMember {
+	for _, member := range r.ArchiveMembers {
+		if member.Name == name && fp.StartsWith(member.Fingerprint) {
+			return member
+		}
+	}
+	return nil
+}
+
+// flushAtEOF wraps a bufio.SplitFunc so the remaining data is returned as a token at the end of the input.
+func flushAtEOF(splitFunc bufio.SplitFunc) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := splitFunc(data, atEOF)
+		if err != nil || token != nil || !atEOF || len(data) == 0 {
+			return advance, token, err
+		}
+		return len(data), data, nil
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+)
+
+type testArchiveMember struct {
+	name    string
+	content string
+}
+
+func writeTar(t *testing.T, w io.Writer, members ...testArchiveMember) {
+	t.Helper()
+	tw := tar.NewWriter(w)
+	for _, m := range members {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: m.name, Mode: 0o600, Size: int64(len(m.content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(m.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+}
+
+func writeZip(t *testing.T, w io.Writer, members ...testArchiveMember) {
+	t.Helper()
+	zw := zip.NewWriter(w)
+	for _, m := range members {
+		fw, err := zw.Create(m.name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(m.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+}
+
+func createArchive(t *testing.T, path string, write func(io.Writer)) *os.File {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	write(f)
+	require.NoError(t, f.Close())
+	f, err = os.Open(path)
+	require.NoError(t, err)
+	return f
+}
+
+func memberAttributes(path, member string) map[string]any {
+	return map[string]any{
+		attrs.LogFileName:          filepath.Base(path),
+		attrs.LogFileArchiveMember: member,
+	}
+}
+
+func fingerprintOf(content string, size int) *fingerprint.Fingerprint {
+	return fingerprint.New([]byte(content[:min(len(content), size)]))
+}
+
+var testMembers = []testArchiveMember{
+	{name: "a.log", content: "a1\na2\n"},
+	{name: "dir/b.log", content: "b1\nb2"},
+}
+
+func TestReadArchive(t *testing.T) {
+	tests := []struct {
+		name          string
+		fileName      string
+		compression   string
+		archiveFormat string
+		write         func(*testing.T, io.Writer, ...testArchiveMember)
+	}{
+		{
+			name:          "tar",
+			fileName:      "logs.tar",
+			archiveFormat: TarArchive,
+			write:         writeTar,
+		},
+		{
+			name:          "detected tar",
+			fileName:      "logs.tar",
+			archiveFormat: AutoArchive,
+			write:         writeTar,
+		},
+		{
+			name:          "detected gzip compressed tar",
+			fileName:      "logs.tar.gz",
+			compression:   compression.Auto,
+			archiveFormat: AutoArchive,
+			write: func(t *testing.T, w io.Writer, members ...testArchiveMember) {
+				gw := gzip.NewWriter(w)
+				writeTar(t, gw, members...)
+				require.NoError(t, gw.Close())
+			},
+		},
+		{
+			name:          "zip",
+			fileName:      "logs.zip",
+			archiveFormat: ZipArchive,
+			write:         writeZip,
+		},
+		{
+			name:          "detected zip",
+			fileName:      "logs.zip",
+			compression:   compression.Auto,
+			archiveFormat: AutoArchive,
+			write:         writeZip,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.fileName)
+			file := createArchive(t, path, func(w io.Writer) { tt.write(t, w, testMembers...) })
+
+			f, sink := testFactory(t, withCompression(tt.compression), withArchiveFormat(tt.archiveFormat))
+			fp, err := f.NewFingerprint(file)
+			require.NoError(t, err)
+			r, err := f.NewReader(file, fp)
+			require.NoError(t, err)
+			defer r.Close()
+			require.NotEmpty(t, r.ArchiveFormat)
+
+			r.ReadToEnd(t.Context())
+			sink.ExpectCalls(t,
+				emit.NewToken([]byte("a1"), memberAttributes(path, "a.log")),
+				emit.NewToken([]byte("a2"), memberAttributes(path, "a.log")),
+				emit.NewToken([]byte("b1"), memberAttributes(path, "dir/b.log")),
+				emit.NewToken([]byte("b2"), memberAttributes(path, "dir/b.log")),
+			)
+			sink.ExpectNoCalls(t)
+			assert.Equal(t, map[string]any{attrs.LogFileName: filepath.Base(path)}, r.FileAttributes)
+
+			require.Len(t, r.ArchiveMembers, 2)
+			assert.Equal(t, "a.log", r.ArchiveMembers[0].Name)
+			assert.Equal(t, int64(6), r.ArchiveMembers[0].Offset)
+			assert.Equal(t, int64(2), r.ArchiveMembers[0].RecordNum)
+			assert.Equal(t, "dir/b.log", r.ArchiveMembers[1].Name)
+			assert.Equal(t, int64(5), r.ArchiveMembers[1].Offset)
+			assert.Equal(t, int64(2), r.ArchiveMembers[1].RecordNum)
+
+			// the members which were already read are not read again
+			r.ReadToEnd(t.Context())
+			sink.ExpectNoCalls(t)
+		})
+	}
+}
+
+func TestReadArchiveNewMembers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs.tar")
+	file := createArchive(t, path, func(w io.Writer) { writeTar(t, w, testMembers[0]) })
+
+	f, sink := testFactory(t, withArchiveFormat(AutoArchive))
+	fp, err := f.NewFingerprint(file)
+	require.NoError(t, err)
+	r, err := f.NewReader(file, fp)
+	require.NoError(t, err)
+
+	r.ReadToEnd(t.Context())
+	sink.ExpectTokens(t, []byte("a1"), []byte("a2"))
+	metadata := r.Close()
+
+	// the archive is recreated with an additional member, such as when it's restored from a checkpoint
+	file = createArchive(t, path, func(w io.Writer) { writeTar(t, w, testMembers...) })
+	r, err = f.NewReaderFromMetadata(file, metadata)
+	require.NoError(t, err)
+	defer r.Close()
+
+	r.ReadToEnd(t.Context())
+	sink.ExpectCalls(t,
+		emit.NewToken([]byte("b1"), memberAttributes(path, "dir/b.log")),
+		emit.NewToken([]byte("b2"), memberAttributes(path, "dir/b.log")),
+	)
+	sink.ExpectNoCalls(t)
+	require.Len(t, r.ArchiveMembers, 2)
+}
+
+func TestReadArchiveMembersWithSamePrefix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.tar")
+	members := []testArchiveMember{
+		{name: "a.log", content: "header\na1\n"},
+		{name: "b.log", content: "header\nb1\n"},
+		// identical to a.log
+		{name: "c.log", content: "header\na1\n"},
+	}
+	file := createArchive(t, path, func(w io.Writer) { writeTar(t, w, members...) })
+
+	// the fingerprint only covers the common header of the members
+	f, sink := testFactory(t, withArchiveFormat(TarArchive), withFingerprintSize(7))
+	fp, err := f.NewFingerprint(file)
+	require.NoError(t, err)
+	r, err := f.NewReader(file, fp)
+	require.NoError(t, err)
+
+	r.ReadToEnd(t.Context())
+	sink.ExpectCalls(t,
+		emit.NewToken([]byte("header"), memberAttributes(path, "a.log")),
+		emit.NewToken([]byte("a1"), memberAttributes(path, "a.log")),
+		emit.NewToken([]byte("header"), memberAttributes(path, "b.log")),
+		emit.NewToken([]byte("b1"), memberAttributes(path, "b.log")),
+		emit.NewToken([]byte("header"), memberAttributes(path, "c.log")),
+		emit.NewToken([]byte("a1"), memberAttributes(path, "c.log")),
+	)
+	sink.ExpectNoCalls(t)
+	require.Len(t, r.ArchiveMembers, 3)
+	metadata := r.Close()
+
+	// each member is checkpointed on its own, so none of them is read again after a restart
+	file = createArchive(t, path, func(w io.Writer) { writeTar(t, w, members...) })
+	r, err = f.NewReaderFromMetadata(file, metadata)
+	require.NoError(t, err)
+	defer r.Close()
+	r.ReadToEnd(t.Context())
+	sink.ExpectNoCalls(t)
+}
+
+func TestReadArchivePartiallyReadMember(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.zip")
+	file := createArchive(t, path, func(w io.Writer) { writeZip(t, w, testMembers...) })
+
+	f, sink := testFactory(t, withArchiveFormat(ZipArchive))
+	fp, err := f.NewFingerprint(file)
+	require.NoError(t, err)
+	r, err := f.NewReader(file, fp)
+	require.NoError(t, err)
+	defer r.Close()
+
+	// the first line of the first member was read before a restart
+	r.ArchiveMembers = []*ArchiveMember{{
+		Name:        "a.log",
+		Fingerprint: fingerprintOf(testMembers[0].content, f.FingerprintSize),
+		Offset:      3,
+		RecordNum:   1,
+	}}
+
+	r.ReadToEnd(t.Context())
+	sink.ExpectTokens(t, []byte("a2"), []byte("b1"), []byte("b2"))
+	sink.ExpectNoCalls(t)
+	assert.Equal(t, int64(2), r.ArchiveMembers[0].RecordNum)
+}
+
+func TestDetectArchiveNotArchive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	file := createArchive(t, path, func(w io.Writer) {
+		_, err := w.Write([]byte("testlog1\ntestlog2\n"))
+		require.NoError(t, err)
+	})
+
+	f, sink := testFactory(t, withCompression(compression.Auto), withArchiveFormat(AutoArchive))
+	fp, err := f.NewFingerprint(file)
+	require.NoError(t, err)
+	r, err := f.NewReader(file, fp)
+	require.NoError(t, err)
+	defer r.Close()
+	assert.Empty(t, r.ArchiveFormat)
+
+	r.ReadToEnd(t.Context())
+	sink.ExpectTokens(t, []byte("testlog1"), []byte("testlog2"))
+}
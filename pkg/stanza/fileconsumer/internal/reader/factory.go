@@ -50,6 +50,7 @@ type Factory struct {
 	IncludeFileRecordNumber bool
 	IncludeFileRecordOffset bool
 	Compression             string
+	ArchiveFormat           string
 	AcquireFSLock           bool
 }
 
@@ -62,10 +63,19 @@ func (f *Factory) NewReader(file *os.File, fp *fingerprint.Fingerprint) (*Reader
 	if err != nil {
 		return nil, err
 	}
-	var filetype string
+	var filetype, archiveFormat string
+
+	compressionFormat := f.Compression
+	if f.Compression != "" {
+		detected := compression.Detect(file, f.Logger)
+		filetype = compressionExtensions[detected]
+		if f.Compression == compression.Auto {
+			compressionFormat = detected
+		}
+	}
 
-	if f.Compression != "" && compression.IsGzipFile(file, f.Logger) {
-		filetype = gzipExtension
+	if f.ArchiveFormat != "" {
+		archiveFormat = f.detectArchive(file, compressionFormat)
 	}
 
 	m := &Metadata{
@@ -75,7 +85,8 @@ func (f *Factory) NewReader(file *os.File, fp *fingerprint.Fingerprint) (*Reader
 		FlushState: flush.State{
 			LastDataChange: time.Now(),
 		},
-		FileType: filetype,
+		FileType:      filetype,
+		ArchiveFormat: archiveFormat,
 	}
 	return f.NewReaderFromMetadata(file, m)
 }
@@ -122,6 +133,10 @@ func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader,
 	tokenLenFunc := m.TokenLenState.Func(f.SplitFunc)
 	flushFunc := m.FlushState.Func(tokenLenFunc, f.FlushTimeout)
 	r.contentSplitFunc = trim.WithFunc(trim.ToLength(flushFunc, f.MaxLogSize), f.TrimFunc)
+	if m.ArchiveFormat != "" {
+		// archive members are complete files, so their last token doesn't need to wait for the flush timeout
+		r.archiveSplitFunc = trim.WithFunc(trim.ToLength(flushAtEOF(f.SplitFunc), f.MaxLogSize), f.TrimFunc)
+	}
 
 	if f.HeaderConfig != nil && !m.HeaderFinalized {
 		r.headerSplitFunc = f.HeaderConfig.SplitFunc
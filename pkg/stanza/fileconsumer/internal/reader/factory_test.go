@@ -61,6 +61,8 @@ func testFactory(t *testing.T, opts ...testFactoryOpt) (*Factory, *emittest.Sink
 		FlushTimeout:      cfg.flushPeriod,
 		EmitFunc:          sink.Callback,
 		Attributes:        cfg.attributes,
+		Compression:       cfg.compression,
+		ArchiveFormat:     cfg.archiveFormat,
 	}, sink
 }
 
@@ -77,6 +79,8 @@ type testFactoryCfg struct {
 	flushPeriod       time.Duration
 	sinkChanSize      int
 	attributes        attrs.Resolver
+	compression       string
+	archiveFormat     string
 }
 
 func withFingerprintSize(size int) testFactoryOpt {
@@ -115,6 +119,18 @@ func withSinkChanSize(n int) testFactoryOpt {
 	}
 }
 
+func withCompression(compression string) testFactoryOpt {
+	return func(c *testFactoryCfg) {
+		c.compression = compression
+	}
+}
+
+func withArchiveFormat(archiveFormat string) testFactoryOpt {
+	return func(c *testFactoryCfg) {
+		c.archiveFormat = archiveFormat
+	}
+}
+
 func fromEnd() testFactoryOpt {
 	return func(c *testFactoryCfg) {
 		c.fromBeginning = false
@@ -5,7 +5,6 @@ package reader // import "github.com/open-telemetry/opentelemetry-collector-cont
 
 import (
 	"bufio"
-	"compress/gzip"
 	"context"
 	"errors"
 	"io"
@@ -18,6 +17,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/textutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/scanner"
@@ -27,6 +27,14 @@ import (
 
 const gzipExtension = ".gz"
 
+// compressionExtensions are the file types recorded for each compression format detected in a file
+var compressionExtensions = map[string]string{
+	compression.Gzip:  gzipExtension,
+	compression.Zstd:  ".zst",
+	compression.Xz:    ".xz",
+	compression.Bzip2: ".bz2",
+}
+
 type Metadata struct {
 	Fingerprint     *fingerprint.Fingerprint
 	Offset          int64
@@ -36,6 +44,8 @@ type Metadata struct {
 	FlushState      flush.State
 	TokenLenState   tokenlen.State
 	FileType        string
+	ArchiveFormat   string
+	ArchiveMembers  []*ArchiveMember
 }
 
 // Reader manages a single file
@@ -51,6 +61,7 @@ type Reader struct {
 	maxLogSize             int
 	headerSplitFunc        bufio.SplitFunc
 	contentSplitFunc       bufio.SplitFunc
+	archiveSplitFunc       bufio.SplitFunc
 	decoder                *encoding.Decoder
 	headerReader           *header.Reader
 	emitFunc               emit.Callback
@@ -70,32 +81,25 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		defer r.unlockFile()
 	}
 
-	switch r.compression {
-	case "gzip":
-		currentEOF, err := r.createGzipReader()
+	if r.ArchiveFormat != "" {
+		r.readArchive(ctx)
+		return
+	}
+
+	if format := r.compressionFormat(); format != "" {
+		currentEOF, decompressed, err := r.createDecompressingReader(format)
 		if err != nil {
 			return
 		}
 		// Offset tracking in an uncompressed file is based on the length of emitted tokens, but in this case
 		// we need to set the offset to the end of the file.
 		defer func() {
+			if err := decompressed.Close(); err != nil {
+				r.set.Logger.Debug("problem closing decompressing reader", zap.Error(err))
+			}
 			r.Offset = currentEOF
 		}()
-	case "auto":
-		if r.FileType == gzipExtension {
-			currentEOF, err := r.createGzipReader()
-			if err != nil {
-				return
-			}
-			// Offset tracking in an uncompressed file is based on the length of emitted tokens, but in this case
-			// we need to set the offset to the end of the file.
-			defer func() {
-				r.Offset = currentEOF
-			}()
-		} else {
-			r.reader = r.file
-		}
-	default:
+	} else {
 		r.reader = r.file
 	}
 
@@ -116,30 +120,50 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		}
 	}
 
-	r.readContents(ctx)
+	if r.readContents(ctx, r.contentSplitFunc) && r.deleteAtEOF {
+		r.delete()
+	}
 }
 
-// createGzipReader creates gzip reader and returns the file offset
-func (r *Reader) createGzipReader() (int64, error) {
-	// We need to create a gzip reader each time ReadToEnd is called because the underlying
+// compressionFormat returns the compression format of the file, or an empty string if it isn't compressed
+func (r *Reader) compressionFormat() string {
+	if r.compression != compression.Auto {
+		return r.compression
+	}
+	for format, extension := range compressionExtensions {
+		if r.FileType == extension {
+			return format
+		}
+	}
+	return ""
+}
+
+// createDecompressingReader creates a reader decompressing the file from the current offset,
+// and returns the file offset
+func (r *Reader) createDecompressingReader(format string) (int64, io.Closer, error) {
+	// We need to create a decompressing reader each time ReadToEnd is called because the underlying
 	// SectionReader can only read a fixed window (from previous offset to EOF).
 	info, err := r.file.Stat()
 	if err != nil {
 		r.set.Logger.Error("failed to stat", zap.Error(err))
-		return 0, err
+		return 0, nil, err
 	}
 	currentEOF := info.Size()
-	// use a gzip Reader with an underlying SectionReader to pick up at the last
-	// offset of a gzip compressed file
-	gzipReader, err := gzip.NewReader(io.NewSectionReader(r.file, r.Offset, currentEOF))
+	if currentEOF <= r.Offset {
+		// nothing was appended since the last read
+		return 0, nil, io.EOF
+	}
+	// use a decompressing Reader with an underlying SectionReader to pick up at the last
+	// offset of a compressed file
+	decompressed, err := compression.NewReader(format, io.NewSectionReader(r.file, r.Offset, currentEOF))
 	if err != nil {
 		if !errors.Is(err, io.EOF) {
-			r.set.Logger.Error("failed to create gzip reader", zap.Error(err))
+			r.set.Logger.Error("failed to create decompressing reader", zap.String("compression", format), zap.Error(err))
 		}
-		return 0, err
+		return 0, nil, err
 	}
-	r.reader = gzipReader
-	return currentEOF, nil
+	r.reader = decompressed
+	return currentEOF, decompressed, nil
 }
 
 func (r *Reader) readHeader(ctx context.Context) (doneReadingFile bool) {
@@ -204,7 +228,9 @@ func (r *Reader) readHeader(ctx context.Context) (doneReadingFile bool) {
 	return false
 }
 
-func (r *Reader) readContents(ctx context.Context) {
+// readContents reads and emits the tokens of the file until its end, and returns true if the end of the file
+// was reached without error
+func (r *Reader) readContents(ctx context.Context, splitFunc bufio.SplitFunc) bool {
 	var buf []byte
 	if r.TokenLenState.MinimumLength <= r.initialBufferSize {
 		bufPtr := r.getBufPtrFromPool()
@@ -216,7 +242,7 @@ func (r *Reader) readContents(ctx context.Context) {
 		// Usually, expect this to be a rare event so that we don't bother pooling this special buffer size.
 		buf = make([]byte, 0, r.TokenLenState.MinimumLength+1)
 	}
-	s := scanner.New(r, r.maxLogSize, buf, r.Offset, r.contentSplitFunc)
+	s := scanner.New(r, r.maxLogSize, buf, r.Offset, splitFunc)
 
 	tokenBodies := make([][]byte, r.maxBatchSize)
 	tokenOffsets := make([]int64, r.maxBatchSize+1)
@@ -227,16 +253,15 @@ func (r *Reader) readContents(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
-			return
+			return false
 		default:
 		}
 
 		ok := s.Scan()
 		if !ok {
-			if err := s.Error(); err != nil {
-				r.set.Logger.Error("failed during scan", zap.Error(err))
-			} else if r.deleteAtEOF {
-				r.delete()
+			scanErr := s.Error()
+			if scanErr != nil {
+				r.set.Logger.Error("failed during scan", zap.Error(scanErr))
 			}
 
 			if numTokensBatched > 0 {
@@ -246,7 +271,7 @@ func (r *Reader) readContents(ctx context.Context) {
 				}
 				r.Offset = s.Pos()
 			}
-			return
+			return scanErr == nil
 		}
 
 		var err error
@@ -9,11 +9,13 @@ require (
 	github.com/goccy/go-json v0.10.5
 	github.com/jonboulle/clockwork v0.5.0
 	github.com/jpillora/backoff v1.0.0
+	github.com/klauspost/compress v1.18.2
 	github.com/leodido/go-syslog/v4 v4.3.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.143.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/common v0.143.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal v0.143.0
 	github.com/stretchr/testify v1.11.1
+	github.com/ulikunitz/xz v0.5.17
 	github.com/valyala/fastjson v1.6.7
 	go.opentelemetry.io/collector/component v1.49.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/component/componenttest v0.143.1-0.20260115162016-5e41fb551263
@@ -5,6 +5,8 @@ package loki // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/grafana/loki/pkg/push"
 	"github.com/prometheus/common/model"
@@ -37,7 +39,9 @@ const (
 // and "loki.resource.labels". Each hint might contain a comma-separated list of
 // attributes (resource or record) that should be promoted to a Loki label. Those
 // attributes are removed from the body as a result, otherwise they would be shown
-// in duplicity in Loki.
+// in duplicity in Loki. Similarly, the attributes listed in the hints
+// "loki.attribute.structured_metadata" and "loki.resource.structured_metadata"
+// are attached to the entries as structured metadata.
 // PushStreams are created based on the labels: all records containing the same
 // set of labels are part of the same stream. All streams are then packed within
 // the resulting PushRequest.
@@ -137,9 +141,12 @@ func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.Instru
 	format := getFormatFromFormatHint(log.Attributes(), resource.Attributes())
 
 	mergedLabels := convertAttributesAndMerge(log.Attributes(), resource.Attributes(), defaultLabelsEnabled)
-	// remove the attributes that were promoted to labels
+	structuredMetadata := convertStructuredMetadata(log.Attributes(), resource.Attributes())
+	// remove the attributes that were promoted to labels or structured metadata
 	removeAttributes(log.Attributes(), mergedLabels)
 	removeAttributes(resource.Attributes(), mergedLabels)
+	removeAttributes(log.Attributes(), structuredMetadata)
+	removeAttributes(resource.Attributes(), structuredMetadata)
 
 	entry, err := convertLogToLokiEntry(log, resource, format, scope)
 	if err != nil {
@@ -158,6 +165,18 @@ func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.Instru
 		labels[model.LabelName(labelName)] = mergedLabels[label]
 	}
 
+	for name, value := range structuredMetadata {
+		// structured metadata names follow the same rules as label names
+		metadataName, err := namer.Build(string(name))
+		if err != nil {
+			return nil, err
+		}
+		entry.StructuredMetadata = append(entry.StructuredMetadata, push.LabelAdapter{Name: metadataName, Value: string(value)})
+	}
+	slices.SortFunc(entry.StructuredMetadata, func(a, b push.LabelAdapter) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
 	return &PushEntry{
 		Entry:  entry,
 		Labels: labels,
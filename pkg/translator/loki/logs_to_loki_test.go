@@ -641,6 +641,38 @@ func TestLogToLokiEntry(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "with structured metadata",
+			timestamp: time.Unix(0, 1677592916000000000),
+			attrs: map[string]any{
+				"trace.id":    "4bf92f3577b34da6",
+				"http.status": 200,
+			},
+			res: map[string]any{
+				"host.name": "guarana",
+				"pod.uid":   "d6f4c8",
+			},
+			hints: map[string]any{
+				hintResources:                    "host.name",
+				hintAttributesStructuredMetadata: "trace.id",
+				hintResourcesStructuredMetadata:  "pod.uid",
+				hintFormat:                       formatLogfmt,
+			},
+			expected: &PushEntry{
+				Entry: &push.Entry{
+					Timestamp: time.Unix(0, 1677592916000000000),
+					Line:      `attribute_http.status=200`,
+					StructuredMetadata: push.LabelsAdapter{
+						{Name: "pod_uid", Value: "d6f4c8"},
+						{Name: "trace_id", Value: "4bf92f3577b34da6"},
+					},
+				},
+				Labels: model.LabelSet{
+					"exporter":  "OTLP",
+					"host_name": "guarana",
+				},
+			},
+		},
 		{
 			name:      "with unknown format hint",
 			timestamp: time.Unix(0, 1677592916000000000),
@@ -25,6 +25,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 )
 
 // Config defines configuration for Resource processor.
@@ -36,6 +37,10 @@ type Config struct {
 	// The default value is `propagate`.
 	ErrorMode ottl.ErrorMode `mapstructure:"error_mode"`
 
+	// Functions declares reusable OTTL functions composed of other OTTL functions, which can be
+	// called in the conditions of all signals and contexts.
+	Functions []ottl.FunctionDefinition `mapstructure:"functions"`
+
 	Metrics MetricFilters `mapstructure:"metrics"`
 
 	Logs LogFilters `mapstructure:"logs"`
@@ -51,6 +56,7 @@ type Config struct {
 	logFunctions       map[string]ottl.Factory[*ottllog.TransformContext]
 	metricFunctions    map[string]ottl.Factory[*ottlmetric.TransformContext]
 	spanEventFunctions map[string]ottl.Factory[*ottlspanevent.TransformContext]
+	spanLinkFunctions  map[string]ottl.Factory[*ottlspanlink.TransformContext]
 	spanFunctions      map[string]ottl.Factory[*ottlspan.TransformContext]
 	profileFunctions   map[string]ottl.Factory[ottlprofile.TransformContext]
 }
@@ -102,6 +108,11 @@ type TraceFilters struct {
 	// If any condition resolves to true, the span event will be dropped.
 	// Supports `and`, `or`, and `()`
 	SpanEventConditions []string `mapstructure:"spanevent"`
+
+	// SpanLinkConditions is a list of OTTL conditions for an ottlspanlink context.
+	// If any condition resolves to true, the span link will be dropped.
+	// Supports `and`, `or`, and `()`
+	SpanLinkConditions []string `mapstructure:"spanlink"`
 }
 
 // LogFilters filters by Log properties.
@@ -313,7 +324,7 @@ var _ component.Config = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
-	if (cfg.Traces.ResourceConditions != nil || cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil) && (cfg.Spans.Include != nil || cfg.Spans.Exclude != nil) {
+	if (cfg.Traces.ResourceConditions != nil || cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil || cfg.Traces.SpanLinkConditions != nil) && (cfg.Spans.Include != nil || cfg.Spans.Exclude != nil) {
 		return errors.New("cannot use ottl conditions and include/exclude for spans at the same time")
 	}
 	if (cfg.Metrics.ResourceConditions != nil || cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil) && (cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil) {
@@ -326,52 +337,57 @@ func (cfg *Config) Validate() error {
 	var errors error
 
 	if cfg.Traces.ResourceConditions != nil {
-		_, err := filterottl.NewBoolExprForResource(cfg.Metrics.ResourceConditions, cfg.resourceFunctions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := newBoolExpr(filterottl.NewBoolExprForResource, cfg.Metrics.ResourceConditions, cfg.resourceFunctions, cfg.Functions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Traces.SpanConditions != nil {
-		_, err := filterottl.NewBoolExprForSpan(cfg.Traces.SpanConditions, cfg.spanFunctions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := newBoolExpr(filterottl.NewBoolExprForSpan, cfg.Traces.SpanConditions, cfg.spanFunctions, cfg.Functions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Traces.SpanEventConditions != nil {
-		_, err := filterottl.NewBoolExprForSpanEvent(cfg.Traces.SpanEventConditions, cfg.spanEventFunctions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := newBoolExpr(filterottl.NewBoolExprForSpanEvent, cfg.Traces.SpanEventConditions, cfg.spanEventFunctions, cfg.Functions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		errors = multierr.Append(errors, err)
+	}
+
+	if cfg.Traces.SpanLinkConditions != nil {
+		_, err := newBoolExpr(filterottl.NewBoolExprForSpanLink, cfg.Traces.SpanLinkConditions, cfg.spanLinkFunctions, cfg.Functions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Metrics.ResourceConditions != nil {
-		_, err := filterottl.NewBoolExprForResource(cfg.Metrics.ResourceConditions, cfg.resourceFunctions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := newBoolExpr(filterottl.NewBoolExprForResource, cfg.Metrics.ResourceConditions, cfg.resourceFunctions, cfg.Functions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Metrics.MetricConditions != nil {
-		_, err := filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, cfg.metricFunctions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := newBoolExpr(filterottl.NewBoolExprForMetric, cfg.Metrics.MetricConditions, cfg.metricFunctions, cfg.Functions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Metrics.DataPointConditions != nil {
-		_, err := filterottl.NewBoolExprForDataPoint(cfg.Metrics.DataPointConditions, cfg.dataPointFunctions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := newBoolExpr(filterottl.NewBoolExprForDataPoint, cfg.Metrics.DataPointConditions, cfg.dataPointFunctions, cfg.Functions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Logs.ResourceConditions != nil {
-		_, err := filterottl.NewBoolExprForResource(cfg.Metrics.ResourceConditions, cfg.resourceFunctions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := newBoolExpr(filterottl.NewBoolExprForResource, cfg.Metrics.ResourceConditions, cfg.resourceFunctions, cfg.Functions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Logs.LogConditions != nil {
-		_, err := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, cfg.logFunctions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := newBoolExpr(filterottl.NewBoolExprForLog, cfg.Logs.LogConditions, cfg.logFunctions, cfg.Functions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Profiles.ResourceConditions != nil {
-		_, err := filterottl.NewBoolExprForResource(cfg.Metrics.ResourceConditions, cfg.resourceFunctions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := newBoolExpr(filterottl.NewBoolExprForResource, cfg.Metrics.ResourceConditions, cfg.resourceFunctions, cfg.Functions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Profiles.ProfileConditions != nil {
-		_, err := filterottl.NewBoolExprForProfile(cfg.Profiles.ProfileConditions, cfg.profileFunctions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := newBoolExpr(filterottl.NewBoolExprForProfile, cfg.Profiles.ProfileConditions, cfg.profileFunctions, cfg.Functions, ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
 	}
 
@@ -38,6 +38,9 @@ func assertConfigContainsDefaultFunctions(t *testing.T, config Config) {
 	for _, f := range DefaultSpanEventFunctionsNew() {
 		assert.Contains(t, config.spanEventFunctions, f.Name(), "missing span event function %v", f.Name())
 	}
+	for _, f := range DefaultSpanLinkFunctions() {
+		assert.Contains(t, config.spanLinkFunctions, f.Name(), "missing span link function %v", f.Name())
+	}
 	for _, f := range DefaultProfileFunctions() {
 		assert.Contains(t, config.profileFunctions, f.Name(), "missing profile function %v", f.Name())
 	}
@@ -883,6 +886,9 @@ func TestLoadingConfigOTTL(t *testing.T) {
 					SpanEventConditions: []string{
 						`attributes["test"] == "pass"`,
 					},
+					SpanLinkConditions: []string{
+						`attributes["test"] == "pass"`,
+					},
 				},
 				Metrics: MetricFilters{
 					ResourceConditions: []string{
@@ -925,6 +931,33 @@ func TestLoadingConfigOTTL(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "functions"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Functions: []ottl.FunctionDefinition{
+					{
+						Name:       "IsHealthCheck",
+						Params:     []string{"path"},
+						Expression: `IsMatch(path, "^/(health|ready)z?$")`,
+					},
+				},
+				Traces: TraceFilters{
+					SpanConditions: []string{
+						`IsHealthCheck(attributes["http.path"])`,
+					},
+				},
+				Logs: LogFilters{
+					LogConditions: []string{
+						`IsHealthCheck(attributes["url.path"])`,
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "bad_function_definition"),
+			errorMessage: `function "IsMatch" is already defined`,
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "spans_mix_config"),
 			errorMessage: "cannot use ottl conditions and include/exclude for spans at the same time",
@@ -943,6 +976,9 @@ func TestLoadingConfigOTTL(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_spanevent"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_spanlink"),
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_metric"),
 		},
@@ -23,6 +23,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor/internal/metadata"
 )
 
@@ -34,6 +35,7 @@ type filterProcessorFactory struct {
 	logFunctions                        map[string]ottl.Factory[*ottllog.TransformContext]
 	metricFunctions                     map[string]ottl.Factory[*ottlmetric.TransformContext]
 	spanEventFunctions                  map[string]ottl.Factory[*ottlspanevent.TransformContext]
+	spanLinkFunctions                   map[string]ottl.Factory[*ottlspanlink.TransformContext]
 	spanFunctions                       map[string]ottl.Factory[*ottlspan.TransformContext]
 	profileFunctions                    map[string]ottl.Factory[ottlprofile.TransformContext]
 	defaultResourceFunctionsOverridden  bool
@@ -41,6 +43,7 @@ type filterProcessorFactory struct {
 	defaultLogFunctionsOverridden       bool
 	defaultMetricFunctionsOverridden    bool
 	defaultSpanEventFunctionsOverridden bool
+	defaultSpanLinkFunctionsOverridden  bool
 	defaultSpanFunctionsOverridden      bool
 	defaultProfileFunctionsOverridden   bool
 }
@@ -153,6 +156,18 @@ func WithSpanEventFunctionsNew(spanEventFunctions []ottl.Factory[*ottlspanevent.
 	}
 }
 
+// WithSpanLinkFunctions will override the default OTTL spanlink context functions with the provided spanLinkFunctions in the resulting processor.
+// Subsequent uses of WithSpanLinkFunctions will merge the provided spanLinkFunctions with the previously registered functions.
+func WithSpanLinkFunctions(spanLinkFunctions []ottl.Factory[*ottlspanlink.TransformContext]) FactoryOption {
+	return func(factory *filterProcessorFactory) {
+		if !factory.defaultSpanLinkFunctionsOverridden {
+			factory.spanLinkFunctions = map[string]ottl.Factory[*ottlspanlink.TransformContext]{}
+			factory.defaultSpanLinkFunctionsOverridden = true
+		}
+		factory.spanLinkFunctions = mergeFunctionsToMap(factory.spanLinkFunctions, spanLinkFunctions)
+	}
+}
+
 // Deprecated: [v0.142.0] use WithSpanFunctionsNew.
 func WithSpanFunctions(spanFunctions []ottl.Factory[ottlspan.TransformContext]) FactoryOption {
 	newSpanFunctions := make([]ottl.Factory[*ottlspan.TransformContext], 0, len(spanFunctions))
@@ -199,6 +214,7 @@ func NewFactoryWithOptions(options ...FactoryOption) processor.Factory {
 		logFunctions:       defaultLogFunctionsMap(),
 		metricFunctions:    defaultMetricFunctionsMap(),
 		spanEventFunctions: defaultSpanEventFunctionsMap(),
+		spanLinkFunctions:  defaultSpanLinkFunctionsMap(),
 		spanFunctions:      defaultSpanFunctionsMap(),
 		profileFunctions:   defaultProfileFunctionsMap(),
 	}
@@ -224,6 +240,7 @@ func (f *filterProcessorFactory) createDefaultConfig() component.Config {
 		logFunctions:       f.logFunctions,
 		metricFunctions:    f.metricFunctions,
 		spanEventFunctions: f.spanEventFunctions,
+		spanLinkFunctions:  f.spanLinkFunctions,
 		spanFunctions:      f.spanFunctions,
 		profileFunctions:   f.profileFunctions,
 	}
@@ -286,11 +303,12 @@ func (f *filterProcessorFactory) createTracesProcessor(
 	cfg component.Config,
 	nextConsumer consumer.Traces,
 ) (processor.Traces, error) {
-	if f.defaultResourceFunctionsOverridden || f.defaultSpanEventFunctionsOverridden || f.defaultSpanFunctionsOverridden {
+	if f.defaultResourceFunctionsOverridden || f.defaultSpanEventFunctionsOverridden || f.defaultSpanLinkFunctionsOverridden || f.defaultSpanFunctionsOverridden {
 		set.Logger.Debug("non-default OTTL trace functions have been registered in the \"filter\" processor",
 			zap.Bool("resource", f.defaultResourceFunctionsOverridden),
 			zap.Bool("span", f.defaultSpanFunctionsOverridden),
 			zap.Bool("spanevent", f.defaultSpanEventFunctionsOverridden),
+			zap.Bool("spanlink", f.defaultSpanLinkFunctionsOverridden),
 		)
 	}
 	fp, err := newFilterSpansProcessor(set, cfg.(*Config))
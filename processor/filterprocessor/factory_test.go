@@ -25,6 +25,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor/internal/metadata"
 )
 
@@ -293,6 +294,30 @@ func Test_FactoryWithFunctions_CreateTraces(t *testing.T) {
 				WithSpanEventFunctionsNew([]ottl.Factory[*ottlspanevent.TransformContext]{createTestFuncFactory[*ottlspanevent.TransformContext]("TestSpanEventFunc")}),
 			},
 		},
+		{
+			name: "with span link functions : statement with added span link func",
+			conditions: TraceFilters{
+				SpanLinkConditions: []string{
+					`TestSpanLinkFunc() and IsBool(true)`,
+				},
+			},
+			factoryOptions: []FactoryOption{
+				WithSpanLinkFunctions(DefaultSpanLinkFunctions()),
+				WithSpanLinkFunctions([]ottl.Factory[*ottlspanlink.TransformContext]{createTestFuncFactory[*ottlspanlink.TransformContext]("TestSpanLinkFunc")}),
+			},
+		},
+		{
+			name: "with span link functions : statement with missing span link func",
+			conditions: TraceFilters{
+				SpanLinkConditions: []string{
+					`TestSpanLinkFunc() and IsBool(true)`,
+				},
+			},
+			wantErrorWith: `undefined function "TestSpanLinkFunc"`,
+			factoryOptions: []FactoryOption{
+				WithSpanLinkFunctions(DefaultSpanLinkFunctions()),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
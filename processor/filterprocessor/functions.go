@@ -7,6 +7,8 @@ import (
 	"maps"
 	"slices"
 
+	"go.opentelemetry.io/collector/component"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
@@ -16,9 +18,26 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
 )
 
+// newBoolExpr parses the conditions with the given functions and the functions declared in the configuration.
+func newBoolExpr[K any](
+	newBoolExprFunc func([]string, map[string]ottl.Factory[K], ottl.ErrorMode, component.TelemetrySettings) (*ottl.ConditionSequence[K], error),
+	conditions []string,
+	functions map[string]ottl.Factory[K],
+	definitions []ottl.FunctionDefinition,
+	errorMode ottl.ErrorMode,
+	set component.TelemetrySettings,
+) (*ottl.ConditionSequence[K], error) {
+	functions, err := ottl.AddFunctionDefinitions(functions, definitions)
+	if err != nil {
+		return nil, err
+	}
+	return newBoolExprFunc(conditions, functions, errorMode, set)
+}
+
 func DefaultResourceFunctions() []ottl.Factory[*ottlresource.TransformContext] {
 	return slices.Collect(maps.Values(defaultResourceFunctionsMap()))
 }
@@ -76,6 +95,10 @@ func DefaultSpanEventFunctionsNew() []ottl.Factory[*ottlspanevent.TransformConte
 	return slices.Collect(maps.Values(defaultSpanEventFunctionsMap()))
 }
 
+func DefaultSpanLinkFunctions() []ottl.Factory[*ottlspanlink.TransformContext] {
+	return slices.Collect(maps.Values(defaultSpanLinkFunctionsMap()))
+}
+
 func DefaultProfileFunctions() []ottl.Factory[ottlprofile.TransformContext] {
 	return slices.Collect(maps.Values(defaultProfileFunctionsMap()))
 }
@@ -104,6 +127,10 @@ func defaultSpanEventFunctionsMap() map[string]ottl.Factory[*ottlspanevent.Trans
 	return filterottl.StandardSpanEventFuncs()
 }
 
+func defaultSpanLinkFunctionsMap() map[string]ottl.Factory[*ottlspanlink.TransformContext] {
+	return filterottl.StandardSpanLinkFuncs()
+}
+
 func defaultProfileFunctionsMap() map[string]ottl.Factory[ottlprofile.TransformContext] {
 	return filterottl.StandardProfileFuncs()
 }
@@ -42,14 +42,14 @@ func newFilterLogsProcessor(set processor.Settings, cfg *Config) (*filterLogProc
 
 	if cfg.Logs.ResourceConditions != nil || cfg.Logs.LogConditions != nil {
 		if cfg.Logs.ResourceConditions != nil {
-			flp.skipResourceExpr, err = filterottl.NewBoolExprForResource(cfg.Logs.ResourceConditions, cfg.resourceFunctions, cfg.ErrorMode, set.TelemetrySettings)
+			flp.skipResourceExpr, err = newBoolExpr(filterottl.NewBoolExprForResource, cfg.Logs.ResourceConditions, cfg.resourceFunctions, cfg.Functions, cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		if cfg.Logs.LogConditions != nil {
-			flp.skipLogRecordExpr, err = filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, cfg.logFunctions, cfg.ErrorMode, set.TelemetrySettings)
+			flp.skipLogRecordExpr, err = newBoolExpr(filterottl.NewBoolExprForLog, cfg.Logs.LogConditions, cfg.logFunctions, cfg.Functions, cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
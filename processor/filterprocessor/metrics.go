@@ -47,21 +47,21 @@ func newFilterMetricProcessor(set processor.Settings, cfg *Config) (*filterMetri
 
 	if cfg.Metrics.ResourceConditions != nil || cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil {
 		if cfg.Metrics.ResourceConditions != nil {
-			fsp.skipResourceExpr, err = filterottl.NewBoolExprForResource(cfg.Metrics.ResourceConditions, cfg.resourceFunctions, cfg.ErrorMode, set.TelemetrySettings)
+			fsp.skipResourceExpr, err = newBoolExpr(filterottl.NewBoolExprForResource, cfg.Metrics.ResourceConditions, cfg.resourceFunctions, cfg.Functions, cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		if cfg.Metrics.MetricConditions != nil {
-			fsp.skipMetricExpr, err = filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, cfg.metricFunctions, cfg.ErrorMode, set.TelemetrySettings)
+			fsp.skipMetricExpr, err = newBoolExpr(filterottl.NewBoolExprForMetric, cfg.Metrics.MetricConditions, cfg.metricFunctions, cfg.Functions, cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		if cfg.Metrics.DataPointConditions != nil {
-			fsp.skipDataPointExpr, err = filterottl.NewBoolExprForDataPoint(cfg.Metrics.DataPointConditions, cfg.dataPointFunctions, cfg.ErrorMode, set.TelemetrySettings)
+			fsp.skipDataPointExpr, err = newBoolExpr(filterottl.NewBoolExprForDataPoint, cfg.Metrics.DataPointConditions, cfg.dataPointFunctions, cfg.Functions, cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
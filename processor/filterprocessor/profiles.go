@@ -39,14 +39,14 @@ func newFilterProfilesProcessor(set processor.Settings, cfg *Config) (*filterPro
 	fpp.telemetry = fpt
 
 	if cfg.Profiles.ResourceConditions != nil {
-		fpp.skipResourceExpr, err = filterottl.NewBoolExprForResource(cfg.Profiles.ResourceConditions, cfg.resourceFunctions, cfg.ErrorMode, set.TelemetrySettings)
+		fpp.skipResourceExpr, err = newBoolExpr(filterottl.NewBoolExprForResource, cfg.Profiles.ResourceConditions, cfg.resourceFunctions, cfg.Functions, cfg.ErrorMode, set.TelemetrySettings)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if cfg.Profiles.ProfileConditions != nil {
-		fpp.skipProfileExpr, err = filterottl.NewBoolExprForProfile(cfg.Profiles.ProfileConditions, cfg.profileFunctions, cfg.ErrorMode, set.TelemetrySettings)
+		fpp.skipProfileExpr, err = newBoolExpr(filterottl.NewBoolExprForProfile, cfg.Profiles.ProfileConditions, cfg.profileFunctions, cfg.Functions, cfg.ErrorMode, set.TelemetrySettings)
 		if err != nil {
 			return nil, err
 		}
@@ -20,12 +20,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 )
 
 type filterSpanProcessor struct {
 	skipResourceExpr  expr.BoolExpr[*ottlresource.TransformContext]
 	skipSpanExpr      expr.BoolExpr[*ottlspan.TransformContext]
 	skipSpanEventExpr expr.BoolExpr[*ottlspanevent.TransformContext]
+	skipSpanLinkExpr  expr.BoolExpr[*ottlspanlink.TransformContext]
 	telemetry         *filterTelemetry
 	logger            *zap.Logger
 }
@@ -42,22 +44,28 @@ func newFilterSpansProcessor(set processor.Settings, cfg *Config) (*filterSpanPr
 	}
 	fsp.telemetry = fpt
 
-	if cfg.Traces.ResourceConditions != nil || cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil {
+	if cfg.Traces.ResourceConditions != nil || cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil || cfg.Traces.SpanLinkConditions != nil {
 		if cfg.Traces.ResourceConditions != nil {
-			fsp.skipResourceExpr, err = filterottl.NewBoolExprForResource(cfg.Traces.ResourceConditions, cfg.resourceFunctions, cfg.ErrorMode, set.TelemetrySettings)
+			fsp.skipResourceExpr, err = newBoolExpr(filterottl.NewBoolExprForResource, cfg.Traces.ResourceConditions, cfg.resourceFunctions, cfg.Functions, cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		if cfg.Traces.SpanConditions != nil {
-			fsp.skipSpanExpr, err = filterottl.NewBoolExprForSpan(cfg.Traces.SpanConditions, cfg.spanFunctions, cfg.ErrorMode, set.TelemetrySettings)
+			fsp.skipSpanExpr, err = newBoolExpr(filterottl.NewBoolExprForSpan, cfg.Traces.SpanConditions, cfg.spanFunctions, cfg.Functions, cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
 		}
 		if cfg.Traces.SpanEventConditions != nil {
-			fsp.skipSpanEventExpr, err = filterottl.NewBoolExprForSpanEvent(cfg.Traces.SpanEventConditions, cfg.spanEventFunctions, cfg.ErrorMode, set.TelemetrySettings)
+			fsp.skipSpanEventExpr, err = newBoolExpr(filterottl.NewBoolExprForSpanEvent, cfg.Traces.SpanEventConditions, cfg.spanEventFunctions, cfg.Functions, cfg.ErrorMode, set.TelemetrySettings)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if cfg.Traces.SpanLinkConditions != nil {
+			fsp.skipSpanLinkExpr, err = newBoolExpr(filterottl.NewBoolExprForSpanLink, cfg.Traces.SpanLinkConditions, cfg.spanLinkFunctions, cfg.Functions, cfg.ErrorMode, set.TelemetrySettings)
 			if err != nil {
 				return nil, err
 			}
@@ -90,7 +98,7 @@ func newFilterSpansProcessor(set processor.Settings, cfg *Config) (*filterSpanPr
 
 // processTraces filters the given spans of a traces based off the filterSpanProcessor's filters.
 func (fsp *filterSpanProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
-	if fsp.skipResourceExpr == nil && fsp.skipSpanExpr == nil && fsp.skipSpanEventExpr == nil {
+	if fsp.skipResourceExpr == nil && fsp.skipSpanExpr == nil && fsp.skipSpanEventExpr == nil && fsp.skipSpanLinkExpr == nil {
 		return td, nil
 	}
 
@@ -111,7 +119,7 @@ func (fsp *filterSpanProcessor) processTraces(ctx context.Context, td ptrace.Tra
 				return true
 			}
 		}
-		if fsp.skipSpanExpr == nil && fsp.skipSpanEventExpr == nil {
+		if fsp.skipSpanExpr == nil && fsp.skipSpanEventExpr == nil && fsp.skipSpanLinkExpr == nil {
 			return rs.ScopeSpans().Len() == 0
 		}
 		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
@@ -140,6 +148,20 @@ func (fsp *filterSpanProcessor) processTraces(ctx context.Context, td ptrace.Tra
 						return skip
 					})
 				}
+				if fsp.skipSpanLinkExpr != nil {
+					linkIndex := int64(-1)
+					span.Links().RemoveIf(func(spanLink ptrace.SpanLink) bool {
+						linkIndex++
+						tCtx := ottlspanlink.NewTransformContextPtr(rs, ss, span, spanLink, ottlspanlink.WithLinkIndex(linkIndex))
+						skip, err := fsp.skipSpanLinkExpr.Eval(ctx, tCtx)
+						tCtx.Close()
+						if err != nil {
+							errors = multierr.Append(errors, err)
+							return false
+						}
+						return skip
+					})
+				}
 				return false
 			})
 			return ss.Spans().Len() == 0
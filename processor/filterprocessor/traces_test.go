@@ -197,6 +197,7 @@ func TestFilterTraceProcessorWithOTTL(t *testing.T) {
 	tests := []struct {
 		name             string
 		conditions       TraceFilters
+		functions        []ottl.FunctionDefinition
 		filterEverything bool
 		want             func(td ptrace.Traces)
 		errorMode        ottl.ErrorMode
@@ -255,6 +256,48 @@ func TestFilterTraceProcessorWithOTTL(t *testing.T) {
 			},
 			errorMode: ottl.IgnoreError,
 		},
+		{
+			name: "drop span links",
+			conditions: TraceFilters{
+				SpanLinkConditions: []string{
+					`link_index == 1 and span.name == "operationB"`,
+				},
+			},
+			want: func(td ptrace.Traces) {
+				for i := 0; i < td.ResourceSpans().At(0).ScopeSpans().Len(); i++ {
+					linkIndex := 0
+					td.ResourceSpans().At(0).ScopeSpans().At(i).Spans().At(1).Links().RemoveIf(func(ptrace.SpanLink) bool {
+						linkIndex++
+						return linkIndex == 2
+					})
+				}
+			},
+			errorMode: ottl.IgnoreError,
+		},
+		{
+			name: "drop spans with a defined function",
+			functions: []ottl.FunctionDefinition{
+				{
+					Name:      "IsOperation",
+					Params:    []string{"value", "suffix"},
+					Condition: `value == Concat(["operation", suffix], "")`,
+				},
+			},
+			conditions: TraceFilters{
+				SpanConditions: []string{
+					`IsOperation(name, "A")`,
+				},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().RemoveIf(func(span ptrace.Span) bool {
+					return span.Name() == "operationA"
+				})
+				td.ResourceSpans().At(0).ScopeSpans().At(1).Spans().RemoveIf(func(span ptrace.Span) bool {
+					return span.Name() == "operationA"
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
 		{
 			name: "multiple conditions",
 			conditions: TraceFilters{
@@ -279,7 +322,7 @@ func TestFilterTraceProcessorWithOTTL(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cfg := &Config{Traces: tt.conditions, ErrorMode: tt.errorMode, spanFunctions: defaultSpanFunctionsMap()}
+			cfg := &Config{Traces: tt.conditions, Functions: tt.functions, ErrorMode: tt.errorMode, spanFunctions: defaultSpanFunctionsMap()}
 			processor, err := newFilterSpansProcessor(processortest.NewNopSettings(metadata.Type), cfg)
 			assert.NoError(t, err)
 
@@ -6,6 +6,8 @@ package geoipprocessor // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"go.opentelemetry.io/collector/component"
@@ -39,9 +41,14 @@ func (c *ContextID) UnmarshalText(text []byte) error {
 
 // Config holds the configuration for the GeoIP processor.
 type Config struct {
-	// Providers specifies the sources to extract geographical information about a given IP.
+	// Providers specifies the sources to extract geographical information about a given IP. The keys are the type of
+	// the providers, optionally followed by a slash and a name to configure several providers of the same type.
 	Providers map[string]provider.Config `mapstructure:"-"`
 
+	// ProviderOrder specifies the order in which the providers are queried. When several providers return the same
+	// attribute, the value of the first one is kept. Defaults to the keys of the providers in alphabetical order.
+	ProviderOrder []string `mapstructure:"provider_order"`
+
 	// Context section allows specifying the source type to look for the IP. Available options: resource or record.
 	Context ContextID `mapstructure:"context"`
 
@@ -66,6 +73,20 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.ProviderOrder != nil {
+		for i, providerID := range cfg.ProviderOrder {
+			if _, ok := cfg.Providers[providerID]; !ok {
+				return fmt.Errorf("provider_order references an unknown provider: %s", providerID)
+			}
+			if slices.Contains(cfg.ProviderOrder[:i], providerID) {
+				return fmt.Errorf("provider_order contains provider %s more than once", providerID)
+			}
+		}
+		if len(cfg.ProviderOrder) != len(cfg.Providers) {
+			return errors.New("provider_order must list all the providers")
+		}
+	}
+
 	if cfg.Attributes != nil && len(cfg.Attributes) == 0 {
 		return errors.New("the attributes array must not be empty")
 	}
@@ -73,6 +94,19 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
+// orderedProviders returns the keys of the providers in the order they must be queried.
+func (cfg *Config) orderedProviders() []string {
+	if cfg.ProviderOrder != nil {
+		return cfg.ProviderOrder
+	}
+	keys := make([]string, 0, len(cfg.Providers))
+	for key := range cfg.Providers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // Unmarshal a config.Parser into the config struct.
 func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 	if componentParser == nil {
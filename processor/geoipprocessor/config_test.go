@@ -7,6 +7,7 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,6 +19,8 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
 	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
@@ -76,6 +79,35 @@ func TestLoadConfig(t *testing.T) {
 				Attributes: []attribute.Key{"client.address", "source.address", "custom.address"},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "multiple_providers"),
+			expected: &Config{
+				Context: resource,
+				Providers: map[string]provider.Config{
+					"csv":          &csvprovider.Config{DatabasePath: "/tmp/networks.csv", ReloadInterval: time.Minute},
+					"ip2location":  &ip2locationprovider.Config{DatabasePath: "/tmp/IP2LOCATION-DB11.BIN"},
+					"maxmind/city": &maxmind.Config{DatabasePath: "/tmp/db"},
+				},
+				ProviderOrder: []string{"csv", "maxmind/city", "ip2location"},
+				Attributes:    defaultAttributes,
+			},
+		},
+		{
+			id:                   component.NewIDWithName(metadata.Type, "unknown_provider_order"),
+			validateErrorMessage: "provider_order references an unknown provider: csv",
+		},
+		{
+			id:                   component.NewIDWithName(metadata.Type, "duplicate_provider_order"),
+			validateErrorMessage: "provider_order contains provider maxmind more than once",
+		},
+		{
+			id:                   component.NewIDWithName(metadata.Type, "incomplete_provider_order"),
+			validateErrorMessage: "provider_order must list all the providers",
+		},
+		{
+			id:                    component.NewIDWithName(metadata.Type, "invalid_provider_name"),
+			unmarshalErrorMessage: "invalid provider key: maxmind/",
+		},
 	}
 
 	for _, tt := range tests {
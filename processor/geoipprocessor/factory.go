@@ -6,6 +6,7 @@ package geoipprocessor // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -16,6 +17,8 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
 	maxmind "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/maxmindprovider"
 )
 
@@ -33,7 +36,9 @@ var (
 
 // providerFactories is a map that stores GeoIPProviderFactory instances, keyed by the provider type.
 var providerFactories = map[string]provider.GeoIPProviderFactory{
-	maxmind.TypeStr: &maxmind.Factory{},
+	maxmind.TypeStr:             &maxmind.Factory{},
+	ip2locationprovider.TypeStr: &ip2locationprovider.Factory{},
+	csvprovider.TypeStr:         &csvprovider.Factory{},
 }
 
 // NewFactory creates a new processor factory with default configuration,
@@ -45,13 +50,23 @@ func NewFactory() processor.Factory {
 // getProviderFactory retrieves the GeoIPProviderFactory for the given key.
 // It returns the factory and a boolean indicating whether the factory was found.
 func getProviderFactory(key string) (provider.GeoIPProviderFactory, bool) {
-	if factory, ok := providerFactories[key]; ok {
+	if strings.HasSuffix(key, "/") {
+		return nil, false
+	}
+	if factory, ok := providerFactories[providerType(key)]; ok {
 		return factory, true
 	}
 
 	return nil, false
 }
 
+// providerType returns the type of the provider configured with the given key, which is either a type or a type
+// followed by a slash and a name, e.g. "csv/datacenters".
+func providerType(key string) string {
+	providerType, _, _ := strings.Cut(key, "/")
+	return providerType
+}
+
 // createDefaultConfig returns a default configuration for the processor.
 func createDefaultConfig() component.Config {
 	return &Config{
@@ -69,8 +84,9 @@ func createGeoIPProviders(
 ) ([]provider.GeoIPProvider, error) {
 	providers := make([]provider.GeoIPProvider, 0, len(config.Providers))
 
-	for key, cfg := range config.Providers {
-		factory := factories[key]
+	for _, key := range config.orderedProviders() {
+		cfg := config.Providers[key]
+		factory := factories[providerType(key)]
 		if factory == nil {
 			return nil, fmt.Errorf("geoIP provider factory not found for key: %q", key)
 		}
@@ -87,3 +87,41 @@ func TestCreateProcessor_FailedProvider(t *testing.T) {
 	_, err := factory.CreateMetrics(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
 	assert.EqualError(t, err, fmt.Errorf("failed to create provider for key %q: %w", providerKey, errors.New("error creating provider")).Error())
 }
+
+func TestCreateGeoIPProviders_Order(t *testing.T) {
+	// each provider configuration creates its own provider
+	configs := map[string]*providerConfigMock{"mock": {}, "mock/a": {}, "mock/b": {}}
+	providers := map[*providerConfigMock]*providerMock{}
+	for _, cfg := range configs {
+		providers[cfg] = &providerMock{}
+	}
+	factories := map[string]provider.GeoIPProviderFactory{
+		"mock": &providerFactoryMock{
+			CreateGeoIPProviderF: func(_ context.Context, _ processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
+				return providers[cfg.(*providerConfigMock)], nil
+			},
+		},
+	}
+	cfg := &Config{Providers: map[string]provider.Config{}}
+	for key, providerCfg := range configs {
+		cfg.Providers[key] = providerCfg
+	}
+
+	// the providers are sorted by key by default
+	created, err := createGeoIPProviders(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, factories)
+	assert.NoError(t, err)
+	assert.Equal(t, []provider.GeoIPProvider{
+		providers[configs["mock"]],
+		providers[configs["mock/a"]],
+		providers[configs["mock/b"]],
+	}, created)
+
+	cfg.ProviderOrder = []string{"mock/b", "mock", "mock/a"}
+	created, err = createGeoIPProviders(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, factories)
+	assert.NoError(t, err)
+	assert.Equal(t, []provider.GeoIPProvider{
+		providers[configs["mock/b"]],
+		providers[configs["mock"]],
+		providers[configs["mock/a"]],
+	}, created)
+}
@@ -68,7 +68,7 @@ func ipFromAttributes(attributes []attribute.Key, resource pcommon.Map) (netip.A
 	return netip.Addr{}, errIPNotFound
 }
 
-// geoLocation fetches geolocation information for the given IP address using the configured providers.
+// geoLocation fetches geolocation information for the given IP address using the configured providers, in order.
 // It returns a set of attributes containing the geolocation data, or an error if the location could not be determined.
 func (g *geoIPProcessor) geoLocation(ctx context.Context, ip netip.Addr) (attribute.Set, error) {
 	allAttributes := &attribute.Set{}
@@ -82,7 +82,8 @@ func (g *geoIPProcessor) geoLocation(ctx context.Context, ip netip.Addr) (attrib
 			}
 			return attribute.Set{}, err
 		}
-		*allAttributes = attribute.NewSet(append(allAttributes.ToSlice(), geoAttributes.ToSlice()...)...)
+		// the attributes of the providers queried first take precedence, as the last value of a key is kept
+		*allAttributes = attribute.NewSet(append(geoAttributes.ToSlice(), allAttributes.ToSlice()...)...)
 	}
 
 	return *allAttributes, nil
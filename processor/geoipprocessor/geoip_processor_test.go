@@ -249,6 +249,35 @@ func TestProcessor(t *testing.T) {
 	}
 }
 
+func TestGeoLocationProviderPrecedence(t *testing.T) {
+	newProvider := func(attrs ...attribute.KeyValue) provider.GeoIPProvider {
+		return &providerMock{
+			LocationF: func(context.Context, netip.Addr) (attribute.Set, error) {
+				if len(attrs) == 0 {
+					return attribute.Set{}, provider.ErrNoMetadataFound
+				}
+				return attribute.NewSet(attrs...), nil
+			},
+		}
+	}
+	processor := newGeoIPProcessor(&Config{}, []provider.GeoIPProvider{
+		newProvider(),
+		newProvider(attribute.String("datacenter", "fra1"), attribute.String(conventions.AttributeGeoCityName, "Frankfurt")),
+		newProvider(),
+		newProvider(attribute.String(conventions.AttributeGeoCityName, "Offenbach"), attribute.String(conventions.AttributeGeoCountryIsoCode, "DE")),
+	}, processortest.NewNopSettings(metadata.Type))
+
+	attrs, err := processor.geoLocation(t.Context(), netip.MustParseAddr("10.12.0.1"))
+	require.NoError(t, err)
+
+	// the attributes of the first providers take precedence
+	assert.Equal(t, attribute.NewSet(
+		attribute.String("datacenter", "fra1"),
+		attribute.String(conventions.AttributeGeoCityName, "Frankfurt"),
+		attribute.String(conventions.AttributeGeoCountryIsoCode, "DE"),
+	), attrs)
+}
+
 func TestProcessorShutdownError(t *testing.T) {
 	// processor with two mocked providers that return error on close
 	processor := geoIPProcessor{
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvprovider
+
+import (
+	"net/netip"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := &Factory{}
+	cfg := factory.CreateDefaultConfig()
+	assert.IsType(t, &Config{}, cfg)
+}
+
+func TestCreateProvider(t *testing.T) {
+	factory := &Factory{}
+
+	provider, err := factory.CreateGeoIPProvider(t.Context(), processortest.NewNopSettings(metadata.Type), &Config{})
+	assert.ErrorContains(t, err, "could not open CSV database")
+	assert.Nil(t, provider)
+
+	provider, err = factory.CreateGeoIPProvider(t.Context(), processortest.NewNopSettings(metadata.Type), &Config{
+		DatabasePath: filepath.Join("testdata", "networks.csv"),
+	})
+	require.NoError(t, err)
+	_, err = provider.Location(t.Context(), netip.MustParseAddr("10.12.0.1"))
+	assert.NoError(t, err)
+	assert.NoError(t, provider.Close(t.Context()))
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.EqualError(t, (&Config{}).Validate(), "a local CSV database path must be provided")
+	assert.EqualError(t, (&Config{DatabasePath: "db.csv", ReloadInterval: -1}).Validate(), "the reload interval must not be negative")
+	assert.NoError(t, (&Config{DatabasePath: "db.csv"}).Validate())
+}
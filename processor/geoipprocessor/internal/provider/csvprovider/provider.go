@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvprovider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/csvprovider"
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// floatAttributes holds the attributes whose values are parsed as numbers, all the other values being strings.
+var floatAttributes = map[string]bool{
+	conventions.AttributeGeoLocationLat: true,
+	conventions.AttributeGeoLocationLon: true,
+}
+
+// csvProvider looks up the attributes of the most specific network containing an IP in a CSV file. The first
+// column of the file holds the networks in CIDR notation, or single IP addresses, and the header of the other
+// columns holds the names of the attributes.
+type csvProvider struct {
+	// networks holds the attributes of each network
+	networks map[netip.Prefix]attribute.Set
+	// prefixLengths holds the distinct prefix lengths of the networks, in decreasing order
+	prefixLengths []int
+}
+
+var _ provider.GeoIPProvider = (*csvProvider)(nil)
+
+func newCSVProvider(path string) (*csvProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open CSV database: %w", err)
+	}
+	defer f.Close()
+
+	p, err := parseNetworks(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not read CSV database %q: %w", path, err)
+	}
+	return p, nil
+}
+
+func parseNetworks(r io.Reader) (*csvProvider, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing header")
+	} else if err != nil {
+		return nil, err
+	}
+	if len(header) < 2 {
+		return nil, errors.New("the header must contain a network column followed by at least one attribute column")
+	}
+	names := header[1:]
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+		if names[i] == "" {
+			return nil, fmt.Errorf("the name of column %d is empty", i+2)
+		}
+		if slices.Contains(names[:i], names[i]) {
+			return nil, fmt.Errorf("duplicate column %q", names[i])
+		}
+	}
+
+	p := &csvProvider{networks: map[netip.Prefix]attribute.Set{}}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		network, err := parseNetwork(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if _, ok := p.networks[network]; ok {
+			return nil, fmt.Errorf("line %d: duplicate network %s", line, network)
+		}
+
+		attrs := make([]attribute.KeyValue, 0, len(names))
+		for i, name := range names {
+			value := strings.TrimSpace(record[i+1])
+			if value == "" {
+				continue
+			}
+			if !floatAttributes[name] {
+				attrs = append(attrs, attribute.String(name, value))
+				continue
+			}
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s value %q", line, name, value)
+			}
+			attrs = append(attrs, attribute.Float64(name, f))
+		}
+		p.networks[network] = attribute.NewSet(attrs...)
+		if !slices.Contains(p.prefixLengths, network.Bits()) {
+			p.prefixLengths = append(p.prefixLengths, network.Bits())
+		}
+	}
+	slices.Sort(p.prefixLengths)
+	slices.Reverse(p.prefixLengths)
+
+	return p, nil
+}
+
+// parseNetwork parses a network in CIDR notation, or a single IP address.
+func parseNetwork(s string) (netip.Prefix, error) {
+	if !strings.Contains(s, "/") {
+		ip, err := netip.ParseAddr(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		ip = ip.Unmap()
+		return netip.PrefixFrom(ip, ip.BitLen()), nil
+	}
+
+	network, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if network.Addr().Is4In6() {
+		network = netip.PrefixFrom(network.Addr().Unmap(), network.Bits()-96)
+		if !network.IsValid() {
+			return netip.Prefix{}, fmt.Errorf("invalid IPv4-mapped network %s", s)
+		}
+	}
+	if network != network.Masked() {
+		return netip.Prefix{}, fmt.Errorf("network %s has host bits set, did you mean %s?", s, network.Masked())
+	}
+	return network, nil
+}
+
+// Location implements provider.GeoIPProvider for CSV files. The attributes of the most specific network containing
+// the IP are returned.
+func (p *csvProvider) Location(_ context.Context, ipAddress netip.Addr) (attribute.Set, error) {
+	ipAddress = ipAddress.Unmap()
+	for _, bits := range p.prefixLengths {
+		if bits > ipAddress.BitLen() {
+			continue
+		}
+		network, err := ipAddress.Prefix(bits)
+		if err != nil {
+			return attribute.Set{}, err
+		}
+		if attrs, ok := p.networks[network]; ok {
+			if attrs.Len() == 0 {
+				// a network without any attribute excludes its addresses from the less specific networks
+				break
+			}
+			return attrs, nil
+		}
+	}
+	return attribute.Set{}, provider.ErrNoMetadataFound
+}
+
+// Close implements provider.GeoIPProvider. The networks are held in memory, there is nothing to release.
+func (*csvProvider) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package csvprovider
+
+import (
+	"net/netip"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+func TestProviderLocation(t *testing.T) {
+	p, err := newCSVProvider(filepath.Join("testdata", "networks.csv"))
+	require.NoError(t, err)
+
+	fra1 := attribute.NewSet(
+		attribute.String("datacenter", "fra1"),
+		attribute.String(conventions.AttributeGeoCityName, "Frankfurt"),
+		attribute.Float64(conventions.AttributeGeoLocationLat, 50.1109),
+		attribute.Float64(conventions.AttributeGeoLocationLon, 8.6821),
+	)
+
+	tests := []struct {
+		name               string
+		sourceIP           netip.Addr
+		expectedAttributes attribute.Set
+		expectedErr        error
+	}{
+		{
+			name:               "most specific network",
+			sourceIP:           netip.MustParseAddr("10.12.34.56"),
+			expectedAttributes: attribute.NewSet(append(fra1.ToSlice(), attribute.String("team", "payments"))...),
+		},
+		{
+			name:               "enclosing network",
+			sourceIP:           netip.MustParseAddr("10.12.35.1"),
+			expectedAttributes: fra1,
+		},
+		{
+			name:               "least specific network",
+			sourceIP:           netip.MustParseAddr("10.200.0.1"),
+			expectedAttributes: attribute.NewSet(attribute.String("team", "platform")),
+		},
+		{
+			name:        "network without attributes",
+			sourceIP:    netip.MustParseAddr("10.12.99.1"),
+			expectedErr: provider.ErrNoMetadataFound,
+		},
+		{
+			name:               "single address",
+			sourceIP:           netip.MustParseAddr("192.168.1.10"),
+			expectedAttributes: attribute.NewSet(attribute.String("datacenter", "office"), attribute.String("team", "it")),
+		},
+		{
+			name:               "IPv4-mapped IPv6 address",
+			sourceIP:           netip.MustParseAddr("::ffff:10.12.35.1"),
+			expectedAttributes: fra1,
+		},
+		{
+			name:               "IPv6 network",
+			sourceIP:           netip.MustParseAddr("fd00:12::1"),
+			expectedAttributes: fra1,
+		},
+		{
+			name:        "no network",
+			sourceIP:    netip.MustParseAddr("1.2.3.4"),
+			expectedErr: provider.ErrNoMetadataFound,
+		},
+		{
+			name:        "no IPv6 network",
+			sourceIP:    netip.MustParseAddr("2001:db8::1"),
+			expectedErr: provider.ErrNoMetadataFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs, err := p.Location(t.Context(), tt.sourceIP)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttributes.ToSlice(), attrs.ToSlice())
+		})
+	}
+	assert.NoError(t, p.Close(t.Context()))
+}
+
+func TestParseNetworksErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		{
+			name:        "empty file",
+			content:     "",
+			expectedErr: "missing header",
+		},
+		{
+			name:        "no attribute columns",
+			content:     "network\n10.0.0.0/8\n",
+			expectedErr: "the header must contain a network column followed by at least one attribute column",
+		},
+		{
+			name:        "empty column name",
+			content:     "network,datacenter, \n",
+			expectedErr: "the name of column 3 is empty",
+		},
+		{
+			name:        "duplicate column",
+			content:     "network,datacenter,datacenter\n",
+			expectedErr: `duplicate column "datacenter"`,
+		},
+		{
+			name:        "wrong number of fields",
+			content:     "network,datacenter\n10.0.0.0/8,fra1,extra\n",
+			expectedErr: "wrong number of fields",
+		},
+		{
+			name:        "invalid network",
+			content:     "network,datacenter\n10.0.0.0/33,fra1\n",
+			expectedErr: `line 2: netip.ParsePrefix("10.0.0.0/33")`,
+		},
+		{
+			name:        "invalid address",
+			content:     "network,datacenter\nlocalhost,fra1\n",
+			expectedErr: `line 2: ParseAddr("localhost")`,
+		},
+		{
+			name:        "host bits set",
+			content:     "network,datacenter\n10.12.1.0/16,fra1\n",
+			expectedErr: "line 2: network 10.12.1.0/16 has host bits set, did you mean 10.12.0.0/16?",
+		},
+		{
+			name:        "duplicate network",
+			content:     "network,datacenter\n10.12.0.0/16,fra1\n# comment\n10.12.0.0/16,ams1\n",
+			expectedErr: "line 4: duplicate network 10.12.0.0/16",
+		},
+		{
+			name:        "invalid coordinate",
+			content:     "network,geo.location.lat\n10.12.0.0/16,north\n",
+			expectedErr: `line 2: invalid geo.location.lat value "north"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := parseNetworks(strings.NewReader(tt.content))
+			assert.ErrorContains(t, err, tt.expectedErr)
+			assert.Nil(t, p)
+		})
+	}
+}
+
+func TestParseIPv4MappedNetwork(t *testing.T) {
+	p, err := parseNetworks(strings.NewReader("network,datacenter\n::ffff:10.12.0.0/112,fra1\n"))
+	require.NoError(t, err)
+
+	attrs, err := p.Location(t.Context(), netip.MustParseAddr("10.12.1.1"))
+	require.NoError(t, err)
+	assert.Equal(t, attribute.NewSet(attribute.String("datacenter", "fra1")), attrs)
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2locationprovider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
+
+import (
+	"errors"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// Config defines configuration for the IP2Location provider.
+type Config struct {
+	// DatabasePath is the path of a local IP2Location BIN database file.
+	DatabasePath string `mapstructure:"database_path"`
+
+	// ReloadInterval is the interval at which the database file is checked for changes, to reload it
+	// when it is updated. Reloading is disabled when not set.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+var _ provider.Config = (*Config)(nil)
+
+// Validate implements provider.Config.
+func (c *Config) Validate() error {
+	if c.DatabasePath == "" {
+		return errors.New("a local IP2Location database path must be provided")
+	}
+	if c.ReloadInterval < 0 {
+		return errors.New("the reload interval must not be negative")
+	}
+	return nil
+}
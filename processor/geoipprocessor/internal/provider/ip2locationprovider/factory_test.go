@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2locationprovider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/metadata"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := &Factory{}
+	cfg := factory.CreateDefaultConfig()
+	assert.IsType(t, &Config{}, cfg)
+}
+
+func TestCreateProvider(t *testing.T) {
+	factory := &Factory{}
+	cfg := &Config{
+		DatabasePath: "",
+	}
+
+	provider, err := factory.CreateGeoIPProvider(t.Context(), processortest.NewNopSettings(metadata.Type), cfg)
+
+	assert.ErrorContains(t, err, "could not open IP2Location database")
+	assert.Nil(t, provider)
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.EqualError(t, (&Config{}).Validate(), "a local IP2Location database path must be provided")
+	assert.EqualError(t, (&Config{DatabasePath: "db.bin", ReloadInterval: -1}).Validate(), "the reload interval must not be negative")
+	assert.NoError(t, (&Config{DatabasePath: "db.bin"}).Validate())
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2locationprovider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider/ip2locationprovider"
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ip2location/ip2location-go/v9"
+	"go.opentelemetry.io/otel/attribute"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+const (
+	// unknownValue is the value of the fields of the networks without metadata, e.g. private networks.
+	unknownValue = "-"
+	// unavailableValuePrefix prefixes the value of the fields that are not part of the database type.
+	unavailableValuePrefix = "This parameter is unavailable"
+	// ipv6CountOffset is the offset in the database header of the number of IPv6 networks.
+	ipv6CountOffset = 13
+)
+
+// dbLock serializes the opening of databases with lookups: the ip2location package initializes package level
+// variables, read during lookups, whenever a database is opened.
+var dbLock sync.RWMutex
+
+type ip2LocationProvider struct {
+	db *ip2location.DB
+	// hasIPv6 is whether the database holds IPv6 networks, as looking up an IPv6 address fails otherwise.
+	hasIPv6 bool
+}
+
+var _ provider.GeoIPProvider = (*ip2LocationProvider)(nil)
+
+func newIP2LocationProvider(path string) (*ip2LocationProvider, error) {
+	hasIPv6, err := hasIPv6Networks(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open IP2Location database: %w", err)
+	}
+
+	dbLock.Lock()
+	defer dbLock.Unlock()
+	db, err := ip2location.OpenDB(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open IP2Location database: %w", err)
+	}
+	return &ip2LocationProvider{db: db, hasIPv6: hasIPv6}, nil
+}
+
+// hasIPv6Networks reads the number of IPv6 networks from the header of the database.
+func hasIPv6Networks(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	count := make([]byte, 4)
+	if _, err := f.ReadAt(count, ipv6CountOffset); err != nil {
+		return false, fmt.Errorf("invalid database header: %w", err)
+	}
+	return binary.LittleEndian.Uint32(count) > 0, nil
+}
+
+// Location implements provider.GeoIPProvider for IP2Location. The fields that are not part of the database type,
+// as well as the unknown ones, are not included in the returned attributes.
+func (p *ip2LocationProvider) Location(_ context.Context, ipAddress netip.Addr) (attribute.Set, error) {
+	ipAddress = ipAddress.Unmap()
+	if ipAddress.Is6() && !p.hasIPv6 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+
+	dbLock.RLock()
+	record, err := p.db.Get_all(ipAddress.String())
+	dbLock.RUnlock()
+	if err != nil {
+		return attribute.Set{}, err
+	}
+
+	attributes := make([]attribute.KeyValue, 0, 8)
+	appendIfKnown := func(keyName, value string) {
+		if value != "" && value != unknownValue && !strings.HasPrefix(value, unavailableValuePrefix) {
+			attributes = append(attributes, attribute.String(keyName, value))
+		}
+	}
+	appendIfKnown(conventions.AttributeGeoCityName, record.City)
+	appendIfKnown(conventions.AttributeGeoCountryName, record.Country_long)
+	appendIfKnown(conventions.AttributeGeoCountryIsoCode, record.Country_short)
+	appendIfKnown(conventions.AttributeGeoRegionName, record.Region)
+	appendIfKnown(conventions.AttributeGeoPostalCode, record.Zipcode)
+	appendIfKnown(conventions.AttributeGeoTimezone, record.Timezone)
+	if len(attributes) == 0 {
+		return attribute.Set{}, provider.ErrNoMetadataFound
+	}
+	// the coordinates are zero when they are not part of the database type
+	if record.Latitude != 0 || record.Longitude != 0 {
+		attributes = append(attributes,
+			attribute.Float64(conventions.AttributeGeoLocationLat, toFloat64(record.Latitude)),
+			attribute.Float64(conventions.AttributeGeoLocationLon, toFloat64(record.Longitude)))
+	}
+
+	return attribute.NewSet(attributes...), nil
+}
+
+// toFloat64 converts the coordinates stored as float32 to the closest float64 with the same decimal representation,
+// e.g. 34.05 rather than 34.04999923706055.
+func toFloat64(f float32) float64 {
+	d, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'f', -1, 32), 64)
+	return d
+}
+
+// Close closes the database file.
+func (p *ip2LocationProvider) Close(context.Context) error {
+	p.db.Close()
+	return nil
+}
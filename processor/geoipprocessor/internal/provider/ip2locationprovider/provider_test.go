@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ip2locationprovider
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	conventions "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/convention"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+)
+
+// testNetwork is a row of a test database, which spans from its start address to the start address of the next row.
+type testNetwork struct {
+	start                           netip.Addr
+	countryShort, countryLong       string
+	region, city, zipcode, timezone string
+	latitude, longitude             float32
+}
+
+// unknownNetwork returns a row without metadata, as found for private networks.
+func unknownNetwork(start string) testNetwork {
+	return testNetwork{
+		start:        netip.MustParseAddr(start),
+		countryShort: "-", countryLong: "-", region: "-", city: "-", zipcode: "-", timezone: "-",
+	}
+}
+
+// writeTestDatabase writes an IP2Location database following the BIN file format read by the ip2location package. A
+// DB11 database holds the country, region, city, coordinates, zip code and time zone of each network, while a DB1
+// database only holds the country.
+func writeTestDatabase(t *testing.T, dbType byte, ipv4, ipv6 []testNetwork) string {
+	const headerSize = 64
+	dbColumns := 8
+	if dbType == 1 {
+		dbColumns = 2
+	}
+	ipv4Row := dbColumns * 4
+	ipv6Row := 16 + (dbColumns-1)*4
+
+	// each section ends with a sentinel row holding the end of the last network, followed by padding as the
+	// start address of the row following the one being read is read as well
+	ipv4Addr := headerSize
+	ipv6Addr := ipv4Addr + (len(ipv4)+1)*ipv4Row + 4
+	stringsAddr := ipv6Addr + (len(ipv6)+1)*ipv6Row + 16
+
+	db := make([]byte, stringsAddr)
+	// strings are stored after the networks as a length followed by the value, and referenced by their offset
+	var stringsSection []byte
+	putString := func(value string) uint32 {
+		offset := uint32(stringsAddr + len(stringsSection))
+		stringsSection = append(stringsSection, byte(len(value)))
+		stringsSection = append(stringsSection, value...)
+		return offset
+	}
+	putFields := func(row []byte, n testNetwork) {
+		// the country code is stored in a fixed size slot, followed by the country name
+		countryOffset := putString(n.countryShort)
+		stringsSection = append(stringsSection, make([]byte, 2-len(n.countryShort))...)
+		putString(n.countryLong)
+		binary.LittleEndian.PutUint32(row[0:], countryOffset)
+		if dbType == 1 {
+			return
+		}
+		binary.LittleEndian.PutUint32(row[4:], putString(n.region))
+		binary.LittleEndian.PutUint32(row[8:], putString(n.city))
+		binary.LittleEndian.PutUint32(row[12:], math.Float32bits(n.latitude))
+		binary.LittleEndian.PutUint32(row[16:], math.Float32bits(n.longitude))
+		binary.LittleEndian.PutUint32(row[20:], putString(n.zipcode))
+		binary.LittleEndian.PutUint32(row[24:], putString(n.timezone))
+	}
+
+	for i, n := range ipv4 {
+		offset := ipv4Addr + i*ipv4Row
+		start := n.start.As4()
+		binary.LittleEndian.PutUint32(db[offset:], binary.BigEndian.Uint32(start[:]))
+		putFields(db[offset+4:offset+ipv4Row], n)
+	}
+	binary.LittleEndian.PutUint32(db[ipv4Addr+len(ipv4)*ipv4Row:], math.MaxUint32)
+
+	for i, n := range ipv6 {
+		offset := ipv6Addr + i*ipv6Row
+		start := n.start.As16()
+		slices.Reverse(start[:])
+		copy(db[offset:], start[:])
+		putFields(db[offset+16:offset+ipv6Row], n)
+	}
+	sentinel := db[ipv6Addr+len(ipv6)*ipv6Row:]
+	for i := range 16 {
+		sentinel[i] = 0xff
+	}
+
+	db = append(db, stringsSection...)
+
+	header := db[:headerSize]
+	header[0] = dbType
+	header[1] = byte(dbColumns)
+	header[2], header[3], header[4] = 25, 1, 1
+	// the addresses are 1-based
+	binary.LittleEndian.PutUint32(header[5:], uint32(len(ipv4)))
+	binary.LittleEndian.PutUint32(header[9:], uint32(ipv4Addr+1))
+	binary.LittleEndian.PutUint32(header[13:], uint32(len(ipv6)))
+	binary.LittleEndian.PutUint32(header[17:], uint32(ipv6Addr+1))
+	// product code of IP2Location databases
+	header[29] = 1
+	binary.LittleEndian.PutUint32(header[31:], uint32(len(db)))
+
+	path := filepath.Join(t.TempDir(), fmt.Sprintf("IP2LOCATION-DB%d.BIN", dbType))
+	require.NoError(t, os.WriteFile(path, db, 0o600))
+	return path
+}
+
+var (
+	mountainView = testNetwork{
+		start:        netip.MustParseAddr("8.8.8.0"),
+		countryShort: "US",
+		countryLong:  "United States of America",
+		region:       "California",
+		city:         "Mountain View",
+		zipcode:      "94043",
+		timezone:     "-07:00",
+		latitude:     37.40599,
+		longitude:    -122.078514,
+	}
+	mountainViewAttributes = attribute.NewSet(
+		attribute.String(conventions.AttributeGeoCityName, "Mountain View"),
+		attribute.String(conventions.AttributeGeoCountryName, "United States of America"),
+		attribute.String(conventions.AttributeGeoCountryIsoCode, "US"),
+		attribute.String(conventions.AttributeGeoRegionName, "California"),
+		attribute.String(conventions.AttributeGeoPostalCode, "94043"),
+		attribute.String(conventions.AttributeGeoTimezone, "-07:00"),
+		attribute.Float64(conventions.AttributeGeoLocationLat, 37.40599),
+		attribute.Float64(conventions.AttributeGeoLocationLon, -122.078514),
+	)
+	countryOnly = testNetwork{
+		start:        netip.MustParseAddr("9.9.9.0"),
+		countryShort: "CH",
+		countryLong:  "Switzerland",
+		region:       "-",
+		city:         "-",
+		zipcode:      "-",
+		timezone:     "-",
+	}
+)
+
+func TestInvalidNewProvider(t *testing.T) {
+	_, err := newIP2LocationProvider(filepath.Join(t.TempDir(), "missing.BIN"))
+	assert.ErrorContains(t, err, "could not open IP2Location database")
+
+	path := filepath.Join(t.TempDir(), "invalid.BIN")
+	require.NoError(t, os.WriteFile(path, []byte("not a database"), 0o600))
+	_, err = newIP2LocationProvider(path)
+	assert.ErrorContains(t, err, "could not open IP2Location database: invalid database header")
+}
+
+func TestProviderLocation(t *testing.T) {
+	ipv4 := []testNetwork{
+		unknownNetwork("0.0.0.0"),
+		mountainView,
+		unknownNetwork("8.8.9.0"),
+		countryOnly,
+		unknownNetwork("9.9.10.0"),
+	}
+	ipv6Network := mountainView
+	ipv6Network.start = netip.MustParseAddr("2001:4860::")
+	ipv6 := []testNetwork{
+		unknownNetwork("::"),
+		ipv6Network,
+		unknownNetwork("2001:4861::"),
+	}
+
+	tests := []struct {
+		name               string
+		ipv6               []testNetwork
+		sourceIP           netip.Addr
+		expectedAttributes attribute.Set
+		expectedErr        error
+	}{
+		{
+			name:               "all attributes",
+			sourceIP:           netip.MustParseAddr("8.8.8.8"),
+			expectedAttributes: mountainViewAttributes,
+		},
+		{
+			name:     "unknown attributes are skipped",
+			sourceIP: netip.MustParseAddr("9.9.9.9"),
+			expectedAttributes: attribute.NewSet(
+				attribute.String(conventions.AttributeGeoCountryName, "Switzerland"),
+				attribute.String(conventions.AttributeGeoCountryIsoCode, "CH"),
+			),
+		},
+		{
+			name:        "private network",
+			sourceIP:    netip.MustParseAddr("10.0.0.1"),
+			expectedErr: provider.ErrNoMetadataFound,
+		},
+		{
+			name:               "IPv4-mapped IPv6 address",
+			sourceIP:           netip.MustParseAddr("::ffff:8.8.8.8"),
+			expectedAttributes: mountainViewAttributes,
+		},
+		{
+			name:               "IPv6 address",
+			ipv6:               ipv6,
+			sourceIP:           netip.MustParseAddr("2001:4860::8888"),
+			expectedAttributes: mountainViewAttributes,
+		},
+		{
+			name:        "IPv6 address without metadata",
+			ipv6:        ipv6,
+			sourceIP:    netip.MustParseAddr("2001:db8::1"),
+			expectedErr: provider.ErrNoMetadataFound,
+		},
+		{
+			name:        "IPv6 address in an IPv4 database",
+			sourceIP:    netip.MustParseAddr("2001:4860::8888"),
+			expectedErr: provider.ErrNoMetadataFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newIP2LocationProvider(writeTestDatabase(t, 11, ipv4, tt.ipv6))
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, p.Close(t.Context()))
+			}()
+
+			attrs, err := p.Location(t.Context(), tt.sourceIP)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAttributes.ToSlice(), attrs.ToSlice())
+		})
+	}
+}
+
+func TestProviderUnavailableFields(t *testing.T) {
+	// the other fields are reported as unavailable by the ip2location package
+	path := writeTestDatabase(t, 1, []testNetwork{unknownNetwork("0.0.0.0"), mountainView, unknownNetwork("8.8.9.0")}, nil)
+	p, err := newIP2LocationProvider(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Close(t.Context()))
+	}()
+
+	attrs, err := p.Location(t.Context(), netip.MustParseAddr("8.8.8.8"))
+	require.NoError(t, err)
+	assert.Equal(t, attribute.NewSet(
+		attribute.String(conventions.AttributeGeoCountryName, "United States of America"),
+		attribute.String(conventions.AttributeGeoCountryIsoCode, "US"),
+	), attrs)
+}
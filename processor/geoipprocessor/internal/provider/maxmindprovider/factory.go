@@ -27,7 +27,9 @@ func (*Factory) CreateDefaultConfig() provider.Config {
 }
 
 // CreateGeoIPProvider creates a provider based on this config.
-func (*Factory) CreateGeoIPProvider(_ context.Context, _ processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
+func (*Factory) CreateGeoIPProvider(_ context.Context, settings processor.Settings, cfg provider.Config) (provider.GeoIPProvider, error) {
 	maxMindConfig := cfg.(*Config)
-	return newMaxMindProvider(maxMindConfig)
+	return provider.NewReloadingProvider(settings.Logger, maxMindConfig.DatabasePath, maxMindConfig.ReloadInterval, func(path string) (provider.GeoIPProvider, error) {
+		return newMaxMindProvider(&Config{DatabasePath: path})
+	})
 }
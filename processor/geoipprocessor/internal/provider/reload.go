@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package provider // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/geoipprocessor/internal/provider"
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// OpenFunc creates a GeoIPProvider from a database file.
+type OpenFunc func(path string) (GeoIPProvider, error)
+
+// reloadingProvider is a GeoIPProvider backed by a database file, which is replaced by a new provider whenever the
+// file changes.
+type reloadingProvider struct {
+	logger   *zap.Logger
+	path     string
+	interval time.Duration
+	open     OpenFunc
+
+	mu       sync.RWMutex
+	current  GeoIPProvider
+	modTime  time.Time
+	size     int64
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+var _ GeoIPProvider = (*reloadingProvider)(nil)
+
+// NewReloadingProvider creates a GeoIPProvider from the database file at the given path. If the interval is greater
+// than zero, the file is checked for changes at that interval, and the provider is replaced by a new one whenever the
+// file is modified. The current provider is kept if the new one cannot be created, e.g. while the file is being written.
+func NewReloadingProvider(logger *zap.Logger, path string, interval time.Duration, open OpenFunc) (GeoIPProvider, error) {
+	if interval <= 0 {
+		return open(path)
+	}
+
+	r := &reloadingProvider{
+		logger:   logger,
+		path:     path,
+		interval: interval,
+		open:     open,
+		stopCh:   make(chan struct{}),
+	}
+	// the file is stat'ed before being opened, so that a change made in between is picked up on the next check
+	info, err := os.Stat(path)
+	if err == nil {
+		r.modTime, r.size = info.ModTime(), info.Size()
+	}
+	if r.current, err = open(path); err != nil {
+		return nil, err
+	}
+
+	r.wg.Add(1)
+	go r.watch()
+	return r, nil
+}
+
+// Location implements GeoIPProvider by delegating to the current provider.
+func (r *reloadingProvider) Location(ctx context.Context, ip netip.Addr) (attribute.Set, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current.Location(ctx, ip)
+}
+
+// Close stops watching the database file and closes the current provider.
+func (r *reloadingProvider) Close(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stopCh) })
+	r.wg.Wait()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current.Close(ctx)
+}
+
+func (r *reloadingProvider) watch() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.reload()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+// reload replaces the current provider if the database file changed since it was last opened.
+func (r *reloadingProvider) reload() {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		r.logger.Warn("failed to check the geo IP database for changes", zap.String("path", r.path), zap.Error(err))
+		return
+	}
+	if info.ModTime().Equal(r.modTime) && info.Size() == r.size {
+		return
+	}
+
+	next, err := r.open(r.path)
+	if err != nil {
+		r.logger.Warn("failed to reload the geo IP database, keeping the current one", zap.String("path", r.path), zap.Error(err))
+		return
+	}
+	r.modTime, r.size = info.ModTime(), info.Size()
+
+	r.mu.Lock()
+	previous := r.current
+	r.current = next
+	r.mu.Unlock()
+
+	// no lookup can be in progress on the previous provider once the lock has been released
+	if err := previous.Close(context.Background()); err != nil {
+		r.logger.Warn("failed to close the previous geo IP database", zap.String("path", r.path), zap.Error(err))
+	}
+	r.logger.Info("reloaded the geo IP database", zap.String("path", r.path))
+}
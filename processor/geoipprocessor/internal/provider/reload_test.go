@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// fileProvider returns the content of the file it was created from as the value of the "content" attribute.
+type fileProvider struct {
+	content string
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func (p *fileProvider) Location(context.Context, netip.Addr) (attribute.Set, error) {
+	return attribute.NewSet(attribute.String("content", p.content)), nil
+}
+
+func (p *fileProvider) Close(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	return nil
+}
+
+func (p *fileProvider) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+// fileOpener opens fileProviders, and keeps track of the opened ones.
+type fileOpener struct {
+	mu        sync.Mutex
+	providers []*fileProvider
+}
+
+func (o *fileOpener) open(path string) (GeoIPProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) == 0 {
+		return nil, errors.New("empty database")
+	}
+	p := &fileProvider{content: string(content)}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.providers = append(o.providers, p)
+	return p, nil
+}
+
+func (o *fileOpener) opened() []*fileProvider {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]*fileProvider(nil), o.providers...)
+}
+
+func assertContent(t *testing.T, p GeoIPProvider, expected string) {
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		attrs, err := p.Location(t.Context(), netip.MustParseAddr("1.2.3.4"))
+		require.NoError(c, err)
+		content, _ := attrs.Value("content")
+		assert.Equal(c, expected, content.AsString())
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReloadingProviderDisabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+	opener := &fileOpener{}
+
+	p, err := NewReloadingProvider(zap.NewNop(), path, 0, opener.open)
+	require.NoError(t, err)
+
+	// the provider is returned as is when reloading is disabled
+	assert.IsType(t, &fileProvider{}, p)
+	require.NoError(t, p.Close(t.Context()))
+}
+
+func TestReloadingProvider(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	path := filepath.Join(dir, "db")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+	opener := &fileOpener{}
+	p, err := NewReloadingProvider(zap.NewNop(), path, 10*time.Millisecond, opener.open)
+	require.NoError(t, err)
+	assertContent(t, p, "v1")
+
+	// test: the file is written in place
+	require.NoError(t, os.WriteFile(path, []byte("v2-updated"), 0o600))
+	assertContent(t, p, "v2-updated")
+
+	// test: the file is replaced
+	tmp := filepath.Join(dir, "db.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("v3-replaced"), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+	assertContent(t, p, "v3-replaced")
+
+	// verify: the previous providers are closed
+	opened := opener.opened()
+	require.Len(t, opened, 3)
+	assert.True(t, opened[0].isClosed())
+	assert.True(t, opened[1].isClosed())
+	assert.False(t, opened[2].isClosed())
+
+	require.NoError(t, p.Close(t.Context()))
+	assert.True(t, opened[2].isClosed())
+}
+
+func TestReloadingProviderKeepsCurrentOnFailure(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "db")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+	opener := &fileOpener{}
+	p, err := NewReloadingProvider(zap.NewNop(), path, 10*time.Millisecond, opener.open)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Close(t.Context()))
+	}()
+
+	// test: the file is truncated, then removed
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	time.Sleep(50 * time.Millisecond)
+	assertContent(t, p, "v1")
+	require.NoError(t, os.Remove(path))
+	time.Sleep(50 * time.Millisecond)
+	assertContent(t, p, "v1")
+
+	// verify: the database is reloaded once the file is valid again
+	require.NoError(t, os.WriteFile(path, []byte("v2"), 0o600))
+	assertContent(t, p, "v2")
+}
+
+func TestReloadingProviderOpenError(t *testing.T) {
+	opener := &fileOpener{}
+	p, err := NewReloadingProvider(zap.NewNop(), filepath.Join(t.TempDir(), "missing"), time.Second, opener.open)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.Nil(t, p)
+}
@@ -29,6 +29,21 @@ const (
 
 	// attributeField is the name of the attribute field
 	attributeField = "attributes"
+
+	// defaultSimilarityThreshold is the default minimum similarity of a message to a template to match it
+	defaultSimilarityThreshold = 0.4
+
+	// defaultTemplateDepth is the default number of leading tokens used to look up templates
+	defaultTemplateDepth = 2
+
+	// defaultMaxChildren is the default maximum number of children of the nodes of the template tree
+	defaultMaxChildren = 100
+
+	// defaultMaxTemplates is the default maximum number of templates
+	defaultMaxTemplates = 1000
+
+	// defaultMaxSamples is the default maximum number of sample variables of the aggregated logs
+	defaultMaxSamples = 3
 )
 
 // Config errors
@@ -37,16 +52,44 @@ var (
 	errInvalidInterval          = errors.New("interval must be greater than 0")
 	errCannotExcludeBody        = errors.New("cannot exclude the entire body")
 	errCannotIncludeBody        = errors.New("cannot include the entire body")
+	errInvalidSimilarity        = errors.New("templates.similarity_threshold must be greater than 0 and less than or equal to 1")
+	errInvalidTemplateDepth     = errors.New("templates.depth must be greater than 0")
+	errInvalidMaxChildren       = errors.New("templates.max_children must be greater than 1")
+	errInvalidMaxTemplates      = errors.New("templates.max_templates must be greater than 0")
+	errInvalidMaxSamples        = errors.New("templates.max_samples must not be negative")
+	errTemplatesIncludeFields   = errors.New("cannot define both templates and include_fields")
 )
 
 // Config is the config of the processor.
 type Config struct {
-	LogCountAttribute string        `mapstructure:"log_count_attribute"`
-	Interval          time.Duration `mapstructure:"interval"`
-	Timezone          string        `mapstructure:"timezone"`
-	ExcludeFields     []string      `mapstructure:"exclude_fields"`
-	IncludeFields     []string      `mapstructure:"include_fields"`
-	Conditions        []string      `mapstructure:"conditions"`
+	LogCountAttribute string          `mapstructure:"log_count_attribute"`
+	Interval          time.Duration   `mapstructure:"interval"`
+	Timezone          string          `mapstructure:"timezone"`
+	ExcludeFields     []string        `mapstructure:"exclude_fields"`
+	IncludeFields     []string        `mapstructure:"include_fields"`
+	Conditions        []string        `mapstructure:"conditions"`
+	Templates         TemplatesConfig `mapstructure:"templates"`
+}
+
+// TemplatesConfig is the config of the deduplication of logs by template. Logs with string bodies are clustered into
+// templates, the parts of the bodies which vary between logs, such as numbers and identifiers, being replaced by
+// wildcards, so that logs that only differ by these parts are deduplicated.
+type TemplatesConfig struct {
+	// Enabled enables the deduplication of logs by template.
+	Enabled bool `mapstructure:"enabled"`
+	// SimilarityThreshold is the minimum share of tokens a body must have in common with a template to match it.
+	SimilarityThreshold float64 `mapstructure:"similarity_threshold"`
+	// Depth is the number of leading tokens of the bodies used to look up the templates. The bodies matching a
+	// template must have the same leading tokens, the tokens with digits excepted.
+	Depth int `mapstructure:"depth"`
+	// MaxChildren is the maximum number of distinct tokens at each of the leading positions of the bodies, the
+	// additional tokens being looked up as wildcards.
+	MaxChildren int `mapstructure:"max_children"`
+	// MaxTemplates is the maximum number of templates, the least recently matched template being discarded when the
+	// maximum is reached.
+	MaxTemplates int `mapstructure:"max_templates"`
+	// MaxSamples is the maximum number of sample variables added to each aggregated log.
+	MaxSamples int `mapstructure:"max_samples"`
 }
 
 // createDefaultConfig returns the default config for the processor.
@@ -58,6 +101,13 @@ func createDefaultConfig() component.Config {
 		ExcludeFields:     []string{},
 		IncludeFields:     []string{},
 		Conditions:        []string{},
+		Templates: TemplatesConfig{
+			SimilarityThreshold: defaultSimilarityThreshold,
+			Depth:               defaultTemplateDepth,
+			MaxChildren:         defaultMaxChildren,
+			MaxTemplates:        defaultMaxTemplates,
+			MaxSamples:          defaultMaxSamples,
+		},
 	}
 }
 
@@ -90,7 +140,7 @@ func (c Config) Validate() error {
 		return err
 	}
 
-	return nil
+	return c.validateTemplates()
 }
 
 // validateExcludeFields validates that all the exclude fields
@@ -146,3 +196,36 @@ func (c Config) validateIncludeFields() error {
 
 	return nil
 }
+
+// validateTemplates validates the templates config when the deduplication by template is enabled
+func (c Config) validateTemplates() error {
+	if !c.Templates.Enabled {
+		return nil
+	}
+
+	if len(c.IncludeFields) > 0 {
+		return errTemplatesIncludeFields
+	}
+
+	if c.Templates.SimilarityThreshold <= 0 || c.Templates.SimilarityThreshold > 1 {
+		return errInvalidSimilarity
+	}
+
+	if c.Templates.Depth <= 0 {
+		return errInvalidTemplateDepth
+	}
+
+	if c.Templates.MaxChildren <= 1 {
+		return errInvalidMaxChildren
+	}
+
+	if c.Templates.MaxTemplates <= 0 {
+		return errInvalidMaxTemplates
+	}
+
+	if c.Templates.MaxSamples < 0 {
+		return errInvalidMaxSamples
+	}
+
+	return nil
+}
@@ -16,6 +16,9 @@ func TestCreateDefaultProcessorConfig(t *testing.T) {
 	require.Equal(t, defaultLogCountAttribute, cfg.LogCountAttribute)
 	require.Equal(t, defaultTimezone, cfg.Timezone)
 	require.Equal(t, []string{}, cfg.ExcludeFields)
+	require.False(t, cfg.Templates.Enabled)
+	require.Equal(t, defaultSimilarityThreshold, cfg.Templates.SimilarityThreshold)
+	require.Equal(t, defaultMaxTemplates, cfg.Templates.MaxTemplates)
 }
 
 func TestValidateConfig(t *testing.T) {
@@ -148,6 +151,143 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: errors.New("cannot define both exclude_fields and include_fields"),
 		},
+		{
+			desc: "valid templates",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Templates: TemplatesConfig{
+					Enabled:             true,
+					SimilarityThreshold: defaultSimilarityThreshold,
+					Depth:               defaultTemplateDepth,
+					MaxChildren:         defaultMaxChildren,
+					MaxTemplates:        defaultMaxTemplates,
+					MaxSamples:          defaultMaxSamples,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "disabled templates are not validated",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Templates: TemplatesConfig{
+					Enabled:             false,
+					SimilarityThreshold: 0,
+					Depth:               defaultTemplateDepth,
+					MaxChildren:         defaultMaxChildren,
+					MaxTemplates:        defaultMaxTemplates,
+					MaxSamples:          defaultMaxSamples,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "invalid templates similarity_threshold",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Templates: TemplatesConfig{
+					Enabled:             true,
+					SimilarityThreshold: 1.5,
+					Depth:               defaultTemplateDepth,
+					MaxChildren:         defaultMaxChildren,
+					MaxTemplates:        defaultMaxTemplates,
+					MaxSamples:          defaultMaxSamples,
+				},
+			},
+			expectedErr: errInvalidSimilarity,
+		},
+		{
+			desc: "invalid templates depth",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Templates: TemplatesConfig{
+					Enabled:             true,
+					SimilarityThreshold: defaultSimilarityThreshold,
+					Depth:               0,
+					MaxChildren:         defaultMaxChildren,
+					MaxTemplates:        defaultMaxTemplates,
+					MaxSamples:          defaultMaxSamples,
+				},
+			},
+			expectedErr: errInvalidTemplateDepth,
+		},
+		{
+			desc: "invalid templates max_children",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Templates: TemplatesConfig{
+					Enabled:             true,
+					SimilarityThreshold: defaultSimilarityThreshold,
+					Depth:               defaultTemplateDepth,
+					MaxChildren:         1,
+					MaxTemplates:        defaultMaxTemplates,
+					MaxSamples:          defaultMaxSamples,
+				},
+			},
+			expectedErr: errInvalidMaxChildren,
+		},
+		{
+			desc: "invalid templates max_templates",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Templates: TemplatesConfig{
+					Enabled:             true,
+					SimilarityThreshold: defaultSimilarityThreshold,
+					Depth:               defaultTemplateDepth,
+					MaxChildren:         defaultMaxChildren,
+					MaxTemplates:        0,
+					MaxSamples:          defaultMaxSamples,
+				},
+			},
+			expectedErr: errInvalidMaxTemplates,
+		},
+		{
+			desc: "invalid templates max_samples",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				Templates: TemplatesConfig{
+					Enabled:             true,
+					SimilarityThreshold: defaultSimilarityThreshold,
+					Depth:               defaultTemplateDepth,
+					MaxChildren:         defaultMaxChildren,
+					MaxTemplates:        defaultMaxTemplates,
+					MaxSamples:          -1,
+				},
+			},
+			expectedErr: errInvalidMaxSamples,
+		},
+		{
+			desc: "invalid config defines both templates and include_fields",
+			cfg: &Config{
+				LogCountAttribute: defaultLogCountAttribute,
+				Interval:          defaultInterval,
+				Timezone:          defaultTimezone,
+				IncludeFields:     []string{"attributes.otherthing"},
+				Templates: TemplatesConfig{
+					Enabled:             true,
+					SimilarityThreshold: defaultSimilarityThreshold,
+					Depth:               defaultTemplateDepth,
+					MaxChildren:         defaultMaxChildren,
+					MaxTemplates:        defaultMaxTemplates,
+					MaxSamples:          defaultMaxSamples,
+				},
+			},
+			expectedErr: errTemplatesIncludeFields,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -5,6 +5,7 @@ package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -20,6 +21,9 @@ const (
 	lastObservedTSAttr  = "last_observed_timestamp"
 )
 
+// sampleVariablesAttr is the name of the attribute holding the sample variables of logs aggregated by template
+const sampleVariablesAttr = "sample_variables"
+
 // timeNow can be reassigned for testing
 var timeNow = time.Now
 
@@ -30,16 +34,19 @@ type logAggregator struct {
 	timezone          *time.Location
 	telemetryBuilder  *metadata.TelemetryBuilder
 	dedupFields       []string
+	templates         *templateMiner
 }
 
-// newLogAggregator creates a new LogCounter.
-func newLogAggregator(logCountAttribute string, timezone *time.Location, telemetryBuilder *metadata.TelemetryBuilder, dedupFields []string) *logAggregator {
+// newLogAggregator creates a new LogCounter. If templates is not nil, the logs with string bodies are aggregated by
+// template.
+func newLogAggregator(logCountAttribute string, timezone *time.Location, telemetryBuilder *metadata.TelemetryBuilder, dedupFields []string, templates *templateMiner) *logAggregator {
 	return &logAggregator{
 		resources:         make(map[uint64]*resourceAggregator),
 		logCountAttribute: logCountAttribute,
 		timezone:          timezone,
 		telemetryBuilder:  telemetryBuilder,
 		dedupFields:       dedupFields,
+		templates:         templates,
 	}
 }
 
@@ -73,6 +80,10 @@ func (l *logAggregator) Export(ctx context.Context) plog.Logs {
 				lr.Attributes().PutStr(firstObservedTSAttr, firstTimestampStr)
 				lastTimestampStr := logAggregator.lastObservedTimestamp.In(l.timezone).Format(time.RFC3339)
 				lr.Attributes().PutStr(lastObservedTSAttr, lastTimestampStr)
+
+				if logAggregator.template != nil {
+					logAggregator.exportTemplate(lr)
+				}
 			}
 		}
 	}
@@ -85,7 +96,7 @@ func (l *logAggregator) Add(resource pcommon.Resource, scope pcommon.Instrumenta
 	key := getResourceKey(resource)
 	resourceAggregator, ok := l.resources[key]
 	if !ok {
-		resourceAggregator = newResourceAggregator(resource, l.dedupFields)
+		resourceAggregator = newResourceAggregator(resource, l.dedupFields, l.templates)
 		l.resources[key] = resourceAggregator
 	}
 	resourceAggregator.Add(scope, logRecord)
@@ -101,16 +112,18 @@ type resourceAggregator struct {
 	resource      pcommon.Resource
 	scopeCounters map[uint64]*scopeAggregator
 	dedupFields   []string
+	templates     *templateMiner
 }
 
 // newResourceAggregator creates a new ResourceCounter.
-func newResourceAggregator(resource pcommon.Resource, dedupFields []string) *resourceAggregator {
+func newResourceAggregator(resource pcommon.Resource, dedupFields []string, templates *templateMiner) *resourceAggregator {
 	cloneResource := pcommon.NewResource()
 	resource.CopyTo(cloneResource)
 	return &resourceAggregator{
 		resource:      cloneResource,
 		scopeCounters: make(map[uint64]*scopeAggregator),
 		dedupFields:   dedupFields,
+		templates:     templates,
 	}
 }
 
@@ -119,7 +132,7 @@ func (r *resourceAggregator) Add(scope pcommon.InstrumentationScope, logRecord p
 	key := getScopeKey(scope)
 	scopeAggregator, ok := r.scopeCounters[key]
 	if !ok {
-		scopeAggregator = newScopeAggregator(scope, r.dedupFields)
+		scopeAggregator = newScopeAggregator(scope, r.dedupFields, r.templates)
 		r.scopeCounters[key] = scopeAggregator
 	}
 	scopeAggregator.Add(logRecord)
@@ -130,21 +143,28 @@ type scopeAggregator struct {
 	scope       pcommon.InstrumentationScope
 	logCounters map[uint64]*logCounter
 	dedupFields []string
+	templates   *templateMiner
 }
 
 // newScopeAggregator creates a new ScopeCounter.
-func newScopeAggregator(scope pcommon.InstrumentationScope, dedupFields []string) *scopeAggregator {
+func newScopeAggregator(scope pcommon.InstrumentationScope, dedupFields []string, templates *templateMiner) *scopeAggregator {
 	cloneScope := pcommon.NewInstrumentationScope()
 	scope.CopyTo(cloneScope)
 	return &scopeAggregator{
 		scope:       cloneScope,
 		logCounters: make(map[uint64]*logCounter),
 		dedupFields: dedupFields,
+		templates:   templates,
 	}
 }
 
 // Add increments the counter that the logRecord matches.
 func (s *scopeAggregator) Add(logRecord plog.LogRecord) {
+	if s.templates != nil && logRecord.Body().Type() == pcommon.ValueTypeStr {
+		s.addByTemplate(logRecord)
+		return
+	}
+
 	key := getLogKey(logRecord, s.dedupFields)
 	lc, ok := s.logCounters[key]
 	if !ok {
@@ -154,12 +174,33 @@ func (s *scopeAggregator) Add(logRecord plog.LogRecord) {
 	lc.Increment()
 }
 
+// addByTemplate increments the counter of the template that the body of the logRecord matches.
+func (s *scopeAggregator) addByTemplate(logRecord plog.LogRecord) {
+	body := logRecord.Body().Str()
+	template := s.templates.Match(body)
+	key := getTemplateLogKey(logRecord, template)
+	lc, ok := s.logCounters[key]
+	if !ok {
+		lc = newLogCounter(logRecord)
+		lc.template = template
+		s.logCounters[key] = lc
+	}
+	if len(lc.samples) < s.templates.maxSamples {
+		lc.samples = append(lc.samples, body)
+	}
+	lc.Increment()
+}
+
 // logCounter is a counter for a log record.
 type logCounter struct {
 	logRecord              plog.LogRecord
 	firstObservedTimestamp time.Time
 	lastObservedTimestamp  time.Time
 	count                  int64
+	// template is the template of the body of the aggregated logs, if aggregated by template
+	template *logTemplate
+	// samples holds sample bodies of the logs aggregated by template
+	samples []string
 }
 
 // newLogCounter creates a new AttributeCounter.
@@ -181,6 +222,31 @@ func (a *logCounter) Increment() {
 	a.count++
 }
 
+// exportTemplate sets the body of the aggregated log to the template, and adds the variables of the sample bodies.
+func (a *logCounter) exportTemplate(lr plog.LogRecord) {
+	lr.Body().SetStr(a.template.String())
+
+	var samples [][]string
+	for _, sample := range a.samples {
+		if variables := a.template.Variables(sample); len(variables) > 0 {
+			samples = append(samples, variables)
+		}
+	}
+	if len(samples) == 0 {
+		return
+	}
+
+	samplesSlice := lr.Attributes().PutEmptySlice(sampleVariablesAttr)
+	samplesSlice.EnsureCapacity(len(samples))
+	for _, variables := range samples {
+		variablesSlice := samplesSlice.AppendEmpty().SetEmptySlice()
+		variablesSlice.EnsureCapacity(len(variables))
+		for _, variable := range variables {
+			variablesSlice.AppendEmpty().SetStr(variable)
+		}
+	}
+}
+
 // getResourceKey creates a unique hash for the resource to use as a map key
 func getResourceKey(resource pcommon.Resource) uint64 {
 	return pdatautil.Hash64(
@@ -226,6 +292,16 @@ func getLogKey(logRecord plog.LogRecord, dedupFields []string) uint64 {
 	)
 }
 
+// getTemplateLogKey creates a unique hash for the log record aggregated by template to use as a map key.
+func getTemplateLogKey(logRecord plog.LogRecord, template *logTemplate) uint64 {
+	return pdatautil.Hash64(
+		pdatautil.WithMap(logRecord.Attributes()),
+		pdatautil.WithString(wildcard+strconv.FormatInt(template.id, 10)),
+		pdatautil.WithString(logRecord.SeverityNumber().String()),
+		pdatautil.WithString(logRecord.SeverityText()),
+	)
+}
+
 func getMap(logRecord plog.LogRecord, leadingPart string) (pcommon.Map, bool) {
 	switch leadingPart {
 	case bodyField:
@@ -21,7 +21,7 @@ func Test_newLogAggregator(t *testing.T) {
 	telemetryBuilder, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
 	require.NoError(t, err)
 
-	aggregator := newLogAggregator(cfg.LogCountAttribute, time.UTC, telemetryBuilder, cfg.IncludeFields)
+	aggregator := newLogAggregator(cfg.LogCountAttribute, time.UTC, telemetryBuilder, cfg.IncludeFields, nil)
 	require.Equal(t, cfg.LogCountAttribute, aggregator.logCountAttribute)
 	require.Equal(t, time.UTC, aggregator.timezone)
 	require.NotNil(t, aggregator.resources)
@@ -43,7 +43,7 @@ func Test_logAggregatorAdd(t *testing.T) {
 	require.NoError(t, err)
 
 	// Setup aggregator
-	aggregator := newLogAggregator("log_count", time.UTC, telemetryBuilder, nil)
+	aggregator := newLogAggregator("log_count", time.UTC, telemetryBuilder, nil, nil)
 	logRecord := plog.NewLogRecord()
 
 	resource := pcommon.NewResource()
@@ -93,12 +93,12 @@ func Test_logAggregatorReset(t *testing.T) {
 	telemetryBuilder, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
 	require.NoError(t, err)
 
-	aggregator := newLogAggregator("log_count", time.UTC, telemetryBuilder, nil)
+	aggregator := newLogAggregator("log_count", time.UTC, telemetryBuilder, nil, nil)
 	for i := range 2 {
 		resource := pcommon.NewResource()
 		resource.Attributes().PutInt("i", int64(i))
 		key := getResourceKey(resource)
-		aggregator.resources[key] = newResourceAggregator(resource, nil)
+		aggregator.resources[key] = newResourceAggregator(resource, nil, nil)
 	}
 
 	require.Len(t, aggregator.resources, 2)
@@ -128,7 +128,7 @@ func Test_logAggregatorExport(t *testing.T) {
 	telemetryBuilder, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
 	require.NoError(t, err)
 
-	aggregator := newLogAggregator(defaultLogCountAttribute, location, telemetryBuilder, nil)
+	aggregator := newLogAggregator(defaultLogCountAttribute, location, telemetryBuilder, nil, nil)
 	resource := pcommon.NewResource()
 	resource.Attributes().PutStr("one", "two")
 	expectedHash := pdatautil.MapHash(resource.Attributes())
@@ -184,10 +184,49 @@ func Test_logAggregatorExport(t *testing.T) {
 	require.Equal(t, expectedTimestampStr, actualLastObserved)
 }
 
+func Test_logAggregatorAddByTemplate(t *testing.T) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	// Setup aggregator
+	cfg := createDefaultConfig().(*Config)
+	cfg.Templates.MaxSamples = 2
+	aggregator := newLogAggregator(defaultLogCountAttribute, time.UTC, telemetryBuilder, nil, newTemplateMiner(cfg.Templates))
+	resource := pcommon.NewResource()
+	scope := pcommon.NewInstrumentationScope()
+
+	// Add logRecords
+	aggregator.Add(resource, scope, generateTestLogRecord(t, "user 1 logged in after 3 attempts"))
+	aggregator.Add(resource, scope, generateTestLogRecord(t, "user 2 logged in after 1 attempts"))
+	aggregator.Add(resource, scope, generateTestLogRecord(t, "user 3 logged in after 2 attempts"))
+	aggregator.Add(resource, scope, generateTestLogRecordWithMap(t))
+
+	exportedLogs := aggregator.Export(t.Context())
+	require.Equal(t, 2, exportedLogs.LogRecordCount())
+
+	logRecords := exportedLogs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for _, logRecord := range logRecords.All() {
+		if logRecord.Body().Type() == pcommon.ValueTypeMap {
+			// the logRecords without string bodies are not aggregated by template
+			count, _ := logRecord.Attributes().Get(defaultLogCountAttribute)
+			require.Equal(t, int64(1), count.Int())
+			_, ok := logRecord.Attributes().Get(sampleVariablesAttr)
+			require.False(t, ok)
+			continue
+		}
+
+		require.Equal(t, "user <*> logged in after <*> attempts", logRecord.Body().Str())
+		count, _ := logRecord.Attributes().Get(defaultLogCountAttribute)
+		require.Equal(t, int64(3), count.Int())
+		samples, _ := logRecord.Attributes().Get(sampleVariablesAttr)
+		require.Equal(t, []any{[]any{"1", "3"}, []any{"2", "1"}}, samples.Slice().AsRaw())
+	}
+}
+
 func Test_newResourceAggregator(t *testing.T) {
 	resource := pcommon.NewResource()
 	resource.Attributes().PutStr("one", "two")
-	aggregator := newResourceAggregator(resource, nil)
+	aggregator := newResourceAggregator(resource, nil, nil)
 	require.NotNil(t, aggregator.scopeCounters)
 	require.Equal(t, resource, aggregator.resource)
 }
@@ -195,7 +234,7 @@ func Test_newResourceAggregator(t *testing.T) {
 func Test_newScopeCounter(t *testing.T) {
 	scope := pcommon.NewInstrumentationScope()
 	scope.Attributes().PutStr("one", "two")
-	sc := newScopeAggregator(scope, nil)
+	sc := newScopeAggregator(scope, nil, nil)
 	require.Equal(t, scope, sc.scope)
 	require.NotNil(t, sc.logCounters)
 }
@@ -390,6 +390,44 @@ func TestProcessorIncludeFields(t *testing.T) {
 	}
 }
 
+func TestProcessorTemplates(t *testing.T) {
+	logsSink := &consumertest.LogsSink{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = 1 * time.Second
+	cfg.Templates.Enabled = true
+
+	// Create a processor
+	p, err := createLogsProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, logsSink)
+	require.NoError(t, err)
+
+	err = p.Start(t.Context(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	logs, err := golden.ReadLogs(filepath.Join("testdata", "input", "templateLogs.yaml"))
+	require.NoError(t, err)
+
+	// Consume the payload
+	err = p.ConsumeLogs(t.Context(), logs)
+	require.NoError(t, err)
+
+	// Wait for the logs to be emitted
+	require.Eventually(t, func() bool {
+		return logsSink.LogRecordCount() > 0
+	}, 3*time.Second, 200*time.Millisecond)
+
+	expectedLogs, err := golden.ReadLogs(filepath.Join("testdata", "expected", "templateLogs.yaml"))
+	require.NoError(t, err)
+
+	allSinkLogs := logsSink.AllLogs()
+	require.Len(t, allSinkLogs, 1)
+
+	require.NoError(t, plogtest.CompareLogs(expectedLogs, allSinkLogs[0], plogtest.IgnoreObservedTimestamp(), plogtest.IgnoreTimestamp(), plogtest.IgnoreLogRecordAttributeValue("first_observed_timestamp"), plogtest.IgnoreLogRecordAttributeValue("last_observed_timestamp"), plogtest.IgnoreLogRecordsOrder()))
+
+	// Cleanup
+	err = p.Shutdown(t.Context())
+	require.NoError(t, err)
+}
+
 func TestProcessorConfigValidate(t *testing.T) {
 	t.Parallel()
 	invalidCfg := &Config{
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"container/list"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// wildcard is the token replacing the variable parts of the messages in the templates.
+const wildcard = "<*>"
+
+// variableRegex matches the tokens which are masked before the messages are clustered: UUIDs, IPv4 addresses with an
+// optional port, hexadecimal identifiers and numbers, optionally followed by a unit.
+var variableRegex = regexp.MustCompile(`^(?:` +
+	`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}` +
+	`|\d{1,3}(?:\.\d{1,3}){3}(?::\d+)?` +
+	`|(?:0[xX])?[0-9a-fA-F]*\d[0-9a-fA-F]*` +
+	`|[-+]?(?:\d+(?:\.\d*)?|\.\d+)(?:[eE][-+]?\d+)?[a-zA-Z%]{0,3}` +
+	`)$`)
+
+// logTemplate is a cluster of messages, the tokens which differ between the messages being replaced by wildcards.
+type logTemplate struct {
+	id     int64
+	tokens []string
+	leaf   *templateNode
+	elem   *list.Element
+}
+
+// String returns the template as a message.
+func (t *logTemplate) String() string {
+	return strings.Join(t.tokens, " ")
+}
+
+// Variables returns the values of the variable parts of the message, according to the template.
+func (t *logTemplate) Variables(message string) []string {
+	tokens := strings.Fields(message)
+	if len(tokens) != len(t.tokens) {
+		return nil
+	}
+	masked, values := maskTokens(tokens)
+
+	var variables []string
+	for i, token := range t.tokens {
+		switch {
+		case token == wildcard:
+			variables = append(variables, tokens[i])
+		case strings.Contains(token, wildcard) && token == masked[i]:
+			variables = append(variables, values[i])
+		}
+	}
+	return variables
+}
+
+// templateNode is a node of the prefix tree of the templates, the templates being held by the leaves.
+type templateNode struct {
+	children  map[string]*templateNode
+	templates []*logTemplate
+}
+
+func newTemplateNode() *templateNode {
+	return &templateNode{children: make(map[string]*templateNode)}
+}
+
+// templateMiner clusters messages into templates with the Drain algorithm: the messages are tokenized and masked, then
+// looked up in a prefix tree by number of tokens and leading tokens, and finally merged into the most similar template
+// of the leaf.
+type templateMiner struct {
+	depth               int
+	similarityThreshold float64
+	maxChildren         int
+	maxTemplates        int
+	// maxSamples is the maximum number of sample variables of the logs aggregated by template
+	maxSamples int
+
+	// roots holds the prefix trees by number of tokens
+	roots map[int]*templateNode
+	// templates holds the templates from the most recently to the least recently matched
+	templates *list.List
+	nextID    int64
+}
+
+func newTemplateMiner(cfg TemplatesConfig) *templateMiner {
+	return &templateMiner{
+		depth:               cfg.Depth,
+		similarityThreshold: cfg.SimilarityThreshold,
+		maxChildren:         cfg.MaxChildren,
+		maxTemplates:        cfg.MaxTemplates,
+		maxSamples:          cfg.MaxSamples,
+		roots:               make(map[int]*templateNode),
+		templates:           list.New(),
+	}
+}
+
+// Match returns the template of the message, creating a new template or generalizing an existing one as needed.
+func (m *templateMiner) Match(message string) *logTemplate {
+	masked, _ := maskTokens(strings.Fields(message))
+	leaf := m.leaf(masked)
+
+	template := m.mostSimilar(leaf, masked)
+	if template == nil {
+		template = &logTemplate{
+			id:     m.nextID,
+			tokens: masked,
+			leaf:   leaf,
+		}
+		m.nextID++
+		leaf.templates = append(leaf.templates, template)
+		template.elem = m.templates.PushFront(template)
+		if m.templates.Len() > m.maxTemplates {
+			m.evict(m.templates.Back().Value.(*logTemplate))
+		}
+		return template
+	}
+
+	for i, token := range masked {
+		if template.tokens[i] != token {
+			template.tokens[i] = wildcard
+		}
+	}
+	m.templates.MoveToFront(template.elem)
+	return template
+}
+
+// leaf returns the leaf of the prefix tree holding the templates of the tokens.
+func (m *templateMiner) leaf(tokens []string) *templateNode {
+	node, ok := m.roots[len(tokens)]
+	if !ok {
+		node = newTemplateNode()
+		m.roots[len(tokens)] = node
+	}
+
+	for i := 0; i < m.depth && i < len(tokens); i++ {
+		key := tokens[i]
+		// the tokens with digits are likely to be variables
+		if strings.ContainsFunc(key, unicode.IsDigit) {
+			key = wildcard
+		}
+		child, ok := node.children[key]
+		if !ok {
+			// the last child is reserved to the wildcard, so that the tree can't grow without bounds
+			if key != wildcard && len(node.children) >= m.maxChildren-1 {
+				key = wildcard
+				child, ok = node.children[key]
+			}
+			if !ok {
+				child = newTemplateNode()
+				node.children[key] = child
+			}
+		}
+		node = child
+	}
+	return node
+}
+
+// mostSimilar returns the template of the leaf which is the most similar to the tokens, or nil when none is similar
+// enough.
+func (m *templateMiner) mostSimilar(leaf *templateNode, tokens []string) *logTemplate {
+	var best *logTemplate
+	bestSimilarity, bestWildcards := -1.0, -1
+	for _, template := range leaf.templates {
+		similarity, wildcards := similarity(template.tokens, tokens)
+		if similarity > bestSimilarity || (similarity == bestSimilarity && wildcards > bestWildcards) {
+			best, bestSimilarity, bestWildcards = template, similarity, wildcards
+		}
+	}
+	if best == nil || bestSimilarity < m.similarityThreshold {
+		return nil
+	}
+	return best
+}
+
+// evict removes the template from the miner.
+func (m *templateMiner) evict(template *logTemplate) {
+	m.templates.Remove(template.elem)
+	for i, t := range template.leaf.templates {
+		if t == template {
+			template.leaf.templates = append(template.leaf.templates[:i], template.leaf.templates[i+1:]...)
+			break
+		}
+	}
+}
+
+// similarity returns the share of the tokens which are equal to the tokens of the template, excluding the wildcards of
+// the template, as well as the number of wildcards of the template.
+func similarity(template, tokens []string) (float64, int) {
+	if len(tokens) == 0 {
+		return 1, 0
+	}
+	equal, wildcards := 0, 0
+	for i, token := range template {
+		switch token {
+		case wildcard:
+			wildcards++
+		case tokens[i]:
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(tokens)), wildcards
+}
+
+// maskTokens replaces the variables in the tokens by wildcards, and returns the masked tokens as well as the values of
+// the variables.
+func maskTokens(tokens []string) (masked, values []string) {
+	masked = make([]string, len(tokens))
+	values = make([]string, len(tokens))
+	for i, token := range tokens {
+		masked[i], values[i] = maskToken(token)
+	}
+	return masked, values
+}
+
+// maskToken replaces the variable in the token, if any, by a wildcard. The variable may be surrounded by punctuation,
+// or be the value of a key=value pair.
+func maskToken(token string) (masked, value string) {
+	start := strings.IndexFunc(token, func(r rune) bool { return !strings.ContainsRune(`"'([{`, r) })
+	if start < 0 {
+		return token, ""
+	}
+	end := strings.LastIndexFunc(token, func(r rune) bool { return !strings.ContainsRune(`"'.,;:!?)]}`, r) }) + 1
+	if end <= start {
+		return token, ""
+	}
+	if i := strings.IndexByte(token[start:end], '='); i >= 0 {
+		start += i + 1
+		for start < end && strings.ContainsRune(`"'`, rune(token[start])) {
+			start++
+		}
+	}
+	core := token[start:end]
+	// all the variables contain at least one digit
+	if !strings.ContainsFunc(core, unicode.IsDigit) || !variableRegex.MatchString(core) {
+		return token, ""
+	}
+	return token[:start] + wildcard + token[end:], core
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTemplateMiner(opts ...func(*TemplatesConfig)) *templateMiner {
+	cfg := createDefaultConfig().(*Config).Templates
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return newTemplateMiner(cfg)
+}
+
+func Test_maskToken(t *testing.T) {
+	testCases := []struct {
+		token          string
+		expectedMasked string
+		expectedValue  string
+	}{
+		{token: "request", expectedMasked: "request"},
+		{token: "v2", expectedMasked: "v2"},
+		{token: "42", expectedMasked: "<*>", expectedValue: "42"},
+		{token: "-3.5", expectedMasked: "<*>", expectedValue: "-3.5"},
+		{token: "12ms", expectedMasked: "<*>", expectedValue: "12ms"},
+		{token: "99.5%", expectedMasked: "<*>", expectedValue: "99.5%"},
+		{token: "0x1f2e", expectedMasked: "<*>", expectedValue: "0x1f2e"},
+		{token: "deadbeef01", expectedMasked: "<*>", expectedValue: "deadbeef01"},
+		{token: "3f2c8a1e-9b7d-4c6e-a5f4-1d2e3f4a5b6c", expectedMasked: "<*>", expectedValue: "3f2c8a1e-9b7d-4c6e-a5f4-1d2e3f4a5b6c"},
+		{token: "10.0.0.1:8080", expectedMasked: "<*>", expectedValue: "10.0.0.1:8080"},
+		{token: "(42),", expectedMasked: "(<*>),", expectedValue: "42"},
+		{token: "id=1234", expectedMasked: "id=<*>", expectedValue: "1234"},
+		{token: `id="1234".`, expectedMasked: `id="<*>".`, expectedValue: "1234"},
+		{token: "id=abc", expectedMasked: "id=abc"},
+		{token: `"'.`, expectedMasked: `"'.`},
+		{token: "((", expectedMasked: "(("},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.token, func(t *testing.T) {
+			masked, value := maskToken(tc.token)
+			require.Equal(t, tc.expectedMasked, masked)
+			require.Equal(t, tc.expectedValue, value)
+		})
+	}
+}
+
+func Test_templateMinerMatch(t *testing.T) {
+	miner := newTestTemplateMiner()
+
+	// the variables are masked
+	first := miner.Match("Request 3f2c8a1e-9b7d-4c6e-a5f4-1d2e3f4a5b6c completed in 12ms")
+	require.Equal(t, "Request <*> completed in <*>", first.String())
+	second := miner.Match("Request 7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d completed in 7ms")
+	require.Same(t, first, second)
+
+	// the differing tokens are replaced by wildcards
+	first = miner.Match("Session closed by the client")
+	require.Equal(t, "Session closed by the client", first.String())
+	second = miner.Match("Session closed by the server")
+	require.Same(t, first, second)
+	require.Equal(t, "Session closed by the <*>", first.String())
+
+	// the messages with a different number of tokens don't match
+	third := miner.Match("Session closed by the server again")
+	require.NotSame(t, first, third)
+
+	// the messages with different leading tokens don't match
+	fourth := miner.Match("Connection closed by the server")
+	require.NotSame(t, first, fourth)
+}
+
+func Test_templateMinerSimilarityThreshold(t *testing.T) {
+	miner := newTestTemplateMiner()
+	first := miner.Match("cache miss for key users")
+	require.Same(t, first, miner.Match("cache miss because evicted orders"))
+	require.Equal(t, "cache miss <*> <*> <*>", first.String())
+
+	miner = newTestTemplateMiner(func(cfg *TemplatesConfig) {
+		cfg.SimilarityThreshold = 0.6
+	})
+	first = miner.Match("cache miss for key users")
+	require.NotSame(t, first, miner.Match("cache miss because evicted orders"))
+	require.Equal(t, "cache miss for key users", first.String())
+}
+
+func Test_templateMinerMaxChildren(t *testing.T) {
+	miner := newTestTemplateMiner(func(cfg *TemplatesConfig) {
+		cfg.Depth = 1
+		cfg.MaxChildren = 2
+	})
+
+	first := miner.Match("alpha started")
+	second := miner.Match("beta started")
+	require.NotSame(t, first, second)
+
+	// the additional leading tokens are looked up as wildcards
+	require.Same(t, second, miner.Match("gamma started"))
+	require.Equal(t, "<*> started", second.String())
+	require.Equal(t, "alpha started", first.String())
+}
+
+func Test_templateMinerMaxTemplates(t *testing.T) {
+	miner := newTestTemplateMiner(func(cfg *TemplatesConfig) {
+		cfg.MaxTemplates = 2
+	})
+
+	first := miner.Match("first message")
+	second := miner.Match("second message here")
+	require.Same(t, first, miner.Match("first message"))
+	third := miner.Match("third message is here")
+	require.Equal(t, 2, miner.templates.Len())
+
+	// the least recently matched template is discarded
+	require.Same(t, first, miner.Match("first message"))
+	require.Same(t, third, miner.Match("third message is here"))
+	require.NotSame(t, second, miner.Match("second message here"))
+}
+
+func Test_logTemplateVariables(t *testing.T) {
+	miner := newTestTemplateMiner()
+	template := miner.Match("Connection from 10.0.0.12:51234 closed by client status=0")
+	miner.Match("Connection from 10.0.0.7:40022 closed by server status=1")
+	require.Equal(t, "Connection from <*> closed by <*> status=<*>", template.String())
+
+	require.Equal(t, []string{"10.0.0.12:51234", "client", "0"}, template.Variables("Connection from 10.0.0.12:51234 closed by client status=0"))
+	require.Nil(t, template.Variables("Connection closed"))
+
+	template = miner.Match("Shutting down")
+	require.Nil(t, template.Variables("Shutting down"))
+}
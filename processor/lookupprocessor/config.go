@@ -4,17 +4,30 @@
 package lookupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/lookupsource"
 )
 
+const (
+	sourceKey     = "source"
+	sourceTypeKey = "type"
+)
+
 type Config struct {
 	Source SourceConfig `mapstructure:"source"`
+
+	// sources holds the source factories known to the processor factory that
+	// created this config; it is used to resolve Source.Type while unmarshaling.
+	sources map[string]lookupsource.SourceFactory
 }
 
 type SourceConfig struct {
-	// Type is the source type identifier (e.g., "noop", "yaml", "dns").
+	// Type is the source type identifier (e.g., "noop", "file", "dns").
 	Type string `mapstructure:"type"`
 
 	// Config holds the source-specific configuration.
@@ -22,8 +35,63 @@ type SourceConfig struct {
 	Config lookupsource.SourceConfig `mapstructure:"-"`
 }
 
-var _ component.Config = (*Config)(nil)
+var (
+	_ component.Config    = (*Config)(nil)
+	_ confmap.Unmarshaler = (*Config)(nil)
+)
+
+func (cfg *Config) Validate() error {
+	if cfg.Source.Type == "" {
+		return errors.New("source.type must be specified")
+	}
+	if cfg.sources != nil {
+		if _, ok := cfg.sources[cfg.Source.Type]; !ok {
+			return fmt.Errorf("unknown source type %q", cfg.Source.Type)
+		}
+	}
+	// Source.Config is validated by the collector along with the rest of the configuration.
+	return nil
+}
+
+// Unmarshal loads the source type and then decodes the remaining keys of the
+// source section into the configuration provided by the matching source factory.
+func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
+	if componentParser == nil {
+		return nil
+	}
+
+	if err := componentParser.Unmarshal(cfg, confmap.WithIgnoreUnused()); err != nil {
+		return err
+	}
+
+	sourceSection, err := componentParser.Sub(sourceKey)
+	if err != nil {
+		return err
+	}
+	sourceSettings := sourceSection.ToStringMap()
+	delete(sourceSettings, sourceTypeKey)
+
+	factory, ok := cfg.sources[cfg.Source.Type]
+	if !ok {
+		if len(sourceSettings) > 0 {
+			return fmt.Errorf("unknown source type %q", cfg.Source.Type)
+		}
+		// Leave unknown types without settings to Validate.
+		cfg.Source.Config = nil
+		return nil
+	}
 
-func (*Config) Validate() error {
+	sourceCfg := factory.CreateDefaultConfig()
+	if sourceCfg == nil {
+		if len(sourceSettings) > 0 {
+			return fmt.Errorf("source type %q does not accept any settings", cfg.Source.Type)
+		}
+		cfg.Source.Config = nil
+		return nil
+	}
+	if err := confmap.NewFromStringMap(sourceSettings).Unmarshal(sourceCfg); err != nil {
+		return fmt.Errorf("error reading settings for source type %q: %w", cfg.Source.Type, err)
+	}
+	cfg.Source.Config = sourceCfg
 	return nil
 }
@@ -5,7 +5,8 @@
 // by performing lookups from various sources and adding the results as attributes.
 //
 // Sources are configured via the processor's configuration using the source.type field.
-// Built-in sources include "noop" (for testing) and "yaml" (file-based lookups).
+// Built-in sources include "noop" (for testing), "file" (CSV, YAML or JSON
+// lookup tables), "dns", "sql" and "http".
 // Third-party sources can be registered using [NewFactoryWithOptions] with [WithSources].
 //
 // The public API for creating custom sources is in the lookupsource subpackage.
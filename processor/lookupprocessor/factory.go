@@ -13,7 +13,11 @@ import (
 	"go.opentelemetry.io/collector/processor/processorhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/internal/source/dns"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/internal/source/file"
+	httpsource "github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/internal/source/http"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/internal/source/noop"
+	sqlsource "github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/internal/source/sql"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/lookupsource"
 )
 
@@ -52,7 +56,10 @@ type lookupProcessorFactory struct {
 func defaultSources() map[string]lookupsource.SourceFactory {
 	return map[string]lookupsource.SourceFactory{
 		"noop": noop.NewFactory(),
-		// yaml and dns sources will be added in subsequent branches
+		"file": file.NewFactory(),
+		"dns":  dns.NewFactory(),
+		"sql":  sqlsource.NewFactory(),
+		"http": httpsource.NewFactory(),
 	}
 }
 
@@ -87,11 +94,12 @@ func NewFactoryWithOptions(options ...FactoryOption) processor.Factory {
 	)
 }
 
-func (*lookupProcessorFactory) createDefaultConfig() component.Config {
+func (f *lookupProcessorFactory) createDefaultConfig() component.Config {
 	return &Config{
 		Source: SourceConfig{
 			Type: "noop",
 		},
+		sources: f.sources,
 	}
 }
 
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dns provides a lookup source that resolves keys using DNS,
+// either in reverse (IP address to host name) or forward (host name to records).
+package dns // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/internal/source/dns"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/lookupsource"
+)
+
+const sourceType = "dns"
+
+const (
+	RecordTypePTR   = "PTR"
+	RecordTypeA     = "A"
+	RecordTypeAAAA  = "AAAA"
+	RecordTypeCNAME = "CNAME"
+	RecordTypeTXT   = "TXT"
+)
+
+type Config struct {
+	// RecordType is the DNS record type to resolve. "PTR" performs reverse
+	// lookups of IP addresses; "A", "AAAA", "CNAME" and "TXT" resolve host names.
+	// Default: PTR
+	RecordType string `mapstructure:"record_type"`
+
+	// Server is an optional "host:port" of the DNS server to query instead
+	// of the system resolver.
+	Server string `mapstructure:"server"`
+
+	// Timeout bounds every individual DNS query.
+	// Default: 2s
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MultipleResults returns all resolved records as a list instead of only
+	// the first one.
+	MultipleResults bool `mapstructure:"multiple_results"`
+
+	Cache lookupsource.CacheConfig `mapstructure:"cache"`
+}
+
+func (c *Config) Validate() error {
+	switch strings.ToUpper(c.RecordType) {
+	case RecordTypePTR, RecordTypeA, RecordTypeAAAA, RecordTypeCNAME, RecordTypeTXT:
+	default:
+		return fmt.Errorf("unsupported record_type %q", c.RecordType)
+	}
+	if c.Server != "" {
+		if _, _, err := net.SplitHostPort(c.Server); err != nil {
+			return fmt.Errorf("invalid server %q: %w", c.Server, err)
+		}
+	}
+	if c.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return c.Cache.Validate()
+}
+
+// resolver is the subset of net.Resolver used by the source.
+type resolver interface {
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+	LookupIP(ctx context.Context, network, host string) ([]net.IP, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+func NewFactory() lookupsource.SourceFactory {
+	return lookupsource.NewSourceFactory(
+		sourceType,
+		createDefaultConfig,
+		createSource,
+	)
+}
+
+func createDefaultConfig() lookupsource.SourceConfig {
+	return &Config{
+		RecordType: RecordTypePTR,
+		Timeout:    2 * time.Second,
+		Cache: lookupsource.CacheConfig{
+			Enabled:     true,
+			Size:        10000,
+			TTL:         5 * time.Minute,
+			NegativeTTL: time.Minute,
+		},
+	}
+}
+
+func createSource(
+	_ context.Context,
+	_ lookupsource.CreateSettings,
+	cfg lookupsource.SourceConfig,
+) (lookupsource.Source, error) {
+	c := cfg.(*Config)
+	return newSource(c, newResolver(c.Server)), nil
+}
+
+func newSource(cfg *Config, r resolver) lookupsource.Source {
+	s := &dnsSource{cfg: cfg, resolver: r}
+	cache := lookupsource.NewCache(cfg.Cache)
+	return lookupsource.NewSource(
+		lookupsource.WrapWithCache(cache, s.lookup),
+		func() string { return sourceType },
+		nil,
+		func(context.Context) error {
+			cache.Clear()
+			return nil
+		},
+	)
+}
+
+func newResolver(server string) *net.Resolver {
+	if server == "" {
+		return net.DefaultResolver
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, server)
+		},
+	}
+}
+
+type dnsSource struct {
+	cfg      *Config
+	resolver resolver
+}
+
+func (s *dnsSource) lookup(ctx context.Context, key string) (any, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	records, err := s.resolve(ctx, key)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if len(records) == 0 {
+		return nil, false, nil
+	}
+	if !s.cfg.MultipleResults {
+		return records[0], true, nil
+	}
+	result := make([]any, len(records))
+	for i, record := range records {
+		result[i] = record
+	}
+	return result, true, nil
+}
+
+func (s *dnsSource) resolve(ctx context.Context, key string) ([]string, error) {
+	switch strings.ToUpper(s.cfg.RecordType) {
+	case RecordTypePTR:
+		if net.ParseIP(key) == nil {
+			return nil, nil
+		}
+		names, err := s.resolver.LookupAddr(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		for i, name := range names {
+			names[i] = strings.TrimSuffix(name, ".")
+		}
+		return names, nil
+	case RecordTypeA, RecordTypeAAAA:
+		network := "ip4"
+		if strings.EqualFold(s.cfg.RecordType, RecordTypeAAAA) {
+			network = "ip6"
+		}
+		ips, err := s.resolver.LookupIP(ctx, network, key)
+		if err != nil {
+			return nil, err
+		}
+		addrs := make([]string, len(ips))
+		for i, ip := range ips {
+			addrs[i] = ip.String()
+		}
+		return addrs, nil
+	case RecordTypeCNAME:
+		cname, err := s.resolver.LookupCNAME(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		return []string{strings.TrimSuffix(cname, ".")}, nil
+	case RecordTypeTXT:
+		return s.resolver.LookupTXT(ctx, key)
+	default:
+		return nil, fmt.Errorf("unsupported record_type %q", s.cfg.RecordType)
+	}
+}
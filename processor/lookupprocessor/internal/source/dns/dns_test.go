@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/lookupsource"
+)
+
+type fakeResolver struct {
+	addrs map[string][]string
+	ips   map[string][]net.IP
+	err   error
+	calls int
+}
+
+func (r *fakeResolver) LookupAddr(_ context.Context, addr string) ([]string, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	names, ok := r.addrs[addr]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: addr, IsNotFound: true}
+	}
+	return append([]string(nil), names...), nil
+}
+
+func (r *fakeResolver) LookupIP(_ context.Context, _, host string) ([]net.IP, error) {
+	r.calls++
+	ips, ok := r.ips[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return ips, nil
+}
+
+func (r *fakeResolver) LookupCNAME(_ context.Context, host string) (string, error) {
+	r.calls++
+	return "alias." + host + ".", nil
+}
+
+func (r *fakeResolver) LookupTXT(_ context.Context, _ string) ([]string, error) {
+	r.calls++
+	return []string{"v=spf1 -all"}, nil
+}
+
+func TestDNSLookup(t *testing.T) {
+	resolver := &fakeResolver{
+		addrs: map[string][]string{
+			"10.0.0.1": {"web-1.internal.", "web.internal."},
+		},
+		ips: map[string][]net.IP{
+			"web.internal": {net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2")},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		recordType    string
+		multiple      bool
+		key           string
+		expected      any
+		expectedFound bool
+	}{
+		{
+			name:          "reverse",
+			recordType:    RecordTypePTR,
+			key:           "10.0.0.1",
+			expected:      "web-1.internal",
+			expectedFound: true,
+		},
+		{
+			name:          "reverse multiple",
+			recordType:    RecordTypePTR,
+			multiple:      true,
+			key:           "10.0.0.1",
+			expected:      []any{"web-1.internal", "web.internal"},
+			expectedFound: true,
+		},
+		{
+			name:       "reverse not found",
+			recordType: RecordTypePTR,
+			key:        "10.0.0.9",
+		},
+		{
+			name:       "reverse invalid ip",
+			recordType: RecordTypePTR,
+			key:        "not-an-ip",
+		},
+		{
+			name:          "forward",
+			recordType:    "a",
+			multiple:      true,
+			key:           "web.internal",
+			expected:      []any{"10.0.0.1", "10.0.0.2"},
+			expectedFound: true,
+		},
+		{
+			name:          "cname",
+			recordType:    RecordTypeCNAME,
+			key:           "web.internal",
+			expected:      "alias.web.internal",
+			expectedFound: true,
+		},
+		{
+			name:          "txt",
+			recordType:    RecordTypeTXT,
+			key:           "example.com",
+			expected:      "v=spf1 -all",
+			expectedFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.RecordType = tt.recordType
+			cfg.MultipleResults = tt.multiple
+			cfg.Cache.Enabled = false
+			require.NoError(t, cfg.Validate())
+
+			val, found, err := newSource(cfg, resolver).Lookup(t.Context(), tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFound, found)
+			assert.Equal(t, tt.expected, val)
+		})
+	}
+}
+
+func TestDNSLookupCache(t *testing.T) {
+	resolver := &fakeResolver{
+		addrs: map[string][]string{"10.0.0.1": {"web-1.internal."}},
+	}
+	source := newSource(createDefaultConfig().(*Config), resolver)
+
+	for range 3 {
+		_, found, err := source.Lookup(t.Context(), "10.0.0.1")
+		require.NoError(t, err)
+		assert.True(t, found)
+
+		_, found, err = source.Lookup(t.Context(), "10.0.0.2")
+		require.NoError(t, err)
+		assert.False(t, found)
+	}
+	assert.Equal(t, 2, resolver.calls)
+}
+
+func TestDNSLookupError(t *testing.T) {
+	errTimeout := errors.New("i/o timeout")
+	resolver := &fakeResolver{err: errTimeout}
+	source := newSource(createDefaultConfig().(*Config), resolver)
+
+	for range 2 {
+		_, found, err := source.Lookup(t.Context(), "10.0.0.1")
+		assert.ErrorIs(t, err, errTimeout)
+		assert.False(t, found)
+	}
+	assert.Equal(t, 2, resolver.calls)
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := createDefaultConfig().(*Config)
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name        string
+		mutate      func(*Config)
+		expectedErr string
+	}{
+		{
+			name:        "unsupported record type",
+			mutate:      func(c *Config) { c.RecordType = "MX" },
+			expectedErr: `unsupported record_type "MX"`,
+		},
+		{
+			name:        "invalid server",
+			mutate:      func(c *Config) { c.Server = "localhost" },
+			expectedErr: `invalid server "localhost": address localhost: missing port in address`,
+		},
+		{
+			name:        "invalid timeout",
+			mutate:      func(c *Config) { c.Timeout = 0 },
+			expectedErr: "timeout must be positive",
+		},
+		{
+			name: "invalid cache",
+			mutate: func(c *Config) {
+				c.Cache = lookupsource.CacheConfig{Enabled: true, TTL: -time.Second}
+			},
+			expectedErr: "cache ttl must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package file provides a lookup source backed by a CSV, YAML or JSON file
+// that is loaded into memory and reloaded when the file changes.
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/internal/source/file"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/lookupsource"
+)
+
+const sourceType = "file"
+
+const (
+	FormatCSV  = "csv"
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+type Config struct {
+	// Path is the path of the lookup table file.
+	Path string `mapstructure:"path"`
+
+	// Format is one of "csv", "yaml" or "json". When empty, the format is
+	// inferred from the file extension.
+	Format string `mapstructure:"format"`
+
+	// KeyColumn is the CSV column used as lookup key.
+	// Default: the first column.
+	KeyColumn string `mapstructure:"key_column"`
+
+	// ValueColumn is the CSV column returned as lookup value. When empty,
+	// a map of all non-key columns is returned.
+	ValueColumn string `mapstructure:"value_column"`
+
+	// ReloadInterval is how often the file is checked for changes.
+	// Default: 30s. Set to 0 to disable reloading.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+func (c *Config) Validate() error {
+	if c.Path == "" {
+		return errors.New("path must be specified")
+	}
+	format := c.format()
+	switch format {
+	case FormatCSV:
+	case FormatYAML, FormatJSON:
+		if c.KeyColumn != "" || c.ValueColumn != "" {
+			return fmt.Errorf("key_column and value_column are only supported for the %q format", FormatCSV)
+		}
+	case "":
+		return fmt.Errorf("unable to infer format from path %q, set format explicitly", c.Path)
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+	if c.ReloadInterval < 0 {
+		return errors.New("reload_interval must not be negative")
+	}
+	return nil
+}
+
+func (c *Config) format() string {
+	if c.Format != "" {
+		return strings.ToLower(c.Format)
+	}
+	switch strings.ToLower(filepath.Ext(c.Path)) {
+	case ".csv":
+		return FormatCSV
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".json":
+		return FormatJSON
+	default:
+		return ""
+	}
+}
+
+func NewFactory() lookupsource.SourceFactory {
+	return lookupsource.NewSourceFactory(
+		sourceType,
+		createDefaultConfig,
+		createSource,
+	)
+}
+
+func createDefaultConfig() lookupsource.SourceConfig {
+	return &Config{
+		ReloadInterval: 30 * time.Second,
+	}
+}
+
+func createSource(
+	_ context.Context,
+	settings lookupsource.CreateSettings,
+	cfg lookupsource.SourceConfig,
+) (lookupsource.Source, error) {
+	s := newFileSource(cfg.(*Config), settings.TelemetrySettings.Logger)
+	return lookupsource.NewSource(
+		s.lookup,
+		func() string { return sourceType },
+		s.start,
+		s.shutdown,
+	), nil
+}
+
+type fileSource struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	table   atomic.Pointer[map[string]any]
+	modTime time.Time
+	size    int64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newFileSource(cfg *Config, logger *zap.Logger) *fileSource {
+	return &fileSource{cfg: cfg, logger: logger}
+}
+
+func (s *fileSource) lookup(_ context.Context, key string) (any, bool, error) {
+	table := s.table.Load()
+	if table == nil {
+		return nil, false, nil
+	}
+	val, ok := (*table)[key]
+	return val, ok, nil
+}
+
+func (s *fileSource) start(_ context.Context, _ component.Host) error {
+	if err := s.load(); err != nil {
+		return err
+	}
+	if s.cfg.ReloadInterval <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.wg.Add(1)
+	go s.watch(ctx)
+	return nil
+}
+
+func (s *fileSource) shutdown(_ context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	return nil
+}
+
+func (s *fileSource) watch(ctx context.Context) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.cfg.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := s.changed()
+			if err != nil {
+				s.logger.Warn("Failed to check lookup file for changes", zap.String("path", s.cfg.Path), zap.Error(err))
+				continue
+			}
+			if !changed {
+				continue
+			}
+			if err := s.load(); err != nil {
+				s.logger.Warn("Failed to reload lookup file, keeping previous contents", zap.String("path", s.cfg.Path), zap.Error(err))
+				continue
+			}
+			s.logger.Info("Reloaded lookup file", zap.String("path", s.cfg.Path), zap.Int("entries", len(*s.table.Load())))
+		}
+	}
+}
+
+func (s *fileSource) changed() (bool, error) {
+	info, err := os.Stat(s.cfg.Path)
+	if err != nil {
+		return false, err
+	}
+	return !info.ModTime().Equal(s.modTime) || info.Size() != s.size, nil
+}
+
+func (s *fileSource) load() error {
+	info, err := os.Stat(s.cfg.Path)
+	if err != nil {
+		return fmt.Errorf("failed to stat lookup file: %w", err)
+	}
+	data, err := os.ReadFile(s.cfg.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read lookup file: %w", err)
+	}
+
+	var table map[string]any
+	switch s.cfg.format() {
+	case FormatCSV:
+		table, err = parseCSV(data, s.cfg.KeyColumn, s.cfg.ValueColumn)
+	case FormatYAML:
+		table, err = parseYAML(data)
+	case FormatJSON:
+		table, err = parseJSON(data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse lookup file %q: %w", s.cfg.Path, err)
+	}
+
+	s.table.Store(&table)
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return nil
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/lookupsource"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr string
+	}{
+		{
+			name: "csv by extension",
+			cfg:  Config{Path: "owners.csv"},
+		},
+		{
+			name: "explicit format",
+			cfg:  Config{Path: "owners.txt", Format: "YAML"},
+		},
+		{
+			name:        "missing path",
+			cfg:         Config{},
+			expectedErr: "path must be specified",
+		},
+		{
+			name:        "unknown extension",
+			cfg:         Config{Path: "owners.txt"},
+			expectedErr: `unable to infer format from path "owners.txt", set format explicitly`,
+		},
+		{
+			name:        "unsupported format",
+			cfg:         Config{Path: "owners.txt", Format: "xml"},
+			expectedErr: `unsupported format "xml"`,
+		},
+		{
+			name:        "columns with json",
+			cfg:         Config{Path: "owners.json", KeyColumn: "name"},
+			expectedErr: `key_column and value_column are only supported for the "csv" format`,
+		},
+		{
+			name:        "negative reload interval",
+			cfg:         Config{Path: "owners.yaml", ReloadInterval: -time.Second},
+			expectedErr: "reload_interval must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestFileSourceFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		cfg      Config
+		key      string
+		expected any
+	}{
+		{
+			name:    "csv row",
+			file:    "owners.csv",
+			content: "service,team,owner\ncheckout,payments,alice\n",
+			key:     "checkout",
+			expected: map[string]any{
+				"team":  "payments",
+				"owner": "alice",
+			},
+		},
+		{
+			name:     "csv columns",
+			file:     "owners.csv",
+			content:  "team,service,owner\npayments,checkout,alice\n",
+			cfg:      Config{KeyColumn: "service", ValueColumn: "owner"},
+			key:      "checkout",
+			expected: "alice",
+		},
+		{
+			name:    "yaml",
+			file:    "owners.yaml",
+			content: "checkout:\n  team: payments\n  tier: 1\n",
+			key:     "checkout",
+			expected: map[string]any{
+				"team": "payments",
+				"tier": 1,
+			},
+		},
+		{
+			name:     "json",
+			file:     "owners.json",
+			content:  `{"checkout": "payments"}`,
+			key:      "checkout",
+			expected: "payments",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Path = filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, os.WriteFile(cfg.Path, []byte(tt.content), 0o600))
+			require.NoError(t, cfg.Validate())
+
+			source := createTestSource(t, &cfg)
+
+			val, found, err := source.Lookup(t.Context(), tt.key)
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, tt.expected, val)
+
+			_, found, err = source.Lookup(t.Context(), "unknown")
+			require.NoError(t, err)
+			assert.False(t, found)
+		})
+	}
+}
+
+func TestFileSourceStartErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	missing := &Config{Path: filepath.Join(dir, "missing.csv")}
+	source, err := createSource(t.Context(), testSettings(), missing)
+	require.NoError(t, err)
+	assert.ErrorContains(t, source.Start(t.Context(), componenttest.NewNopHost()), "failed to stat lookup file")
+
+	invalid := &Config{Path: filepath.Join(dir, "invalid.csv"), KeyColumn: "name"}
+	require.NoError(t, os.WriteFile(invalid.Path, []byte("service,team\n"), 0o600))
+	source, err = createSource(t.Context(), testSettings(), invalid)
+	require.NoError(t, err)
+	assert.ErrorContains(t, source.Start(t.Context(), componenttest.NewNopHost()), `key column "name" not found in header`)
+}
+
+func TestFileSourceReload(t *testing.T) {
+	cfg := &Config{
+		Path:           filepath.Join(t.TempDir(), "owners.json"),
+		ReloadInterval: 10 * time.Millisecond,
+	}
+	require.NoError(t, os.WriteFile(cfg.Path, []byte(`{"checkout": "payments"}`), 0o600))
+	source := createTestSource(t, cfg)
+
+	val, found, err := source.Lookup(t.Context(), "checkout")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "payments", val)
+
+	// Invalid content keeps the previous table.
+	require.NoError(t, os.WriteFile(cfg.Path, []byte(`{"checkout":`), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	val, found, err = source.Lookup(t.Context(), "checkout")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "payments", val)
+
+	require.NoError(t, os.WriteFile(cfg.Path, []byte(`{"checkout": "commerce", "cart": "shopping"}`), 0o600))
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		val, found, err := source.Lookup(t.Context(), "cart")
+		assert.NoError(c, err)
+		assert.True(c, found)
+		assert.Equal(c, "shopping", val)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func createTestSource(t *testing.T, cfg *Config) lookupsource.Source {
+	source, err := createSource(t.Context(), testSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, source.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, source.Shutdown(t.Context()))
+	})
+	return source
+}
+
+func testSettings() lookupsource.CreateSettings {
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.Logger = zap.NewNop()
+	return lookupsource.CreateSettings{TelemetrySettings: settings}
+}
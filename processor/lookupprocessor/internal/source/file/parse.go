@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package file // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/internal/source/file"
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseCSV parses a CSV document with a header row. Each row is keyed by
+// keyColumn (the first column when empty). When valueColumn is set, the value
+// is that column's string; otherwise it is a map of all other columns.
+func parseCSV(data []byte, keyColumn, valueColumn string) (map[string]any, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("missing header row")
+	}
+
+	header := records[0]
+	keyIdx := 0
+	if keyColumn != "" {
+		keyIdx = slices.Index(header, keyColumn)
+		if keyIdx < 0 {
+			return nil, fmt.Errorf("key column %q not found in header", keyColumn)
+		}
+	}
+	valueIdx := -1
+	if valueColumn != "" {
+		valueIdx = slices.Index(header, valueColumn)
+		if valueIdx < 0 {
+			return nil, fmt.Errorf("value column %q not found in header", valueColumn)
+		}
+	}
+
+	table := make(map[string]any, len(records)-1)
+	for _, record := range records[1:] {
+		key := record[keyIdx]
+		if valueIdx >= 0 {
+			table[key] = record[valueIdx]
+			continue
+		}
+		row := make(map[string]any, len(header)-1)
+		for i, column := range header {
+			if i == keyIdx {
+				continue
+			}
+			row[column] = record[i]
+		}
+		table[key] = row
+	}
+	return table, nil
+}
+
+// parseYAML parses a YAML document whose top level is a mapping of keys to values.
+func parseYAML(data []byte) (map[string]any, error) {
+	table := map[string]any{}
+	if err := yaml.Unmarshal(data, &table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// parseJSON parses a JSON document whose top level is an object of keys to values.
+func parseJSON(data []byte) (map[string]any, error) {
+	table := map[string]any{}
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
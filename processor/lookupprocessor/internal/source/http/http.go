@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package http provides a lookup source that queries a JSON HTTP endpoint for every key.
+package http // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/internal/source/http"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/lookupsource"
+)
+
+const (
+	sourceType = "http"
+
+	// keyPlaceholder is replaced by the escaped lookup key in the endpoint.
+	keyPlaceholder = "{key}"
+
+	// maxResponseSize bounds the size of a response body that is decoded.
+	maxResponseSize = 4 << 20
+)
+
+type Config struct {
+	// Endpoint must contain the "{key}" placeholder, e.g.
+	// "https://cmdb.example.com/api/services/{key}" or
+	// "https://cmdb.example.com/api/lookup?name={key}".
+	confighttp.ClientConfig `mapstructure:",squash"`
+
+	// Method is the HTTP method used for lookups.
+	// Default: GET
+	Method string `mapstructure:"method"`
+
+	// ResultField is an optional dot-separated path selecting the value to
+	// return from the JSON response, e.g. "data.owner". When empty, the whole
+	// decoded response is returned.
+	ResultField string `mapstructure:"result_field"`
+
+	Cache lookupsource.CacheConfig `mapstructure:"cache"`
+}
+
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	if !strings.Contains(c.Endpoint, keyPlaceholder) {
+		return fmt.Errorf("endpoint must contain the %q placeholder", keyPlaceholder)
+	}
+	if _, err := url.Parse(strings.ReplaceAll(c.Endpoint, keyPlaceholder, "key")); err != nil {
+		return fmt.Errorf("invalid endpoint: %w", err)
+	}
+	switch c.Method {
+	case http.MethodGet, http.MethodPost:
+	default:
+		return fmt.Errorf("unsupported method %q", c.Method)
+	}
+	return c.Cache.Validate()
+}
+
+func NewFactory() lookupsource.SourceFactory {
+	return lookupsource.NewSourceFactory(
+		sourceType,
+		createDefaultConfig,
+		createSource,
+	)
+}
+
+func createDefaultConfig() lookupsource.SourceConfig {
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Timeout = 5 * time.Second
+	return &Config{
+		ClientConfig: clientConfig,
+		Method:       http.MethodGet,
+		Cache: lookupsource.CacheConfig{
+			Enabled:     true,
+			Size:        1000,
+			TTL:         5 * time.Minute,
+			NegativeTTL: time.Minute,
+		},
+	}
+}
+
+func createSource(
+	_ context.Context,
+	settings lookupsource.CreateSettings,
+	cfg lookupsource.SourceConfig,
+) (lookupsource.Source, error) {
+	s := &httpSource{
+		cfg:      cfg.(*Config),
+		settings: settings.TelemetrySettings,
+	}
+	cache := lookupsource.NewCache(s.cfg.Cache)
+	return lookupsource.NewSource(
+		lookupsource.WrapWithCache(cache, s.lookup),
+		func() string { return sourceType },
+		s.start,
+		func(context.Context) error {
+			cache.Clear()
+			if s.client != nil {
+				s.client.CloseIdleConnections()
+			}
+			return nil
+		},
+	), nil
+}
+
+type httpSource struct {
+	cfg      *Config
+	settings component.TelemetrySettings
+	client   *http.Client
+}
+
+func (s *httpSource) start(ctx context.Context, host component.Host) error {
+	client, err := s.cfg.ToClient(ctx, host.GetExtensions(), s.settings)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	s.client = client
+	return nil
+}
+
+func (s *httpSource) lookup(ctx context.Context, key string) (any, bool, error) {
+	if s.client == nil {
+		return nil, false, errors.New("http source is not started")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, s.cfg.Method, s.requestURL(key), http.NoBody)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, false, nil
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, false, fmt.Errorf("lookup request failed with status %d", resp.StatusCode)
+	}
+
+	var body any
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&body); err != nil {
+		return nil, false, fmt.Errorf("failed to decode lookup response: %w", err)
+	}
+
+	val, ok := selectField(body, s.cfg.ResultField)
+	if !ok || val == nil {
+		return nil, false, nil
+	}
+	return val, true, nil
+}
+
+// requestURL substitutes the key into the endpoint, escaping it according to
+// whether the placeholder is part of the path or the query string.
+func (s *httpSource) requestURL(key string) string {
+	endpoint := s.cfg.Endpoint
+	queryStart := strings.IndexByte(endpoint, '?')
+	if queryStart < 0 {
+		return strings.ReplaceAll(endpoint, keyPlaceholder, url.PathEscape(key))
+	}
+	return strings.ReplaceAll(endpoint[:queryStart], keyPlaceholder, url.PathEscape(key)) +
+		strings.ReplaceAll(endpoint[queryStart:], keyPlaceholder, url.QueryEscape(key))
+}
+
+func selectField(val any, path string) (any, bool) {
+	if path == "" {
+		return val, true
+	}
+	for _, field := range strings.Split(path, ".") {
+		m, ok := val.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if val, ok = m[field]; !ok {
+			return nil, false
+		}
+	}
+	return val, true
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/lookupsource"
+)
+
+func TestHTTPLookup(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/services/checkout":
+			_, _ = fmt.Fprint(w, `{"data": {"owner": "alice", "team": "payments"}}`)
+		case "/services/search service":
+			_, _ = fmt.Fprint(w, `{"data": {"owner": null}}`)
+		case "/services/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/services/invalid":
+			_, _ = fmt.Fprint(w, `{"data":`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		resultField   string
+		key           string
+		expected      any
+		expectedFound bool
+		expectedErr   string
+	}{
+		{
+			name:          "whole body",
+			key:           "checkout",
+			expected:      map[string]any{"data": map[string]any{"owner": "alice", "team": "payments"}},
+			expectedFound: true,
+		},
+		{
+			name:          "result field",
+			resultField:   "data.owner",
+			key:           "checkout",
+			expected:      "alice",
+			expectedFound: true,
+		},
+		{
+			name:        "missing result field",
+			resultField: "data.manager",
+			key:         "checkout",
+		},
+		{
+			name:        "null result field",
+			resultField: "data.owner",
+			key:         "search service",
+		},
+		{
+			name: "not found",
+			key:  "unknown",
+		},
+		{
+			name:        "server error",
+			key:         "broken",
+			expectedErr: "lookup request failed with status 500",
+		},
+		{
+			name:        "invalid body",
+			key:         "invalid",
+			expectedErr: "failed to decode lookup response: unexpected EOF",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = server.URL + "/services/{key}"
+			cfg.ResultField = tt.resultField
+			cfg.Cache.Enabled = false
+			require.NoError(t, cfg.Validate())
+			source := startTestSource(t, cfg)
+
+			val, found, err := source.Lookup(t.Context(), tt.key)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFound, found)
+			assert.Equal(t, tt.expected, val)
+		})
+	}
+
+	t.Run("cache", func(t *testing.T) {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Endpoint = server.URL + "/services/{key}"
+		source := startTestSource(t, cfg)
+
+		requests.Store(0)
+		for range 3 {
+			_, found, err := source.Lookup(t.Context(), "checkout")
+			require.NoError(t, err)
+			assert.True(t, found)
+			_, found, err = source.Lookup(t.Context(), "unknown")
+			require.NoError(t, err)
+			assert.False(t, found)
+		}
+		assert.Equal(t, int32(2), requests.Load())
+	})
+}
+
+func TestRequestURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		key      string
+		expected string
+	}{
+		{
+			endpoint: "http://localhost/services/{key}",
+			key:      "a/b c",
+			expected: "http://localhost/services/a%2Fb%20c",
+		},
+		{
+			endpoint: "http://localhost/lookup?name={key}&format=json",
+			key:      "a&b c",
+			expected: "http://localhost/lookup?name=a%26b+c&format=json",
+		},
+		{
+			endpoint: "http://localhost/{key}/info?name={key}",
+			key:      "a b",
+			expected: "http://localhost/a%20b/info?name=a+b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			s := &httpSource{cfg: &Config{}}
+			s.cfg.Endpoint = tt.endpoint
+			assert.Equal(t, tt.expected, s.requestURL(tt.key))
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		mutate      func(*Config)
+		expectedErr string
+	}{
+		{
+			name:        "missing endpoint",
+			mutate:      func(*Config) {},
+			expectedErr: "endpoint must be specified",
+		},
+		{
+			name:        "missing placeholder",
+			mutate:      func(c *Config) { c.Endpoint = "http://localhost/services" },
+			expectedErr: `endpoint must contain the "{key}" placeholder`,
+		},
+		{
+			name: "unsupported method",
+			mutate: func(c *Config) {
+				c.Endpoint = "http://localhost/services/{key}"
+				c.Method = http.MethodDelete
+			},
+			expectedErr: `unsupported method "DELETE"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.mutate(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+		})
+	}
+}
+
+func startTestSource(t *testing.T, cfg *Config) lookupsource.Source {
+	source, err := createSource(
+		t.Context(),
+		lookupsource.CreateSettings{TelemetrySettings: componenttest.NewNopTelemetrySettings()},
+		cfg,
+	)
+	require.NoError(t, err)
+	require.NoError(t, source.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, source.Shutdown(t.Context()))
+	})
+	return source
+}
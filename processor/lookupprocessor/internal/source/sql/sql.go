@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sql provides a lookup source that runs a parameterized SQL query
+// for every key, using the database clients from internal/sqlquery.
+package sql // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/internal/source/sql"
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	// Register the database drivers supported by internal/sqlquery.
+	_ "github.com/SAP/go-hdb/driver"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/microsoft/go-mssqldb"
+	_ "github.com/microsoft/go-mssqldb/integratedauth/krb5"
+	_ "github.com/sijms/go-ora/v2"
+	_ "github.com/snowflakedb/gosnowflake"
+	_ "github.com/thda/tds"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sqlquery"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/lookupsource"
+)
+
+const sourceType = "sql"
+
+type Config struct {
+	// Driver is the database driver, one of the drivers of the SQL query
+	// receiver: "hdb", "mysql", "oracle", "postgres", "snowflake",
+	// "sqlserver" or "tds".
+	Driver string `mapstructure:"driver"`
+
+	// DataSource is the driver-specific connection string. Mutually exclusive
+	// with the individual connection settings below.
+	DataSource       string              `mapstructure:"datasource"`
+	Host             string              `mapstructure:"host"`
+	Port             int                 `mapstructure:"port"`
+	Database         string              `mapstructure:"database"`
+	Username         string              `mapstructure:"username"`
+	Password         configopaque.String `mapstructure:"password"`
+	AdditionalParams map[string]any      `mapstructure:"additional_params"`
+
+	// Query is executed with the lookup key as its only argument,
+	// e.g. "SELECT team, owner FROM services WHERE name = $1".
+	Query string `mapstructure:"query"`
+
+	// ValueColumn is the column returned as lookup value. When empty, a map
+	// of all columns of the first row is returned.
+	ValueColumn string `mapstructure:"value_column"`
+
+	// MaxOpenConn limits the number of open connections to the database.
+	// Default: 0 (unlimited)
+	MaxOpenConn int `mapstructure:"max_open_conn"`
+
+	// Timeout bounds every individual query.
+	// Default: 5s
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	Cache lookupsource.CacheConfig `mapstructure:"cache"`
+}
+
+func (c *Config) Validate() error {
+	if c.Driver == "" {
+		return errors.New("'driver' cannot be empty")
+	}
+	if !sqlquery.IsValidDriver(c.Driver) {
+		return fmt.Errorf("unsupported driver: %s", c.Driver)
+	}
+	if c.DataSource == "" {
+		if c.Host == "" {
+			return errors.New("'host' or 'datasource' must be specified")
+		}
+		// For sqlserver, port is optional
+		if c.Driver != sqlquery.DriverSQLServer && c.Port == 0 {
+			return errors.New("'port' or 'datasource' must be specified")
+		}
+		if c.Database == "" {
+			return errors.New("'database' or 'datasource' must be specified")
+		}
+	} else if c.Host != "" || c.Port != 0 || c.Database != "" || c.Username != "" || c.Password != "" || len(c.AdditionalParams) > 0 {
+		return errors.New("connection settings cannot be set when 'datasource' is specified")
+	}
+	if c.Query == "" {
+		return errors.New("'query' cannot be empty")
+	}
+	if c.MaxOpenConn < 0 {
+		return errors.New("'max_open_conn' must not be negative")
+	}
+	if c.Timeout <= 0 {
+		return errors.New("'timeout' must be positive")
+	}
+	return c.Cache.Validate()
+}
+
+func (c *Config) dataSource() (string, error) {
+	if c.DataSource != "" {
+		return c.DataSource, nil
+	}
+	return sqlquery.BuildDataSourceString(sqlquery.Config{
+		Driver:           c.Driver,
+		Host:             c.Host,
+		Port:             c.Port,
+		Database:         c.Database,
+		Username:         c.Username,
+		Password:         c.Password,
+		AdditionalParams: c.AdditionalParams,
+	})
+}
+
+func NewFactory() lookupsource.SourceFactory {
+	return lookupsource.NewSourceFactory(
+		sourceType,
+		createDefaultConfig,
+		createSource,
+	)
+}
+
+func createDefaultConfig() lookupsource.SourceConfig {
+	return &Config{
+		Timeout: 5 * time.Second,
+		Cache: lookupsource.CacheConfig{
+			Enabled: true,
+			Size:    1000,
+			TTL:     5 * time.Minute,
+		},
+	}
+}
+
+func createSource(
+	_ context.Context,
+	settings lookupsource.CreateSettings,
+	cfg lookupsource.SourceConfig,
+) (lookupsource.Source, error) {
+	return newSource(cfg.(*Config), settings.TelemetrySettings.Logger, sql.Open, sqlquery.NewDbClient), nil
+}
+
+func newSource(
+	cfg *Config,
+	logger *zap.Logger,
+	openDB sqlquery.SQLOpenerFunc,
+	newClient sqlquery.ClientProviderFunc,
+) lookupsource.Source {
+	s := &sqlSource{
+		cfg:       cfg,
+		logger:    logger,
+		openDB:    openDB,
+		newClient: newClient,
+	}
+	cache := lookupsource.NewCache(cfg.Cache)
+	return lookupsource.NewSource(
+		lookupsource.WrapWithCache(cache, s.lookup),
+		func() string { return sourceType },
+		s.start,
+		func(ctx context.Context) error {
+			cache.Clear()
+			return s.shutdown(ctx)
+		},
+	)
+}
+
+type sqlSource struct {
+	cfg       *Config
+	logger    *zap.Logger
+	openDB    sqlquery.SQLOpenerFunc
+	newClient sqlquery.ClientProviderFunc
+
+	db     *sql.DB
+	client sqlquery.DbClient
+}
+
+func (s *sqlSource) start(_ context.Context, _ component.Host) error {
+	dataSource, err := s.cfg.dataSource()
+	if err != nil {
+		return err
+	}
+	db, err := s.openDB(s.cfg.Driver, dataSource)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(s.cfg.MaxOpenConn)
+	s.db = db
+	s.client = s.newClient(sqlquery.DbWrapper{Db: db}, s.cfg.Query, s.logger, sqlquery.TelemetryConfig{})
+	return nil
+}
+
+func (s *sqlSource) shutdown(_ context.Context) error {
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func (s *sqlSource) lookup(ctx context.Context, key string) (any, bool, error) {
+	if s.client == nil {
+		return nil, false, errors.New("sql source is not started")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	rows, err := s.client.QueryRows(ctx, key)
+	if err != nil {
+		if !errors.Is(err, sqlquery.ErrNullValueWarning) {
+			return nil, false, err
+		}
+		s.logger.Debug("SQL lookup returned NULL values", zap.String("key", key), zap.Error(err))
+	}
+	if len(rows) == 0 {
+		return nil, false, nil
+	}
+	if len(rows) > 1 {
+		s.logger.Debug("SQL lookup returned more than one row, using the first one", zap.String("key", key), zap.Int("rows", len(rows)))
+	}
+
+	row := rows[0]
+	if s.cfg.ValueColumn != "" {
+		val, ok := row[s.cfg.ValueColumn]
+		if !ok {
+			return nil, false, fmt.Errorf("column %q not found in query result", s.cfg.ValueColumn)
+		}
+		return val, true, nil
+	}
+	result := make(map[string]any, len(row))
+	for column, val := range row {
+		result[column] = val
+	}
+	return result, true, nil
+}
@@ -4,10 +4,15 @@
 package lookupsource // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/lookupprocessor/lookupsource"
 
 import (
+	"container/list"
 	"context"
+	"errors"
+	"sync"
 	"time"
 )
 
+const defaultCacheSize = 1000
+
 type CacheConfig struct {
 	Enabled bool `mapstructure:"enabled"`
 
@@ -16,30 +21,143 @@ type CacheConfig struct {
 
 	// Default: 0 (no expiration)
 	TTL time.Duration `mapstructure:"ttl"`
+
+	// NegativeTTL controls how long "not found" results are cached.
+	// Default: 0 (not found results are not cached)
+	NegativeTTL time.Duration `mapstructure:"negative_ttl"`
+}
+
+func (c *CacheConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Size < 0 {
+		return errors.New("cache size must not be negative")
+	}
+	if c.TTL < 0 {
+		return errors.New("cache ttl must not be negative")
+	}
+	if c.NegativeTTL < 0 {
+		return errors.New("cache negative_ttl must not be negative")
+	}
+	return nil
 }
 
+// Cache is a size-bounded LRU cache with optional expiration of entries.
+// It is safe for concurrent use.
 type Cache struct {
 	config CacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	key       string
+	value     any
+	found     bool
+	expiresAt time.Time
 }
 
 func NewCache(cfg CacheConfig) *Cache {
-	return &Cache{config: cfg}
+	if cfg.Size <= 0 {
+		cfg.Size = defaultCacheSize
+	}
+	return &Cache{
+		config:  cfg,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached value for key. Keys cached as "not found"
+// are reported as missing; use WrapWithCache to take advantage of negative caching.
+func (c *Cache) Get(key string) (any, bool) {
+	entry, ok := c.get(key)
+	if !ok || !entry.found {
+		return nil, false
+	}
+	return entry.value, true
 }
 
-func (*Cache) Get(_ string) (any, bool) {
-	return nil, false
+func (c *Cache) Set(key string, value any) {
+	c.set(key, value, true, c.config.TTL)
 }
 
-func (*Cache) Set(_ string, _ any) {
-	// Stub
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
 }
 
-func (*Cache) Clear() {
-	// Stub
+// Len returns the number of entries currently held, including expired entries
+// that have not been evicted yet.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *Cache) get(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return cacheEntry{}, false
+	}
+	c.order.MoveToFront(elem)
+	return *entry, true
+}
+
+func (c *Cache) set(key string, value any, found bool, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.value = value
+		entry.found = found
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{
+		key:       key,
+		value:     value,
+		found:     found,
+		expiresAt: expiresAt,
+	})
+
+	for c.order.Len() > c.config.Size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
 }
 
 // WrapWithCache wraps a lookup function with caching.
 //
+// Successful lookups are cached for CacheConfig.TTL. When CacheConfig.NegativeTTL
+// is positive, "not found" results are cached for that duration as well.
+// Errors are never cached.
+//
 // Example:
 //
 //	cache := lookupsource.NewCache(cfg.Cache)
@@ -49,8 +167,8 @@ func WrapWithCache(cache *Cache, fn LookupFunc) LookupFunc {
 		return fn
 	}
 	return func(ctx context.Context, key string) (any, bool, error) {
-		if val, found := cache.Get(key); found {
-			return val, true, nil
+		if entry, ok := cache.get(key); ok {
+			return entry.value, entry.found, nil
 		}
 
 		val, found, err := fn(ctx, key)
@@ -58,8 +176,11 @@ func WrapWithCache(cache *Cache, fn LookupFunc) LookupFunc {
 			return nil, false, err
 		}
 
-		if found {
-			cache.Set(key, val)
+		switch {
+		case found:
+			cache.set(key, val, true, cache.config.TTL)
+		case cache.config.NegativeTTL > 0:
+			cache.set(key, nil, false, cache.config.NegativeTTL)
 		}
 
 		return val, found, nil
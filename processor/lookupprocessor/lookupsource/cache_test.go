@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lookupsource
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheEviction(t *testing.T) {
+	cache := NewCache(CacheConfig{Enabled: true, Size: 2})
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	_, ok := cache.Get("a") // "a" is now the most recently used entry
+	require.True(t, ok)
+	cache.Set("c", 3)
+
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	val, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+	val, ok = cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+
+	cache.Clear()
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestCacheTTL(t *testing.T) {
+	now := time.Unix(0, 0)
+	cache := NewCache(CacheConfig{Enabled: true, TTL: time.Minute})
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", 1)
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestWrapWithCache(t *testing.T) {
+	errLookup := errors.New("lookup failed")
+	calls := map[string]int{}
+	fn := func(_ context.Context, key string) (any, bool, error) {
+		calls[key]++
+		switch key {
+		case "found":
+			return "value", true, nil
+		case "error":
+			return nil, false, errLookup
+		default:
+			return nil, false, nil
+		}
+	}
+
+	tests := []struct {
+		name          string
+		cfg           CacheConfig
+		key           string
+		expectedVal   any
+		expectedFound bool
+		expectedErr   error
+		expectedCalls int
+	}{
+		{
+			name:          "disabled",
+			cfg:           CacheConfig{},
+			key:           "found",
+			expectedVal:   "value",
+			expectedFound: true,
+			expectedCalls: 2,
+		},
+		{
+			name:          "found is cached",
+			cfg:           CacheConfig{Enabled: true},
+			key:           "found",
+			expectedVal:   "value",
+			expectedFound: true,
+			expectedCalls: 1,
+		},
+		{
+			name:          "not found without negative ttl",
+			cfg:           CacheConfig{Enabled: true},
+			key:           "missing",
+			expectedCalls: 2,
+		},
+		{
+			name:          "not found with negative ttl",
+			cfg:           CacheConfig{Enabled: true, NegativeTTL: time.Minute},
+			key:           "missing",
+			expectedCalls: 1,
+		},
+		{
+			name:          "errors are not cached",
+			cfg:           CacheConfig{Enabled: true, NegativeTTL: time.Minute},
+			key:           "error",
+			expectedErr:   errLookup,
+			expectedCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clear(calls)
+			lookup := WrapWithCache(NewCache(tt.cfg), fn)
+			for range 2 {
+				val, found, err := lookup(t.Context(), tt.key)
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Equal(t, tt.expectedVal, val)
+				assert.Equal(t, tt.expectedFound, found)
+			}
+			assert.Equal(t, tt.expectedCalls, calls[tt.key])
+		})
+	}
+}
+
+func TestCacheConfigValidate(t *testing.T) {
+	assert.NoError(t, (&CacheConfig{Size: -1}).Validate())
+	assert.NoError(t, (&CacheConfig{Enabled: true, Size: 10, TTL: time.Second}).Validate())
+	assert.Error(t, (&CacheConfig{Enabled: true, Size: -1}).Validate())
+	assert.Error(t, (&CacheConfig{Enabled: true, TTL: -time.Second}).Validate())
+	assert.Error(t, (&CacheConfig{Enabled: true, NegativeTTL: -time.Second}).Validate())
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command detokenize reverts the tokens created by the tokenization of the
+// redaction processor to their values, given the tokenization key.
+//
+// The tokens are either passed as arguments, or replaced in the text read
+// from the standard input:
+//
+//	detokenize -reason INC-1234 tok:AbC... tok:DeF...
+//	detokenize -reason INC-1234 < redacted.log
+//
+// Every reverted token is recorded in an audit log, written to the standard
+// error, along with the user and the reason.
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/cmd/detokenize"
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/user"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/tokenizer"
+)
+
+// keyEnv is the environment variable holding the key when no key file is given.
+const keyEnv = "REDACTION_TOKENIZATION_KEY"
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, os.Getenv); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer, getenv func(string) string) error {
+	flags := flag.NewFlagSet("detokenize", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	keyFile := flags.String("key-file", "", "path of the file holding the base64 encoded key (default: the "+keyEnv+" environment variable)")
+	prefix := flags.String("prefix", tokenizer.DefaultPrefix, "prefix of the tokens")
+	reason := flags.String("reason", "", "reason for reverting the tokens, recorded in the audit log (required)")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: detokenize -reason REASON [-key-file FILE] [-prefix PREFIX] [TOKEN...]\n\n")
+		fmt.Fprintf(flags.Output(), "Reverts the tokens passed as arguments, or the tokens found in the standard input.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *reason == "" {
+		return errors.New("the reason must be provided")
+	}
+
+	encodedKey := getenv(keyEnv)
+	if *keyFile != "" {
+		content, err := os.ReadFile(*keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the key: %w", err)
+		}
+		encodedKey = string(content)
+	}
+	if encodedKey == "" {
+		return fmt.Errorf("the key must be provided with -key-file or the %s environment variable", keyEnv)
+	}
+	key, err := tokenizer.ParseKey(encodedKey)
+	if err != nil {
+		return err
+	}
+	t, err := tokenizer.New(key, *prefix)
+	if err != nil {
+		return err
+	}
+
+	audit := newAuditLogger(stderr, *reason)
+	if flags.NArg() > 0 {
+		for _, token := range flags.Args() {
+			value, err := t.Detokenize(token)
+			if err != nil {
+				return fmt.Errorf("failed to revert %q: %w", token, err)
+			}
+			audit.Info("token reverted", slog.String("token", token))
+			fmt.Fprintln(stdout, value)
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line, tokens := t.DetokenizeAll(scanner.Text())
+		for _, token := range tokens {
+			audit.Info("token reverted", slog.String("token", token))
+		}
+		fmt.Fprintln(stdout, line)
+	}
+	return scanner.Err()
+}
+
+// newAuditLogger returns a logger of JSON records holding the user and the reason.
+func newAuditLogger(w io.Writer, reason string) *slog.Logger {
+	username := "unknown"
+	if u, err := user.Current(); err == nil {
+		username = u.Username
+	}
+	return slog.New(slog.NewJSONHandler(w, nil)).With(slog.String("user", username), slog.String("reason", reason))
+}
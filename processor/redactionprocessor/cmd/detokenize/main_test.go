@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/tokenizer"
+)
+
+var testKey = bytes.Repeat([]byte{1}, 64)
+
+func newTestTokenizer(t *testing.T) *tokenizer.Tokenizer {
+	t.Helper()
+	tk, err := tokenizer.New(testKey, "")
+	require.NoError(t, err)
+	return tk
+}
+
+func getenv(env map[string]string) func(string) string {
+	return func(key string) string {
+		return env[key]
+	}
+}
+
+// auditedTokens returns the tokens of the audit records, and checks their reason.
+func auditedTokens(t *testing.T, audit, reason string) []string {
+	var tokens []string
+	for _, line := range strings.Split(strings.TrimSpace(audit), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		assert.Equal(t, reason, record["reason"])
+		assert.NotEmpty(t, record["user"])
+		tokens = append(tokens, record["token"].(string))
+	}
+	return tokens
+}
+
+func TestRunArgs(t *testing.T) {
+	tk := newTestTokenizer(t)
+	email, id := tk.Tokenize("jane.doe@example.com"), tk.Tokenize("12345")
+	env := getenv(map[string]string{keyEnv: base64.StdEncoding.EncodeToString(testKey)})
+
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, run([]string{"-reason", "INC-1234", email, id}, strings.NewReader(""), &stdout, &stderr, env))
+	assert.Equal(t, "jane.doe@example.com\n12345\n", stdout.String())
+	assert.Equal(t, []string{email, id}, auditedTokens(t, stderr.String(), "INC-1234"))
+
+	// the invalid tokens are reported
+	stdout.Reset()
+	err := run([]string{"-reason", "INC-1234", "tok:invalid"}, strings.NewReader(""), &stdout, &stderr, env)
+	assert.ErrorIs(t, err, tokenizer.ErrInvalidToken)
+	assert.Empty(t, stdout.String())
+}
+
+func TestRunStdin(t *testing.T) {
+	tk := newTestTokenizer(t)
+	email := tk.Tokenize("jane.doe@example.com")
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(testKey)+"\n"), 0o600))
+
+	stdin := strings.NewReader("login of " + email + "\nno token\nunknown tok:invalid\n")
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, run([]string{"-reason", "INC-1234", "-key-file", keyFile}, stdin, &stdout, &stderr, getenv(nil)))
+	assert.Equal(t, "login of jane.doe@example.com\nno token\nunknown tok:invalid\n", stdout.String())
+	assert.Equal(t, []string{email}, auditedTokens(t, stderr.String(), "INC-1234"))
+}
+
+func TestRunErrors(t *testing.T) {
+	env := getenv(map[string]string{keyEnv: base64.StdEncoding.EncodeToString(testKey)})
+	tests := []struct {
+		name string
+		args []string
+		env  func(string) string
+		err  string
+	}{
+		{
+			name: "missing reason",
+			args: []string{"tok:abc"},
+			env:  env,
+			err:  "the reason must be provided",
+		},
+		{
+			name: "missing key",
+			args: []string{"-reason", "test"},
+			env:  getenv(nil),
+			err:  "the key must be provided with -key-file or the REDACTION_TOKENIZATION_KEY environment variable",
+		},
+		{
+			name: "missing key file",
+			args: []string{"-reason", "test", "-key-file", filepath.Join(t.TempDir(), "missing")},
+			env:  env,
+			err:  "failed to read the key",
+		},
+		{
+			name: "invalid key",
+			args: []string{"-reason", "test"},
+			env:  getenv(map[string]string{keyEnv: "AAAA"}),
+			err:  "invalid key size 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(tt.args, strings.NewReader(""), &stdout, &stderr, tt.env)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -9,8 +9,12 @@ import (
 	"fmt"
 	"strings"
 
+	"go.opentelemetry.io/collector/config/configopaque"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/db"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/detector"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/url"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/tokenizer"
 )
 
 var _ encoding.TextUnmarshaler = (*HashFunction)(nil)
@@ -44,6 +48,12 @@ type Config struct {
 	// and masking with a fixed string is performed.
 	HashFunction HashFunction `mapstructure:"hash_function"`
 
+	// Tokenization replaces the values with deterministic tokens instead of
+	// masking them with a fixed string. Unlike hashes, the tokens can be
+	// reverted to the values with the key. It can't be used together with
+	// HashFunction.
+	Tokenization *TokenizationConfig `mapstructure:"tokenization"`
+
 	// IgnoredKeys is a list of span attribute keys that are not redacted.
 	// Span attributes in this list are allowed to pass through the filter
 	// without being changed or removed.
@@ -66,6 +76,12 @@ type Config struct {
 	// blocked span attributes. Values that match are not masked.
 	AllowedValues []string `mapstructure:"allowed_values"`
 
+	// Detectors is a list of names of built-in detectors of sensitive values,
+	// e.g. `credit_card` or `email`. The values they find in attribute values,
+	// log bodies and span event names are masked, and the number of values
+	// found by each detector is added to the summary.
+	Detectors []string `mapstructure:"detectors"`
+
 	// DBSanitizer is a flag to enable database query sanitization.
 	DBSanitizer db.DBSanitizerConfig `mapstructure:"db_sanitizer"`
 
@@ -80,6 +96,46 @@ type Config struct {
 	URLSanitization url.URLSanitizationConfig `mapstructure:"url_sanitizer"`
 }
 
+// TokenizationConfig configures the replacement of values with tokens
+// encrypted with AES-256-SIV. The same value is always replaced with the same
+// token, and the value can be recovered from the token with the key, e.g.
+// with the detokenize command.
+type TokenizationConfig struct {
+	// Key is the base64 encoded key used to encrypt the values. It must
+	// decode to 64 bytes.
+	Key configopaque.String `mapstructure:"key"`
+
+	// Prefix is the prefix of the tokens, which allows finding them in
+	// texts. By default, the prefix is `tok:`.
+	Prefix string `mapstructure:"prefix"`
+}
+
+// Validate checks that the detectors exist, that the tokenization is not
+// used together with a hash function, and that the tokenization key is valid.
+func (c *Config) Validate() error {
+	if _, err := detector.New(c.Detectors); err != nil {
+		return err
+	}
+	if c.Tokenization == nil {
+		return nil
+	}
+	if c.HashFunction != None {
+		return errors.New("hash_function and tokenization cannot be used together")
+	}
+	if _, err := c.Tokenization.newTokenizer(); err != nil {
+		return fmt.Errorf("invalid tokenization key: %w", err)
+	}
+	return nil
+}
+
+func (c *TokenizationConfig) newTokenizer() (*tokenizer.Tokenizer, error) {
+	key, err := tokenizer.ParseKey(string(c.Key))
+	if err != nil {
+		return nil, err
+	}
+	return tokenizer.New(key, c.Prefix)
+}
+
 func (u HashFunction) String() string {
 	return string(u)
 }
@@ -4,6 +4,8 @@
 package redactionprocessor
 
 import (
+	"bytes"
+	"encoding/base64"
 	"errors"
 	"path/filepath"
 	"testing"
@@ -11,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 
@@ -35,6 +38,7 @@ func TestLoadConfig(t *testing.T) {
 				BlockedKeyPatterns: []string{".*(token|api_key).*"},
 				HashFunction:       MD5,
 				AllowedValues:      []string{".+@mycompany.com"},
+				Detectors:          []string{"credit_card", "email"},
 				Summary:            debug,
 				DBSanitizer: db.DBSanitizerConfig{
 					SQLConfig: db.SQLConfig{
@@ -107,3 +111,48 @@ func TestValidateConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 64))
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected string
+	}{
+		{
+			name: "valid",
+			cfg:  &Config{Tokenization: &TokenizationConfig{Key: configopaque.String(key)}},
+		},
+		{
+			name:     "unknown detector",
+			cfg:      &Config{Detectors: []string{"email", "ssn"}},
+			expected: `unknown detector "ssn"`,
+		},
+		{
+			name:     "with hash function",
+			cfg:      &Config{HashFunction: SHA3, Tokenization: &TokenizationConfig{Key: configopaque.String(key)}},
+			expected: "hash_function and tokenization cannot be used together",
+		},
+		{
+			name:     "missing key",
+			cfg:      &Config{Tokenization: &TokenizationConfig{}},
+			expected: "invalid tokenization key: invalid key size 0, the key must be 64 bytes long",
+		},
+		{
+			name:     "key not base64 encoded",
+			cfg:      &Config{Tokenization: &TokenizationConfig{Key: "not base64!"}},
+			expected: "invalid tokenization key: the key must be base64 encoded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expected != "" {
+				assert.ErrorContains(t, err, tt.expected)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -6,8 +6,10 @@ require (
 	github.com/DataDog/datadog-agent/pkg/obfuscate v0.76.0-devel
 	github.com/grafana/clusterurl v0.2.1
 	github.com/stretchr/testify v1.11.1
+	github.com/tink-crypto/tink-go/v2 v2.6.0
 	go.opentelemetry.io/collector/component v1.49.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/component/componenttest v0.143.1-0.20260115162016-5e41fb551263
+	go.opentelemetry.io/collector/config/configopaque v1.49.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/confmap v1.49.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/confmap/xconfmap v0.143.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/consumer v1.49.1-0.20260115162016-5e41fb551263
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package detector // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/detector"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	CreditCard   = "credit_card"
+	IBAN         = "iban"
+	JWT          = "jwt"
+	AWSAccessKey = "aws_access_key"
+	GCPAPIKey    = "gcp_api_key"
+	GitHubToken  = "github_token"
+	Email        = "email"
+	Phone        = "phone"
+)
+
+// Detector finds a kind of sensitive value in texts. The candidates are matched with a regular expression, then
+// validated, e.g. with their checksum, to limit the false positives.
+type Detector struct {
+	name     string
+	regex    *regexp.Regexp
+	validate func(string) bool
+}
+
+// catalog holds the detectors by name.
+var catalog = map[string]*Detector{
+	CreditCard: {
+		name:     CreditCard,
+		regex:    regexp.MustCompile(`\d(?:[ -]?\d){12,18}`),
+		validate: validLuhn,
+	},
+	IBAN: {
+		name:     IBAN,
+		regex:    regexp.MustCompile(`[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?`),
+		validate: validIBAN,
+	},
+	JWT: {
+		name:     JWT,
+		regex:    regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+		validate: validJWT,
+	},
+	AWSAccessKey: {
+		name:  AWSAccessKey,
+		regex: regexp.MustCompile(`(?:AKIA|ASIA|ABIA|ACCA)[A-Z0-9]{16}`),
+	},
+	GCPAPIKey: {
+		name:  GCPAPIKey,
+		regex: regexp.MustCompile(`AIza[A-Za-z0-9_-]{35}`),
+	},
+	GitHubToken: {
+		name:  GitHubToken,
+		regex: regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255}`),
+	},
+	Email: {
+		name:  Email,
+		regex: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+	},
+	Phone: {
+		name:     Phone,
+		regex:    regexp.MustCompile(`\+\d{1,3}[ .-]?(?:\(\d{1,4}\)|\d{1,4})(?:[ .-]?\d{2,4}){2,4}|\(\d{3}\) ?\d{3}[ .-]\d{4}|\d{3}[.-]\d{3}[.-]\d{4}`),
+		validate: validPhone,
+	},
+}
+
+// Names returns the names of the available detectors.
+func Names() []string {
+	names := make([]string, 0, len(catalog))
+	for name := range catalog {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns the detectors with the given names.
+func New(names []string) ([]*Detector, error) {
+	detectors := make([]*Detector, 0, len(names))
+	for _, name := range names {
+		d, ok := catalog[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown detector %q, available detectors are %s", name, strings.Join(Names(), ", "))
+		}
+		detectors = append(detectors, d)
+	}
+	return detectors, nil
+}
+
+// Name returns the name of the detector.
+func (d *Detector) Name() string {
+	return d.name
+}
+
+// Redact replaces the values found in the text with the result of mask, and returns the number of replaced values.
+// The candidates which are part of a longer word are left unchanged.
+func (d *Detector) Redact(text string, mask func(string) string) (string, int) {
+	matches := d.regex.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return text, 0
+	}
+
+	var sb strings.Builder
+	last, count := 0, 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if (start > 0 && isWordByte(text[start-1])) || (end < len(text) && isWordByte(text[end])) {
+			continue
+		}
+		match := text[start:end]
+		if d.validate != nil && !d.validate(match) {
+			continue
+		}
+		sb.WriteString(text[last:start])
+		sb.WriteString(mask(match))
+		last = end
+		count++
+	}
+	if count == 0 {
+		return text, 0
+	}
+	sb.WriteString(text[last:])
+	return sb.String(), count
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+// digits returns the digits of the value, without the separators.
+func digits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+// validLuhn returns whether the card number has 13 to 19 digits and a valid Luhn checksum.
+func validLuhn(value string) bool {
+	number := digits(value)
+	if len(number) < 13 || len(number) > 19 {
+		return false
+	}
+	sum := 0
+	for i := range len(number) {
+		digit := int(number[len(number)-1-i] - '0')
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// validIBAN returns whether the IBAN has 15 to 34 characters and a valid ISO 7064 MOD 97-10 checksum.
+func validIBAN(value string) bool {
+	iban := strings.ReplaceAll(value, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	// the country code and the check digits are moved to the end, and the letters are converted to numbers
+	var numeric strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			fmt.Fprintf(&numeric, "%d", r-'A'+10)
+		} else {
+			numeric.WriteRune(r)
+		}
+	}
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	if !ok {
+		return false
+	}
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// validJWT returns whether the header of the token is a JSON object.
+func validJWT(value string) bool {
+	header, _, _ := strings.Cut(value, ".")
+	decoded, err := base64.RawURLEncoding.DecodeString(header)
+	if err != nil {
+		return false
+	}
+	var fields map[string]any
+	return json.Unmarshal(decoded, &fields) == nil
+}
+
+// validPhone returns whether the phone number has 10 to 15 digits, as in E.164.
+func validPhone(value string) bool {
+	n := len(digits(value))
+	return n >= 10 && n <= 15
+}
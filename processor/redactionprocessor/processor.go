@@ -23,7 +23,9 @@ import (
 	"golang.org/x/crypto/sha3"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/db"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/detector"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/internal/url"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/tokenizer"
 )
 
 const attrValuesSeparator = ","
@@ -43,6 +45,10 @@ type redaction struct {
 	blockKeyRegexList map[string]*regexp.Regexp
 	// Hash function to hash blocked values
 	hashFunction HashFunction
+	// Tokenizer to replace blocked values with tokens
+	tokenizer *tokenizer.Tokenizer
+	// Detectors of sensitive values
+	detectors []*detector.Detector
 	// Redaction processor configuration
 	config *Config
 	// Logger
@@ -88,6 +94,19 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*red
 	}
 	dbObfuscator := db.NewObfuscator(config.DBSanitizer)
 
+	detectors, err := detector.New(config.Detectors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create detectors: %w", err)
+	}
+
+	var valueTokenizer *tokenizer.Tokenizer
+	if config.Tokenization != nil {
+		valueTokenizer, err = config.Tokenization.newTokenizer()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tokenizer: %w", err)
+		}
+	}
+
 	return &redaction{
 		allowList:          allowList,
 		ignoreList:         ignoreList,
@@ -96,6 +115,8 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*red
 		allowRegexList:     allowRegexList,
 		blockKeyRegexList:  blockKeysRegexList,
 		hashFunction:       config.HashFunction,
+		tokenizer:          valueTokenizer,
+		detectors:          detectors,
 		config:             config,
 		logger:             logger,
 		urlSanitizer:       urlSanitizer,
@@ -171,7 +192,13 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 
 func (s *redaction) processSpanEvents(ctx context.Context, events ptrace.SpanEventSlice) {
 	for i := 0; i < events.Len(); i++ {
-		s.processAttrs(ctx, events.At(i).Attributes())
+		event := events.At(i)
+		s.processAttrs(ctx, event.Attributes())
+		if len(s.detectors) > 0 && !s.shouldAllowValue(event.Name()) {
+			detected := make(map[string]int64)
+			event.SetName(s.detect(event.Name(), detected))
+			s.addDetectedCounts(detected, event.Attributes(), redactionDetectedCountFormat)
+		}
 	}
 }
 
@@ -196,6 +223,7 @@ func (s *redaction) processResourceLog(ctx context.Context, rl plog.ResourceLogs
 
 func (s *redaction) processLogBody(ctx context.Context, body pcommon.Value, attributes pcommon.Map) {
 	var redactedKeys, maskedKeys, allowedKeys, ignoredKeys []string
+	detected := make(map[string]int64)
 
 	switch body.Type() {
 	case pcommon.ValueTypeMap:
@@ -214,7 +242,7 @@ func (s *redaction) processLogBody(ctx context.Context, body pcommon.Value, attr
 				v.SetStr(s.maskValue(v.Str(), regexp.MustCompile(".*")))
 				return true
 			}
-			s.redactLogBodyRecursive(ctx, k, v, &redactedKeys, &maskedKeys, &allowedKeys, &ignoredKeys)
+			s.redactLogBodyRecursive(ctx, k, v, &redactedKeys, &maskedKeys, &allowedKeys, &ignoredKeys, detected)
 			return true
 		})
 		for _, k := range redactedBodyKeys {
@@ -223,7 +251,7 @@ func (s *redaction) processLogBody(ctx context.Context, body pcommon.Value, attr
 		}
 	case pcommon.ValueTypeSlice:
 		for i := 0; i < body.Slice().Len(); i++ {
-			s.redactLogBodyRecursive(ctx, fmt.Sprintf("[%d]", i), body.Slice().At(i), &redactedKeys, &maskedKeys, &allowedKeys, &ignoredKeys)
+			s.redactLogBodyRecursive(ctx, fmt.Sprintf("[%d]", i), body.Slice().At(i), &redactedKeys, &maskedKeys, &allowedKeys, &ignoredKeys, detected)
 		}
 	default:
 		strVal := body.AsString()
@@ -231,7 +259,7 @@ func (s *redaction) processLogBody(ctx context.Context, body pcommon.Value, attr
 			allowedKeys = append(allowedKeys, "body")
 			return
 		}
-		processedValue := s.processStringValueForLogBody(strVal)
+		processedValue := s.processStringValueForLogBody(strVal, detected)
 		if strVal != processedValue {
 			maskedKeys = append(maskedKeys, "body")
 			body.SetStr(processedValue)
@@ -242,9 +270,10 @@ func (s *redaction) processLogBody(ctx context.Context, body pcommon.Value, attr
 	s.addMetaAttrs(maskedKeys, attributes, redactionBodyMaskedKeys, redactionBodyMaskedCount)
 	s.addMetaAttrs(allowedKeys, attributes, redactionBodyAllowedKeys, redactionBodyAllowedCount)
 	s.addMetaAttrs(ignoredKeys, attributes, "", redactionBodyIgnoredCount)
+	s.addDetectedCounts(detected, attributes, redactionBodyDetectedCountFormat)
 }
 
-func (s *redaction) redactLogBodyRecursive(ctx context.Context, key string, value pcommon.Value, redactedKeys, maskedKeys, allowedKeys, ignoredKeys *[]string) {
+func (s *redaction) redactLogBodyRecursive(ctx context.Context, key string, value pcommon.Value, redactedKeys, maskedKeys, allowedKeys, ignoredKeys *[]string, detected map[string]int64) {
 	switch value.Type() {
 	case pcommon.ValueTypeMap:
 		var redactedCurrentValueKeys []string
@@ -263,7 +292,7 @@ func (s *redaction) redactLogBodyRecursive(ctx context.Context, key string, valu
 				v.SetStr(s.maskValue(v.Str(), regexp.MustCompile(".*")))
 				return true
 			}
-			s.redactLogBodyRecursive(ctx, keyWithPath, v, redactedKeys, maskedKeys, allowedKeys, ignoredKeys)
+			s.redactLogBodyRecursive(ctx, keyWithPath, v, redactedKeys, maskedKeys, allowedKeys, ignoredKeys, detected)
 			return true
 		})
 		for _, k := range redactedCurrentValueKeys {
@@ -274,7 +303,7 @@ func (s *redaction) redactLogBodyRecursive(ctx context.Context, key string, valu
 	case pcommon.ValueTypeSlice:
 		for i := 0; i < value.Slice().Len(); i++ {
 			keyWithPath := fmt.Sprintf("%s.[%d]", key, i)
-			s.redactLogBodyRecursive(ctx, keyWithPath, value.Slice().At(i), redactedKeys, maskedKeys, allowedKeys, ignoredKeys)
+			s.redactLogBodyRecursive(ctx, keyWithPath, value.Slice().At(i), redactedKeys, maskedKeys, allowedKeys, ignoredKeys, detected)
 		}
 	default:
 		strVal := value.AsString()
@@ -282,7 +311,7 @@ func (s *redaction) redactLogBodyRecursive(ctx context.Context, key string, valu
 			*allowedKeys = append(*allowedKeys, key)
 			return
 		}
-		processedValue := s.processStringValueForLogBody(strVal)
+		processedValue := s.processStringValueForLogBody(strVal, detected)
 		if strVal != processedValue {
 			*maskedKeys = append(*maskedKeys, key)
 			value.SetStr(processedValue)
@@ -337,6 +366,7 @@ func (s *redaction) processResourceMetric(ctx context.Context, rm pmetric.Resour
 func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	// TODO: Use the context for recording metrics
 	var redactedKeys, maskedKeys, allowedKeys, ignoredKeys []string
+	detected := make(map[string]int64)
 
 	// Identify attributes to redact and mask in the following sequence
 	// 1. Make a list of attribute keys to redact
@@ -370,7 +400,7 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 			value.SetStr(maskedValue)
 			continue
 		}
-		processedString := s.processStringValueForAttribute(strVal, k)
+		processedString := s.processStringValueForAttribute(strVal, k, detected)
 		if processedString != strVal {
 			maskedKeys = append(maskedKeys, k)
 			value.SetStr(processedString)
@@ -386,23 +416,43 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	s.addMetaAttrs(maskedKeys, attributes, redactionMaskedKeys, redactionMaskedCount)
 	s.addMetaAttrs(allowedKeys, attributes, redactionAllowedKeys, redactionAllowedCount)
 	s.addMetaAttrs(ignoredKeys, attributes, "", redactionIgnoredCount)
+	s.addDetectedCounts(detected, attributes, redactionDetectedCountFormat)
 }
 
-//nolint:gosec
 func (s *redaction) maskValue(val string, regex *regexp.Regexp) string {
-	hashFunc := func(match string) string {
-		switch s.hashFunction {
-		case SHA1:
-			return hashString(match, sha1.New())
-		case SHA3:
-			return hashString(match, sha3.New256())
-		case MD5:
-			return hashString(match, md5.New())
-		default:
-			return "****"
-		}
-	}
-	return regex.ReplaceAllStringFunc(val, hashFunc)
+	return regex.ReplaceAllStringFunc(val, s.mask)
+}
+
+// mask replaces a blocked value with its token, its hash or a fixed string
+//
+//nolint:gosec
+func (s *redaction) mask(match string) string {
+	if s.tokenizer != nil {
+		return s.tokenizer.Tokenize(match)
+	}
+	switch s.hashFunction {
+	case SHA1:
+		return hashString(match, sha1.New())
+	case SHA3:
+		return hashString(match, sha3.New256())
+	case MD5:
+		return hashString(match, md5.New())
+	default:
+		return "****"
+	}
+}
+
+// detect masks the values found by the detectors, and adds the number of
+// values found by each detector to detected
+func (s *redaction) detect(val string, detected map[string]int64) string {
+	for _, d := range s.detectors {
+		var count int
+		val, count = d.Redact(val, s.mask)
+		if count > 0 {
+			detected[d.Name()] += int64(count)
+		}
+	}
+	return val
 }
 
 func hashString(input string, hasher hash.Hash) string {
@@ -410,6 +460,21 @@ func hashString(input string, hasher hash.Hash) string {
 	return hex.EncodeToString(hasher.Sum(nil))
 }
 
+// addDetectedCounts adds the number of values found by each detector, with
+// the attribute keys given by the format and the detector names
+func (s *redaction) addDetectedCounts(detected map[string]int64, attributes pcommon.Map, countAttrFormat string) {
+	if s.config.Summary != info && s.config.Summary != debug {
+		return
+	}
+	for name, count := range detected {
+		countAttr := fmt.Sprintf(countAttrFormat, name)
+		if existingVal, found := attributes.Get(countAttr); found {
+			count += existingVal.Int()
+		}
+		attributes.PutInt(countAttr, count)
+	}
+}
+
 // addMetaAttrs adds diagnostic information about redacted or masked attribute keys
 func (s *redaction) addMetaAttrs(redactedAttrs []string, attributes pcommon.Map, valuesAttr, countAttr string) {
 	redactedCount := int64(len(redactedAttrs))
@@ -433,7 +498,7 @@ func (s *redaction) addMetaAttrs(redactedAttrs []string, attributes pcommon.Map,
 	}
 }
 
-func (s *redaction) processStringValueForAttribute(strVal, attributeKey string) string {
+func (s *redaction) processStringValueForAttribute(strVal, attributeKey string, detected map[string]int64) string {
 	for _, compiledRE := range s.blockRegexList {
 		match := compiledRE.MatchString(strVal)
 		if match {
@@ -441,6 +506,8 @@ func (s *redaction) processStringValueForAttribute(strVal, attributeKey string)
 		}
 	}
 
+	strVal = s.detect(strVal, detected)
+
 	if s.urlSanitizer != nil {
 		strVal = s.urlSanitizer.SanitizeAttributeURL(strVal, attributeKey)
 	}
@@ -456,7 +523,7 @@ func (s *redaction) processStringValueForAttribute(strVal, attributeKey string)
 	return strVal
 }
 
-func (s *redaction) processStringValueForLogBody(strVal string) string {
+func (s *redaction) processStringValueForLogBody(strVal string, detected map[string]int64) string {
 	// Mask any blocked values for the other attributes
 	for _, compiledRE := range s.blockRegexList {
 		match := compiledRE.MatchString(strVal)
@@ -465,6 +532,8 @@ func (s *redaction) processStringValueForLogBody(strVal string) string {
 		}
 	}
 
+	strVal = s.detect(strVal, detected)
+
 	if s.urlSanitizer != nil {
 		strVal = s.urlSanitizer.SanitizeURL(strVal)
 	}
@@ -578,6 +647,9 @@ const (
 	redactionBodyAllowedKeys   = "redaction.body.allowed.keys"
 	redactionBodyAllowedCount  = "redaction.body.allowed.count"
 	redactionBodyIgnoredCount  = "redaction.body.ignored.count"
+	// The detected count attributes are suffixed with the detector names
+	redactionDetectedCountFormat     = "redaction.detected.%s.count"
+	redactionBodyDetectedCountFormat = "redaction.body.detected.%s.count"
 )
 
 // makeAllowList sets up a lookup table of allowed span attribute keys
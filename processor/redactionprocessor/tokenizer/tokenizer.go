@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tokenizer replaces values with deterministic tokens, encrypted with AES-256-SIV (RFC 5297). The same value is
+// always replaced with the same token for a given key, so that tokens can be joined, and the value can be recovered
+// from the token with the key.
+package tokenizer // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor/tokenizer"
+
+import (
+	"crypto/aes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tink-crypto/tink-go/v2/daead/subtle"
+)
+
+// DefaultPrefix is the default prefix of the tokens.
+const DefaultPrefix = "tok:"
+
+// minTokenLength is the length of the encoding of the synthetic IV, which every token contains.
+var minTokenLength = base64.RawURLEncoding.EncodedLen(aes.BlockSize)
+
+// ErrInvalidToken is returned when a token is malformed, or was not created with the key of the tokenizer.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Tokenizer creates and reverts tokens.
+type Tokenizer struct {
+	siv    *subtle.AESSIV
+	prefix string
+	// tokenRegex matches the tokens in texts
+	tokenRegex *regexp.Regexp
+}
+
+// New returns a Tokenizer with the given key and token prefix. The key must be 64 bytes long. The default prefix is
+// used if prefix is empty.
+func New(key []byte, prefix string) (*Tokenizer, error) {
+	if len(key) != subtle.AESSIVKeySize {
+		return nil, fmt.Errorf("invalid key size %d, the key must be %d bytes long", len(key), subtle.AESSIVKeySize)
+	}
+	siv, err := subtle.NewAESSIV(key)
+	if err != nil {
+		return nil, err
+	}
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	return &Tokenizer{
+		siv:        siv,
+		prefix:     prefix,
+		tokenRegex: regexp.MustCompile(regexp.QuoteMeta(prefix) + fmt.Sprintf("[A-Za-z0-9_-]{%d,}", minTokenLength)),
+	}, nil
+}
+
+// ParseKey decodes a base64 encoded key.
+func ParseKey(key string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, fmt.Errorf("the key must be base64 encoded: %w", err)
+	}
+	return decoded, nil
+}
+
+// Tokenize returns the token of the value.
+func (t *Tokenizer) Tokenize(value string) string {
+	// the encryption only fails for plaintexts larger than the memory
+	ciphertext, _ := t.siv.EncryptDeterministically([]byte(value), nil)
+	return t.prefix + base64.RawURLEncoding.EncodeToString(ciphertext)
+}
+
+// Detokenize returns the value of the token.
+func (t *Tokenizer) Detokenize(token string) (string, error) {
+	encoded, ok := strings.CutPrefix(token, t.prefix)
+	if !ok {
+		return "", fmt.Errorf("%w: missing prefix %q", ErrInvalidToken, t.prefix)
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	value, err := t.siv.DecryptDeterministically(ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	return string(value), nil
+}
+
+// DetokenizeAll replaces the tokens found in the text with their values, and returns the replaced tokens. The
+// substrings which look like tokens but can't be reverted with the key of the tokenizer are left unchanged.
+func (t *Tokenizer) DetokenizeAll(text string) (string, []string) {
+	var tokens []string
+	replaced := t.tokenRegex.ReplaceAllStringFunc(text, func(token string) string {
+		value, err := t.Detokenize(token)
+		if err != nil {
+			return token
+		}
+		tokens = append(tokens, token)
+		return value
+	})
+	return replaced, tokens
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenizer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTokenizer(t *testing.T, key byte, prefix string) *Tokenizer {
+	t.Helper()
+	tokenizer, err := New(bytes.Repeat([]byte{key}, 64), prefix)
+	require.NoError(t, err)
+	return tokenizer
+}
+
+// TestTokenizeKnownAnswer checks the tokens against an AES-256-SIV ciphertext computed with an empty associated
+// data by an RFC 5297 implementation verified against the examples of the RFC, which only covers AES-128.
+func TestTokenizeKnownAnswer(t *testing.T) {
+	key := make([]byte, 64)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	tokenizer, err := New(key, "")
+	require.NoError(t, err)
+	ciphertext, err := hex.DecodeString("dbf40f7d65912dac543f9fe6152f08fc7d3390a2227c77d9376efec6ae2a9dd432")
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultPrefix+base64.RawURLEncoding.EncodeToString(ciphertext), tokenizer.Tokenize("alice@example.com"))
+}
+
+func TestNewKeySize(t *testing.T) {
+	for _, size := range []int{0, 32, 48} {
+		_, err := New(make([]byte, size), "")
+		assert.EqualError(t, err, fmt.Sprintf("invalid key size %d, the key must be 64 bytes long", size))
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tokenizer := newTestTokenizer(t, 1, "")
+
+	token := tokenizer.Tokenize("jane.doe@example.com")
+	assert.True(t, strings.HasPrefix(token, DefaultPrefix))
+	assert.NotContains(t, token, "jane")
+
+	// the tokens are deterministic
+	assert.Equal(t, token, tokenizer.Tokenize("jane.doe@example.com"))
+	assert.NotEqual(t, token, tokenizer.Tokenize("john.doe@example.com"))
+	assert.NotEqual(t, token, newTestTokenizer(t, 2, "").Tokenize("jane.doe@example.com"))
+
+	value, err := tokenizer.Detokenize(token)
+	require.NoError(t, err)
+	assert.Equal(t, "jane.doe@example.com", value)
+
+	value, err = tokenizer.Detokenize(tokenizer.Tokenize(""))
+	require.NoError(t, err)
+	assert.Empty(t, value)
+}
+
+func TestTokenizePrefix(t *testing.T) {
+	tokenizer := newTestTokenizer(t, 1, "customer-")
+	token := tokenizer.Tokenize("12345")
+	assert.True(t, strings.HasPrefix(token, "customer-"))
+
+	value, err := tokenizer.Detokenize(token)
+	require.NoError(t, err)
+	assert.Equal(t, "12345", value)
+}
+
+func TestDetokenizeInvalid(t *testing.T) {
+	tokenizer := newTestTokenizer(t, 1, "")
+	token := tokenizer.Tokenize("12345")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "missing prefix", token: strings.TrimPrefix(token, DefaultPrefix)},
+		{name: "invalid encoding", token: DefaultPrefix + "not base64!"},
+		{name: "too short", token: DefaultPrefix + "AAAA"},
+		{name: "tampered", token: token[:len(token)-1] + "A"},
+		{name: "other key", token: newTestTokenizer(t, 2, "").Tokenize("12345")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tokenizer.Detokenize(tt.token)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestDetokenizeAll(t *testing.T) {
+	tokenizer := newTestTokenizer(t, 1, "")
+	other := newTestTokenizer(t, 2, "")
+	email := tokenizer.Tokenize("jane.doe@example.com")
+	id := tokenizer.Tokenize("12345")
+	otherID := other.Tokenize("12345")
+
+	text := "user " + email + " (" + id + "), unknown " + otherID + ", " + DefaultPrefix + "short"
+	detokenized, tokens := tokenizer.DetokenizeAll(text)
+	assert.Equal(t, "user jane.doe@example.com (12345), unknown "+otherID+", "+DefaultPrefix+"short", detokenized)
+	assert.Equal(t, []string{email, id}, tokens)
+}
+
+func TestParseKey(t *testing.T) {
+	key, err := ParseKey(" AAECAw==\n")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 1, 2, 3}, key)
+
+	_, err = ParseKey("not base64!")
+	assert.ErrorContains(t, err, "the key must be base64 encoded")
+}
@@ -4,8 +4,11 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -47,6 +50,9 @@ const (
 	OTTLCondition PolicyType = "ottl_condition"
 	// BytesLimiting allows all traces until the specified byte limits are satisfied.
 	BytesLimiting PolicyType = "bytes_limiting"
+	// AdaptiveThroughput samples traces with a probability that keeps the rate of sampled spans
+	// within a budget for each value of an attribute.
+	AdaptiveThroughput PolicyType = "adaptive_throughput"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -70,6 +76,8 @@ type sharedPolicyCfg struct {
 	RateLimitingCfg RateLimitingCfg `mapstructure:"rate_limiting"`
 	// Configs for bytes limiting filter sampling policy evaluator.
 	BytesLimitingCfg BytesLimitingCfg `mapstructure:"bytes_limiting"`
+	// Configs for adaptive throughput sampling policy evaluator.
+	AdaptiveThroughputCfg AdaptiveThroughputCfg `mapstructure:"adaptive_throughput"`
 	// Configs for span count filter sampling policy evaluator.
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for defining trace_state policy
@@ -234,6 +242,23 @@ type BytesLimitingCfg struct {
 	BurstCapacity int64 `mapstructure:"burst_capacity"`
 }
 
+// AdaptiveThroughputCfg holds the configurable settings to create an adaptive throughput
+// sampling policy evaluator.
+type AdaptiveThroughputCfg struct {
+	// Key is the resource or span attribute whose values get their own budget, e.g. service.name.
+	Key string `mapstructure:"key"`
+	// SpansPerSecond is the number of spans per second to sample for each value of Key.
+	SpansPerSecond float64 `mapstructure:"spans_per_second"`
+	// AdjustmentInterval is how often the sampling probability of each value is recomputed
+	// from the observed traffic. Defaults to 10s.
+	AdjustmentInterval time.Duration `mapstructure:"adjustment_interval"`
+	// MaxKeys is the maximum number of values of Key that get their own budget. Traces with
+	// further values share a budget with traces that don't have the attribute. Defaults to 1000.
+	MaxKeys int `mapstructure:"max_keys"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 // SpanCountCfg holds the configurable settings to create a Span Count filter sampling
 // policy evaluator
 type SpanCountCfg struct {
@@ -264,6 +289,9 @@ type OTTLConditionCfg struct {
 	ErrorMode           ottl.ErrorMode `mapstructure:"error_mode"`
 	SpanConditions      []string       `mapstructure:"span"`
 	SpanEventConditions []string       `mapstructure:"spanevent"`
+	// Functions declares reusable OTTL functions composed of other OTTL functions, which can be
+	// called in the conditions of the policy.
+	Functions []ottl.FunctionDefinition `mapstructure:"functions"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -312,4 +340,26 @@ type Config struct {
 	// DropPendingTracesOnShutdown will drop all traces that are part of batches that have not yet reached the decision
 	// wait when the processor is shutdown.
 	DropPendingTracesOnShutdown bool `mapstructure:"drop_pending_traces_on_shutdown"`
+	// StorageID is the ID of a storage extension used to persist pending traces, so that they survive
+	// restarts of the collector. If set, pending traces are not evaluated on shutdown, but once
+	// decision_wait has passed after the next start.
+	StorageID *component.ID `mapstructure:"storage"`
+	// MaxTracesInMemory is the maximum number of pending traces whose spans are held in memory when a
+	// storage extension is used. The spans of the least recently updated traces are only held in storage
+	// until a decision is made for them. If 0, the spans of all pending traces are held in memory.
+	MaxTracesInMemory uint64 `mapstructure:"max_traces_in_memory"`
+}
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.StorageID == nil {
+		if cfg.MaxTracesInMemory > 0 {
+			return errors.New("max_traces_in_memory requires a storage extension")
+		}
+		return nil
+	}
+	if cfg.DropPendingTracesOnShutdown {
+		return errors.New("drop_pending_traces_on_shutdown cannot be used with a storage extension")
+	}
+	return nil
 }
@@ -124,6 +124,13 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name:                  "test-policy-13",
+						Type:                  AdaptiveThroughput,
+						AdaptiveThroughputCfg: AdaptiveThroughputCfg{Key: "service.name", SpansPerSecond: 100, AdjustmentInterval: 30 * time.Second, MaxKeys: 500},
+					},
+				},
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name: "and-policy-1",
@@ -193,3 +200,82 @@ func TestLoadConfig(t *testing.T) {
 			},
 		}, cfg)
 }
+
+func TestLoadConfigOTTLConditionFunctions(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "ottl_condition_functions_config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+
+	assert.Equal(t,
+		[]PolicyCfg{
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name: "ottl-functions-policy",
+					Type: OTTLCondition,
+					OTTLConditionCfg: OTTLConditionCfg{
+						ErrorMode:           ottl.IgnoreError,
+						SpanConditions:      []string{"IsCheckout(attributes[\"http.route\"])"},
+						SpanEventConditions: []string{"IsTestEvent(name)"},
+						Functions: []ottl.FunctionDefinition{
+							{
+								Name:      "IsCheckout",
+								Params:    []string{"route"},
+								Condition: "IsMatch(route, \"^/checkout\")",
+							},
+							{
+								Name:      "IsTestEvent",
+								Params:    []string{"event_name"},
+								Condition: "event_name == \"test_span_event_name\"",
+							},
+						},
+					},
+				},
+			},
+		}, cfg.PolicyCfgs)
+}
+
+func TestConfigValidate(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr string
+	}{
+		{
+			name: "default",
+			cfg:  Config{},
+		},
+		{
+			name: "storage",
+			cfg:  Config{StorageID: &storageID, MaxTracesInMemory: 100},
+		},
+		{
+			name:        "max traces in memory without storage",
+			cfg:         Config{MaxTracesInMemory: 100},
+			expectedErr: "max_traces_in_memory requires a storage extension",
+		},
+		{
+			name:        "storage and drop pending traces on shutdown",
+			cfg:         Config{StorageID: &storageID, DropPendingTracesOnShutdown: true},
+			expectedErr: "drop_pending_traces_on_shutdown cannot be used with a storage extension",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
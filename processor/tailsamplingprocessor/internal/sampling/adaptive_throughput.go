@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/pkg/samplingpolicy"
+)
+
+const (
+	defaultAdjustmentInterval = 10 * time.Second
+	defaultMaxKeys            = 1000
+
+	// rateSmoothing is the weight of the rate observed in the last interval
+	// in the exponentially weighted moving average of the rate of a key.
+	rateSmoothing = 0.5
+	// thresholdPrecision is the number of hex digits used to encode thresholds.
+	thresholdPrecision = 4
+)
+
+type adaptiveKeyState struct {
+	// spans is the number of spans observed in the current interval.
+	spans int64
+	// rate is the smoothed rate of spans per second, negative until the
+	// first interval in which the key was seen is over.
+	rate      float64
+	threshold sampling.Threshold
+}
+
+type adaptiveThroughput struct {
+	key                string
+	spansPerSecond     float64
+	adjustmentInterval time.Duration
+	maxKeys            int
+	logger             *zap.Logger
+
+	now           func() time.Time
+	intervalStart time.Time
+	keys          map[string]*adaptiveKeyState
+	// overflow is shared by traces without the key and by keys beyond maxKeys.
+	overflow *adaptiveKeyState
+}
+
+var _ samplingpolicy.Evaluator = (*adaptiveThroughput)(nil)
+
+// NewAdaptiveThroughput creates a policy evaluator that samples up to spansPerSecond
+// spans per second for every value of the given resource or span attribute. The
+// sampling probability of each value is recomputed every adjustmentInterval from
+// the observed traffic, and the resulting threshold is recorded in the tracestate
+// of sampled spans so that their adjusted count can be computed downstream.
+func NewAdaptiveThroughput(settings component.TelemetrySettings, key string, spansPerSecond float64, adjustmentInterval time.Duration, maxKeys int) (samplingpolicy.Evaluator, error) {
+	if key == "" {
+		return nil, errors.New("key must not be empty")
+	}
+	if spansPerSecond <= 0 {
+		return nil, errors.New("spans_per_second must be greater than zero")
+	}
+	if adjustmentInterval < 0 {
+		return nil, errors.New("adjustment_interval must not be negative")
+	}
+	if adjustmentInterval == 0 {
+		adjustmentInterval = defaultAdjustmentInterval
+	}
+	if maxKeys < 0 {
+		return nil, errors.New("max_keys must not be negative")
+	}
+	if maxKeys == 0 {
+		maxKeys = defaultMaxKeys
+	}
+
+	return &adaptiveThroughput{
+		key:                key,
+		spansPerSecond:     spansPerSecond,
+		adjustmentInterval: adjustmentInterval,
+		maxKeys:            maxKeys,
+		logger:             settings.Logger,
+		now:                time.Now,
+		keys:               make(map[string]*adaptiveKeyState),
+		overflow:           newAdaptiveKeyState(),
+	}, nil
+}
+
+func newAdaptiveKeyState() *adaptiveKeyState {
+	return &adaptiveKeyState{
+		rate:      -1,
+		threshold: sampling.AlwaysSampleThreshold,
+	}
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (a *adaptiveThroughput) Evaluate(_ context.Context, traceID pcommon.TraceID, trace *samplingpolicy.TraceData) (samplingpolicy.Decision, error) {
+	a.logger.Debug("Evaluating spans in adaptive throughput filter")
+
+	now := a.now()
+	if a.intervalStart.IsZero() {
+		a.intervalStart = now
+	} else if elapsed := now.Sub(a.intervalStart); elapsed >= a.adjustmentInterval {
+		a.adjust(elapsed)
+		a.intervalStart = now
+	}
+
+	state := a.keyState(trace.ReceivedBatches)
+	state.spans += trace.SpanCount
+
+	if !state.threshold.ShouldSample(traceRandomness(traceID, trace.ReceivedBatches)) {
+		return samplingpolicy.NotSampled, nil
+	}
+	if state.threshold != sampling.AlwaysSampleThreshold {
+		updateTraceThreshold(trace.ReceivedBatches, state.threshold)
+	}
+	return samplingpolicy.Sampled, nil
+}
+
+// keyState returns the state of the key of the given trace, creating it if needed.
+func (a *adaptiveThroughput) keyState(td ptrace.Traces) *adaptiveKeyState {
+	value, ok := findAttribute(td, a.key)
+	if !ok {
+		return a.overflow
+	}
+	if state, ok := a.keys[value]; ok {
+		return state
+	}
+	if len(a.keys) >= a.maxKeys {
+		return a.overflow
+	}
+	state := newAdaptiveKeyState()
+	a.keys[value] = state
+	return state
+}
+
+// adjust recomputes the threshold of every key from the spans observed during the
+// last interval. Keys without spans in the last interval whose rate is within the
+// budget are forgotten, as they would start over with the same threshold.
+func (a *adaptiveThroughput) adjust(elapsed time.Duration) {
+	a.adjustKey(a.overflow, elapsed)
+	for value, state := range a.keys {
+		idle := state.spans == 0
+		a.adjustKey(state, elapsed)
+		if idle && state.rate <= a.spansPerSecond {
+			delete(a.keys, value)
+			continue
+		}
+		a.logger.Debug("Adjusted sampling probability",
+			zap.String("key", value),
+			zap.Float64("spans_per_second", state.rate),
+			zap.Float64("probability", state.threshold.Probability()))
+	}
+}
+
+func (a *adaptiveThroughput) adjustKey(state *adaptiveKeyState, elapsed time.Duration) {
+	observed := float64(state.spans) / elapsed.Seconds()
+	state.spans = 0
+	if state.rate < 0 {
+		state.rate = observed
+	} else {
+		state.rate = rateSmoothing*observed + (1-rateSmoothing)*state.rate
+	}
+
+	probability := 1.0
+	if state.rate > a.spansPerSecond {
+		probability = max(a.spansPerSecond/state.rate, sampling.MinSamplingProbability)
+	}
+	threshold, err := sampling.ProbabilityToThresholdWithPrecision(probability, thresholdPrecision)
+	if err != nil {
+		// Not expected, the probability is always within range.
+		a.logger.Warn("Failed to compute sampling threshold", zap.Float64("probability", probability), zap.Error(err))
+		return
+	}
+	state.threshold = threshold
+}
+
+// findAttribute returns the value of the first resource or span attribute with the given key.
+func findAttribute(td ptrace.Traces, key string) (string, bool) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		if v, ok := rs.Resource().Attributes().Get(key); ok {
+			return v.AsString(), true
+		}
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if v, ok := spans.At(k).Attributes().Get(key); ok {
+					return v.AsString(), true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// traceRandomness returns the explicit randomness of the trace from the tracestate
+// of its spans, or the randomness derived from the trace ID if there is none, so
+// that decisions are consistent with other samplers that use the same randomness.
+func traceRandomness(traceID pcommon.TraceID, td ptrace.Traces) sampling.Randomness {
+	rnd := sampling.TraceIDToRandomness(traceID)
+	forEachSpan(td, func(span ptrace.Span) bool {
+		raw := span.TraceState().AsRaw()
+		if !strings.Contains(raw, "rv:") {
+			return true
+		}
+		w3c, err := sampling.NewW3CTraceState(raw)
+		if err != nil {
+			return true
+		}
+		if rv, ok := w3c.OTelValue().RValueRandomness(); ok {
+			rnd = rv
+			return false
+		}
+		return true
+	})
+	return rnd
+}
+
+// updateTraceThreshold records the threshold in the tracestate of all spans of the
+// trace. Spans whose tracestate already holds a higher threshold, i.e. they were
+// sampled with a lower probability before, and spans with an invalid tracestate
+// are left unchanged.
+func updateTraceThreshold(td ptrace.Traces, threshold sampling.Threshold) {
+	forEachSpan(td, func(span ptrace.Span) bool {
+		w3c, err := sampling.NewW3CTraceState(span.TraceState().AsRaw())
+		if err != nil {
+			return true
+		}
+		if err := w3c.OTelValue().UpdateTValueWithSampling(threshold); err != nil {
+			return true
+		}
+		var sb strings.Builder
+		if err := w3c.Serialize(&sb); err != nil {
+			return true
+		}
+		span.TraceState().FromRaw(sb.String())
+		return true
+	})
+}
+
+// forEachSpan calls fn for every span of the trace until fn returns false.
+func forEachSpan(td ptrace.Traces, fn func(span ptrace.Span) bool) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		ilss := td.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if !fn(spans.At(k)) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/pkg/samplingpolicy"
+)
+
+func TestNewAdaptiveThroughputErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            string
+		spansPerSecond float64
+		interval       time.Duration
+		maxKeys        int
+		expectedErr    string
+	}{
+		{name: "empty key", spansPerSecond: 10, expectedErr: "key must not be empty"},
+		{name: "zero spans per second", key: "service.name", expectedErr: "spans_per_second must be greater than zero"},
+		{name: "negative interval", key: "service.name", spansPerSecond: 10, interval: -time.Second, expectedErr: "adjustment_interval must not be negative"},
+		{name: "negative max keys", key: "service.name", spansPerSecond: 10, maxKeys: -1, expectedErr: "max_keys must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAdaptiveThroughput(componenttest.NewNopTelemetrySettings(), tt.key, tt.spansPerSecond, tt.interval, tt.maxKeys)
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestAdaptiveThroughputDefaults(t *testing.T) {
+	evaluator, err := NewAdaptiveThroughput(componenttest.NewNopTelemetrySettings(), "service.name", 10, 0, 0)
+	require.NoError(t, err)
+	a := evaluator.(*adaptiveThroughput)
+	assert.Equal(t, defaultAdjustmentInterval, a.adjustmentInterval)
+	assert.Equal(t, defaultMaxKeys, a.maxKeys)
+}
+
+func TestAdaptiveThroughput(t *testing.T) {
+	evaluator, err := NewAdaptiveThroughput(componenttest.NewNopTelemetrySettings(), "service.name", 100, time.Second, 10)
+	require.NoError(t, err)
+	a := evaluator.(*adaptiveThroughput)
+	now := time.Unix(0, 0)
+	a.now = func() time.Time { return now }
+	rnd := rand.New(rand.NewPCG(1, 2))
+
+	// Every second, the noisy service sends 1000 single span traces and the quiet one 50
+	sampled := map[string]int{}
+	for second := range 5 {
+		sampled = map[string]int{}
+		for i := range 1000 {
+			now = time.Unix(int64(second), int64(i)*int64(time.Millisecond))
+			services := []string{"noisy"}
+			if i%20 == 0 {
+				services = append(services, "quiet")
+			}
+			for _, service := range services {
+				traceID := randomTraceID(rnd)
+				trace := newAdaptiveTrace(traceID, service, "")
+				decision, err := a.Evaluate(t.Context(), traceID, trace)
+				require.NoError(t, err)
+				if decision == samplingpolicy.Sampled {
+					sampled[service]++
+				}
+			}
+		}
+	}
+
+	// The noisy service is throttled to its budget, the quiet one is sampled entirely
+	assert.InDelta(t, 100, sampled["noisy"], 30)
+	assert.Equal(t, 50, sampled["quiet"])
+	assert.InDelta(t, 0.1, a.keys["noisy"].threshold.Probability(), 0.001)
+	assert.Equal(t, sampling.AlwaysSampleThreshold, a.keys["quiet"].threshold)
+}
+
+func TestAdaptiveThroughputTraceState(t *testing.T) {
+	evaluator, err := NewAdaptiveThroughput(componenttest.NewNopTelemetrySettings(), "service.name", 10, time.Second, 10)
+	require.NoError(t, err)
+	a := evaluator.(*adaptiveThroughput)
+	now := time.Unix(0, 0)
+	a.now = func() time.Time { return now }
+
+	// 40 spans in the first second result in a sampling probability of 25%
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	trace := newAdaptiveTrace(traceID, "svc", "")
+	trace.SpanCount = 40
+	decision, err := a.Evaluate(t.Context(), traceID, trace)
+	require.NoError(t, err)
+	assert.Equal(t, samplingpolicy.Sampled, decision)
+	assert.Empty(t, trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceState().AsRaw())
+
+	now = now.Add(time.Second)
+	threshold, err := sampling.ProbabilityToThresholdWithPrecision(0.25, thresholdPrecision)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		traceState         string
+		expectedDecision   samplingpolicy.Decision
+		expectedTraceState string
+	}{
+		{
+			name:               "randomness above threshold",
+			traceState:         "ot=rv:ffffffffffffff",
+			expectedDecision:   samplingpolicy.Sampled,
+			expectedTraceState: "ot=rv:ffffffffffffff;th:" + threshold.TValue(),
+		},
+		{
+			name:               "randomness below threshold",
+			traceState:         "ot=rv:00000000000000",
+			expectedDecision:   samplingpolicy.NotSampled,
+			expectedTraceState: "ot=rv:00000000000000",
+		},
+		{
+			name:               "higher threshold is kept",
+			traceState:         "ot=rv:ffffffffffffff;th:f",
+			expectedDecision:   samplingpolicy.Sampled,
+			expectedTraceState: "ot=rv:ffffffffffffff;th:f",
+		},
+		{
+			name:               "other vendors are kept",
+			traceState:         "ot=rv:ffffffffffffff,vendor=value",
+			expectedDecision:   samplingpolicy.Sampled,
+			expectedTraceState: "ot=rv:ffffffffffffff;th:" + threshold.TValue() + ",vendor=value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace := newAdaptiveTrace(traceID, "svc", tt.traceState)
+			decision, err := a.Evaluate(t.Context(), traceID, trace)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDecision, decision)
+			span := trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.expectedTraceState, span.TraceState().AsRaw())
+		})
+	}
+}
+
+func TestAdaptiveThroughputMaxKeys(t *testing.T) {
+	evaluator, err := NewAdaptiveThroughput(componenttest.NewNopTelemetrySettings(), "service.name", 10, time.Second, 2)
+	require.NoError(t, err)
+	a := evaluator.(*adaptiveThroughput)
+	now := time.Unix(0, 0)
+	a.now = func() time.Time { return now }
+
+	for _, service := range []string{"a", "b", "c", ""} {
+		traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+		trace := newAdaptiveTrace(traceID, service, "")
+		trace.SpanCount = 15
+		_, err := a.Evaluate(t.Context(), traceID, trace)
+		require.NoError(t, err)
+	}
+	assert.Len(t, a.keys, 2)
+	assert.Contains(t, a.keys, "a")
+	assert.Contains(t, a.keys, "b")
+	assert.Equal(t, int64(30), a.overflow.spans)
+
+	// Keys within budget are forgotten once they are idle, which frees room for new keys
+	now = now.Add(time.Second)
+	trace := newAdaptiveTrace(pcommon.TraceID{}, "a", "")
+	trace.SpanCount = 5
+	_, err = a.Evaluate(t.Context(), pcommon.TraceID{}, trace)
+	require.NoError(t, err)
+	assert.InDelta(t, 10.0/15, a.keys["a"].threshold.Probability(), 0.001)
+	assert.InDelta(t, 10.0/30, a.overflow.threshold.Probability(), 0.001)
+
+	now = now.Add(time.Second)
+	trace = newAdaptiveTrace(pcommon.TraceID{}, "c", "")
+	_, err = a.Evaluate(t.Context(), pcommon.TraceID{}, trace)
+	require.NoError(t, err)
+	assert.Len(t, a.keys, 2)
+	assert.Contains(t, a.keys, "a")
+	assert.Contains(t, a.keys, "c")
+}
+
+func randomTraceID(rnd *rand.Rand) pcommon.TraceID {
+	var traceID pcommon.TraceID
+	for i := range traceID {
+		traceID[i] = byte(rnd.IntN(256))
+	}
+	return traceID
+}
+
+func newAdaptiveTrace(traceID pcommon.TraceID, service, traceState string) *samplingpolicy.TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	if service != "" {
+		rs.Resource().Attributes().PutStr("service.name", service)
+	}
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.TraceState().FromRaw(traceState)
+	return &samplingpolicy.TraceData{
+		ReceivedBatches: traces,
+		SpanCount:       1,
+	}
+}
@@ -28,26 +28,32 @@ type ottlConditionFilter struct {
 var _ samplingpolicy.Evaluator = (*ottlConditionFilter)(nil)
 
 // NewOTTLConditionFilter looks at the trace data and returns a corresponding SamplingDecision.
-func NewOTTLConditionFilter(settings component.TelemetrySettings, spanConditions, spanEventConditions []string, errMode ottl.ErrorMode) (samplingpolicy.Evaluator, error) {
+func NewOTTLConditionFilter(settings component.TelemetrySettings, spanConditions, spanEventConditions []string, functions []ottl.FunctionDefinition, errMode ottl.ErrorMode) (samplingpolicy.Evaluator, error) {
 	filter := &ottlConditionFilter{
 		errorMode: errMode,
 		logger:    settings.Logger,
 	}
 
-	var err error
-
 	if len(spanConditions) == 0 && len(spanEventConditions) == 0 {
 		return nil, errors.New("expected at least one OTTL condition to filter on")
 	}
 
 	if len(spanConditions) > 0 {
-		if filter.sampleSpanExpr, err = filterottl.NewBoolExprForSpan(spanConditions, filterottl.StandardSpanFuncs(), errMode, settings); err != nil {
+		spanFuncs, err := ottl.AddFunctionDefinitions(filterottl.StandardSpanFuncs(), functions)
+		if err != nil {
+			return nil, err
+		}
+		if filter.sampleSpanExpr, err = filterottl.NewBoolExprForSpan(spanConditions, spanFuncs, errMode, settings); err != nil {
 			return nil, err
 		}
 	}
 
 	if len(spanEventConditions) > 0 {
-		if filter.sampleSpanEventExpr, err = filterottl.NewBoolExprForSpanEvent(spanEventConditions, filterottl.StandardSpanEventFuncs(), errMode, settings); err != nil {
+		spanEventFuncs, err := ottl.AddFunctionDefinitions(filterottl.StandardSpanEventFuncs(), functions)
+		if err != nil {
+			return nil, err
+		}
+		if filter.sampleSpanEventExpr, err = filterottl.NewBoolExprForSpanEvent(spanEventConditions, spanEventFuncs, errMode, settings); err != nil {
 			return nil, err
 		}
 	}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -87,7 +88,7 @@ func TestEvaluate_OTTL(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.Desc, func(t *testing.T) {
-			filter, err := NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), c.SpanConditions, c.SpanEventConditions, ottl.IgnoreError)
+			filter, err := NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), c.SpanConditions, c.SpanEventConditions, nil, ottl.IgnoreError)
 			assert.Equal(t, err != nil, c.WantErr)
 
 			if err == nil {
@@ -99,6 +100,35 @@ func TestEvaluate_OTTL(t *testing.T) {
 	}
 }
 
+func TestEvaluate_OTTL_FunctionDefinitions(t *testing.T) {
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	functions := []ottl.FunctionDefinition{
+		{
+			Name:      "HasAttribute",
+			Params:    []string{"key", "value"},
+			Condition: `attributes[key] == value`,
+		},
+	}
+
+	filter, err := NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), []string{`HasAttribute("attr_k_1", "attr_v_1")`}, []string{`HasAttribute("event_attr_k_1", "event_attr_v_1")`}, functions, ottl.IgnoreError)
+	require.NoError(t, err)
+
+	decision, err := filter.Evaluate(t.Context(), traceID, newTraceWithSpansAttributes([]spanWithAttributes{{SpanAttributes: map[string]string{"attr_k_1": "attr_v_1"}}}))
+	require.NoError(t, err)
+	assert.Equal(t, samplingpolicy.Sampled, decision)
+
+	decision, err = filter.Evaluate(t.Context(), traceID, newTraceWithSpansAttributes([]spanWithAttributes{{SpanEventAttributes: map[string]string{"event_attr_k_1": "event_attr_v_1"}}}))
+	require.NoError(t, err)
+	assert.Equal(t, samplingpolicy.Sampled, decision)
+
+	decision, err = filter.Evaluate(t.Context(), traceID, newTraceWithSpansAttributes([]spanWithAttributes{{SpanAttributes: map[string]string{"attr_k_1": "attr_v_2"}}}))
+	require.NoError(t, err)
+	assert.Equal(t, samplingpolicy.NotSampled, decision)
+
+	_, err = NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), []string{`IsMatch(name, "a")`}, nil, []ottl.FunctionDefinition{{Name: "IsMatch", Params: []string{"value"}, Condition: `value != nil`}}, ottl.IgnoreError)
+	assert.EqualError(t, err, `function "IsMatch" is already defined`)
+}
+
 type spanWithAttributes struct {
 	SpanAttributes      map[string]string
 	SpanEventAttributes map[string]string
@@ -53,6 +53,8 @@ type traceData struct {
 	finalDecision samplingpolicy.Decision
 	policyName    string
 	deleteElement *list.Element
+	// stored is the state of the trace in storage, if a storage extension is used.
+	stored storedTrace
 }
 
 type tailSamplingSpanProcessor struct {
@@ -77,6 +79,8 @@ type tailSamplingSpanProcessor struct {
 
 	cfg  Config
 	host component.Host
+	// storage persists pending traces, it is nil if no storage extension is configured.
+	storage *traceStorage
 
 	newPolicyChan chan newPolicyCmd
 	workChan      chan []traceBatch
@@ -145,7 +149,7 @@ func (*tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(_ context.Context, host component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
 	tsp.host = host
 	policies, err := tsp.loadSamplingPolicies(host, tsp.cfg.PolicyCfgs)
 	if err != nil {
@@ -178,11 +182,44 @@ func (tsp *tailSamplingSpanProcessor) Start(_ context.Context, host component.Ho
 		tsp.rootReceivedBatcher = idBatcher
 	}
 
+	if tsp.cfg.StorageID != nil {
+		if err := tsp.startStorage(ctx, host); err != nil {
+			return err
+		}
+	}
+
 	tsp.doneChan = make(chan struct{})
 	go tsp.loop()
 	return nil
 }
 
+// startStorage creates the storage client and restores the traces that were
+// pending when the processor was shut down. Their decision is made once the
+// decision wait has passed.
+func (tsp *tailSamplingSpanProcessor) startStorage(ctx context.Context, host component.Host) error {
+	traceStorage, err := newTraceStorage(ctx, host, *tsp.cfg.StorageID, tsp.set.ID, tsp.cfg.MaxTracesInMemory)
+	if err != nil {
+		return err
+	}
+	restored, err := traceStorage.load(ctx)
+	if err != nil {
+		return errors.Join(err, traceStorage.close(ctx))
+	}
+
+	for id, trace := range restored {
+		tsp.idToTrace[id] = trace
+		tsp.decisionBatcher.AddToCurrentBatch(id)
+		if !tsp.blockOnOverflow {
+			trace.deleteElement = tsp.deleteTraceQueue.PushBack(id)
+		}
+	}
+	if len(restored) > 0 {
+		tsp.logger.Info("Restored pending traces from storage", zap.Int("traces", len(restored)))
+	}
+	tsp.storage = traceStorage
+	return nil
+}
+
 // ConsumeTraces is required by the processor.Traces interface.
 func (tsp *tailSamplingSpanProcessor) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
 	for _, rss := range td.ResourceSpans().All() {
@@ -374,6 +411,9 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 			return sampling.NewBytesLimitingWithBurstCapacity(settings, blfCfg.BytesPerSecond, blfCfg.BurstCapacity), nil
 		}
 		return sampling.NewBytesLimiting(settings, blfCfg.BytesPerSecond), nil
+	case AdaptiveThroughput:
+		atCfg := cfg.AdaptiveThroughputCfg
+		return sampling.NewAdaptiveThroughput(settings, atCfg.Key, atCfg.SpansPerSecond, atCfg.AdjustmentInterval, atCfg.MaxKeys)
 	case SpanCount:
 		spCfg := cfg.SpanCountCfg
 		return sampling.NewSpanCount(settings, spCfg.MinSpans, spCfg.MaxSpans), nil
@@ -385,7 +425,7 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 		return sampling.NewBooleanAttributeFilter(settings, bafCfg.Key, bafCfg.Value, bafCfg.InvertMatch), nil
 	case OTTLCondition:
 		ottlfCfg := cfg.OTTLConditionCfg
-		return sampling.NewOTTLConditionFilter(settings, ottlfCfg.SpanConditions, ottlfCfg.SpanEventConditions, ottlfCfg.ErrorMode)
+		return sampling.NewOTTLConditionFilter(settings, ottlfCfg.SpanConditions, ottlfCfg.SpanEventConditions, ottlfCfg.Functions, ottlfCfg.ErrorMode)
 	default:
 		t := string(cfg.Type)
 		extension, ok := policyExtensions[t]
@@ -467,6 +507,12 @@ func (tsp *tailSamplingSpanProcessor) iter(tickChan <-chan time.Time, workChan <
 				tsp.rootReceivedBatcher.Stop()
 			}
 
+			// Keep the traces we have already ingested for the next start if they are persisted.
+			if tsp.storage != nil {
+				tsp.flushStorage()
+				return false
+			}
+
 			// Do the best decision we can for any traces we have already ingested unless a user wants to drop them.
 			if !tsp.cfg.DropPendingTracesOnShutdown {
 				for tsp.samplingPolicyOnTick() {
@@ -598,6 +644,13 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() bool {
 
 		trace.decisionTime = time.Now()
 
+		if tsp.storage != nil {
+			if err := tsp.storage.restore(ctx, id, trace); err != nil {
+				tsp.logger.Warn("Failed to restore spans from storage, making a decision with the spans in memory", zap.Error(err))
+			}
+			tsp.storage.release(id, trace)
+		}
+
 		decision, policyName := tsp.makeDecision(id, &trace.TraceData, metrics)
 		globalTracesSampledByDecision[decision]++
 
@@ -614,6 +667,8 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() bool {
 		}
 	}
 
+	tsp.flushStorage()
+
 	tsp.telemetry.ProcessorTailSamplingSamplingDecisionTimerLatency.Record(tsp.ctx, time.Since(startTime).Milliseconds())
 	tsp.telemetry.ProcessorTailSamplingSamplingTracesOnMemory.Record(tsp.ctx, int64(len(tsp.idToTrace)))
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceDroppedTooEarly.Add(tsp.ctx, metrics.idNotFoundOnMapCount)
@@ -797,6 +852,9 @@ func (tsp *tailSamplingSpanProcessor) processTrace(id pcommon.TraceID, rss ptrac
 		// If the final decision hasn't been made, add the new spans to the
 		// existing trace.
 		appendToTraces(actualData.ReceivedBatches, rss)
+		if tsp.storage != nil {
+			tsp.storage.touch(id, actualData)
+		}
 		return
 	}
 
@@ -832,12 +890,15 @@ func extensions(host component.Host) map[string]samplingpolicy.Extension {
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	// All receivers will be shutdown before processors so no sends will be done anymore.
 	close(tsp.workChan)
 	if tsp.doneChan != nil {
 		<-tsp.doneChan
 	}
+	if tsp.storage != nil {
+		return tsp.storage.close(ctx)
+	}
 	return nil
 }
 
@@ -853,11 +914,24 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 	if trace.deleteElement != nil {
 		tsp.deleteTraceQueue.Remove(trace.deleteElement)
 	}
+	if tsp.storage != nil {
+		tsp.storage.release(traceID, trace)
+	}
 
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceRemovalAge.Record(tsp.ctx, int64(deletionTime.Sub(trace.arrivalTime)/time.Second))
 	return true
 }
 
+// flushStorage writes the changes to pending traces to storage.
+func (tsp *tailSamplingSpanProcessor) flushStorage() {
+	if tsp.storage == nil {
+		return
+	}
+	if err := tsp.storage.flush(context.Background(), tsp.idToTrace); err != nil {
+		tsp.logger.Warn("Failed to persist pending traces, retrying on the next tick", zap.Error(err))
+	}
+}
+
 // forwardSpans sends the trace data to the next consumer. it is different from
 // releaseSampledTrace in that it does not modify any tsp state.
 func (tsp *tailSamplingSpanProcessor) forwardSpans(ctx context.Context, td ptrace.Traces) {
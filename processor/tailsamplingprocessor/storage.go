@@ -0,0 +1,374 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"container/list"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/pkg/samplingpolicy"
+)
+
+const (
+	// indexKey is the key of the list of pending traces in storage.
+	indexKey = "pending_traces"
+	// maxIndexSegments is the number of segments of changes written after the
+	// list of pending traces before it is written again as a whole.
+	maxIndexSegments = 64
+	// indexVersion is written as the first byte of the index, so that the
+	// encoding can be changed without misreading the index of older versions.
+	indexVersion byte = 1
+	// indexEntrySize is the size of an encoded trace in the index: the trace
+	// ID, the arrival time, the span count and the number of chunks. Traces
+	// without chunks are removed from the index.
+	indexEntrySize = 16 + 8 + 8 + 4
+)
+
+// storedTrace is the state of a pending trace that is kept in storage.
+type storedTrace struct {
+	// chunks is the number of chunks of spans of the trace in storage.
+	chunks uint32
+	// flushedBatches is the number of resource spans of ReceivedBatches that
+	// are already in storage.
+	flushedBatches int
+	// evicted is true if the spans in storage are not held in ReceivedBatches.
+	evicted bool
+	// residentElement is the element of the trace in the list of traces whose
+	// spans are held in memory.
+	residentElement *list.Element
+	// modified is true if ReceivedBatches holds spans that are not in storage.
+	modified bool
+}
+
+// traceStorage persists the spans of pending traces to a storage extension so
+// that they survive restarts, and keeps the spans of at most maxTracesInMemory
+// traces in memory. Spans are written in chunks, one per trace and tick, along
+// with an index of all pending traces. The index is written as a whole, then
+// only the traces that changed during a tick are appended to it as a segment,
+// until maxIndexSegments segments were written and the index is written as a
+// whole again. All writes of a tick are done in a single batch, so the storage
+// always holds the pending traces as of the last tick. It is not safe for
+// concurrent use.
+type traceStorage struct {
+	client            storage.Client
+	maxTracesInMemory uint64
+
+	marshaler   ptrace.ProtoMarshaler
+	unmarshaler ptrace.ProtoUnmarshaler
+
+	// resident holds the IDs of the traces whose spans are held in memory,
+	// least recently updated first.
+	resident *list.List
+	// modified holds the IDs of the traces with spans that are not in storage.
+	modified map[pcommon.TraceID]struct{}
+	// released holds the number of chunks of the traces that must be removed
+	// from storage.
+	released map[pcommon.TraceID]uint32
+	// indexChanged is true if the index must be written on the next flush.
+	indexChanged bool
+	// segments is the number of segments written since the index was written
+	// as a whole.
+	segments int
+}
+
+func newTraceStorage(ctx context.Context, host component.Host, storageID, componentID component.ID, maxTracesInMemory uint64) (*traceStorage, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, componentID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage client: %w", err)
+	}
+	return &traceStorage{
+		client:            client,
+		maxTracesInMemory: maxTracesInMemory,
+		resident:          list.New(),
+		modified:          make(map[pcommon.TraceID]struct{}),
+		released:          make(map[pcommon.TraceID]uint32),
+	}, nil
+}
+
+// load returns the pending traces in storage. The spans of the traces are
+// not loaded until restore is called.
+func (s *traceStorage) load(ctx context.Context) (map[pcommon.TraceID]*traceData, error) {
+	ops := make([]*storage.Operation, maxIndexSegments+1)
+	ops[0] = storage.GetOperation(indexKey)
+	for i := range maxIndexSegments {
+		ops[i+1] = storage.GetOperation(segmentKey(i))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return nil, fmt.Errorf("failed to read pending traces: %w", err)
+	}
+
+	traces := make(map[pcommon.TraceID]*traceData)
+	if err := decodeIndex(ops[0].Value, traces); err != nil {
+		return nil, err
+	}
+	for _, op := range ops[1:] {
+		if op.Value == nil {
+			break
+		}
+		if err := decodeIndex(op.Value, traces); err != nil {
+			return nil, err
+		}
+		s.segments++
+	}
+	return traces, nil
+}
+
+// decodeIndex adds the traces of an encoded index or segment to traces, and
+// removes the traces without chunks.
+func decodeIndex(buf []byte, traces map[pcommon.TraceID]*traceData) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	if buf[0] != indexVersion {
+		return fmt.Errorf("unsupported version %d of pending traces", buf[0])
+	}
+	buf = buf[1:]
+	if len(buf)%indexEntrySize != 0 {
+		return errors.New("pending traces are corrupted")
+	}
+	for ; len(buf) > 0; buf = buf[indexEntrySize:] {
+		id := pcommon.TraceID(buf[:16])
+		chunks := binary.BigEndian.Uint32(buf[32:36])
+		if chunks == 0 {
+			delete(traces, id)
+			continue
+		}
+		traces[id] = &traceData{
+			TraceData: samplingpolicy.TraceData{
+				SpanCount:       int64(binary.BigEndian.Uint64(buf[24:32])),
+				ReceivedBatches: ptrace.NewTraces(),
+			},
+			arrivalTime: time.Unix(0, int64(binary.BigEndian.Uint64(buf[16:24]))),
+			stored: storedTrace{
+				chunks:  chunks,
+				evicted: true,
+			},
+		}
+	}
+	return nil
+}
+
+// touch records that spans were added to a pending trace.
+func (s *traceStorage) touch(id pcommon.TraceID, trace *traceData) {
+	if !trace.stored.modified {
+		trace.stored.modified = true
+		s.modified[id] = struct{}{}
+	}
+	if trace.stored.evicted {
+		return
+	}
+	if trace.stored.residentElement == nil {
+		trace.stored.residentElement = s.resident.PushBack(id)
+		return
+	}
+	s.resident.MoveToBack(trace.stored.residentElement)
+}
+
+// restore loads the spans in storage of an evicted trace into ReceivedBatches,
+// ahead of the spans received since the last flush.
+func (s *traceStorage) restore(ctx context.Context, id pcommon.TraceID, trace *traceData) error {
+	if !trace.stored.evicted || trace.stored.chunks == 0 {
+		return nil
+	}
+	ops := make([]*storage.Operation, trace.stored.chunks)
+	for i := range ops {
+		ops[i] = storage.GetOperation(chunkKey(id, uint32(i)))
+	}
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to read spans of trace %s: %w", id, err)
+	}
+
+	restored := ptrace.NewTraces()
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		chunk, err := s.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal spans of trace %s: %w", id, err)
+		}
+		chunk.ResourceSpans().MoveAndAppendTo(restored.ResourceSpans())
+	}
+	trace.stored.flushedBatches = restored.ResourceSpans().Len()
+	trace.ReceivedBatches.ResourceSpans().MoveAndAppendTo(restored.ResourceSpans())
+	trace.ReceivedBatches = restored
+	trace.stored.evicted = false
+	return nil
+}
+
+// release removes a trace from storage on the next flush, once a decision was
+// made for it or it was dropped.
+func (s *traceStorage) release(id pcommon.TraceID, trace *traceData) {
+	if trace.stored.chunks > 0 {
+		s.released[id] = trace.stored.chunks
+		s.indexChanged = true
+	}
+	if trace.stored.residentElement != nil {
+		s.resident.Remove(trace.stored.residentElement)
+	}
+	if trace.stored.modified {
+		delete(s.modified, id)
+	}
+	trace.stored = storedTrace{}
+}
+
+// flush writes the spans received since the last flush and the index of
+// pending traces to storage, removes released traces from storage, and then
+// evicts the spans of the least recently updated traces from memory.
+func (s *traceStorage) flush(ctx context.Context, idToTrace map[pcommon.TraceID]*traceData) error {
+	if len(s.modified) == 0 && len(s.released) == 0 && !s.indexChanged {
+		return nil
+	}
+
+	ops := make([]*storage.Operation, 0, len(s.released)+len(s.modified)+1)
+	// Released traces are removed first, so that the chunks of a trace that
+	// was received again after being released are not removed.
+	for id, chunks := range s.released {
+		for i := range chunks {
+			ops = append(ops, storage.DeleteOperation(chunkKey(id, i)))
+		}
+	}
+	for id := range s.modified {
+		trace := idToTrace[id]
+		chunk := ptrace.NewTraces()
+		batches := trace.ReceivedBatches.ResourceSpans()
+		for i := trace.stored.flushedBatches; i < batches.Len(); i++ {
+			batches.At(i).CopyTo(chunk.ResourceSpans().AppendEmpty())
+		}
+		buf, err := s.marshaler.MarshalTraces(chunk)
+		if err != nil {
+			return fmt.Errorf("failed to marshal spans of trace %s: %w", id, err)
+		}
+		ops = append(ops, storage.SetOperation(chunkKey(id, trace.stored.chunks), buf))
+	}
+	indexOps, segments := s.indexOperations(idToTrace)
+	ops = append(ops, indexOps...)
+
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to write pending traces: %w", err)
+	}
+
+	s.segments = segments
+	clear(s.released)
+	s.indexChanged = false
+	for id := range s.modified {
+		trace := idToTrace[id]
+		trace.stored.chunks++
+		trace.stored.modified = false
+		if trace.stored.evicted {
+			trace.ReceivedBatches = ptrace.NewTraces()
+		} else {
+			trace.stored.flushedBatches = trace.ReceivedBatches.ResourceSpans().Len()
+		}
+	}
+	clear(s.modified)
+
+	if s.maxTracesInMemory == 0 {
+		return nil
+	}
+	for uint64(s.resident.Len()) > s.maxTracesInMemory {
+		id := s.resident.Remove(s.resident.Front()).(pcommon.TraceID)
+		trace := idToTrace[id]
+		trace.stored.residentElement = nil
+		trace.stored.evicted = true
+		trace.stored.flushedBatches = 0
+		trace.ReceivedBatches = ptrace.NewTraces()
+	}
+	return nil
+}
+
+// indexOperations returns the operations writing the changes of the index
+// along with the number of segments once they are done. The changes are
+// appended as a new segment, unless the maximum number of segments is
+// reached, in which case the index is written as a whole and the segments are
+// removed.
+func (s *traceStorage) indexOperations(idToTrace map[pcommon.TraceID]*traceData) ([]*storage.Operation, int) {
+	if s.segments < maxIndexSegments {
+		return []*storage.Operation{storage.SetOperation(segmentKey(s.segments), s.encodeSegment(idToTrace))}, s.segments + 1
+	}
+	ops := make([]*storage.Operation, 0, s.segments+1)
+	ops = append(ops, storage.SetOperation(indexKey, s.encodeIndex(idToTrace)))
+	for i := range s.segments {
+		ops = append(ops, storage.DeleteOperation(segmentKey(i)))
+	}
+	return ops, 0
+}
+
+// encodeIndex encodes the pending traces with the number of chunks they will
+// have once the current flush is done.
+func (s *traceStorage) encodeIndex(idToTrace map[pcommon.TraceID]*traceData) []byte {
+	buf := make([]byte, 1, 1+len(idToTrace)*indexEntrySize)
+	buf[0] = indexVersion
+	for id, trace := range idToTrace {
+		if trace.finalDecision != samplingpolicy.Unspecified {
+			continue
+		}
+		chunks := trace.stored.chunks
+		if trace.stored.modified {
+			chunks++
+		}
+		if chunks == 0 {
+			continue
+		}
+		buf = appendIndexEntry(buf, id, trace.arrivalTime, trace.SpanCount, chunks)
+	}
+	return buf
+}
+
+// encodeSegment encodes the traces released and modified since the last
+// flush, with the number of chunks they will have once the current flush is
+// done. The released traces come first, with no chunks, since a trace can be
+// received again after being released.
+func (s *traceStorage) encodeSegment(idToTrace map[pcommon.TraceID]*traceData) []byte {
+	buf := make([]byte, 1, 1+(len(s.released)+len(s.modified))*indexEntrySize)
+	buf[0] = indexVersion
+	for id := range s.released {
+		buf = appendIndexEntry(buf, id, time.Unix(0, 0), 0, 0)
+	}
+	for id := range s.modified {
+		trace := idToTrace[id]
+		buf = appendIndexEntry(buf, id, trace.arrivalTime, trace.SpanCount, trace.stored.chunks+1)
+	}
+	return buf
+}
+
+func appendIndexEntry(buf []byte, id pcommon.TraceID, arrivalTime time.Time, spanCount int64, chunks uint32) []byte {
+	buf = append(buf, id[:]...)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(arrivalTime.UnixNano()))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(spanCount))
+	return binary.BigEndian.AppendUint32(buf, chunks)
+}
+
+func (s *traceStorage) close(ctx context.Context) error {
+	return s.client.Close(ctx)
+}
+
+// segmentKey returns the key of a segment of the index.
+func segmentKey(segment int) string {
+	return indexKey + "_" + strconv.Itoa(segment)
+}
+
+// chunkKey returns the key of a chunk of spans of a trace.
+func chunkKey(id pcommon.TraceID, chunk uint32) string {
+	return "trace_" + hex.EncodeToString(id[:]) + "_" + strconv.FormatUint(uint64(chunk), 10)
+}
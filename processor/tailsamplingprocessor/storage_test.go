@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/pkg/samplingpolicy"
+)
+
+func newStorageTestProcessor(t *testing.T, controller *testTSPController, storageID component.ID, maxTracesInMemory uint64) (*tailSamplingSpanProcessor, *consumertest.TracesSink) {
+	cfg := Config{
+		DecisionWait:      defaultTestDecisionWait,
+		NumTraces:         defaultNumTraces,
+		PolicyCfgs:        testPolicy,
+		StorageID:         &storageID,
+		MaxTracesInMemory: maxTracesInMemory,
+		Options: []Option{
+			withTestController(controller),
+		},
+	}
+	sink := new(consumertest.TracesSink)
+	sp, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+	require.NoError(t, err)
+	return sp.(*tailSamplingSpanProcessor), sink
+}
+
+func TestStoragePersistsPendingTracesAcrossRestarts(t *testing.T) {
+	ext := storagetest.NewFileBackedStorageExtension("test", t.TempDir())
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+	traceIDs, batches := generateIDsAndBatches(10)
+
+	controller := newTestTSPController()
+	tsp, sink := newStorageTestProcessor(t, controller, ext.ID, 0)
+	require.NoError(t, tsp.Start(t.Context(), host))
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(t.Context(), batch))
+	}
+	require.NoError(t, tsp.Shutdown(t.Context()))
+	// Pending traces are persisted instead of being evaluated on shutdown
+	assert.Zero(t, sink.SpanCount())
+
+	controller = newTestTSPController()
+	tsp, sink = newStorageTestProcessor(t, controller, ext.ID, 0)
+	require.NoError(t, tsp.Start(t.Context(), host))
+	require.Len(t, tsp.idToTrace, len(traceIDs))
+	for i, id := range traceIDs {
+		trace := tsp.idToTrace[id]
+		require.NotNil(t, trace)
+		assert.Equal(t, int64(i+1), trace.SpanCount)
+		assert.Zero(t, trace.ReceivedBatches.SpanCount(), "spans are only loaded when a decision is made")
+	}
+
+	controller.waitForTick()
+	controller.waitForTick()
+
+	assert.Equal(t, len(batches), sink.SpanCount())
+	for i, id := range traceIDs {
+		assert.Len(t, collectSpanIDs(findTrace(t, sink.AllTraces(), id)), i+1)
+	}
+
+	require.NoError(t, tsp.Shutdown(t.Context()))
+
+	// Decided traces are removed from storage
+	client, err := ext.GetClient(t.Context(), component.KindProcessor, tsp.set.ID, "")
+	require.NoError(t, err)
+	pending, err := (&traceStorage{client: client}).load(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	for _, id := range traceIDs {
+		chunk, err := client.Get(t.Context(), chunkKey(id, 0))
+		require.NoError(t, err)
+		assert.Nil(t, chunk, "trace %s is still in storage", id)
+	}
+}
+
+func TestStorageEvictsLeastRecentlyUpdatedTraces(t *testing.T) {
+	ext := storagetest.NewInMemoryStorageExtension("test")
+	host := storagetest.NewStorageHost().WithExtension(ext.ID, ext)
+	traceIDs, batches := generateIDsAndBatches(4)
+
+	controller := newTestTSPController()
+	tsp, sink := newStorageTestProcessor(t, controller, ext.ID, 2)
+	require.NoError(t, tsp.Start(t.Context(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(t.Context()))
+	}()
+
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(t.Context(), batch))
+	}
+	controller.waitForTick()
+
+	// Only the spans of the last updated traces are kept in memory
+	for i, id := range traceIDs {
+		trace := tsp.idToTrace[id]
+		if i < 2 {
+			assert.True(t, trace.stored.evicted)
+			assert.Zero(t, trace.ReceivedBatches.SpanCount())
+		} else {
+			assert.False(t, trace.stored.evicted)
+			assert.Equal(t, i+1, trace.ReceivedBatches.SpanCount())
+		}
+		assert.Equal(t, uint32(1), trace.stored.chunks)
+	}
+
+	// Spans of evicted traces are added to the spans in storage
+	late := simpleTracesWithID(traceIDs[0])
+	late.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetSpanID(pcommon.SpanID{0xff})
+	require.NoError(t, tsp.ConsumeTraces(t.Context(), late))
+	controller.waitForTick()
+
+	assert.Equal(t, len(batches)+1, sink.SpanCount())
+	assert.Len(t, collectSpanIDs(findTrace(t, sink.AllTraces(), traceIDs[0])), 2)
+	assert.Len(t, collectSpanIDs(findTrace(t, sink.AllTraces(), traceIDs[3])), 4)
+}
+
+func TestStorageErrors(t *testing.T) {
+	ext := storagetest.NewInMemoryStorageExtension("test")
+	nonStorage := storagetest.NewNonStorageExtension("test")
+	host := storagetest.NewStorageHost().
+		WithExtension(ext.ID, ext).
+		WithExtension(nonStorage.ID, nonStorage)
+
+	tests := []struct {
+		name        string
+		storageID   component.ID
+		expectedErr string
+	}{
+		{
+			name:        "missing extension",
+			storageID:   storagetest.NewStorageID("missing"),
+			expectedErr: `storage extension "test_storage/missing" not found`,
+		},
+		{
+			name:        "non storage extension",
+			storageID:   nonStorage.ID,
+			expectedErr: `extension "non_storage/test" is not a storage extension`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tsp, _ := newStorageTestProcessor(t, newTestTSPController(), tt.storageID, 0)
+			assert.EqualError(t, tsp.Start(t.Context(), host), tt.expectedErr)
+		})
+	}
+
+	t.Run("corrupted index", func(t *testing.T) {
+		client := storagetest.NewInMemoryClient(component.KindProcessor, processortest.NewNopSettings(metadata.Type).ID, "")
+		require.NoError(t, client.Set(t.Context(), indexKey, []byte{indexVersion, 1, 2, 3}))
+		id := storagetest.NewStorageID("corrupted")
+		host := storagetest.NewStorageHost().WithExtension(id, &singleClientStorage{client: client})
+
+		tsp, _ := newStorageTestProcessor(t, newTestTSPController(), id, 0)
+		assert.EqualError(t, tsp.Start(t.Context(), host), "pending traces are corrupted")
+	})
+}
+
+func TestStorageFlushFailureKeepsSpansInMemory(t *testing.T) {
+	client := &failingClient{Client: storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "")}
+	ext := &singleClientStorage{client: client}
+	id := storagetest.NewStorageID("failing")
+	host := storagetest.NewStorageHost().WithExtension(id, ext)
+	traceIDs, batches := generateIDsAndBatches(3)
+
+	controller := newTestTSPController()
+	tsp, sink := newStorageTestProcessor(t, controller, id, 1)
+	require.NoError(t, tsp.Start(t.Context(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(t.Context()))
+	}()
+
+	client.failBatch = true
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(t.Context(), batch))
+	}
+	controller.waitForTick()
+	for _, id := range traceIDs {
+		assert.False(t, tsp.idToTrace[id].stored.evicted)
+		assert.Zero(t, tsp.idToTrace[id].stored.chunks)
+	}
+
+	client.failBatch = false
+	controller.waitForTick()
+	assert.Equal(t, len(batches), sink.SpanCount())
+}
+
+func TestStorageWritesIndexIncrementally(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "")
+	host := storagetest.NewStorageHost().WithExtension(storagetest.NewStorageID("test"), &singleClientStorage{client: client})
+	s, err := newTraceStorage(t.Context(), host, storagetest.NewStorageID("test"), component.MustNewID("tail_sampling"), 0)
+	require.NoError(t, err)
+	traceIDs, batches := generateIDsAndBatches(10)
+	idToTrace := make(map[pcommon.TraceID]*traceData)
+	for i, id := range traceIDs {
+		idToTrace[id] = &traceData{
+			TraceData: samplingpolicy.TraceData{
+				SpanCount:       int64(i + 1),
+				ReceivedBatches: batches[i],
+			},
+			arrivalTime: time.Unix(int64(i), 0),
+		}
+		s.touch(id, idToTrace[id])
+	}
+	segment := func(i int) []byte {
+		buf, err := client.Get(t.Context(), segmentKey(i))
+		require.NoError(t, err)
+		return buf
+	}
+
+	// the first flush writes all the traces in a segment
+	require.NoError(t, s.flush(t.Context(), idToTrace))
+	assert.Len(t, segment(0), 1+10*indexEntrySize)
+
+	// the next flushes only write the traces that changed
+	appendToTraces(idToTrace[traceIDs[0]].ReceivedBatches, simpleTracesWithID(traceIDs[0]).ResourceSpans().At(0))
+	idToTrace[traceIDs[0]].SpanCount++
+	s.touch(traceIDs[0], idToTrace[traceIDs[0]])
+	require.NoError(t, s.flush(t.Context(), idToTrace))
+	assert.Len(t, segment(1), 1+indexEntrySize)
+
+	s.release(traceIDs[1], idToTrace[traceIDs[1]])
+	delete(idToTrace, traceIDs[1])
+	require.NoError(t, s.flush(t.Context(), idToTrace))
+	assert.Len(t, segment(2), 1+indexEntrySize)
+
+	require.NoError(t, s.flush(t.Context(), idToTrace))
+	assert.Nil(t, segment(3), "nothing is written without changes")
+
+	assertLoaded := func() {
+		t.Helper()
+		loaded, err := (&traceStorage{client: client}).load(t.Context())
+		require.NoError(t, err)
+		require.Len(t, loaded, len(idToTrace))
+		for id, trace := range idToTrace {
+			require.Contains(t, loaded, id)
+			assert.Equal(t, trace.SpanCount, loaded[id].SpanCount)
+			assert.Equal(t, trace.arrivalTime, loaded[id].arrivalTime)
+			assert.Equal(t, trace.stored.chunks, loaded[id].stored.chunks)
+		}
+	}
+	assertLoaded()
+
+	// the index is written as a whole once the maximum number of segments is reached
+	for s.segments < maxIndexSegments {
+		s.touch(traceIDs[2], idToTrace[traceIDs[2]])
+		require.NoError(t, s.flush(t.Context(), idToTrace))
+	}
+	index, err := client.Get(t.Context(), indexKey)
+	require.NoError(t, err)
+	assert.Nil(t, index)
+	s.touch(traceIDs[2], idToTrace[traceIDs[2]])
+	require.NoError(t, s.flush(t.Context(), idToTrace))
+	index, err = client.Get(t.Context(), indexKey)
+	require.NoError(t, err)
+	assert.Len(t, index, 1+9*indexEntrySize)
+	assert.Zero(t, s.segments)
+	assert.Nil(t, segment(0))
+	assertLoaded()
+}
+
+// singleClientStorage is a storage extension that returns the same client to all components.
+type singleClientStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	client storage.Client
+}
+
+func (s *singleClientStorage) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return s.client, nil
+}
+
+// failingClient is a storage client whose batches fail while failBatch is set.
+type failingClient struct {
+	storage.Client
+	failBatch bool
+}
+
+func (c *failingClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	if c.failBatch {
+		return errors.New("batch failed")
+	}
+	return c.Client.Batch(ctx, ops...)
+}
@@ -289,6 +289,45 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "functions"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Functions: []ottl.FunctionDefinition{
+					{
+						Name:   "normalize_http",
+						Params: []string{"url"},
+						Statements: []string{
+							`replace_pattern(url, "\\?.*$", "")`,
+							`set(url, ConvertCase(url, "lower"))`,
+						},
+					},
+					{
+						Name:       "Kilobytes",
+						Params:     []string{"bytes"},
+						Expression: `bytes / 1024`,
+					},
+				},
+				TraceStatements: []common.ContextStatements{
+					{
+						Statements: []string{`normalize_http(span.attributes["http.url"])`},
+					},
+				},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Statements: []string{`set(log.attributes["size_kb"], Kilobytes(log.attributes["size"]))`},
+					},
+				},
+				ProfileStatements: []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_function_definition"),
+			errors: []error{
+				errors.New(`function "set" is already defined`),
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "context_statements_error_mode"),
 			expected: &Config{
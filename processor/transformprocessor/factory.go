@@ -23,6 +23,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metadata"
@@ -38,12 +39,14 @@ type transformProcessorFactory struct {
 	logFunctions                        map[string]ottl.Factory[*ottllog.TransformContext]
 	metricFunctions                     map[string]ottl.Factory[*ottlmetric.TransformContext]
 	spanEventFunctions                  map[string]ottl.Factory[*ottlspanevent.TransformContext]
+	spanLinkFunctions                   map[string]ottl.Factory[*ottlspanlink.TransformContext]
 	spanFunctions                       map[string]ottl.Factory[*ottlspan.TransformContext]
 	profileFunctions                    map[string]ottl.Factory[ottlprofile.TransformContext]
 	defaultDataPointFunctionsOverridden bool
 	defaultLogFunctionsOverridden       bool
 	defaultMetricFunctionsOverridden    bool
 	defaultSpanEventFunctionsOverridden bool
+	defaultSpanLinkFunctionsOverridden  bool
 	defaultSpanFunctionsOverridden      bool
 	defaultProfileFunctionsOverridden   bool
 }
@@ -135,6 +138,18 @@ func WithSpanEventFunctionsNew(spanEventFunctions []ottl.Factory[*ottlspanevent.
 	}
 }
 
+// WithSpanLinkFunctions will override the default OTTL spanlink context functions with the provided spanLinkFunctions in the resulting processor.
+// Subsequent uses of WithSpanLinkFunctions will merge the provided spanLinkFunctions with the previously registered functions.
+func WithSpanLinkFunctions(spanLinkFunctions []ottl.Factory[*ottlspanlink.TransformContext]) FactoryOption {
+	return func(factory *transformProcessorFactory) {
+		if !factory.defaultSpanLinkFunctionsOverridden {
+			factory.spanLinkFunctions = map[string]ottl.Factory[*ottlspanlink.TransformContext]{}
+			factory.defaultSpanLinkFunctionsOverridden = true
+		}
+		factory.spanLinkFunctions = mergeFunctionsToMap(factory.spanLinkFunctions, spanLinkFunctions)
+	}
+}
+
 // Deprecated: [v0.142.0] use WithSpanFunctionsNew.
 func WithSpanFunctions(spanFunctions []ottl.Factory[ottlspan.TransformContext]) FactoryOption {
 	newSpanFunctions := make([]ottl.Factory[*ottlspan.TransformContext], 0, len(spanFunctions))
@@ -179,6 +194,7 @@ func NewFactoryWithOptions(options ...FactoryOption) processor.Factory {
 		logFunctions:       defaultLogFunctionsMap(),
 		metricFunctions:    defaultMetricFunctionsMap(),
 		spanEventFunctions: defaultSpanEventFunctionsMap(),
+		spanLinkFunctions:  defaultSpanLinkFunctionsMap(),
 		spanFunctions:      defaultSpanFunctionsMap(),
 		profileFunctions:   defaultProfileFunctionsMap(),
 	}
@@ -207,6 +223,7 @@ func (f *transformProcessorFactory) createDefaultConfig() component.Config {
 		logFunctions:       f.logFunctions,
 		metricFunctions:    f.metricFunctions,
 		spanEventFunctions: f.spanEventFunctions,
+		spanLinkFunctions:  f.spanLinkFunctions,
 		spanFunctions:      f.spanFunctions,
 		profileFunctions:   f.profileFunctions,
 	}
@@ -222,7 +239,7 @@ func (f *transformProcessorFactory) createLogsProcessor(
 	if f.defaultLogFunctionsOverridden {
 		set.Logger.Debug("non-default OTTL log functions have been registered in the \"transform\" processor", zap.Bool("log", f.defaultLogFunctionsOverridden))
 	}
-	proc, err := logs.NewProcessor(oCfg.LogStatements, oCfg.ErrorMode, oCfg.FlattenData, set.TelemetrySettings, f.logFunctions)
+	proc, err := logs.NewProcessor(oCfg.LogStatements, oCfg.ErrorMode, oCfg.FlattenData, set.TelemetrySettings, f.logFunctions, oCfg.Functions)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
@@ -242,13 +259,14 @@ func (f *transformProcessorFactory) createTracesProcessor(
 	nextConsumer consumer.Traces,
 ) (processor.Traces, error) {
 	oCfg := cfg.(*Config)
-	if f.defaultSpanEventFunctionsOverridden || f.defaultSpanFunctionsOverridden {
+	if f.defaultSpanEventFunctionsOverridden || f.defaultSpanLinkFunctionsOverridden || f.defaultSpanFunctionsOverridden {
 		set.Logger.Debug("non-default OTTL trace functions have been registered in the \"transform\" processor",
 			zap.Bool("span", f.defaultSpanFunctionsOverridden),
 			zap.Bool("spanevent", f.defaultSpanEventFunctionsOverridden),
+			zap.Bool("spanlink", f.defaultSpanLinkFunctionsOverridden),
 		)
 	}
-	proc, err := traces.NewProcessor(oCfg.TraceStatements, oCfg.ErrorMode, set.TelemetrySettings, f.spanFunctions, f.spanEventFunctions)
+	proc, err := traces.NewProcessor(oCfg.TraceStatements, oCfg.ErrorMode, set.TelemetrySettings, f.spanFunctions, f.spanEventFunctions, f.spanLinkFunctions, oCfg.Functions)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
@@ -275,7 +293,7 @@ func (f *transformProcessorFactory) createMetricsProcessor(
 			zap.Bool("metric", f.defaultMetricFunctionsOverridden),
 		)
 	}
-	proc, err := metrics.NewProcessor(oCfg.MetricStatements, oCfg.ErrorMode, set.TelemetrySettings, f.metricFunctions, f.dataPointFunctions)
+	proc, err := metrics.NewProcessor(oCfg.MetricStatements, oCfg.ErrorMode, set.TelemetrySettings, f.metricFunctions, f.dataPointFunctions, oCfg.Functions)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
@@ -300,7 +318,7 @@ func (f *transformProcessorFactory) createProfilesProcessor(
 	if f.defaultProfileFunctionsOverridden {
 		set.Logger.Debug("non-default OTTL profile functions have been registered in the \"transform\" processor", zap.Bool("profile", f.defaultProfileFunctionsOverridden))
 	}
-	proc, err := profiles.NewProcessor(oCfg.ProfileStatements, oCfg.ErrorMode, set.TelemetrySettings, f.profileFunctions)
+	proc, err := profiles.NewProcessor(oCfg.ProfileStatements, oCfg.ErrorMode, set.TelemetrySettings, f.profileFunctions, oCfg.Functions)
 	if err != nil {
 		return nil, fmt.Errorf("invalid config for \"transform\" processor %w", err)
 	}
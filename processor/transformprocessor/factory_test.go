@@ -27,6 +27,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pprofiletest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metadata"
@@ -49,6 +50,9 @@ func assertConfigContainsDefaultFunctions(t *testing.T, config Config) {
 	for _, f := range DefaultSpanEventFunctionsNew() {
 		assert.Contains(t, config.spanEventFunctions, f.Name(), "missing span event function %v", f.Name())
 	}
+	for _, f := range DefaultSpanLinkFunctions() {
+		assert.Contains(t, config.spanLinkFunctions, f.Name(), "missing span link function %v", f.Name())
+	}
 	for _, f := range DefaultProfileFunctions() {
 		assert.Contains(t, config.profileFunctions, f.Name(), "missing profile function %v", f.Name())
 	}
@@ -891,6 +895,40 @@ func TestFactoryCreateSpanEventProcessor(t *testing.T) {
 	}
 }
 
+func TestFactoryCreateSpanLinkProcessor(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.ErrorMode = ottl.IgnoreError
+	oCfg.TraceStatements = []common.ContextStatements{
+		{
+			Context:    "spanlink",
+			Conditions: []string{`trace_state["vendor"] == "a"`},
+			Statements: []string{`set(attributes["test"], "pass")`},
+		},
+	}
+	mp, err := factory.CreateTraces(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewNop())
+	assert.NotNil(t, mp)
+	require.NoError(t, err)
+
+	createTraces := func() ptrace.Traces {
+		td := ptrace.NewTraces()
+		links := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().Links()
+		links.AppendEmpty().TraceState().FromRaw("vendor=a")
+		links.AppendEmpty().TraceState().FromRaw("vendor=b")
+		return td
+	}
+	td := createTraces()
+
+	err = mp.ConsumeTraces(t.Context(), td)
+	require.NoError(t, err)
+
+	exTd := createTraces()
+	exTd.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Links().At(0).Attributes().PutStr("test", "pass")
+
+	assert.Equal(t, exTd, td)
+}
+
 func createTestFuncFactory[K any](name string) ottl.Factory[K] {
 	type TestFuncArguments[K any] struct{}
 	createFunc := func(_ ottl.FunctionContext, _ ottl.Arguments) (ottl.ExprFunc[K], error) {
@@ -1016,6 +1054,32 @@ func Test_FactoryWithFunctions_CreateTraces(t *testing.T) {
 				WithSpanEventFunctionsNew([]ottl.Factory[*ottlspanevent.TransformContext]{createTestFuncFactory[*ottlspanevent.TransformContext]("TestSpanEventFunc")}),
 			},
 		},
+		{
+			name: "with span link functions : statement with added span link func",
+			statements: []common.ContextStatements{
+				{
+					Context:    common.ContextID("spanlink"),
+					Statements: []string{`set(cache["attr"], TestSpanLinkFunc())`},
+				},
+			},
+			factoryOptions: []FactoryOption{
+				WithSpanLinkFunctions(DefaultSpanLinkFunctions()),
+				WithSpanLinkFunctions([]ottl.Factory[*ottlspanlink.TransformContext]{createTestFuncFactory[*ottlspanlink.TransformContext]("TestSpanLinkFunc")}),
+			},
+		},
+		{
+			name: "with span link functions : missing default functions",
+			statements: []common.ContextStatements{
+				{
+					Context:    common.ContextID("spanlink"),
+					Statements: []string{`set(attributes["test"], "TestSpanLinkFunc()")`},
+				},
+			},
+			wantErrorWith: `undefined function "set"`,
+			factoryOptions: []FactoryOption{
+				WithSpanLinkFunctions([]ottl.Factory[*ottlspanlink.TransformContext]{createTestFuncFactory[*ottlspanlink.TransformContext]("TestSpanLinkFunc")}),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
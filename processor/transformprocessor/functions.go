@@ -14,6 +14,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlprofile"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
@@ -75,6 +76,10 @@ func DefaultSpanEventFunctionsNew() []ottl.Factory[*ottlspanevent.TransformConte
 	return slices.Collect(maps.Values(defaultSpanEventFunctionsMap()))
 }
 
+func DefaultSpanLinkFunctions() []ottl.Factory[*ottlspanlink.TransformContext] {
+	return slices.Collect(maps.Values(defaultSpanLinkFunctionsMap()))
+}
+
 func DefaultProfileFunctions() []ottl.Factory[ottlprofile.TransformContext] {
 	return slices.Collect(maps.Values(defaultProfileFunctionsMap()))
 }
@@ -99,6 +104,10 @@ func defaultSpanEventFunctionsMap() map[string]ottl.Factory[*ottlspanevent.Trans
 	return traces.SpanEventFunctions()
 }
 
+func defaultSpanLinkFunctionsMap() map[string]ottl.Factory[*ottlspanlink.TransformContext] {
+	return traces.SpanLinkFunctions()
+}
+
 func defaultProfileFunctionsMap() map[string]ottl.Factory[ottlprofile.TransformContext] {
 	return profiles.ProfileFunctions()
 }
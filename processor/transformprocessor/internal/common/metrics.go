@@ -205,6 +205,10 @@ func WithMetricErrorMode(errorMode ottl.ErrorMode) MetricParserCollectionOption
 	return MetricParserCollectionOption(ottl.WithParserCollectionErrorMode[MetricsConsumer](errorMode))
 }
 
+func WithMetricFunctionDefinitions(definitions []ottl.FunctionDefinition) MetricParserCollectionOption {
+	return MetricParserCollectionOption(ottl.WithParserCollectionFunctionDefinitions[MetricsConsumer](definitions))
+}
+
 func NewMetricParserCollection(settings component.TelemetrySettings, options ...MetricParserCollectionOption) (*MetricParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[MetricsConsumer]{
 		withCommonContextParsers[MetricsConsumer](),
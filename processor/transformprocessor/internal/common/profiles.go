@@ -69,6 +69,10 @@ func WithProfileErrorMode(errorMode ottl.ErrorMode) ProfileParserCollectionOptio
 	return ProfileParserCollectionOption(ottl.WithParserCollectionErrorMode[ProfilesConsumer](errorMode))
 }
 
+func WithProfileFunctionDefinitions(definitions []ottl.FunctionDefinition) ProfileParserCollectionOption {
+	return ProfileParserCollectionOption(ottl.WithParserCollectionFunctionDefinitions[ProfilesConsumer](definitions))
+}
+
 func NewProfileParserCollection(settings component.TelemetrySettings, options ...ProfileParserCollectionOption) (*ProfileParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[ProfilesConsumer]{
 		withCommonContextParsers[ProfilesConsumer](),
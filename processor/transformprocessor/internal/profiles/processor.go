@@ -21,8 +21,8 @@ type Processor struct {
 	logger   *zap.Logger
 }
 
-func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, settings component.TelemetrySettings, profileFunctions map[string]ottl.Factory[ottlprofile.TransformContext]) (*Processor, error) {
-	pc, err := common.NewProfileParserCollection(settings, common.WithProfileParser(profileFunctions), common.WithProfileErrorMode(errorMode))
+func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, settings component.TelemetrySettings, profileFunctions map[string]ottl.Factory[ottlprofile.TransformContext], functionDefinitions []ottl.FunctionDefinition) (*Processor, error) {
+	pc, err := common.NewProfileParserCollection(settings, common.WithProfileParser(profileFunctions), common.WithProfileErrorMode(errorMode), common.WithProfileFunctionDefinitions(functionDefinitions))
 	if err != nil {
 		return nil, err
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
 )
 
@@ -29,3 +30,8 @@ func SpanEventFunctions() map[string]ottl.Factory[*ottlspanevent.TransformContex
 	// No trace-only functions yet.
 	return ottlfuncs.StandardFuncs[*ottlspanevent.TransformContext]()
 }
+
+func SpanLinkFunctions() map[string]ottl.Factory[*ottlspanlink.TransformContext] {
+	// No trace-only functions yet.
+	return ottlfuncs.StandardFuncs[*ottlspanlink.TransformContext]()
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanlink"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 )
 
@@ -34,6 +35,7 @@ var (
 
 	DefaultSpanFunctions      = SpanFunctions()
 	DefaultSpanEventFunctions = SpanEventFunctions()
+	DefaultSpanLinkFunctions  = SpanLinkFunctions()
 )
 
 func Test_ProcessTraces_ResourceContext(t *testing.T) {
@@ -63,7 +65,7 @@ func Test_ProcessTraces_ResourceContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -104,7 +106,7 @@ func Test_ProcessTraces_InferredResourceContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -145,7 +147,7 @@ func Test_ProcessTraces_ScopeContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -186,7 +188,7 @@ func Test_ProcessTraces_InferredScopeContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -486,7 +488,7 @@ func Test_ProcessTraces_TraceContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "span", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "span", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -786,7 +788,7 @@ func Test_ProcessTraces_InferredTraceContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -816,7 +818,7 @@ func Test_ProcessTraces_SpanEventContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "spanevent", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "spanevent", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -846,7 +848,120 @@ func Test_ProcessTraces_InferredSpanEventContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
+			require.NoError(t, err)
+
+			_, err = processor.ProcessTraces(t.Context(), td)
+			require.NoError(t, err)
+
+			exTd := constructTraces()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func Test_ProcessTraces_SpanLinkContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(td ptrace.Traces)
+	}{
+		{
+			statement: `set(attributes["test"], "pass") where link_index == 1`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().At(1).Attributes().PutStr("test", "pass")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			td := constructTraces()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "spanlink", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
+			require.NoError(t, err)
+
+			_, err = processor.ProcessTraces(t.Context(), td)
+			require.NoError(t, err)
+
+			exTd := constructTraces()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func Test_ProcessTraces_FunctionDefinitions(t *testing.T) {
+	definitions := []ottl.FunctionDefinition{
+		{
+			Name:   "normalize_http",
+			Params: []string{"url"},
+			Statements: []string{
+				`replace_pattern(url, "^http://", "https://")`,
+				`set(url, Concat([url, "v1"], "/"))`,
+			},
+		},
+		{
+			Name:       "Route",
+			Params:     []string{"method", "path"},
+			Expression: `Concat([ConvertCase(method, "upper"), path], " ")`,
+		},
+	}
+
+	tests := []struct {
+		contextStatements []common.ContextStatements
+		want              func(td ptrace.Traces)
+	}{
+		{
+			contextStatements: []common.ContextStatements{{Statements: []string{`normalize_http(span.attributes["http.url"]) where span.name == "operationA"`}}},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutStr("http.url", "https://localhost/health/v1")
+			},
+		},
+		{
+			contextStatements: []common.ContextStatements{{Context: "span", Statements: []string{`set(attributes["route"], Route(path = attributes["http.path"], method = attributes["http.method"])) where name == "operationA"`}}},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutStr("route", "GET /health")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contextStatements[0].Statements[0], func(t *testing.T) {
+			td := constructTraces()
+			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, definitions)
+			require.NoError(t, err)
+
+			_, err = processor.ProcessTraces(t.Context(), td)
+			require.NoError(t, err)
+
+			exTd := constructTraces()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func Test_ProcessTraces_InferredSpanLinkContext(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(td ptrace.Traces)
+	}{
+		{
+			statement: `set(spanlink.attributes["test"], "pass") where spanlink.dropped_attributes_count == 4 and span.name == "operationB"`,
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().At(0).Attributes().PutStr("test", "pass")
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Links().At(1).Attributes().PutStr("test", "pass")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			td := constructTraces()
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -963,7 +1078,7 @@ func Test_ProcessTraces_MixContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -999,7 +1114,7 @@ func Test_ProcessTraces_ErrorMode(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(string(tt.context), func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON("1"))`}}}, ottl.PropagateError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON("1"))`}}}, ottl.PropagateError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -1101,7 +1216,7 @@ func Test_ProcessTraces_StatementsErrorMode(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor(tt.statements, tt.errorMode, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor(tt.statements, tt.errorMode, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 			_, err = processor.ProcessTraces(t.Context(), td)
 			if tt.wantErrorWith != "" {
@@ -1258,7 +1373,7 @@ func Test_ProcessTraces_CacheAccess(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor(tt.statements, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor(tt.statements, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -1315,7 +1430,7 @@ func Test_ProcessTraces_InferredContextFromConditions(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructTraces()
-			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(t, err)
 
 			_, err = processor.ProcessTraces(t.Context(), td)
@@ -1385,7 +1500,7 @@ func Test_NewProcessor_ConditionsParse(t *testing.T) {
 		t.Run(ctx, func(t *testing.T) {
 			for _, tt := range tests {
 				t.Run(tt.name, func(t *testing.T) {
-					_, err := NewProcessor(tt.statements, ottl.PropagateError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+					_, err := NewProcessor(tt.statements, ottl.PropagateError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 					if tt.wantErrorWith != "" {
 						if err == nil {
 							t.Errorf("expected error containing '%s', got: <nil>", tt.wantErrorWith)
@@ -1416,6 +1531,10 @@ func NewTestSpanEventFuncFactory[K any]() ottl.Factory[K] {
 	return ottl.NewFactory("TestSpanEventFunc", &TestFuncArguments[K]{}, createTestFunc[K])
 }
 
+func NewTestSpanLinkFuncFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("TestSpanLinkFunc", &TestFuncArguments[K]{}, createTestFunc[K])
+}
+
 func Test_NewProcessor_NonDefaultFunctions(t *testing.T) {
 	type testCase struct {
 		name               string
@@ -1423,6 +1542,7 @@ func Test_NewProcessor_NonDefaultFunctions(t *testing.T) {
 		wantErrorWith      string
 		spanFunctions      map[string]ottl.Factory[*ottlspan.TransformContext]
 		spanEventFunctions map[string]ottl.Factory[*ottlspanevent.TransformContext]
+		spanLinkFunctions  map[string]ottl.Factory[*ottlspanlink.TransformContext]
 	}
 
 	tests := []testCase{
@@ -1478,11 +1598,39 @@ func Test_NewProcessor_NonDefaultFunctions(t *testing.T) {
 			spanFunctions:      DefaultSpanFunctions,
 			spanEventFunctions: DefaultSpanEventFunctions,
 		},
+		{
+			name: "span link functions : statement with added span link func",
+			statements: []common.ContextStatements{
+				{
+					Context:    common.ContextID("spanlink"),
+					Statements: []string{`set(cache["attr"], TestSpanLinkFunc())`},
+				},
+			},
+			spanFunctions:      DefaultSpanFunctions,
+			spanEventFunctions: DefaultSpanEventFunctions,
+			spanLinkFunctions: map[string]ottl.Factory[*ottlspanlink.TransformContext]{
+				"set":              DefaultSpanLinkFunctions["set"],
+				"TestSpanLinkFunc": NewTestSpanLinkFuncFactory[*ottlspanlink.TransformContext](),
+			},
+		},
+		{
+			name: "span link functions : statement with missing span link func",
+			statements: []common.ContextStatements{
+				{
+					Context:    common.ContextID("spanlink"),
+					Statements: []string{`set(cache["attr"], TestSpanLinkFunc())`},
+				},
+			},
+			wantErrorWith:      `undefined function "TestSpanLinkFunc"`,
+			spanFunctions:      DefaultSpanFunctions,
+			spanEventFunctions: DefaultSpanEventFunctions,
+			spanLinkFunctions:  DefaultSpanLinkFunctions,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := NewProcessor(tt.statements, ottl.PropagateError, componenttest.NewNopTelemetrySettings(), tt.spanFunctions, tt.spanEventFunctions)
+			_, err := NewProcessor(tt.statements, ottl.PropagateError, componenttest.NewNopTelemetrySettings(), tt.spanFunctions, tt.spanEventFunctions, tt.spanLinkFunctions, nil)
 			if tt.wantErrorWith != "" {
 				if err == nil {
 					t.Errorf("expected error containing '%s', got: <nil>", tt.wantErrorWith)
@@ -1533,7 +1681,7 @@ func BenchmarkTwoSpans(b *testing.B) {
 		b.Run(tt.name, func(b *testing.B) {
 			b.ReportAllocs()
 			b.ResetTimer()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "span", Statements: tt.statements}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "span", Statements: tt.statements}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(b, err)
 			b.ResetTimer()
 			for b.Loop() {
@@ -1575,7 +1723,7 @@ func BenchmarkHundredSpans(b *testing.B) {
 	}
 	for _, tt := range tests {
 		b.Run(tt.name, func(b *testing.B) {
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "span", Statements: tt.statements}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "span", Statements: tt.statements}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 			require.NoError(b, err)
 			b.ResetTimer()
 			for b.Loop() {
@@ -1592,7 +1740,7 @@ func BenchmarkSetName(b *testing.B) {
 	processor, err := NewProcessor([]common.ContextStatements{{
 		Context:    "span",
 		Statements: []string{`set(name, "operationA") where name == "operationA"`},
-	}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions)
+	}}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings(), DefaultSpanFunctions, DefaultSpanEventFunctions, DefaultSpanLinkFunctions, nil)
 	require.NoError(b, err)
 
 	td := constructTraces()
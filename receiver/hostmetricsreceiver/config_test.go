@@ -24,8 +24,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/networkscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/nfsscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemscraper"
 )
 
@@ -81,6 +83,18 @@ func TestLoadConfig(t *testing.T) {
 					component.MustNewType("nfs"):       nfsscraper.NewFactory().CreateDefaultConfig(),
 					component.MustNewType("processes"): processesscraper.NewFactory().CreateDefaultConfig(),
 					component.MustNewType("paging"):    pagingscraper.NewFactory().CreateDefaultConfig(),
+					component.MustNewType("pressure"): (func() component.Config {
+						cfg := pressurescraper.NewFactory().CreateDefaultConfig()
+						cfg.(*pressurescraper.Config).Cgroups = pressurescraper.CgroupsConfig{
+							Enabled: true,
+							Root:    "/kubepods.slice",
+							Exclude: pressurescraper.MatchConfig{
+								Paths:  []string{"/kubepods.slice"},
+								Config: filterset.Config{MatchType: "strict"},
+							},
+						}
+						return cfg
+					})(),
 					component.MustNewType("process"): (func() component.Config {
 						cfg := processscraper.NewFactory().CreateDefaultConfig()
 						cfg.(*processscraper.Config).Include = processscraper.MatchConfig{
@@ -89,6 +103,14 @@ func TestLoadConfig(t *testing.T) {
 						}
 						return cfg
 					})(),
+					component.MustNewType("sensors"): (func() component.Config {
+						cfg := sensorsscraper.NewFactory().CreateDefaultConfig()
+						cfg.(*sensorsscraper.Config).Exclude = sensorsscraper.MatchConfig{
+							Chips:  []string{"nvme"},
+							Config: filterset.Config{MatchType: "strict"},
+						}
+						return cfg
+					})(),
 					component.MustNewType("system"): systemscraper.NewFactory().CreateDefaultConfig(),
 				},
 			},
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+// Config relating to Pressure Metric Scraper.
+type Config struct {
+	// MetricsBuilderConfig allows to customize scraped metrics/attributes representation.
+	metadata.MetricsBuilderConfig `mapstructure:",squash"`
+
+	// Cgroups configures the scraping of the cgroup v2 controllers statistics.
+	Cgroups CgroupsConfig `mapstructure:"cgroups"`
+}
+
+// CgroupsConfig relating to the scraping of cgroups.
+type CgroupsConfig struct {
+	// Enabled specifies whether the cgroups are scraped, in addition to the pressure stall information of the host.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Root is the path of the cgroup under which the cgroups are scraped, relative to the root of the
+	// cgroup v2 hierarchy. The root cgroup is scraped along with all its descendants.
+	Root string `mapstructure:"root"`
+
+	// Include specifies a filter on the paths of the cgroups that should be included from the generated metrics.
+	// Exclude specifies a filter on the paths of the cgroups that should be excluded from the generated metrics.
+	// If neither `include` or `exclude` are set, metrics will be generated for all cgroups under the root.
+	Include MatchConfig `mapstructure:"include"`
+	Exclude MatchConfig `mapstructure:"exclude"`
+}
+
+type MatchConfig struct {
+	filterset.Config `mapstructure:",squash"`
+
+	Paths []string `mapstructure:"paths"`
+}
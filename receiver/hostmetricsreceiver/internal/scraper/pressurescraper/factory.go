@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"context"
+	"errors"
+	"runtime"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/scraper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+var (
+	supportedOS      = runtime.GOOS == "linux"
+	errUnsupportedOS = errors.New("the pressure scraper is only available on Linux")
+)
+
+// NewFactory for Pressure scraper.
+func NewFactory() scraper.Factory {
+	return scraper.NewFactory(metadata.Type, createDefaultConfig, scraper.WithMetrics(createMetricsScraper, metadata.MetricsStability))
+}
+
+// createDefaultConfig creates the default configuration for the Scraper.
+func createDefaultConfig() component.Config {
+	return &Config{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Cgroups: CgroupsConfig{
+			Root: "/",
+		},
+	}
+}
+
+// createMetricsScraper creates a resource scraper based on provided config.
+func createMetricsScraper(
+	_ context.Context,
+	settings scraper.Settings,
+	cfg component.Config,
+) (scraper.Metrics, error) {
+	if !supportedOS {
+		return nil, errUnsupportedOS
+	}
+
+	pressureScraper, err := newPressureScraper(settings, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+
+	return scraper.NewMetrics(
+		pressureScraper.scrape,
+		scraper.WithStart(pressureScraper.start),
+	)
+}
@@ -0,0 +1,133 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/filter"
+)
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for pressure metrics.
+type MetricsConfig struct {
+	SystemCgroupCPUThrottledPeriods  MetricConfig `mapstructure:"system.cgroup.cpu.throttled.periods"`
+	SystemCgroupCPUThrottledTime     MetricConfig `mapstructure:"system.cgroup.cpu.throttled.time"`
+	SystemCgroupCPUTime              MetricConfig `mapstructure:"system.cgroup.cpu.time"`
+	SystemCgroupIoBytes              MetricConfig `mapstructure:"system.cgroup.io.bytes"`
+	SystemCgroupIoOperations         MetricConfig `mapstructure:"system.cgroup.io.operations"`
+	SystemCgroupMemoryEvents         MetricConfig `mapstructure:"system.cgroup.memory.events"`
+	SystemCgroupMemoryUsage          MetricConfig `mapstructure:"system.cgroup.memory.usage"`
+	SystemCgroupPressureStallAverage MetricConfig `mapstructure:"system.cgroup.pressure.stall.average"`
+	SystemCgroupPressureStallTime    MetricConfig `mapstructure:"system.cgroup.pressure.stall.time"`
+	SystemPressureStallAverage       MetricConfig `mapstructure:"system.pressure.stall.average"`
+	SystemPressureStallTime          MetricConfig `mapstructure:"system.pressure.stall.time"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		SystemCgroupCPUThrottledPeriods: MetricConfig{
+			Enabled: true,
+		},
+		SystemCgroupCPUThrottledTime: MetricConfig{
+			Enabled: true,
+		},
+		SystemCgroupCPUTime: MetricConfig{
+			Enabled: true,
+		},
+		SystemCgroupIoBytes: MetricConfig{
+			Enabled: true,
+		},
+		SystemCgroupIoOperations: MetricConfig{
+			Enabled: true,
+		},
+		SystemCgroupMemoryEvents: MetricConfig{
+			Enabled: true,
+		},
+		SystemCgroupMemoryUsage: MetricConfig{
+			Enabled: true,
+		},
+		SystemCgroupPressureStallAverage: MetricConfig{
+			Enabled: true,
+		},
+		SystemCgroupPressureStallTime: MetricConfig{
+			Enabled: true,
+		},
+		SystemPressureStallAverage: MetricConfig{
+			Enabled: true,
+		},
+		SystemPressureStallTime: MetricConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Experimental: MetricsInclude defines a list of filters for attribute values.
+	// If the list is not empty, only metrics with matching resource attribute values will be emitted.
+	MetricsInclude []filter.Config `mapstructure:"metrics_include"`
+	// Experimental: MetricsExclude defines a list of filters for attribute values.
+	// If the list is not empty, metrics with matching resource attribute values will not be emitted.
+	// MetricsInclude has higher priority than MetricsExclude.
+	MetricsExclude []filter.Config `mapstructure:"metrics_exclude"`
+
+	enabledSetByUser bool
+}
+
+func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(rac)
+	if err != nil {
+		return err
+	}
+	rac.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// ResourceAttributesConfig provides config for pressure resource attributes.
+type ResourceAttributesConfig struct {
+	SystemCgroupPath ResourceAttributeConfig `mapstructure:"system.cgroup.path"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		SystemCgroupPath: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for pressure metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics            MetricsConfig            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
+	}
+}
@@ -0,0 +1,1078 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/filter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper"
+	conventions "go.opentelemetry.io/otel/semconv/v1.9.0"
+)
+
+// AttributeCPUMode specifies the value cpu.mode attribute.
+type AttributeCPUMode int
+
+const (
+	_ AttributeCPUMode = iota
+	AttributeCPUModeUser
+	AttributeCPUModeSystem
+)
+
+// String returns the string representation of the AttributeCPUMode.
+func (av AttributeCPUMode) String() string {
+	switch av {
+	case AttributeCPUModeUser:
+		return "user"
+	case AttributeCPUModeSystem:
+		return "system"
+	}
+	return ""
+}
+
+// MapAttributeCPUMode is a helper map of string to AttributeCPUMode attribute value.
+var MapAttributeCPUMode = map[string]AttributeCPUMode{
+	"user":   AttributeCPUModeUser,
+	"system": AttributeCPUModeSystem,
+}
+
+// AttributeDiskIoDirection specifies the value disk.io.direction attribute.
+type AttributeDiskIoDirection int
+
+const (
+	_ AttributeDiskIoDirection = iota
+	AttributeDiskIoDirectionRead
+	AttributeDiskIoDirectionWrite
+)
+
+// String returns the string representation of the AttributeDiskIoDirection.
+func (av AttributeDiskIoDirection) String() string {
+	switch av {
+	case AttributeDiskIoDirectionRead:
+		return "read"
+	case AttributeDiskIoDirectionWrite:
+		return "write"
+	}
+	return ""
+}
+
+// MapAttributeDiskIoDirection is a helper map of string to AttributeDiskIoDirection attribute value.
+var MapAttributeDiskIoDirection = map[string]AttributeDiskIoDirection{
+	"read":  AttributeDiskIoDirectionRead,
+	"write": AttributeDiskIoDirectionWrite,
+}
+
+// AttributeSystemCgroupMemoryEvent specifies the value system.cgroup.memory.event attribute.
+type AttributeSystemCgroupMemoryEvent int
+
+const (
+	_ AttributeSystemCgroupMemoryEvent = iota
+	AttributeSystemCgroupMemoryEventLow
+	AttributeSystemCgroupMemoryEventHigh
+	AttributeSystemCgroupMemoryEventMax
+	AttributeSystemCgroupMemoryEventOom
+	AttributeSystemCgroupMemoryEventOomKill
+)
+
+// String returns the string representation of the AttributeSystemCgroupMemoryEvent.
+func (av AttributeSystemCgroupMemoryEvent) String() string {
+	switch av {
+	case AttributeSystemCgroupMemoryEventLow:
+		return "low"
+	case AttributeSystemCgroupMemoryEventHigh:
+		return "high"
+	case AttributeSystemCgroupMemoryEventMax:
+		return "max"
+	case AttributeSystemCgroupMemoryEventOom:
+		return "oom"
+	case AttributeSystemCgroupMemoryEventOomKill:
+		return "oom_kill"
+	}
+	return ""
+}
+
+// MapAttributeSystemCgroupMemoryEvent is a helper map of string to AttributeSystemCgroupMemoryEvent attribute value.
+var MapAttributeSystemCgroupMemoryEvent = map[string]AttributeSystemCgroupMemoryEvent{
+	"low":      AttributeSystemCgroupMemoryEventLow,
+	"high":     AttributeSystemCgroupMemoryEventHigh,
+	"max":      AttributeSystemCgroupMemoryEventMax,
+	"oom":      AttributeSystemCgroupMemoryEventOom,
+	"oom_kill": AttributeSystemCgroupMemoryEventOomKill,
+}
+
+// AttributeSystemPressureResource specifies the value system.pressure.resource attribute.
+type AttributeSystemPressureResource int
+
+const (
+	_ AttributeSystemPressureResource = iota
+	AttributeSystemPressureResourceCpu
+	AttributeSystemPressureResourceMemory
+	AttributeSystemPressureResourceIo
+)
+
+// String returns the string representation of the AttributeSystemPressureResource.
+func (av AttributeSystemPressureResource) String() string {
+	switch av {
+	case AttributeSystemPressureResourceCpu:
+		return "cpu"
+	case AttributeSystemPressureResourceMemory:
+		return "memory"
+	case AttributeSystemPressureResourceIo:
+		return "io"
+	}
+	return ""
+}
+
+// MapAttributeSystemPressureResource is a helper map of string to AttributeSystemPressureResource attribute value.
+var MapAttributeSystemPressureResource = map[string]AttributeSystemPressureResource{
+	"cpu":    AttributeSystemPressureResourceCpu,
+	"memory": AttributeSystemPressureResourceMemory,
+	"io":     AttributeSystemPressureResourceIo,
+}
+
+// AttributeSystemPressureStallType specifies the value system.pressure.stall.type attribute.
+type AttributeSystemPressureStallType int
+
+const (
+	_ AttributeSystemPressureStallType = iota
+	AttributeSystemPressureStallTypeSome
+	AttributeSystemPressureStallTypeFull
+)
+
+// String returns the string representation of the AttributeSystemPressureStallType.
+func (av AttributeSystemPressureStallType) String() string {
+	switch av {
+	case AttributeSystemPressureStallTypeSome:
+		return "some"
+	case AttributeSystemPressureStallTypeFull:
+		return "full"
+	}
+	return ""
+}
+
+// MapAttributeSystemPressureStallType is a helper map of string to AttributeSystemPressureStallType attribute value.
+var MapAttributeSystemPressureStallType = map[string]AttributeSystemPressureStallType{
+	"some": AttributeSystemPressureStallTypeSome,
+	"full": AttributeSystemPressureStallTypeFull,
+}
+
+// AttributeSystemPressureWindow specifies the value system.pressure.window attribute.
+type AttributeSystemPressureWindow int
+
+const (
+	_ AttributeSystemPressureWindow = iota
+	AttributeSystemPressureWindow10s
+	AttributeSystemPressureWindow60s
+	AttributeSystemPressureWindow300s
+)
+
+// String returns the string representation of the AttributeSystemPressureWindow.
+func (av AttributeSystemPressureWindow) String() string {
+	switch av {
+	case AttributeSystemPressureWindow10s:
+		return "10s"
+	case AttributeSystemPressureWindow60s:
+		return "60s"
+	case AttributeSystemPressureWindow300s:
+		return "300s"
+	}
+	return ""
+}
+
+// MapAttributeSystemPressureWindow is a helper map of string to AttributeSystemPressureWindow attribute value.
+var MapAttributeSystemPressureWindow = map[string]AttributeSystemPressureWindow{
+	"10s":  AttributeSystemPressureWindow10s,
+	"60s":  AttributeSystemPressureWindow60s,
+	"300s": AttributeSystemPressureWindow300s,
+}
+
+var MetricsInfo = metricsInfo{
+	SystemCgroupCPUThrottledPeriods: metricInfo{
+		Name: "system.cgroup.cpu.throttled.periods",
+	},
+	SystemCgroupCPUThrottledTime: metricInfo{
+		Name: "system.cgroup.cpu.throttled.time",
+	},
+	SystemCgroupCPUTime: metricInfo{
+		Name: "system.cgroup.cpu.time",
+	},
+	SystemCgroupIoBytes: metricInfo{
+		Name: "system.cgroup.io.bytes",
+	},
+	SystemCgroupIoOperations: metricInfo{
+		Name: "system.cgroup.io.operations",
+	},
+	SystemCgroupMemoryEvents: metricInfo{
+		Name: "system.cgroup.memory.events",
+	},
+	SystemCgroupMemoryUsage: metricInfo{
+		Name: "system.cgroup.memory.usage",
+	},
+	SystemCgroupPressureStallAverage: metricInfo{
+		Name: "system.cgroup.pressure.stall.average",
+	},
+	SystemCgroupPressureStallTime: metricInfo{
+		Name: "system.cgroup.pressure.stall.time",
+	},
+	SystemPressureStallAverage: metricInfo{
+		Name: "system.pressure.stall.average",
+	},
+	SystemPressureStallTime: metricInfo{
+		Name: "system.pressure.stall.time",
+	},
+}
+
+type metricsInfo struct {
+	SystemCgroupCPUThrottledPeriods  metricInfo
+	SystemCgroupCPUThrottledTime     metricInfo
+	SystemCgroupCPUTime              metricInfo
+	SystemCgroupIoBytes              metricInfo
+	SystemCgroupIoOperations         metricInfo
+	SystemCgroupMemoryEvents         metricInfo
+	SystemCgroupMemoryUsage          metricInfo
+	SystemCgroupPressureStallAverage metricInfo
+	SystemCgroupPressureStallTime    metricInfo
+	SystemPressureStallAverage       metricInfo
+	SystemPressureStallTime          metricInfo
+}
+
+type metricInfo struct {
+	Name string
+}
+
+type metricSystemCgroupCPUThrottledPeriods struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.cgroup.cpu.throttled.periods metric with initial data.
+func (m *metricSystemCgroupCPUThrottledPeriods) init() {
+	m.data.SetName("system.cgroup.cpu.throttled.periods")
+	m.data.SetDescription("The number of enforcement periods in which the cgroup was throttled.")
+	m.data.SetUnit("{period}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemCgroupCPUThrottledPeriods) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemCgroupCPUThrottledPeriods) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemCgroupCPUThrottledPeriods) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemCgroupCPUThrottledPeriods(cfg MetricConfig) metricSystemCgroupCPUThrottledPeriods {
+	m := metricSystemCgroupCPUThrottledPeriods{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemCgroupCPUThrottledTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.cgroup.cpu.throttled.time metric with initial data.
+func (m *metricSystemCgroupCPUThrottledTime) init() {
+	m.data.SetName("system.cgroup.cpu.throttled.time")
+	m.data.SetDescription("The total time the cgroup was throttled.")
+	m.data.SetUnit("s")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemCgroupCPUThrottledTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemCgroupCPUThrottledTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemCgroupCPUThrottledTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemCgroupCPUThrottledTime(cfg MetricConfig) metricSystemCgroupCPUThrottledTime {
+	m := metricSystemCgroupCPUThrottledTime{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemCgroupCPUTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.cgroup.cpu.time metric with initial data.
+func (m *metricSystemCgroupCPUTime) init() {
+	m.data.SetName("system.cgroup.cpu.time")
+	m.data.SetDescription("The CPU time consumed by the tasks of the cgroup.")
+	m.data.SetUnit("s")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemCgroupCPUTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, cpuModeAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("cpu.mode", cpuModeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemCgroupCPUTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemCgroupCPUTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemCgroupCPUTime(cfg MetricConfig) metricSystemCgroupCPUTime {
+	m := metricSystemCgroupCPUTime{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemCgroupIoBytes struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.cgroup.io.bytes metric with initial data.
+func (m *metricSystemCgroupIoBytes) init() {
+	m.data.SetName("system.cgroup.io.bytes")
+	m.data.SetDescription("The number of bytes read and written by the cgroup per device.")
+	m.data.SetUnit("By")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemCgroupIoBytes) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, systemDeviceAttributeValue string, diskIoDirectionAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("system.device", systemDeviceAttributeValue)
+	dp.Attributes().PutStr("disk.io.direction", diskIoDirectionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemCgroupIoBytes) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemCgroupIoBytes) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemCgroupIoBytes(cfg MetricConfig) metricSystemCgroupIoBytes {
+	m := metricSystemCgroupIoBytes{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemCgroupIoOperations struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.cgroup.io.operations metric with initial data.
+func (m *metricSystemCgroupIoOperations) init() {
+	m.data.SetName("system.cgroup.io.operations")
+	m.data.SetDescription("The number of read and write operations of the cgroup per device.")
+	m.data.SetUnit("{operation}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemCgroupIoOperations) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, systemDeviceAttributeValue string, diskIoDirectionAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("system.device", systemDeviceAttributeValue)
+	dp.Attributes().PutStr("disk.io.direction", diskIoDirectionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemCgroupIoOperations) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemCgroupIoOperations) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemCgroupIoOperations(cfg MetricConfig) metricSystemCgroupIoOperations {
+	m := metricSystemCgroupIoOperations{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemCgroupMemoryEvents struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.cgroup.memory.events metric with initial data.
+func (m *metricSystemCgroupMemoryEvents) init() {
+	m.data.SetName("system.cgroup.memory.events")
+	m.data.SetDescription("The number of memory events of the cgroup.")
+	m.data.SetUnit("{event}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemCgroupMemoryEvents) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, systemCgroupMemoryEventAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("system.cgroup.memory.event", systemCgroupMemoryEventAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemCgroupMemoryEvents) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemCgroupMemoryEvents) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemCgroupMemoryEvents(cfg MetricConfig) metricSystemCgroupMemoryEvents {
+	m := metricSystemCgroupMemoryEvents{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemCgroupMemoryUsage struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.cgroup.memory.usage metric with initial data.
+func (m *metricSystemCgroupMemoryUsage) init() {
+	m.data.SetName("system.cgroup.memory.usage")
+	m.data.SetDescription("The memory currently used by the cgroup and its descendants.")
+	m.data.SetUnit("By")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemCgroupMemoryUsage) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemCgroupMemoryUsage) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemCgroupMemoryUsage) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemCgroupMemoryUsage(cfg MetricConfig) metricSystemCgroupMemoryUsage {
+	m := metricSystemCgroupMemoryUsage{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemCgroupPressureStallAverage struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.cgroup.pressure.stall.average metric with initial data.
+func (m *metricSystemCgroupPressureStallAverage) init() {
+	m.data.SetName("system.cgroup.pressure.stall.average")
+	m.data.SetDescription("The percentage of time some or all tasks of the cgroup were stalled on the resource, averaged over the time window.")
+	m.data.SetUnit("%")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemCgroupPressureStallAverage) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, systemPressureResourceAttributeValue string, systemPressureStallTypeAttributeValue string, systemPressureWindowAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("system.pressure.resource", systemPressureResourceAttributeValue)
+	dp.Attributes().PutStr("system.pressure.stall.type", systemPressureStallTypeAttributeValue)
+	dp.Attributes().PutStr("system.pressure.window", systemPressureWindowAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemCgroupPressureStallAverage) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemCgroupPressureStallAverage) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemCgroupPressureStallAverage(cfg MetricConfig) metricSystemCgroupPressureStallAverage {
+	m := metricSystemCgroupPressureStallAverage{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemCgroupPressureStallTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.cgroup.pressure.stall.time metric with initial data.
+func (m *metricSystemCgroupPressureStallTime) init() {
+	m.data.SetName("system.cgroup.pressure.stall.time")
+	m.data.SetDescription("The total time some or all tasks of the cgroup were stalled on the resource.")
+	m.data.SetUnit("s")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemCgroupPressureStallTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, systemPressureResourceAttributeValue string, systemPressureStallTypeAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("system.pressure.resource", systemPressureResourceAttributeValue)
+	dp.Attributes().PutStr("system.pressure.stall.type", systemPressureStallTypeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemCgroupPressureStallTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemCgroupPressureStallTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemCgroupPressureStallTime(cfg MetricConfig) metricSystemCgroupPressureStallTime {
+	m := metricSystemCgroupPressureStallTime{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemPressureStallAverage struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.pressure.stall.average metric with initial data.
+func (m *metricSystemPressureStallAverage) init() {
+	m.data.SetName("system.pressure.stall.average")
+	m.data.SetDescription("The percentage of time some or all tasks were stalled on the resource, averaged over the time window.")
+	m.data.SetUnit("%")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemPressureStallAverage) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, systemPressureResourceAttributeValue string, systemPressureStallTypeAttributeValue string, systemPressureWindowAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("system.pressure.resource", systemPressureResourceAttributeValue)
+	dp.Attributes().PutStr("system.pressure.stall.type", systemPressureStallTypeAttributeValue)
+	dp.Attributes().PutStr("system.pressure.window", systemPressureWindowAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemPressureStallAverage) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemPressureStallAverage) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemPressureStallAverage(cfg MetricConfig) metricSystemPressureStallAverage {
+	m := metricSystemPressureStallAverage{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemPressureStallTime struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills system.pressure.stall.time metric with initial data.
+func (m *metricSystemPressureStallTime) init() {
+	m.data.SetName("system.pressure.stall.time")
+	m.data.SetDescription("The total time some or all tasks were stalled on the resource.")
+	m.data.SetUnit("s")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemPressureStallTime) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, systemPressureResourceAttributeValue string, systemPressureStallTypeAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("system.pressure.resource", systemPressureResourceAttributeValue)
+	dp.Attributes().PutStr("system.pressure.stall.type", systemPressureStallTypeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemPressureStallTime) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemPressureStallTime) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemPressureStallTime(cfg MetricConfig) metricSystemPressureStallTime {
+	m := metricSystemPressureStallTime{config: cfg}
+
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user config.
+type MetricsBuilder struct {
+	config                                 MetricsBuilderConfig // config of the metrics builder.
+	startTime                              pcommon.Timestamp    // start time that will be applied to all recorded data points.
+	metricsCapacity                        int                  // maximum observed number of metrics per resource.
+	metricsBuffer                          pmetric.Metrics      // accumulates metrics data before emitting.
+	buildInfo                              component.BuildInfo  // contains version information.
+	resourceAttributeIncludeFilter         map[string]filter.Filter
+	resourceAttributeExcludeFilter         map[string]filter.Filter
+	metricSystemCgroupCPUThrottledPeriods  metricSystemCgroupCPUThrottledPeriods
+	metricSystemCgroupCPUThrottledTime     metricSystemCgroupCPUThrottledTime
+	metricSystemCgroupCPUTime              metricSystemCgroupCPUTime
+	metricSystemCgroupIoBytes              metricSystemCgroupIoBytes
+	metricSystemCgroupIoOperations         metricSystemCgroupIoOperations
+	metricSystemCgroupMemoryEvents         metricSystemCgroupMemoryEvents
+	metricSystemCgroupMemoryUsage          metricSystemCgroupMemoryUsage
+	metricSystemCgroupPressureStallAverage metricSystemCgroupPressureStallAverage
+	metricSystemCgroupPressureStallTime    metricSystemCgroupPressureStallTime
+	metricSystemPressureStallAverage       metricSystemPressureStallAverage
+	metricSystemPressureStallTime          metricSystemPressureStallTime
+}
+
+// MetricBuilderOption applies changes to default metrics builder.
+type MetricBuilderOption interface {
+	apply(*MetricsBuilder)
+}
+
+type metricBuilderOptionFunc func(mb *MetricsBuilder)
+
+func (mbof metricBuilderOptionFunc) apply(mb *MetricsBuilder) {
+	mbof(mb)
+}
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) MetricBuilderOption {
+	return metricBuilderOptionFunc(func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	})
+}
+func NewMetricsBuilder(mbc MetricsBuilderConfig, settings scraper.Settings, options ...MetricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		config:                                 mbc,
+		startTime:                              pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                          pmetric.NewMetrics(),
+		buildInfo:                              settings.BuildInfo,
+		metricSystemCgroupCPUThrottledPeriods:  newMetricSystemCgroupCPUThrottledPeriods(mbc.Metrics.SystemCgroupCPUThrottledPeriods),
+		metricSystemCgroupCPUThrottledTime:     newMetricSystemCgroupCPUThrottledTime(mbc.Metrics.SystemCgroupCPUThrottledTime),
+		metricSystemCgroupCPUTime:              newMetricSystemCgroupCPUTime(mbc.Metrics.SystemCgroupCPUTime),
+		metricSystemCgroupIoBytes:              newMetricSystemCgroupIoBytes(mbc.Metrics.SystemCgroupIoBytes),
+		metricSystemCgroupIoOperations:         newMetricSystemCgroupIoOperations(mbc.Metrics.SystemCgroupIoOperations),
+		metricSystemCgroupMemoryEvents:         newMetricSystemCgroupMemoryEvents(mbc.Metrics.SystemCgroupMemoryEvents),
+		metricSystemCgroupMemoryUsage:          newMetricSystemCgroupMemoryUsage(mbc.Metrics.SystemCgroupMemoryUsage),
+		metricSystemCgroupPressureStallAverage: newMetricSystemCgroupPressureStallAverage(mbc.Metrics.SystemCgroupPressureStallAverage),
+		metricSystemCgroupPressureStallTime:    newMetricSystemCgroupPressureStallTime(mbc.Metrics.SystemCgroupPressureStallTime),
+		metricSystemPressureStallAverage:       newMetricSystemPressureStallAverage(mbc.Metrics.SystemPressureStallAverage),
+		metricSystemPressureStallTime:          newMetricSystemPressureStallTime(mbc.Metrics.SystemPressureStallTime),
+		resourceAttributeIncludeFilter:         make(map[string]filter.Filter),
+		resourceAttributeExcludeFilter:         make(map[string]filter.Filter),
+	}
+	if mbc.ResourceAttributes.SystemCgroupPath.MetricsInclude != nil {
+		mb.resourceAttributeIncludeFilter["system.cgroup.path"] = filter.CreateFilter(mbc.ResourceAttributes.SystemCgroupPath.MetricsInclude)
+	}
+	if mbc.ResourceAttributes.SystemCgroupPath.MetricsExclude != nil {
+		mb.resourceAttributeExcludeFilter["system.cgroup.path"] = filter.CreateFilter(mbc.ResourceAttributes.SystemCgroupPath.MetricsExclude)
+	}
+
+	for _, op := range options {
+		op.apply(mb)
+	}
+	return mb
+}
+
+// NewResourceBuilder returns a new resource builder that should be used to build a resource associated with for the emitted metrics.
+func (mb *MetricsBuilder) NewResourceBuilder() *ResourceBuilder {
+	return NewResourceBuilder(mb.config.ResourceAttributes)
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption interface {
+	apply(pmetric.ResourceMetrics)
+}
+
+type resourceMetricsOptionFunc func(pmetric.ResourceMetrics)
+
+func (rmof resourceMetricsOptionFunc) apply(rm pmetric.ResourceMetrics) {
+	rmof(rm)
+}
+
+// WithResource sets the provided resource on the emitted ResourceMetrics.
+// It's recommended to use ResourceBuilder to create the resource.
+func WithResource(res pcommon.Resource) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		res.CopyTo(rm.Resource())
+	})
+}
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return resourceMetricsOptionFunc(func(rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	})
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(options ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	rm.SetSchemaUrl(conventions.SchemaURL)
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName(ScopeName)
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricSystemCgroupCPUThrottledPeriods.emit(ils.Metrics())
+	mb.metricSystemCgroupCPUThrottledTime.emit(ils.Metrics())
+	mb.metricSystemCgroupCPUTime.emit(ils.Metrics())
+	mb.metricSystemCgroupIoBytes.emit(ils.Metrics())
+	mb.metricSystemCgroupIoOperations.emit(ils.Metrics())
+	mb.metricSystemCgroupMemoryEvents.emit(ils.Metrics())
+	mb.metricSystemCgroupMemoryUsage.emit(ils.Metrics())
+	mb.metricSystemCgroupPressureStallAverage.emit(ils.Metrics())
+	mb.metricSystemCgroupPressureStallTime.emit(ils.Metrics())
+	mb.metricSystemPressureStallAverage.emit(ils.Metrics())
+	mb.metricSystemPressureStallTime.emit(ils.Metrics())
+
+	for _, op := range options {
+		op.apply(rm)
+	}
+	for attr, filter := range mb.resourceAttributeIncludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && !filter.Matches(val.AsString()) {
+			return
+		}
+	}
+	for attr, filter := range mb.resourceAttributeExcludeFilter {
+		if val, ok := rm.Resource().Attributes().Get(attr); ok && filter.Matches(val.AsString()) {
+			return
+		}
+	}
+
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user config, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(options ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(options...)
+	metrics := mb.metricsBuffer
+	mb.metricsBuffer = pmetric.NewMetrics()
+	return metrics
+}
+
+// RecordSystemCgroupCPUThrottledPeriodsDataPoint adds a data point to system.cgroup.cpu.throttled.periods metric.
+func (mb *MetricsBuilder) RecordSystemCgroupCPUThrottledPeriodsDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricSystemCgroupCPUThrottledPeriods.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemCgroupCPUThrottledTimeDataPoint adds a data point to system.cgroup.cpu.throttled.time metric.
+func (mb *MetricsBuilder) RecordSystemCgroupCPUThrottledTimeDataPoint(ts pcommon.Timestamp, val float64) {
+	mb.metricSystemCgroupCPUThrottledTime.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemCgroupCPUTimeDataPoint adds a data point to system.cgroup.cpu.time metric.
+func (mb *MetricsBuilder) RecordSystemCgroupCPUTimeDataPoint(ts pcommon.Timestamp, val float64, cpuModeAttributeValue AttributeCPUMode) {
+	mb.metricSystemCgroupCPUTime.recordDataPoint(mb.startTime, ts, val, cpuModeAttributeValue.String())
+}
+
+// RecordSystemCgroupIoBytesDataPoint adds a data point to system.cgroup.io.bytes metric.
+func (mb *MetricsBuilder) RecordSystemCgroupIoBytesDataPoint(ts pcommon.Timestamp, val int64, systemDeviceAttributeValue string, diskIoDirectionAttributeValue AttributeDiskIoDirection) {
+	mb.metricSystemCgroupIoBytes.recordDataPoint(mb.startTime, ts, val, systemDeviceAttributeValue, diskIoDirectionAttributeValue.String())
+}
+
+// RecordSystemCgroupIoOperationsDataPoint adds a data point to system.cgroup.io.operations metric.
+func (mb *MetricsBuilder) RecordSystemCgroupIoOperationsDataPoint(ts pcommon.Timestamp, val int64, systemDeviceAttributeValue string, diskIoDirectionAttributeValue AttributeDiskIoDirection) {
+	mb.metricSystemCgroupIoOperations.recordDataPoint(mb.startTime, ts, val, systemDeviceAttributeValue, diskIoDirectionAttributeValue.String())
+}
+
+// RecordSystemCgroupMemoryEventsDataPoint adds a data point to system.cgroup.memory.events metric.
+func (mb *MetricsBuilder) RecordSystemCgroupMemoryEventsDataPoint(ts pcommon.Timestamp, val int64, systemCgroupMemoryEventAttributeValue AttributeSystemCgroupMemoryEvent) {
+	mb.metricSystemCgroupMemoryEvents.recordDataPoint(mb.startTime, ts, val, systemCgroupMemoryEventAttributeValue.String())
+}
+
+// RecordSystemCgroupMemoryUsageDataPoint adds a data point to system.cgroup.memory.usage metric.
+func (mb *MetricsBuilder) RecordSystemCgroupMemoryUsageDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricSystemCgroupMemoryUsage.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemCgroupPressureStallAverageDataPoint adds a data point to system.cgroup.pressure.stall.average metric.
+func (mb *MetricsBuilder) RecordSystemCgroupPressureStallAverageDataPoint(ts pcommon.Timestamp, val float64, systemPressureResourceAttributeValue AttributeSystemPressureResource, systemPressureStallTypeAttributeValue AttributeSystemPressureStallType, systemPressureWindowAttributeValue AttributeSystemPressureWindow) {
+	mb.metricSystemCgroupPressureStallAverage.recordDataPoint(mb.startTime, ts, val, systemPressureResourceAttributeValue.String(), systemPressureStallTypeAttributeValue.String(), systemPressureWindowAttributeValue.String())
+}
+
+// RecordSystemCgroupPressureStallTimeDataPoint adds a data point to system.cgroup.pressure.stall.time metric.
+func (mb *MetricsBuilder) RecordSystemCgroupPressureStallTimeDataPoint(ts pcommon.Timestamp, val float64, systemPressureResourceAttributeValue AttributeSystemPressureResource, systemPressureStallTypeAttributeValue AttributeSystemPressureStallType) {
+	mb.metricSystemCgroupPressureStallTime.recordDataPoint(mb.startTime, ts, val, systemPressureResourceAttributeValue.String(), systemPressureStallTypeAttributeValue.String())
+}
+
+// RecordSystemPressureStallAverageDataPoint adds a data point to system.pressure.stall.average metric.
+func (mb *MetricsBuilder) RecordSystemPressureStallAverageDataPoint(ts pcommon.Timestamp, val float64, systemPressureResourceAttributeValue AttributeSystemPressureResource, systemPressureStallTypeAttributeValue AttributeSystemPressureStallType, systemPressureWindowAttributeValue AttributeSystemPressureWindow) {
+	mb.metricSystemPressureStallAverage.recordDataPoint(mb.startTime, ts, val, systemPressureResourceAttributeValue.String(), systemPressureStallTypeAttributeValue.String(), systemPressureWindowAttributeValue.String())
+}
+
+// RecordSystemPressureStallTimeDataPoint adds a data point to system.pressure.stall.time metric.
+func (mb *MetricsBuilder) RecordSystemPressureStallTimeDataPoint(ts pcommon.Timestamp, val float64, systemPressureResourceAttributeValue AttributeSystemPressureResource, systemPressureStallTypeAttributeValue AttributeSystemPressureStallType) {
+	mb.metricSystemPressureStallTime.recordDataPoint(mb.startTime, ts, val, systemPressureResourceAttributeValue.String(), systemPressureStallTypeAttributeValue.String())
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...MetricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op.apply(mb)
+	}
+}
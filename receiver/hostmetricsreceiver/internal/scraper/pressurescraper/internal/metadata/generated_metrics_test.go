@@ -0,0 +1,340 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type testDataSet int
+
+const (
+	testDataSetDefault testDataSet = iota
+	testDataSetAll
+	testDataSetNone
+)
+
+func TestMetricsBuilder(t *testing.T) {
+	tests := []struct {
+		name        string
+		metricsSet  testDataSet
+		resAttrsSet testDataSet
+		expectEmpty bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:        "all_set",
+			metricsSet:  testDataSetAll,
+			resAttrsSet: testDataSetAll,
+		},
+		{
+			name:        "none_set",
+			metricsSet:  testDataSetNone,
+			resAttrsSet: testDataSetNone,
+			expectEmpty: true,
+		},
+		{
+			name:        "filter_set_include",
+			resAttrsSet: testDataSetAll,
+		},
+		{
+			name:        "filter_set_exclude",
+			resAttrsSet: testDataSetAll,
+			expectEmpty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := pcommon.Timestamp(1_000_000_000)
+			ts := pcommon.Timestamp(1_000_001_000)
+			observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+			settings := scrapertest.NewNopSettings(scrapertest.NopType)
+			settings.Logger = zap.New(observedZapCore)
+			mb := NewMetricsBuilder(loadMetricsBuilderConfig(t, tt.name), settings, WithStartTime(start))
+
+			expectedWarnings := 0
+			assert.Equal(t, expectedWarnings, observedLogs.Len())
+
+			defaultMetricsCount := 0
+			allMetricsCount := 0
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemCgroupCPUThrottledPeriodsDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemCgroupCPUThrottledTimeDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemCgroupCPUTimeDataPoint(ts, 1, AttributeCPUModeUser)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemCgroupIoBytesDataPoint(ts, 1, "system.device-val", AttributeDiskIoDirectionRead)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemCgroupIoOperationsDataPoint(ts, 1, "system.device-val", AttributeDiskIoDirectionRead)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemCgroupMemoryEventsDataPoint(ts, 1, AttributeSystemCgroupMemoryEventLow)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemCgroupMemoryUsageDataPoint(ts, 1)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemCgroupPressureStallAverageDataPoint(ts, 1, AttributeSystemPressureResourceCpu, AttributeSystemPressureStallTypeSome, AttributeSystemPressureWindow10s)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemCgroupPressureStallTimeDataPoint(ts, 1, AttributeSystemPressureResourceCpu, AttributeSystemPressureStallTypeSome)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemPressureStallAverageDataPoint(ts, 1, AttributeSystemPressureResourceCpu, AttributeSystemPressureStallTypeSome, AttributeSystemPressureWindow10s)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemPressureStallTimeDataPoint(ts, 1, AttributeSystemPressureResourceCpu, AttributeSystemPressureStallTypeSome)
+
+			rb := mb.NewResourceBuilder()
+			rb.SetSystemCgroupPath("system.cgroup.path-val")
+			res := rb.Emit()
+			metrics := mb.Emit(WithResource(res))
+
+			if tt.expectEmpty {
+				assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+				return
+			}
+
+			assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+			rm := metrics.ResourceMetrics().At(0)
+			assert.Equal(t, res, rm.Resource())
+			assert.Equal(t, 1, rm.ScopeMetrics().Len())
+			ms := rm.ScopeMetrics().At(0).Metrics()
+			if tt.metricsSet == testDataSetDefault {
+				assert.Equal(t, defaultMetricsCount, ms.Len())
+			}
+			if tt.metricsSet == testDataSetAll {
+				assert.Equal(t, allMetricsCount, ms.Len())
+			}
+			validatedMetrics := make(map[string]bool)
+			for i := 0; i < ms.Len(); i++ {
+				switch ms.At(i).Name() {
+				case "system.cgroup.cpu.throttled.periods":
+					assert.False(t, validatedMetrics["system.cgroup.cpu.throttled.periods"], "Found a duplicate in the metrics slice: system.cgroup.cpu.throttled.periods")
+					validatedMetrics["system.cgroup.cpu.throttled.periods"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of enforcement periods in which the cgroup was throttled.", ms.At(i).Description())
+					assert.Equal(t, "{period}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "system.cgroup.cpu.throttled.time":
+					assert.False(t, validatedMetrics["system.cgroup.cpu.throttled.time"], "Found a duplicate in the metrics slice: system.cgroup.cpu.throttled.time")
+					validatedMetrics["system.cgroup.cpu.throttled.time"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The total time the cgroup was throttled.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+				case "system.cgroup.cpu.time":
+					assert.False(t, validatedMetrics["system.cgroup.cpu.time"], "Found a duplicate in the metrics slice: system.cgroup.cpu.time")
+					validatedMetrics["system.cgroup.cpu.time"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The CPU time consumed by the tasks of the cgroup.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("cpu.mode")
+					assert.True(t, ok)
+					assert.Equal(t, "user", attrVal.Str())
+				case "system.cgroup.io.bytes":
+					assert.False(t, validatedMetrics["system.cgroup.io.bytes"], "Found a duplicate in the metrics slice: system.cgroup.io.bytes")
+					validatedMetrics["system.cgroup.io.bytes"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of bytes read and written by the cgroup per device.", ms.At(i).Description())
+					assert.Equal(t, "By", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("system.device")
+					assert.True(t, ok)
+					assert.Equal(t, "system.device-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("disk.io.direction")
+					assert.True(t, ok)
+					assert.Equal(t, "read", attrVal.Str())
+				case "system.cgroup.io.operations":
+					assert.False(t, validatedMetrics["system.cgroup.io.operations"], "Found a duplicate in the metrics slice: system.cgroup.io.operations")
+					validatedMetrics["system.cgroup.io.operations"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of read and write operations of the cgroup per device.", ms.At(i).Description())
+					assert.Equal(t, "{operation}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("system.device")
+					assert.True(t, ok)
+					assert.Equal(t, "system.device-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("disk.io.direction")
+					assert.True(t, ok)
+					assert.Equal(t, "read", attrVal.Str())
+				case "system.cgroup.memory.events":
+					assert.False(t, validatedMetrics["system.cgroup.memory.events"], "Found a duplicate in the metrics slice: system.cgroup.memory.events")
+					validatedMetrics["system.cgroup.memory.events"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The number of memory events of the cgroup.", ms.At(i).Description())
+					assert.Equal(t, "{event}", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("system.cgroup.memory.event")
+					assert.True(t, ok)
+					assert.Equal(t, "low", attrVal.Str())
+				case "system.cgroup.memory.usage":
+					assert.False(t, validatedMetrics["system.cgroup.memory.usage"], "Found a duplicate in the metrics slice: system.cgroup.memory.usage")
+					validatedMetrics["system.cgroup.memory.usage"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The memory currently used by the cgroup and its descendants.", ms.At(i).Description())
+					assert.Equal(t, "By", ms.At(i).Unit())
+					assert.False(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+				case "system.cgroup.pressure.stall.average":
+					assert.False(t, validatedMetrics["system.cgroup.pressure.stall.average"], "Found a duplicate in the metrics slice: system.cgroup.pressure.stall.average")
+					validatedMetrics["system.cgroup.pressure.stall.average"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The percentage of time some or all tasks of the cgroup were stalled on the resource, averaged over the time window.", ms.At(i).Description())
+					assert.Equal(t, "%", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("system.pressure.resource")
+					assert.True(t, ok)
+					assert.Equal(t, "cpu", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.pressure.stall.type")
+					assert.True(t, ok)
+					assert.Equal(t, "some", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.pressure.window")
+					assert.True(t, ok)
+					assert.Equal(t, "10s", attrVal.Str())
+				case "system.cgroup.pressure.stall.time":
+					assert.False(t, validatedMetrics["system.cgroup.pressure.stall.time"], "Found a duplicate in the metrics slice: system.cgroup.pressure.stall.time")
+					validatedMetrics["system.cgroup.pressure.stall.time"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The total time some or all tasks of the cgroup were stalled on the resource.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("system.pressure.resource")
+					assert.True(t, ok)
+					assert.Equal(t, "cpu", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.pressure.stall.type")
+					assert.True(t, ok)
+					assert.Equal(t, "some", attrVal.Str())
+				case "system.pressure.stall.average":
+					assert.False(t, validatedMetrics["system.pressure.stall.average"], "Found a duplicate in the metrics slice: system.pressure.stall.average")
+					validatedMetrics["system.pressure.stall.average"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The percentage of time some or all tasks were stalled on the resource, averaged over the time window.", ms.At(i).Description())
+					assert.Equal(t, "%", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("system.pressure.resource")
+					assert.True(t, ok)
+					assert.Equal(t, "cpu", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.pressure.stall.type")
+					assert.True(t, ok)
+					assert.Equal(t, "some", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.pressure.window")
+					assert.True(t, ok)
+					assert.Equal(t, "10s", attrVal.Str())
+				case "system.pressure.stall.time":
+					assert.False(t, validatedMetrics["system.pressure.stall.time"], "Found a duplicate in the metrics slice: system.pressure.stall.time")
+					validatedMetrics["system.pressure.stall.time"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "The total time some or all tasks were stalled on the resource.", ms.At(i).Description())
+					assert.Equal(t, "s", ms.At(i).Unit())
+					assert.True(t, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("system.pressure.resource")
+					assert.True(t, ok)
+					assert.Equal(t, "cpu", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.pressure.stall.type")
+					assert.True(t, ok)
+					assert.Equal(t, "some", attrVal.Str())
+				}
+			}
+		})
+	}
+}
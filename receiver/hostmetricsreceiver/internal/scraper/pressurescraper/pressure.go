@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// stall holds a line of a pressure file, such as /proc/pressure/cpu or cpu.pressure in a cgroup:
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+type stall struct {
+	stallType string
+	averages  map[string]float64
+	// total is the total stall time in microseconds
+	total uint64
+}
+
+// ioStat holds a line of the io.stat file of a cgroup:
+//
+//	8:0 rbytes=90430464 wbytes=299008000 rios=8950 wios=1252 dbytes=50331648 dios=3021
+type ioStat struct {
+	device string
+	rbytes uint64
+	wbytes uint64
+	rios   uint64
+	wios   uint64
+}
+
+func readPressureFile(path string) ([]stall, error) {
+	var stalls []stall
+	err := scanFile(path, func(fields []string) error {
+		if len(fields) < 2 {
+			return fmt.Errorf("invalid line in %s: %v", path, fields)
+		}
+		s := stall{stallType: fields[0], averages: map[string]float64{}}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return fmt.Errorf("invalid field %q in %s", field, path)
+			}
+			var err error
+			if key == "total" {
+				s.total, err = strconv.ParseUint(value, 10, 64)
+			} else {
+				s.averages[strings.TrimPrefix(key, "avg")+"s"], err = strconv.ParseFloat(value, 64)
+			}
+			if err != nil {
+				return fmt.Errorf("invalid field %q in %s: %w", field, path, err)
+			}
+		}
+		stalls = append(stalls, s)
+		return nil
+	})
+	return stalls, err
+}
+
+// readKeyValueFile reads a flat keyed file such as cpu.stat or memory.events
+func readKeyValueFile(path string) (map[string]uint64, error) {
+	values := map[string]uint64{}
+	err := scanFile(path, func(fields []string) error {
+		if len(fields) != 2 {
+			return fmt.Errorf("invalid line in %s: %v", path, fields)
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid value of %s in %s: %w", fields[0], path, err)
+		}
+		values[fields[0]] = value
+		return nil
+	})
+	return values, err
+}
+
+// readSingleValueFile reads a file holding a single value such as memory.current
+func readSingleValueFile(path string) (uint64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value in %s: %w", path, err)
+	}
+	return value, nil
+}
+
+func readIOStatFile(path string) ([]ioStat, error) {
+	var stats []ioStat
+	err := scanFile(path, func(fields []string) error {
+		stat := ioStat{device: fields[0]}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return fmt.Errorf("invalid field %q in %s", field, path)
+			}
+			var dst *uint64
+			switch key {
+			case "rbytes":
+				dst = &stat.rbytes
+			case "wbytes":
+				dst = &stat.wbytes
+			case "rios":
+				dst = &stat.rios
+			case "wios":
+				dst = &stat.wios
+			default:
+				continue
+			}
+			var err error
+			if *dst, err = strconv.ParseUint(value, 10, 64); err != nil {
+				return fmt.Errorf("invalid field %q in %s: %w", field, path, err)
+			}
+		}
+		stats = append(stats, stat)
+		return nil
+	})
+	return stats, err
+}
+
+// scanFile calls parseLine with the whitespace separated fields of each non empty line of the file
+func scanFile(path string, parseLine func(fields []string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if err := parseLine(fields); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/gopsutilenv"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+const (
+	// 2 pressure metrics per resource
+	pressureMetricsLen = 2
+	// 9 cgroup metrics
+	cgroupMetricsLen = 9
+
+	microsecondsPerSecond = 1e6
+)
+
+var pressureResources = []string{"cpu", "memory", "io"}
+
+// pressureScraper for Pressure Metrics
+type pressureScraper struct {
+	settings  scraper.Settings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+}
+
+// newPressureScraper creates a metric scraper for the pressure stall information and the cgroups
+func newPressureScraper(settings scraper.Settings, cfg *Config) (*pressureScraper, error) {
+	s := &pressureScraper{settings: settings, config: cfg}
+
+	var err error
+
+	if len(cfg.Cgroups.Include.Paths) > 0 {
+		s.includeFS, err = filterset.CreateFilterSet(cfg.Cgroups.Include.Paths, &cfg.Cgroups.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Cgroups.Exclude.Paths) > 0 {
+		s.excludeFS, err = filterset.CreateFilterSet(cfg.Cgroups.Exclude.Paths, &cfg.Cgroups.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup exclude filters: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *pressureScraper) start(context.Context, component.Host) error {
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings)
+	return nil
+}
+
+func (s *pressureScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	procPressure := gopsutilenv.GetEnvWithContext(ctx, string(common.HostProcEnvKey), "/proc", "pressure")
+	for _, resource := range pressureResources {
+		stalls, err := readPressureFile(filepath.Join(procPressure, resource))
+		if err != nil {
+			errs.AddPartial(pressureMetricsLen, err)
+			continue
+		}
+		s.recordPressureMetrics(now, resource, stalls)
+	}
+	s.mb.EmitForResource()
+
+	if s.config.Cgroups.Enabled {
+		s.scrapeCgroups(ctx, now, &errs)
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *pressureScraper) recordPressureMetrics(now pcommon.Timestamp, resource string, stalls []stall) {
+	resourceAttr := metadata.MapAttributeSystemPressureResource[resource]
+	for _, st := range stalls {
+		stallTypeAttr, ok := metadata.MapAttributeSystemPressureStallType[st.stallType]
+		if !ok {
+			continue
+		}
+		s.mb.RecordSystemPressureStallTimeDataPoint(now, float64(st.total)/microsecondsPerSecond, resourceAttr, stallTypeAttr)
+		for window, average := range st.averages {
+			if windowAttr, ok := metadata.MapAttributeSystemPressureWindow[window]; ok {
+				s.mb.RecordSystemPressureStallAverageDataPoint(now, average, resourceAttr, stallTypeAttr, windowAttr)
+			}
+		}
+	}
+}
+
+// scrapeCgroups walks the cgroups under the configured root and records the metrics of the ones matching the filters
+func (s *pressureScraper) scrapeCgroups(ctx context.Context, now pcommon.Timestamp, errs *scrapererror.ScrapeErrors) {
+	hierarchyRoot := gopsutilenv.GetEnvWithContext(ctx, string(common.HostSysEnvKey), "/sys", "fs", "cgroup")
+	root := filepath.Join(hierarchyRoot, s.config.Cgroups.Root)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// cgroups are removed while they are walked when their processes exit, which doesn't
+			// prevent the other cgroups from being scraped
+			if path != root && errors.Is(err, fs.ErrNotExist) {
+				if d != nil && d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(hierarchyRoot, path)
+		if err != nil {
+			return err
+		}
+		cgroupPath := filepath.Join("/", rel)
+		if !s.includeCgroup(cgroupPath) {
+			return nil
+		}
+
+		s.recordCgroupMetrics(now, path, errs)
+		rb := s.mb.NewResourceBuilder()
+		rb.SetSystemCgroupPath(cgroupPath)
+		s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+		return nil
+	})
+	if err != nil {
+		errs.AddPartial(cgroupMetricsLen, fmt.Errorf("failed to walk the cgroups: %w", err))
+	}
+}
+
+func (s *pressureScraper) includeCgroup(cgroupPath string) bool {
+	return (s.includeFS == nil || s.includeFS.Matches(cgroupPath)) &&
+		(s.excludeFS == nil || !s.excludeFS.Matches(cgroupPath))
+}
+
+// recordCgroupMetrics records the metrics of the cgroup directory.
+// Missing files are ignored since they depend on the controllers enabled for the cgroup.
+func (s *pressureScraper) recordCgroupMetrics(now pcommon.Timestamp, dir string, errs *scrapererror.ScrapeErrors) {
+	addErr := func(metricsLen int, err error) {
+		if !errors.Is(err, os.ErrNotExist) {
+			errs.AddPartial(metricsLen, err)
+		}
+	}
+
+	for _, resource := range pressureResources {
+		stalls, err := readPressureFile(filepath.Join(dir, resource+".pressure"))
+		if err != nil {
+			addErr(pressureMetricsLen, err)
+			continue
+		}
+		resourceAttr := metadata.MapAttributeSystemPressureResource[resource]
+		for _, st := range stalls {
+			stallTypeAttr, ok := metadata.MapAttributeSystemPressureStallType[st.stallType]
+			if !ok {
+				continue
+			}
+			s.mb.RecordSystemCgroupPressureStallTimeDataPoint(now, float64(st.total)/microsecondsPerSecond, resourceAttr, stallTypeAttr)
+			for window, average := range st.averages {
+				if windowAttr, ok := metadata.MapAttributeSystemPressureWindow[window]; ok {
+					s.mb.RecordSystemCgroupPressureStallAverageDataPoint(now, average, resourceAttr, stallTypeAttr, windowAttr)
+				}
+			}
+		}
+	}
+
+	if cpuStat, err := readKeyValueFile(filepath.Join(dir, "cpu.stat")); err == nil {
+		s.mb.RecordSystemCgroupCPUTimeDataPoint(now, float64(cpuStat["user_usec"])/microsecondsPerSecond, metadata.AttributeCPUModeUser)
+		s.mb.RecordSystemCgroupCPUTimeDataPoint(now, float64(cpuStat["system_usec"])/microsecondsPerSecond, metadata.AttributeCPUModeSystem)
+		// The throttling statistics are only reported when the cpu controller is enabled
+		if nrThrottled, ok := cpuStat["nr_throttled"]; ok {
+			s.mb.RecordSystemCgroupCPUThrottledPeriodsDataPoint(now, int64(nrThrottled))
+			s.mb.RecordSystemCgroupCPUThrottledTimeDataPoint(now, float64(cpuStat["throttled_usec"])/microsecondsPerSecond)
+		}
+	} else {
+		addErr(3, err)
+	}
+
+	if memoryCurrent, err := readSingleValueFile(filepath.Join(dir, "memory.current")); err == nil {
+		s.mb.RecordSystemCgroupMemoryUsageDataPoint(now, int64(memoryCurrent))
+	} else {
+		addErr(1, err)
+	}
+
+	if memoryEvents, err := readKeyValueFile(filepath.Join(dir, "memory.events")); err == nil {
+		for event, count := range memoryEvents {
+			if eventAttr, ok := metadata.MapAttributeSystemCgroupMemoryEvent[event]; ok {
+				s.mb.RecordSystemCgroupMemoryEventsDataPoint(now, int64(count), eventAttr)
+			}
+		}
+	} else {
+		addErr(1, err)
+	}
+
+	if ioStats, err := readIOStatFile(filepath.Join(dir, "io.stat")); err == nil {
+		for _, stat := range ioStats {
+			s.mb.RecordSystemCgroupIoBytesDataPoint(now, int64(stat.rbytes), stat.device, metadata.AttributeDiskIoDirectionRead)
+			s.mb.RecordSystemCgroupIoBytesDataPoint(now, int64(stat.wbytes), stat.device, metadata.AttributeDiskIoDirectionWrite)
+			s.mb.RecordSystemCgroupIoOperationsDataPoint(now, int64(stat.rios), stat.device, metadata.AttributeDiskIoDirectionRead)
+			s.mb.RecordSystemCgroupIoOperationsDataPoint(now, int64(stat.wios), stat.device, metadata.AttributeDiskIoDirectionWrite)
+		}
+	} else {
+		addErr(2, err)
+	}
+}
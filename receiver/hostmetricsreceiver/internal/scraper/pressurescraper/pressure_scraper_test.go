@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+// newTestContext returns a context reading the proc and sys files from the root path
+func newTestContext(t *testing.T, rootPath string) context.Context {
+	return context.WithValue(t.Context(), common.EnvKey, common.EnvMap{
+		common.HostProcEnvKey: filepath.Join(rootPath, "proc"),
+		common.HostSysEnvKey:  filepath.Join(rootPath, "sys"),
+	})
+}
+
+func scrape(t *testing.T, ctx context.Context, cfg *Config) (pmetric.Metrics, error) {
+	t.Helper()
+	s, err := newPressureScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NoError(t, s.start(ctx, componenttest.NewNopHost()))
+	return s.scrape(ctx)
+}
+
+// dataPoints returns the values of the data points of the metric by their attributes
+func dataPoints(t *testing.T, rm pmetric.ResourceMetrics, name string) map[string]float64 {
+	t.Helper()
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		if metric.Name() != name {
+			continue
+		}
+		var dps pmetric.NumberDataPointSlice
+		if metric.Type() == pmetric.MetricTypeGauge {
+			dps = metric.Gauge().DataPoints()
+		} else {
+			dps = metric.Sum().DataPoints()
+		}
+		values := map[string]float64{}
+		for j := 0; j < dps.Len(); j++ {
+			key := ""
+			for _, k := range []string{"system.pressure.resource", "system.pressure.stall.type", "system.pressure.window", "cpu.mode", "system.device", "disk.io.direction", "system.cgroup.memory.event"} {
+				if v, ok := dps.At(j).Attributes().Get(k); ok {
+					key += "/" + v.Str()
+				}
+			}
+			if dps.At(j).ValueType() == pmetric.NumberDataPointValueTypeInt {
+				values[key] = float64(dps.At(j).IntValue())
+			} else {
+				values[key] = dps.At(j).DoubleValue()
+			}
+		}
+		return values
+	}
+	t.Fatalf("metric %s not found", name)
+	return nil
+}
+
+func cgroupPath(rm pmetric.ResourceMetrics) string {
+	path, _ := rm.Resource().Attributes().Get("system.cgroup.path")
+	return path.Str()
+}
+
+func TestScrape(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	metrics, err := scrape(t, newTestContext(t, "testdata"), cfg)
+	require.NoError(t, err)
+
+	// The cgroups are not scraped by default
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	rm := metrics.ResourceMetrics().At(0)
+	assert.Equal(t, 0, rm.Resource().Attributes().Len())
+	assert.Equal(t, 2, rm.ScopeMetrics().At(0).Metrics().Len())
+
+	assert.Equal(t, map[string]float64{
+		"/cpu/some":    123.456789,
+		"/cpu/full":    0,
+		"/memory/some": 2,
+		"/memory/full": 1,
+		"/io/some":     4,
+		"/io/full":     3,
+	}, dataPoints(t, rm, "system.pressure.stall.time"))
+
+	averages := dataPoints(t, rm, "system.pressure.stall.average")
+	assert.Len(t, averages, 18)
+	assert.Equal(t, 1.5, averages["/cpu/some/10s"])
+	assert.Equal(t, 0.75, averages["/cpu/some/60s"])
+	assert.Equal(t, 0.25, averages["/cpu/some/300s"])
+	assert.Equal(t, 2.5, averages["/io/full/60s"])
+}
+
+func TestScrapeCgroups(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Cgroups.Enabled = true
+	cfg.Cgroups.Exclude = MatchConfig{
+		Config: filterset.Config{MatchType: filterset.Strict},
+		Paths:  []string{"/user.slice"},
+	}
+	metrics, err := scrape(t, newTestContext(t, "testdata"), cfg)
+	require.NoError(t, err)
+
+	// The host metrics followed by the metrics of the root cgroup and the nginx service,
+	// the system slice has no statistics and the user slice is excluded
+	require.Equal(t, 3, metrics.ResourceMetrics().Len())
+	assert.Empty(t, cgroupPath(metrics.ResourceMetrics().At(0)))
+	assert.Equal(t, "/", cgroupPath(metrics.ResourceMetrics().At(1)))
+	assert.Equal(t, "/system.slice/nginx.service", cgroupPath(metrics.ResourceMetrics().At(2)))
+
+	root := metrics.ResourceMetrics().At(1)
+	assert.Equal(t, map[string]float64{"/user": 600, "/system": 300}, dataPoints(t, root, "system.cgroup.cpu.time"))
+	assert.Equal(t, map[string]float64{"/8:0/read": 1048576, "/8:0/write": 2097152}, dataPoints(t, root, "system.cgroup.io.bytes"))
+
+	nginx := metrics.ResourceMetrics().At(2)
+	assert.Equal(t, 9, nginx.ScopeMetrics().At(0).Metrics().Len())
+	assert.Equal(t, map[string]float64{"/user": 2.5, "/system": 1}, dataPoints(t, nginx, "system.cgroup.cpu.time"))
+	assert.Equal(t, map[string]float64{"": 25}, dataPoints(t, nginx, "system.cgroup.cpu.throttled.periods"))
+	assert.Equal(t, map[string]float64{"": 0.75}, dataPoints(t, nginx, "system.cgroup.cpu.throttled.time"))
+	assert.Equal(t, map[string]float64{"": 104857600}, dataPoints(t, nginx, "system.cgroup.memory.usage"))
+	assert.Equal(t, map[string]float64{
+		"/low":      0,
+		"/high":     3,
+		"/max":      1,
+		"/oom":      1,
+		"/oom_kill": 1,
+	}, dataPoints(t, nginx, "system.cgroup.memory.events"))
+	assert.Equal(t, map[string]float64{
+		"/8:0/read":    1,
+		"/8:0/write":   2,
+		"/259:0/read":  4,
+		"/259:0/write": 0,
+	}, dataPoints(t, nginx, "system.cgroup.io.operations"))
+	assert.Equal(t, map[string]float64{
+		"/cpu/some":    50,
+		"/cpu/full":    40,
+		"/memory/some": 0.0015,
+		"/memory/full": 0.001,
+		"/io/some":     0,
+		"/io/full":     0,
+	}, dataPoints(t, nginx, "system.cgroup.pressure.stall.time"))
+	assert.Equal(t, 12.5, dataPoints(t, nginx, "system.cgroup.pressure.stall.average")["/cpu/some/10s"])
+}
+
+func TestScrapeCgroupsRootAndInclude(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Cgroups.Enabled = true
+	cfg.Cgroups.Root = "/system.slice"
+	cfg.Cgroups.Include = MatchConfig{
+		Config: filterset.Config{MatchType: filterset.Regexp},
+		Paths:  []string{`\.service$`},
+	}
+	metrics, err := scrape(t, newTestContext(t, "testdata"), cfg)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, metrics.ResourceMetrics().Len())
+	assert.Equal(t, "/system.slice/nginx.service", cgroupPath(metrics.ResourceMetrics().At(1)))
+}
+
+// removingFilterSet removes the directory of the cgroups it matches, as if they were
+// removed while they are walked
+type removingFilterSet struct {
+	hierarchyRoot string
+}
+
+func (f removingFilterSet) Matches(cgroupPath string) bool {
+	if cgroupPath == "/system.slice/nginx.service" {
+		_ = os.RemoveAll(filepath.Join(f.hierarchyRoot, cgroupPath))
+	}
+	return true
+}
+
+func TestScrapeCgroupsRemovedDuringWalk(t *testing.T) {
+	rootPath := t.TempDir()
+	require.NoError(t, os.CopyFS(rootPath, os.DirFS("testdata")))
+	ctx := newTestContext(t, rootPath)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Cgroups.Enabled = true
+	s, err := newPressureScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	s.includeFS = removingFilterSet{hierarchyRoot: filepath.Join(rootPath, "sys", "fs", "cgroup")}
+	require.NoError(t, s.start(ctx, componenttest.NewNopHost()))
+
+	metrics, err := s.scrape(ctx)
+	require.NoError(t, err)
+
+	// the cgroups walked after the removed cgroup are still scraped
+	rms := metrics.ResourceMetrics()
+	assert.Equal(t, "/user.slice", cgroupPath(rms.At(rms.Len()-1)))
+}
+
+func TestScrapeErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Cgroups.Enabled = true
+	metrics, err := scrape(t, newTestContext(t, filepath.Join("testdata", "invalid")), cfg)
+
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.ErrorContains(t, err, `invalid field "avg10=not_a_number"`)
+	assert.ErrorContains(t, err, "failed to walk the cgroups")
+	assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+}
+
+func TestNewPressureScraperInvalidFilter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Cgroups.Include = MatchConfig{
+		Config: filterset.Config{MatchType: filterset.Regexp},
+		Paths:  []string{"("},
+	}
+	_, err := newPressureScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+	assert.ErrorContains(t, err, "error creating cgroup include filters")
+}
@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sensorsscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper/internal/metadata"
+)
+
+// Config relating to Sensors Metric Scraper.
+type Config struct {
+	// MetricsBuilderConfig allows to customize scraped metrics/attributes representation.
+	metadata.MetricsBuilderConfig `mapstructure:",squash"`
+
+	// Include specifies a filter on the chips that should be included from the generated metrics.
+	// Exclude specifies a filter on the chips that should be excluded from the generated metrics.
+	// If neither `include` or `exclude` are set, metrics will be generated for all chips.
+	// Batteries are not filtered.
+	Include MatchConfig `mapstructure:"include"`
+	Exclude MatchConfig `mapstructure:"exclude"`
+}
+
+type MatchConfig struct {
+	filterset.Config `mapstructure:",squash"`
+
+	Chips []string `mapstructure:"chips"`
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sensorsscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper"
+
+import (
+	"context"
+	"errors"
+	"runtime"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/scraper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper/internal/metadata"
+)
+
+var (
+	supportedOS      = runtime.GOOS == "linux"
+	errUnsupportedOS = errors.New("the sensors scraper is only available on Linux")
+)
+
+// NewFactory for Sensors scraper.
+func NewFactory() scraper.Factory {
+	return scraper.NewFactory(metadata.Type, createDefaultConfig, scraper.WithMetrics(createMetricsScraper, metadata.MetricsStability))
+}
+
+// createDefaultConfig creates the default configuration for the Scraper.
+func createDefaultConfig() component.Config {
+	return &Config{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+	}
+}
+
+// createMetricsScraper creates a resource scraper based on provided config.
+func createMetricsScraper(
+	_ context.Context,
+	settings scraper.Settings,
+	cfg component.Config,
+) (scraper.Metrics, error) {
+	if !supportedOS {
+		return nil, errUnsupportedOS
+	}
+
+	sensorsScraper, err := newSensorsScraper(settings, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+
+	return scraper.NewMetrics(
+		sensorsScraper.scrape,
+		scraper.WithStart(sensorsScraper.start),
+	)
+}
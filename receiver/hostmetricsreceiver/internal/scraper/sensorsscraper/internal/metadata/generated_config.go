@@ -0,0 +1,79 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+
+	err := parser.Unmarshal(ms)
+	if err != nil {
+		return err
+	}
+
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for sensors metrics.
+type MetricsConfig struct {
+	SystemBatteryCharge          MetricConfig `mapstructure:"system.battery.charge"`
+	SystemBatteryPower           MetricConfig `mapstructure:"system.battery.power"`
+	SystemBatteryVoltage         MetricConfig `mapstructure:"system.battery.voltage"`
+	SystemSensorFanSpeed         MetricConfig `mapstructure:"system.sensor.fan.speed"`
+	SystemSensorPower            MetricConfig `mapstructure:"system.sensor.power"`
+	SystemSensorTemperature      MetricConfig `mapstructure:"system.sensor.temperature"`
+	SystemSensorTemperatureLimit MetricConfig `mapstructure:"system.sensor.temperature.limit"`
+	SystemSensorVoltage          MetricConfig `mapstructure:"system.sensor.voltage"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		SystemBatteryCharge: MetricConfig{
+			Enabled: true,
+		},
+		SystemBatteryPower: MetricConfig{
+			Enabled: true,
+		},
+		SystemBatteryVoltage: MetricConfig{
+			Enabled: true,
+		},
+		SystemSensorFanSpeed: MetricConfig{
+			Enabled: true,
+		},
+		SystemSensorPower: MetricConfig{
+			Enabled: true,
+		},
+		SystemSensorTemperature: MetricConfig{
+			Enabled: true,
+		},
+		SystemSensorTemperatureLimit: MetricConfig{
+			Enabled: false,
+		},
+		SystemSensorVoltage: MetricConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for sensors metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics MetricsConfig `mapstructure:"metrics"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics: DefaultMetricsConfig(),
+	}
+}
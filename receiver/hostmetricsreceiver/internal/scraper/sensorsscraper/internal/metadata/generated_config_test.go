@@ -0,0 +1,73 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestMetricsBuilderConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		want MetricsBuilderConfig
+	}{
+		{
+			name: "default",
+			want: DefaultMetricsBuilderConfig(),
+		},
+		{
+			name: "all_set",
+			want: MetricsBuilderConfig{
+				Metrics: MetricsConfig{
+					SystemBatteryCharge:          MetricConfig{Enabled: true},
+					SystemBatteryPower:           MetricConfig{Enabled: true},
+					SystemBatteryVoltage:         MetricConfig{Enabled: true},
+					SystemSensorFanSpeed:         MetricConfig{Enabled: true},
+					SystemSensorPower:            MetricConfig{Enabled: true},
+					SystemSensorTemperature:      MetricConfig{Enabled: true},
+					SystemSensorTemperatureLimit: MetricConfig{Enabled: true},
+					SystemSensorVoltage:          MetricConfig{Enabled: true},
+				},
+			},
+		},
+		{
+			name: "none_set",
+			want: MetricsBuilderConfig{
+				Metrics: MetricsConfig{
+					SystemBatteryCharge:          MetricConfig{Enabled: false},
+					SystemBatteryPower:           MetricConfig{Enabled: false},
+					SystemBatteryVoltage:         MetricConfig{Enabled: false},
+					SystemSensorFanSpeed:         MetricConfig{Enabled: false},
+					SystemSensorPower:            MetricConfig{Enabled: false},
+					SystemSensorTemperature:      MetricConfig{Enabled: false},
+					SystemSensorTemperatureLimit: MetricConfig{Enabled: false},
+					SystemSensorVoltage:          MetricConfig{Enabled: false},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadMetricsBuilderConfig(t, tt.name)
+			diff := cmp.Diff(tt.want, cfg, cmpopts.IgnoreUnexported(MetricConfig{}))
+			require.Emptyf(t, diff, "Config mismatch (-expected +actual):\n%s", diff)
+		})
+	}
+}
+
+func loadMetricsBuilderConfig(t *testing.T, name string) MetricsBuilderConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	cfg := DefaultMetricsBuilderConfig()
+	require.NoError(t, sub.Unmarshal(&cfg, confmap.WithIgnoreUnused()))
+	return cfg
+}
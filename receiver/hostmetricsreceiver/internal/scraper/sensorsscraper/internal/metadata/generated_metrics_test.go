@@ -0,0 +1,274 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type testDataSet int
+
+const (
+	testDataSetDefault testDataSet = iota
+	testDataSetAll
+	testDataSetNone
+)
+
+func TestMetricsBuilder(t *testing.T) {
+	tests := []struct {
+		name        string
+		metricsSet  testDataSet
+		resAttrsSet testDataSet
+		expectEmpty bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:        "all_set",
+			metricsSet:  testDataSetAll,
+			resAttrsSet: testDataSetAll,
+		},
+		{
+			name:        "none_set",
+			metricsSet:  testDataSetNone,
+			resAttrsSet: testDataSetNone,
+			expectEmpty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := pcommon.Timestamp(1_000_000_000)
+			ts := pcommon.Timestamp(1_000_001_000)
+			observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+			settings := scrapertest.NewNopSettings(scrapertest.NopType)
+			settings.Logger = zap.New(observedZapCore)
+			mb := NewMetricsBuilder(loadMetricsBuilderConfig(t, tt.name), settings, WithStartTime(start))
+
+			expectedWarnings := 0
+			assert.Equal(t, expectedWarnings, observedLogs.Len())
+
+			defaultMetricsCount := 0
+			allMetricsCount := 0
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemBatteryChargeDataPoint(ts, 1, "system.battery.name-val", AttributeSystemBatteryStateCharging)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemBatteryPowerDataPoint(ts, 1, "system.battery.name-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemBatteryVoltageDataPoint(ts, 1, "system.battery.name-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemSensorFanSpeedDataPoint(ts, 1, "system.sensor.chip-val", "system.sensor.device-val", "system.sensor.label-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemSensorPowerDataPoint(ts, 1, "system.sensor.chip-val", "system.sensor.device-val", "system.sensor.label-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemSensorTemperatureDataPoint(ts, 1, "system.sensor.chip-val", "system.sensor.device-val", "system.sensor.label-val")
+
+			allMetricsCount++
+			mb.RecordSystemSensorTemperatureLimitDataPoint(ts, 1, "system.sensor.chip-val", "system.sensor.device-val", "system.sensor.label-val", AttributeSystemSensorTemperatureLimitTypeMax)
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordSystemSensorVoltageDataPoint(ts, 1, "system.sensor.chip-val", "system.sensor.device-val", "system.sensor.label-val")
+
+			res := pcommon.NewResource()
+			metrics := mb.Emit(WithResource(res))
+
+			if tt.expectEmpty {
+				assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+				return
+			}
+
+			assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+			rm := metrics.ResourceMetrics().At(0)
+			assert.Equal(t, res, rm.Resource())
+			assert.Equal(t, 1, rm.ScopeMetrics().Len())
+			ms := rm.ScopeMetrics().At(0).Metrics()
+			if tt.metricsSet == testDataSetDefault {
+				assert.Equal(t, defaultMetricsCount, ms.Len())
+			}
+			if tt.metricsSet == testDataSetAll {
+				assert.Equal(t, allMetricsCount, ms.Len())
+			}
+			validatedMetrics := make(map[string]bool)
+			for i := 0; i < ms.Len(); i++ {
+				switch ms.At(i).Name() {
+				case "system.battery.charge":
+					assert.False(t, validatedMetrics["system.battery.charge"], "Found a duplicate in the metrics slice: system.battery.charge")
+					validatedMetrics["system.battery.charge"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The remaining charge of the battery as a fraction of its full capacity.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("system.battery.name")
+					assert.True(t, ok)
+					assert.Equal(t, "system.battery.name-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.battery.state")
+					assert.True(t, ok)
+					assert.Equal(t, "charging", attrVal.Str())
+				case "system.battery.power":
+					assert.False(t, validatedMetrics["system.battery.power"], "Found a duplicate in the metrics slice: system.battery.power")
+					validatedMetrics["system.battery.power"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The power the battery is charging or discharging at.", ms.At(i).Description())
+					assert.Equal(t, "W", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("system.battery.name")
+					assert.True(t, ok)
+					assert.Equal(t, "system.battery.name-val", attrVal.Str())
+				case "system.battery.voltage":
+					assert.False(t, validatedMetrics["system.battery.voltage"], "Found a duplicate in the metrics slice: system.battery.voltage")
+					validatedMetrics["system.battery.voltage"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The voltage of the battery.", ms.At(i).Description())
+					assert.Equal(t, "V", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("system.battery.name")
+					assert.True(t, ok)
+					assert.Equal(t, "system.battery.name-val", attrVal.Str())
+				case "system.sensor.fan.speed":
+					assert.False(t, validatedMetrics["system.sensor.fan.speed"], "Found a duplicate in the metrics slice: system.sensor.fan.speed")
+					validatedMetrics["system.sensor.fan.speed"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The rotation speed of the fan.", ms.At(i).Description())
+					assert.Equal(t, "{rpm}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("system.sensor.chip")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.chip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.sensor.device")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.device-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.sensor.label")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.label-val", attrVal.Str())
+				case "system.sensor.power":
+					assert.False(t, validatedMetrics["system.sensor.power"], "Found a duplicate in the metrics slice: system.sensor.power")
+					validatedMetrics["system.sensor.power"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The power reported by the sensor.", ms.At(i).Description())
+					assert.Equal(t, "W", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("system.sensor.chip")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.chip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.sensor.device")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.device-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.sensor.label")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.label-val", attrVal.Str())
+				case "system.sensor.temperature":
+					assert.False(t, validatedMetrics["system.sensor.temperature"], "Found a duplicate in the metrics slice: system.sensor.temperature")
+					validatedMetrics["system.sensor.temperature"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The temperature reported by the sensor.", ms.At(i).Description())
+					assert.Equal(t, "Cel", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("system.sensor.chip")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.chip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.sensor.device")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.device-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.sensor.label")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.label-val", attrVal.Str())
+				case "system.sensor.temperature.limit":
+					assert.False(t, validatedMetrics["system.sensor.temperature.limit"], "Found a duplicate in the metrics slice: system.sensor.temperature.limit")
+					validatedMetrics["system.sensor.temperature.limit"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The temperature limits of the sensor, as set by the chip or its driver.", ms.At(i).Description())
+					assert.Equal(t, "Cel", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("system.sensor.chip")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.chip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.sensor.device")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.device-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.sensor.label")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.label-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.sensor.temperature.limit.type")
+					assert.True(t, ok)
+					assert.Equal(t, "max", attrVal.Str())
+				case "system.sensor.voltage":
+					assert.False(t, validatedMetrics["system.sensor.voltage"], "Found a duplicate in the metrics slice: system.sensor.voltage")
+					validatedMetrics["system.sensor.voltage"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "The voltage reported by the sensor.", ms.At(i).Description())
+					assert.Equal(t, "V", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+					assert.InDelta(t, float64(1), dp.DoubleValue(), 0.01)
+					attrVal, ok := dp.Attributes().Get("system.sensor.chip")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.chip-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.sensor.device")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.device-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("system.sensor.label")
+					assert.True(t, ok)
+					assert.Equal(t, "system.sensor.label-val", attrVal.Str())
+				}
+			}
+		})
+	}
+}
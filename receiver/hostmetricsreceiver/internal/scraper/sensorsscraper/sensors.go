@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sensorsscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper"
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sensorFileRegexp matches the files holding the value of a hwmon sensor, such as temp1_input.
+// Some drivers only report the average power of a sensor, in a file such as power1_average.
+var sensorFileRegexp = regexp.MustCompile(`^((temp|fan|in|power)\d+)_(input|average)$`)
+
+// chip is a hardware monitoring chip of /sys/class/hwmon
+type chip struct {
+	name   string
+	device string
+	// dir is the directory holding the sensor files of the chip
+	dir string
+}
+
+// sensor is a sensor of a chip, such as temp1 or fan2
+type sensor struct {
+	name  string
+	kind  string
+	label string
+	// inputFile is the file holding the value of the sensor
+	inputFile string
+}
+
+// readChip reads the chip of a hwmon directory. Older drivers expose the name and the
+// sensor files of the chip in the device directory rather than in the hwmon directory.
+func readChip(dir string) (chip, error) {
+	c := chip{dir: dir}
+
+	name, err := readString(filepath.Join(dir, "name"))
+	if errors.Is(err, os.ErrNotExist) {
+		c.dir = filepath.Join(dir, "device")
+		name, err = readString(filepath.Join(c.dir, "name"))
+	}
+	if err != nil {
+		return chip{}, err
+	}
+	c.name = name
+
+	if target, err := os.Readlink(filepath.Join(dir, "device")); err == nil {
+		c.device = filepath.Base(target)
+	}
+	return c, nil
+}
+
+// sensors lists the sensors of the chip, labeled with their name when the chip has no label for them
+func (c chip) sensors() ([]sensor, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		files[entry.Name()] = true
+	}
+
+	var sensors []sensor
+	for _, entry := range entries {
+		match := sensorFileRegexp.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		name, kind, value := match[1], match[2], match[3]
+		if value == "average" && (kind != "power" || files[name+"_input"]) {
+			continue
+		}
+
+		s := sensor{name: name, kind: kind, label: name, inputFile: filepath.Join(c.dir, entry.Name())}
+		if label, err := readString(filepath.Join(c.dir, name+"_label")); err == nil && label != "" {
+			s.label = label
+		}
+		sensors = append(sensors, s)
+	}
+	return sensors, nil
+}
+
+func readString(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func readInt(path string) (int64, error) {
+	content, err := readString(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(content, 10, 64)
+}
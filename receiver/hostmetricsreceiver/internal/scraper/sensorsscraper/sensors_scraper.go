@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sensorsscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/gopsutilenv"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper/internal/metadata"
+)
+
+const (
+	// 5 hwmon metrics
+	hwmonMetricsLen = 5
+	// 2 thermal zone metrics
+	thermalMetricsLen = 2
+	// 3 battery metrics
+	batteryMetricsLen = 3
+
+	millisPerUnit = 1e3
+	microsPerUnit = 1e6
+)
+
+// sensorsScraper for Sensors Metrics
+type sensorsScraper struct {
+	settings  scraper.Settings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+}
+
+// newSensorsScraper creates a metric scraper for the hwmon sensors, thermal zones and batteries
+func newSensorsScraper(settings scraper.Settings, cfg *Config) (*sensorsScraper, error) {
+	s := &sensorsScraper{settings: settings, config: cfg}
+
+	var err error
+
+	if len(cfg.Include.Chips) > 0 {
+		s.includeFS, err = filterset.CreateFilterSet(cfg.Include.Chips, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating chip include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Chips) > 0 {
+		s.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Chips, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating chip exclude filters: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *sensorsScraper) start(context.Context, component.Host) error {
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings)
+	return nil
+}
+
+func (s *sensorsScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	classDir := gopsutilenv.GetEnvWithContext(ctx, string(common.HostSysEnvKey), "/sys", "class")
+	s.scrapeHwmon(now, filepath.Join(classDir, "hwmon"), &errs)
+	s.scrapeThermalZones(now, filepath.Join(classDir, "thermal"), &errs)
+	s.scrapeBatteries(now, filepath.Join(classDir, "power_supply"), &errs)
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *sensorsScraper) includeChip(name string) bool {
+	return (s.includeFS == nil || s.includeFS.Matches(name)) &&
+		(s.excludeFS == nil || !s.excludeFS.Matches(name))
+}
+
+// readClassDir lists the devices of a /sys/class directory, which doesn't exist when the kernel has no such device
+func readClassDir(dir string, metricsLen int, errs *scrapererror.ScrapeErrors) []os.DirEntry {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		errs.AddPartial(metricsLen, err)
+	}
+	return entries
+}
+
+func (s *sensorsScraper) scrapeHwmon(now pcommon.Timestamp, dir string, errs *scrapererror.ScrapeErrors) {
+	for _, entry := range readClassDir(dir, hwmonMetricsLen, errs) {
+		c, err := readChip(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			errs.AddPartial(hwmonMetricsLen, fmt.Errorf("failed to read the chip of %s: %w", entry.Name(), err))
+			continue
+		}
+		if !s.includeChip(c.name) {
+			continue
+		}
+
+		sensors, err := c.sensors()
+		if err != nil {
+			errs.AddPartial(hwmonMetricsLen, fmt.Errorf("failed to list the sensors of %s: %w", entry.Name(), err))
+			continue
+		}
+		for _, sn := range sensors {
+			s.recordSensor(now, c, sn)
+		}
+	}
+}
+
+// recordSensor records the value of the sensor. Drivers commonly fail to read the sensors
+// which are not connected, so they are skipped without reporting an error.
+func (s *sensorsScraper) recordSensor(now pcommon.Timestamp, c chip, sn sensor) {
+	value, err := readInt(sn.inputFile)
+	if err != nil {
+		s.settings.Logger.Debug("failed to read sensor", zap.String("chip", c.name), zap.String("sensor", sn.name), zap.Error(err))
+		return
+	}
+
+	switch sn.kind {
+	case "temp":
+		s.mb.RecordSystemSensorTemperatureDataPoint(now, float64(value)/millisPerUnit, c.name, c.device, sn.label)
+		if s.config.Metrics.SystemSensorTemperatureLimit.Enabled {
+			for limitType, limitAttr := range metadata.MapAttributeSystemSensorTemperatureLimitType {
+				if limit, err := readInt(filepath.Join(c.dir, sn.name+"_"+limitType)); err == nil {
+					s.mb.RecordSystemSensorTemperatureLimitDataPoint(now, float64(limit)/millisPerUnit, c.name, c.device, sn.label, limitAttr)
+				}
+			}
+		}
+	case "fan":
+		s.mb.RecordSystemSensorFanSpeedDataPoint(now, value, c.name, c.device, sn.label)
+	case "in":
+		s.mb.RecordSystemSensorVoltageDataPoint(now, float64(value)/millisPerUnit, c.name, c.device, sn.label)
+	case "power":
+		s.mb.RecordSystemSensorPowerDataPoint(now, float64(value)/microsPerUnit, c.name, c.device, sn.label)
+	}
+}
+
+// scrapeThermalZones records the temperature of the thermal zones which aren't
+// registered as a hwmon chip, since those are already reported with the hwmon sensors
+func (s *sensorsScraper) scrapeThermalZones(now pcommon.Timestamp, dir string, errs *scrapererror.ScrapeErrors) {
+	for _, entry := range readClassDir(dir, thermalMetricsLen, errs) {
+		if !strings.HasPrefix(entry.Name(), "thermal_zone") {
+			continue
+		}
+		zoneDir := filepath.Join(dir, entry.Name())
+		if hwmon, _ := filepath.Glob(filepath.Join(zoneDir, "hwmon*")); len(hwmon) > 0 {
+			continue
+		}
+
+		zoneType, err := readString(filepath.Join(zoneDir, "type"))
+		if err != nil {
+			errs.AddPartial(thermalMetricsLen, fmt.Errorf("failed to read the type of %s: %w", entry.Name(), err))
+			continue
+		}
+		if !s.includeChip(zoneType) {
+			continue
+		}
+
+		temp, err := readInt(filepath.Join(zoneDir, "temp"))
+		if err != nil {
+			s.settings.Logger.Debug("failed to read thermal zone", zap.String("zone", entry.Name()), zap.Error(err))
+			continue
+		}
+		s.mb.RecordSystemSensorTemperatureDataPoint(now, float64(temp)/millisPerUnit, zoneType, entry.Name(), "temp1")
+
+		if s.config.Metrics.SystemSensorTemperatureLimit.Enabled {
+			s.recordTripPoints(now, zoneDir, zoneType, entry.Name())
+		}
+	}
+}
+
+// recordTripPoints records the hot and critical trip points of the thermal zone as its max and crit limits
+func (s *sensorsScraper) recordTripPoints(now pcommon.Timestamp, zoneDir, zoneType, zone string) {
+	typeFiles, _ := filepath.Glob(filepath.Join(zoneDir, "trip_point_*_type"))
+	for _, typeFile := range typeFiles {
+		tripType, err := readString(typeFile)
+		if err != nil {
+			continue
+		}
+		var limitAttr metadata.AttributeSystemSensorTemperatureLimitType
+		switch tripType {
+		case "hot":
+			limitAttr = metadata.AttributeSystemSensorTemperatureLimitTypeMax
+		case "critical":
+			limitAttr = metadata.AttributeSystemSensorTemperatureLimitTypeCrit
+		default:
+			continue
+		}
+		if limit, err := readInt(strings.TrimSuffix(typeFile, "_type") + "_temp"); err == nil {
+			s.mb.RecordSystemSensorTemperatureLimitDataPoint(now, float64(limit)/millisPerUnit, zoneType, zone, "temp1", limitAttr)
+		}
+	}
+}
+
+func (s *sensorsScraper) scrapeBatteries(now pcommon.Timestamp, dir string, errs *scrapererror.ScrapeErrors) {
+	for _, entry := range readClassDir(dir, batteryMetricsLen, errs) {
+		supplyDir := filepath.Join(dir, entry.Name())
+		if supplyType, err := readString(filepath.Join(supplyDir, "type")); err != nil || supplyType != "Battery" {
+			continue
+		}
+		name := entry.Name()
+
+		if capacity, err := readInt(filepath.Join(supplyDir, "capacity")); err == nil {
+			s.mb.RecordSystemBatteryChargeDataPoint(now, float64(capacity)/100, name, batteryState(supplyDir))
+		}
+
+		voltage, voltageErr := readInt(filepath.Join(supplyDir, "voltage_now"))
+		if voltageErr == nil {
+			s.mb.RecordSystemBatteryVoltageDataPoint(now, float64(voltage)/microsPerUnit, name)
+		}
+
+		// Batteries which don't report their power report their current instead
+		if power, err := readInt(filepath.Join(supplyDir, "power_now")); err == nil {
+			s.mb.RecordSystemBatteryPowerDataPoint(now, float64(power)/microsPerUnit, name)
+		} else if current, err := readInt(filepath.Join(supplyDir, "current_now")); err == nil && voltageErr == nil {
+			s.mb.RecordSystemBatteryPowerDataPoint(now, float64(current)/microsPerUnit*float64(voltage)/microsPerUnit, name)
+		}
+	}
+}
+
+// batteryState maps the status of the battery, such as "Not charging", to its state attribute
+func batteryState(supplyDir string) metadata.AttributeSystemBatteryState {
+	status, err := readString(filepath.Join(supplyDir, "status"))
+	if err != nil {
+		return metadata.AttributeSystemBatteryStateUnknown
+	}
+	if state, ok := metadata.MapAttributeSystemBatteryState[strings.ReplaceAll(strings.ToLower(status), " ", "_")]; ok {
+		return state
+	}
+	return metadata.AttributeSystemBatteryStateUnknown
+}
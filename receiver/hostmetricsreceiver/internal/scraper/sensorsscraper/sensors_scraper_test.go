@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sensorsscraper
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/scraper/scrapererror"
+	"go.opentelemetry.io/collector/scraper/scrapertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/sensorsscraper/internal/metadata"
+)
+
+// newTestContext returns a context reading the sys files from the root path
+func newTestContext(t *testing.T, rootPath string) context.Context {
+	return context.WithValue(t.Context(), common.EnvKey, common.EnvMap{
+		common.HostSysEnvKey: filepath.Join(rootPath, "sys"),
+	})
+}
+
+func scrape(t *testing.T, ctx context.Context, cfg *Config) (pmetric.Metrics, error) {
+	t.Helper()
+	s, err := newSensorsScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NoError(t, s.start(ctx, componenttest.NewNopHost()))
+	return s.scrape(ctx)
+}
+
+// dataPoints returns the values of the data points of the metric by their attributes
+func dataPoints(t *testing.T, metrics pmetric.Metrics, name string) map[string]float64 {
+	t.Helper()
+	if metrics.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		metric := ms.At(i)
+		if metric.Name() != name {
+			continue
+		}
+		values := map[string]float64{}
+		dps := metric.Gauge().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			key := ""
+			for _, k := range []string{"system.sensor.chip", "system.sensor.device", "system.sensor.label", "system.sensor.temperature.limit.type", "system.battery.name", "system.battery.state"} {
+				if v, ok := dps.At(j).Attributes().Get(k); ok {
+					key += "/" + v.Str()
+				}
+			}
+			if dps.At(j).ValueType() == pmetric.NumberDataPointValueTypeInt {
+				values[key] = float64(dps.At(j).IntValue())
+			} else {
+				values[key] = dps.At(j).DoubleValue()
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func TestScrape(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	metrics, err := scrape(t, newTestContext(t, "testdata"), cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]float64{
+		"/coretemp/coretemp.0/Package id 0": 45,
+		"/coretemp/coretemp.0/Core 0":       43,
+		"/acpitz//temp1":                    27.8,
+		"/x86_pkg_temp/thermal_zone0/temp1": 52,
+	}, dataPoints(t, metrics, "system.sensor.temperature"))
+	assert.Equal(t, map[string]float64{
+		"/nct6775/nct6775.656/fan1": 1200,
+	}, dataPoints(t, metrics, "system.sensor.fan.speed"))
+	assert.Equal(t, map[string]float64{
+		"/nct6775/nct6775.656/Vcore": 1.136,
+	}, dataPoints(t, metrics, "system.sensor.voltage"))
+	assert.Equal(t, map[string]float64{
+		"/nct6775/nct6775.656/power1": 15,
+		"/nct6775/nct6775.656/power2": 12.5,
+	}, dataPoints(t, metrics, "system.sensor.power"))
+	assert.Nil(t, dataPoints(t, metrics, "system.sensor.temperature.limit"))
+
+	assert.Equal(t, map[string]float64{"/BAT0/not_charging": 0.87}, dataPoints(t, metrics, "system.battery.charge"))
+	assert.Equal(t, map[string]float64{"/BAT0": 12.1}, dataPoints(t, metrics, "system.battery.voltage"))
+	assert.InDelta(t, 18.15, dataPoints(t, metrics, "system.battery.power")["/BAT0"], 1e-9)
+}
+
+func TestScrapeTemperatureLimits(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.SystemSensorTemperatureLimit.Enabled = true
+	metrics, err := scrape(t, newTestContext(t, "testdata"), cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]float64{
+		"/coretemp/coretemp.0/Package id 0/max":  80,
+		"/coretemp/coretemp.0/Package id 0/crit": 100,
+		"/coretemp/coretemp.0/Core 0/max":        80,
+		"/x86_pkg_temp/thermal_zone0/temp1/crit": 105,
+	}, dataPoints(t, metrics, "system.sensor.temperature.limit"))
+}
+
+func TestScrapeFilters(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = MatchConfig{
+		Config: filterset.Config{MatchType: filterset.Regexp},
+		Chips:  []string{"temp$"},
+	}
+	cfg.Exclude = MatchConfig{
+		Config: filterset.Config{MatchType: filterset.Strict},
+		Chips:  []string{"x86_pkg_temp"},
+	}
+	metrics, err := scrape(t, newTestContext(t, "testdata"), cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]float64{
+		"/coretemp/coretemp.0/Package id 0": 45,
+		"/coretemp/coretemp.0/Core 0":       43,
+	}, dataPoints(t, metrics, "system.sensor.temperature"))
+	assert.Nil(t, dataPoints(t, metrics, "system.sensor.fan.speed"))
+	// Batteries are not filtered by chip
+	assert.Len(t, dataPoints(t, metrics, "system.battery.charge"), 1)
+}
+
+func TestScrapeErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	metrics, err := scrape(t, newTestContext(t, filepath.Join("testdata", "invalid")), cfg)
+
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.ErrorContains(t, err, "failed to read the chip of hwmon0")
+	assert.ErrorContains(t, err, "failed to read the type of thermal_zone0")
+	assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+}
+
+func TestNewSensorsScraperInvalidFilter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Exclude = MatchConfig{
+		Config: filterset.Config{MatchType: filterset.Regexp},
+		Chips:  []string{"("},
+	}
+	_, err := newSensorsScraper(scrapertest.NewNopSettings(metadata.Type), cfg)
+	assert.ErrorContains(t, err, "error creating chip exclude filters")
+}
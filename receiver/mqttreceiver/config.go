@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines configuration for the MQTT receiver.
+type Config struct {
+	mqtt.ClientConfig `mapstructure:",squash"`
+
+	// Logs holds configuration about how logs should be received from MQTT.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be received from MQTT.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be received from MQTT.
+	Traces SignalConfig `mapstructure:"traces"`
+}
+
+// SignalConfig holds the configuration of the topics a signal is received from.
+type SignalConfig struct {
+	// Topics are the topic filters subscribed to. The levels of a topic filter may be
+	// %{attribute} placeholders, which match any value like the + wildcard and set the
+	// resource attribute to the value of the level in the topic of each message.
+	Topics []string `mapstructure:"topics"`
+	// QoS is the maximum quality of service of the messages delivered by the broker.
+	QoS byte `mapstructure:"qos"`
+	// Encoding of the payload of the messages.
+	Encoding string `mapstructure:"encoding"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	return errors.Join(
+		cfg.ClientConfig.Validate(),
+		cfg.Logs.validate("logs"),
+		cfg.Metrics.validate("metrics"),
+		cfg.Traces.validate("traces"),
+	)
+}
+
+func (cfg *SignalConfig) validate(signal string) error {
+	var errs []error
+	if len(cfg.Topics) == 0 {
+		errs = append(errs, fmt.Errorf("%s::topics must not be empty", signal))
+	}
+	for _, topic := range cfg.Topics {
+		if _, err := parseTopicPattern(topic); err != nil {
+			errs = append(errs, fmt.Errorf("%s::topics: %q %w", signal, topic, err))
+		}
+	}
+	if cfg.QoS > 2 {
+		errs = append(errs, fmt.Errorf("%s::qos must be 0, 1 or 2", signal))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     func() *Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: func() *Config { return createDefaultConfig().(*Config) },
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_fields"),
+			expected: func() *Config {
+				clientCfg := mqtt.NewDefaultClientConfig()
+				clientCfg.Endpoint = "ssl://broker:8883"
+				clientCfg.ProtocolVersion = mqtt.ProtocolVersion5
+				clientCfg.ClientID = "gateway"
+				clientCfg.Username = "otelcol"
+				clientCfg.Password = "secret"
+				clientCfg.TLS = configoptional.Some(configtls.ClientConfig{
+					Config: configtls.Config{CAFile: "ca.pem"},
+				})
+				clientCfg.ConnectionTimeout = time.Second
+				clientCfg.KeepAlive = time.Minute
+				clientCfg.ReconnectWait = 3 * time.Second
+				clientCfg.CleanSession = false
+				clientCfg.SessionExpiryInterval = time.Hour
+				return &Config{
+					ClientConfig: clientCfg,
+					Logs: SignalConfig{
+						Topics:   []string{"sites/%{site}/devices/%{device.id}/logs", "$share/collectors/logs/#"},
+						QoS:      2,
+						Encoding: "json",
+					},
+					Metrics: SignalConfig{
+						Topics:   []string{"sites/+/metrics"},
+						QoS:      0,
+						Encoding: "otlp_json",
+					},
+					Traces: SignalConfig{
+						Topics:   []string{"traces"},
+						QoS:      1,
+						Encoding: "otlp_encoding/mqtt",
+					},
+				}
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			errorMessage: "endpoint is required",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_topics"),
+			errorMessage: "traces::topics must not be empty",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_topics"),
+			errorMessage: `logs::topics: "logs/#/devices" the # wildcard must be the last level` + "\n" +
+				`logs::topics: "logs/dev%{device.id}" placeholders must occupy an entire level`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_qos"),
+			errorMessage: "metrics::qos must be 0, 1 or 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expected == nil {
+				assert.ErrorContains(t, xconfmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected(), cfg)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+const (
+	defaultEncoding = "otlp_proto"
+	defaultQoS      = 1
+
+	defaultLogsTopic    = "otlp/logs"
+	defaultMetricsTopic = "otlp/metrics"
+	defaultTracesTopic  = "otlp/traces"
+)
+
+// NewFactory creates a factory for the MQTT receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ClientConfig: mqtt.NewDefaultClientConfig(),
+		Logs: SignalConfig{
+			Topics:   []string{defaultLogsTopic},
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+		Metrics: SignalConfig{
+			Topics:   []string{defaultMetricsTopic},
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+		Traces: SignalConfig{
+			Topics:   []string{defaultTracesTopic},
+			QoS:      defaultQoS,
+			Encoding: defaultEncoding,
+		},
+	}
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (receiver.Logs, error) {
+	return newLogsReceiver(cfg.(*Config), set, nextConsumer)
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	return newMetricsReceiver(cfg.(*Config), set, nextConsumer)
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (receiver.Traces, error) {
+	return newTracesReceiver(cfg.(*Config), set, nextConsumer)
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+)
+
+const transport = "mqtt"
+
+// mqttReceiver receives the messages of a signal from the topics it subscribes to
+type mqttReceiver struct {
+	config     *Config
+	settings   receiver.Settings
+	signal     string
+	signalCfg  SignalConfig
+	obsrecv    *receiverhelper.ObsReport
+	newHandler func(host component.Host) (messaging.Handler, error)
+
+	cancel context.CancelFunc
+	client mqtt.Client
+}
+
+func newMQTTReceiver(cfg *Config, set receiver.Settings, signal string, signalCfg SignalConfig) (*mqttReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mqttReceiver{
+		config:    cfg,
+		settings:  set,
+		signal:    signal,
+		signalCfg: signalCfg,
+		obsrecv:   obsrecv,
+	}, nil
+}
+
+func newLogsReceiver(cfg *Config, set receiver.Settings, nextConsumer consumer.Logs) (*mqttReceiver, error) {
+	r, err := newMQTTReceiver(cfg, set, "logs", cfg.Logs)
+	if err != nil {
+		return nil, err
+	}
+	r.newHandler = func(host component.Host) (messaging.Handler, error) {
+		unmarshaler, err := messaging.NewLogsUnmarshaler(cfg.Logs.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		return messaging.NewLogsHandler(r.obsrecv, cfg.Logs.Encoding, unmarshaler, nextConsumer), nil
+	}
+	return r, nil
+}
+
+func newMetricsReceiver(cfg *Config, set receiver.Settings, nextConsumer consumer.Metrics) (*mqttReceiver, error) {
+	r, err := newMQTTReceiver(cfg, set, "metrics", cfg.Metrics)
+	if err != nil {
+		return nil, err
+	}
+	r.newHandler = func(host component.Host) (messaging.Handler, error) {
+		unmarshaler, err := messaging.NewMetricsUnmarshaler(cfg.Metrics.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		return messaging.NewMetricsHandler(r.obsrecv, cfg.Metrics.Encoding, unmarshaler, nextConsumer), nil
+	}
+	return r, nil
+}
+
+func newTracesReceiver(cfg *Config, set receiver.Settings, nextConsumer consumer.Traces) (*mqttReceiver, error) {
+	r, err := newMQTTReceiver(cfg, set, "traces", cfg.Traces)
+	if err != nil {
+		return nil, err
+	}
+	r.newHandler = func(host component.Host) (messaging.Handler, error) {
+		unmarshaler, err := messaging.NewTracesUnmarshaler(cfg.Traces.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		return messaging.NewTracesHandler(r.obsrecv, cfg.Traces.Encoding, unmarshaler, nextConsumer), nil
+	}
+	return r, nil
+}
+
+func (r *mqttReceiver) Start(ctx context.Context, host component.Host) error {
+	handler, err := r.newHandler(host)
+	if err != nil {
+		return err
+	}
+
+	patterns := make([]topicPattern, 0, len(r.signalCfg.Topics))
+	subscriptions := make([]mqtt.Subscription, 0, len(r.signalCfg.Topics))
+	for _, topic := range r.signalCfg.Topics {
+		pattern, err := parseTopicPattern(topic)
+		if err != nil {
+			return fmt.Errorf("invalid topic %q: %w", topic, err)
+		}
+		patterns = append(patterns, pattern)
+		subscriptions = append(subscriptions, mqtt.Subscription{TopicFilter: pattern.filter, QoS: r.signalCfg.QoS})
+	}
+
+	// the messages are handled with a context which is only cancelled when the receiver is shut down
+	handlerCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	// each signal has its own connection, which needs its own client ID
+	clientCfg := r.config.ClientConfig
+	if clientCfg.ClientID != "" {
+		clientCfg.ClientID += "-" + r.signal
+	}
+	client, err := mqtt.Connect(ctx, clientCfg, r.settings.Logger, subscriptions, func(msg mqtt.Message) {
+		var attributes map[string]string
+		for _, pattern := range patterns {
+			if captured, ok := pattern.match(msg.Topic); ok {
+				attributes = captured
+				break
+			}
+		}
+		// messages are acknowledged once handled, whether the pipeline accepted them or not,
+		// since they can't be redelivered to the same session otherwise
+		if err := handler(handlerCtx, msg.Payload, attributes); err != nil {
+			r.settings.Logger.Error("Failed to handle message, dropping it", zap.String("topic", msg.Topic), zap.Error(err))
+		}
+	})
+	if err != nil {
+		return err
+	}
+	r.client = client
+	return nil
+}
+
+func (r *mqttReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	if r.client != nil {
+		return r.client.Disconnect(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	server "github.com/mochi-mqtt/server/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/mqtt/mqtttest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver/internal/metadata"
+)
+
+func newTestConfig(t *testing.T, protocolVersion string) (*server.Server, *Config) {
+	broker, clientCfg := mqtttest.NewBroker(t)
+	clientCfg.ProtocolVersion = protocolVersion
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig = clientCfg
+	return broker, cfg
+}
+
+func startReceiver(t *testing.T, r *mqttReceiver) {
+	t.Helper()
+	require.NoError(t, r.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, r.Shutdown(context.Background())) })
+}
+
+func TestReceiveLogsWithTopicAttributes(t *testing.T) {
+	for _, protocolVersion := range []string{mqtt.ProtocolVersion311, mqtt.ProtocolVersion5} {
+		t.Run(protocolVersion, func(t *testing.T) {
+			broker, cfg := newTestConfig(t, protocolVersion)
+			cfg.Logs.Topics = []string{"sites/%{site}/devices/%{device.id}/logs", "alerts"}
+			cfg.Logs.Encoding = "json"
+			sink := new(consumertest.LogsSink)
+			r, err := newLogsReceiver(cfg, receivertest.NewNopSettings(metadata.Type), sink)
+			require.NoError(t, err)
+			startReceiver(t, r)
+
+			require.NoError(t, broker.Publish("sites/paris/devices/sensor-1/logs", []byte(`{"message":"started"}`), false, 1))
+			// messages which can't be unmarshaled are dropped
+			require.NoError(t, broker.Publish("alerts", []byte("invalid"), false, 1))
+			require.NoError(t, broker.Publish("alerts", []byte(`"overheating"`), false, 1))
+
+			require.Eventually(t, func() bool { return sink.LogRecordCount() == 2 }, 5*time.Second, 10*time.Millisecond)
+			logs := sink.AllLogs()
+			rl := logs[0].ResourceLogs().At(0)
+			assert.Equal(t, map[string]any{"site": "paris", "device.id": "sensor-1"}, rl.Resource().Attributes().AsRaw())
+			assert.Equal(t, map[string]any{"message": "started"}, rl.ScopeLogs().At(0).LogRecords().At(0).Body().AsRaw())
+			rl = logs[1].ResourceLogs().At(0)
+			assert.Zero(t, rl.Resource().Attributes().Len())
+			assert.Equal(t, "overheating", rl.ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+		})
+	}
+}
+
+func TestReceiveMetrics(t *testing.T) {
+	broker, cfg := newTestConfig(t, mqtt.ProtocolVersion5)
+	cfg.Metrics.Topics = []string{"devices/%{device.id}/metrics"}
+	cfg.Metrics.QoS = 2
+	sink := new(consumertest.MetricsSink)
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopSettings(metadata.Type), sink)
+	require.NoError(t, err)
+	startReceiver(t, r)
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("device.id", "overridden")
+	rm.Resource().Attributes().PutStr("host.name", "gateway")
+	rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	data, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	require.NoError(t, broker.Publish("devices/sensor-1/metrics", data, false, 2))
+
+	require.Eventually(t, func() bool { return sink.DataPointCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, map[string]any{"device.id": "sensor-1", "host.name": "gateway"},
+		sink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+}
+
+func TestReceiveTracesAfterPipelineError(t *testing.T) {
+	broker, cfg := newTestConfig(t, mqtt.ProtocolVersion311)
+	var attempts atomic.Int32
+	sink := new(consumertest.TracesSink)
+	next, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if attempts.Add(1) == 1 {
+			return errors.New("pipeline failure")
+		}
+		return sink.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+	r, err := newTracesReceiver(cfg, receivertest.NewNopSettings(metadata.Type), next)
+	require.NoError(t, err)
+	startReceiver(t, r)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("test_span")
+	data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	for range 2 {
+		require.NoError(t, broker.Publish(defaultTracesTopic, data, false, 1))
+	}
+
+	// the message refused by the pipeline is dropped, and the next one is received
+	require.Eventually(t, func() bool { return sink.SpanCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestStartErrors(t *testing.T) {
+	_, cfg := newTestConfig(t, mqtt.ProtocolVersion311)
+	cfg.Logs.Encoding = "unknown"
+	r, err := newLogsReceiver(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, r.Start(t.Context(), componenttest.NewNopHost()), `unrecognized logs encoding "unknown"`)
+	assert.NoError(t, r.Shutdown(t.Context()))
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Endpoint = "tcp://127.0.0.1:1"
+	cfg.ConnectionTimeout = 100 * time.Millisecond
+	r, err = newLogsReceiver(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.ErrorContains(t, r.Start(t.Context(), componenttest.NewNopHost()), "failed to connect to the MQTT broker")
+	assert.NoError(t, r.Shutdown(t.Context()))
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mqttreceiver"
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const (
+	singleLevelWildcard = "+"
+	multiLevelWildcard  = "#"
+	// sharedSubscriptionPrefix starts the topic filters of shared subscriptions, $share/<group>/<filter>,
+	// whose messages are delivered to a single subscriber of the group.
+	sharedSubscriptionPrefix = "$share/"
+)
+
+var placeholderPattern = regexp.MustCompile(`^%\{([^}]+)\}$`)
+
+// topicPattern is a topic filter which may capture levels of the topics of messages
+// into resource attributes, with %{attribute} levels.
+type topicPattern struct {
+	// filter is the topic filter subscribed to, with the placeholders replaced with the + wildcard
+	filter string
+	// levels are the levels of the filter matched against the topics of messages,
+	// without the prefix of shared subscriptions
+	levels []string
+	// attributes are the names of the attributes captured by the placeholders, by level
+	attributes map[int]string
+}
+
+func parseTopicPattern(topic string) (topicPattern, error) {
+	if topic == "" {
+		return topicPattern{}, errors.New("must not be empty")
+	}
+	filter := topic
+	if strings.HasPrefix(topic, sharedSubscriptionPrefix) {
+		group, rest, ok := strings.Cut(strings.TrimPrefix(topic, sharedSubscriptionPrefix), "/")
+		if !ok || group == "" || rest == "" || strings.ContainsAny(group, "+#") {
+			return topicPattern{}, errors.New("must be $share/<group>/<filter> for shared subscriptions")
+		}
+		filter = rest
+	}
+
+	levels := strings.Split(filter, "/")
+	pattern := topicPattern{levels: levels, attributes: map[int]string{}}
+	for i, level := range levels {
+		if match := placeholderPattern.FindStringSubmatch(level); match != nil {
+			pattern.attributes[i] = match[1]
+			continue
+		}
+		switch {
+		case strings.Contains(level, "%{"):
+			return topicPattern{}, errors.New("placeholders must occupy an entire level")
+		case level == multiLevelWildcard && i != len(levels)-1:
+			return topicPattern{}, errors.New("the # wildcard must be the last level")
+		case level != singleLevelWildcard && level != multiLevelWildcard && strings.ContainsAny(level, "+#"):
+			return topicPattern{}, errors.New("wildcards must occupy an entire level")
+		}
+	}
+
+	filterLevels := strings.Split(topic, "/")
+	offset := len(filterLevels) - len(levels)
+	for i := range pattern.attributes {
+		filterLevels[offset+i] = singleLevelWildcard
+	}
+	pattern.filter = strings.Join(filterLevels, "/")
+	return pattern, nil
+}
+
+// match returns whether the topic of a message matches the pattern, and the resource attributes
+// captured from its levels.
+func (p topicPattern) match(topic string) (map[string]string, bool) {
+	levels := strings.Split(topic, "/")
+	// topics starting with $ are reserved for the broker, and aren't matched by wildcards
+	if strings.HasPrefix(topic, "$") && p.isWildcard(0) {
+		return nil, false
+	}
+	var attributes map[string]string
+	for i, level := range p.levels {
+		if level == multiLevelWildcard {
+			return attributes, true
+		}
+		if i >= len(levels) {
+			return nil, false
+		}
+		if key, ok := p.attributes[i]; ok {
+			if attributes == nil {
+				attributes = map[string]string{}
+			}
+			attributes[key] = levels[i]
+		} else if level != singleLevelWildcard && level != levels[i] {
+			return nil, false
+		}
+	}
+	return attributes, len(levels) == len(p.levels)
+}
+
+func (p topicPattern) isWildcard(i int) bool {
+	_, placeholder := p.attributes[i]
+	return placeholder || p.levels[i] == singleLevelWildcard || p.levels[i] == multiLevelWildcard
+}
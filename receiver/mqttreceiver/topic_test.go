@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mqttreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTopicPattern(t *testing.T) {
+	tests := []struct {
+		topic      string
+		wantFilter string
+		wantErr    string
+	}{
+		{topic: "otlp/logs", wantFilter: "otlp/logs"},
+		{topic: "sites/+/devices/#", wantFilter: "sites/+/devices/#"},
+		{topic: "#", wantFilter: "#"},
+		{topic: "sites/%{site}/devices/%{device.id}", wantFilter: "sites/+/devices/+"},
+		{topic: "$share/collectors/sites/%{site}/#", wantFilter: "$share/collectors/sites/+/#"},
+		{topic: "", wantErr: "must not be empty"},
+		{topic: "logs/#/devices", wantErr: "the # wildcard must be the last level"},
+		{topic: "logs/dev+", wantErr: "wildcards must occupy an entire level"},
+		{topic: "logs/dev%{device.id}", wantErr: "placeholders must occupy an entire level"},
+		{topic: "logs/%{device.id", wantErr: "placeholders must occupy an entire level"},
+		{topic: "$share/collectors", wantErr: "must be $share/<group>/<filter> for shared subscriptions"},
+		{topic: "$share/+/logs", wantErr: "must be $share/<group>/<filter> for shared subscriptions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			pattern, err := parseTopicPattern(tt.topic)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFilter, pattern.filter)
+		})
+	}
+}
+
+func TestTopicPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern        string
+		topic          string
+		wantMatch      bool
+		wantAttributes map[string]string
+	}{
+		{pattern: "otlp/logs", topic: "otlp/logs", wantMatch: true},
+		{pattern: "otlp/logs", topic: "otlp/metrics"},
+		{pattern: "otlp/logs", topic: "otlp/logs/extra"},
+		{pattern: "sites/+/logs", topic: "sites/paris/logs", wantMatch: true},
+		{pattern: "sites/+/logs", topic: "sites/logs"},
+		{pattern: "sites/#", topic: "sites", wantMatch: true},
+		{pattern: "sites/#", topic: "sites/paris/devices/1", wantMatch: true},
+		{pattern: "#", topic: "$SYS/broker/uptime"},
+		{
+			pattern:   "sites/%{site}/devices/%{device.id}/#",
+			topic:     "sites/paris/devices/sensor-1/logs/errors",
+			wantMatch: true,
+			wantAttributes: map[string]string{
+				"site":      "paris",
+				"device.id": "sensor-1",
+			},
+		},
+		{pattern: "sites/%{site}/logs", topic: "sites/logs"},
+		{
+			pattern:        "$share/collectors/sites/%{site}",
+			topic:          "sites/paris",
+			wantMatch:      true,
+			wantAttributes: map[string]string{"site": "paris"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.topic, func(t *testing.T) {
+			pattern, err := parseTopicPattern(tt.pattern)
+			require.NoError(t, err)
+			attributes, ok := pattern.match(tt.topic)
+			assert.Equal(t, tt.wantMatch, ok)
+			assert.Equal(t, tt.wantAttributes, attributes)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsclient"
+)
+
+const (
+	deliverPolicyAll = "all"
+	deliverPolicyNew = "new"
+)
+
+var _ component.Config = (*Config)(nil)
+
+// Config defines configuration for the NATS receiver.
+type Config struct {
+	natsclient.ClientConfig `mapstructure:",squash"`
+
+	// Logs holds configuration about how logs should be received from NATS.
+	Logs SignalConfig `mapstructure:"logs"`
+
+	// Metrics holds configuration about how metrics should be received from NATS.
+	Metrics SignalConfig `mapstructure:"metrics"`
+
+	// Traces holds configuration about how traces should be received from NATS.
+	Traces SignalConfig `mapstructure:"traces"`
+
+	// QueueGroup is the name of the queue group of the core NATS subscriptions.
+	// Each message is delivered to a single member of the group, so that it can be
+	// shared by several collectors. It can't be used with JetStream.
+	QueueGroup string `mapstructure:"queue_group"`
+
+	// JetStream consumes messages from a durable JetStream consumer when configured,
+	// acknowledging each message once it was accepted by the pipeline. Messages are
+	// received from core NATS subscriptions when JetStream is not configured.
+	JetStream configoptional.Optional[JetStreamConfig] `mapstructure:"jetstream"`
+}
+
+// SignalConfig holds the subject and encoding of the messages of a signal.
+type SignalConfig struct {
+	// Subject is the subject messages are received from. It may contain wildcards.
+	// (default = otlp.traces for traces, otlp.metrics for metrics, otlp.logs for logs)
+	Subject string `mapstructure:"subject"`
+	// Encoding of the messages (default = otlp_proto). It may be the ID of an
+	// encoding extension.
+	Encoding string `mapstructure:"encoding"`
+}
+
+// JetStreamConfig holds the configuration of the durable JetStream consumers.
+type JetStreamConfig struct {
+	// Stream is the name of the stream messages are consumed from.
+	Stream string `mapstructure:"stream"`
+	// Durable is the prefix of the names of the durable consumers. The consumer
+	// of each signal is named <durable>-<signal>, e.g. otelcol-logs.
+	Durable string `mapstructure:"durable"`
+	// AckWait is the time the server waits for a message to be acknowledged
+	// before redelivering it.
+	AckWait time.Duration `mapstructure:"ack_wait"`
+	// MaxAckPending is the maximum number of messages delivered to the consumer
+	// which weren't acknowledged yet.
+	MaxAckPending int `mapstructure:"max_ack_pending"`
+	// DeliverPolicy is the position in the stream the consumer starts from when
+	// it's created: all or new.
+	DeliverPolicy string `mapstructure:"deliver_policy"`
+	// NakDelay is the delay before a message refused by the pipeline is redelivered. It's
+	// doubled for each redelivery of the message, up to MaxNakDelay.
+	NakDelay time.Duration `mapstructure:"nak_delay"`
+	// MaxNakDelay is the maximum delay before a message refused by the pipeline is redelivered.
+	MaxNakDelay time.Duration `mapstructure:"max_nak_delay"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	var errs []error
+	if err := cfg.ClientConfig.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.Logs.Subject == "" {
+		errs = append(errs, errors.New("logs::subject is required"))
+	}
+	if cfg.Metrics.Subject == "" {
+		errs = append(errs, errors.New("metrics::subject is required"))
+	}
+	if cfg.Traces.Subject == "" {
+		errs = append(errs, errors.New("traces::subject is required"))
+	}
+	if cfg.JetStream.HasValue() && cfg.QueueGroup != "" {
+		errs = append(errs, errors.New("queue_group can't be used with jetstream"))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks the JetStream configuration is valid
+func (cfg *JetStreamConfig) Validate() error {
+	var errs []error
+	if cfg.Stream == "" {
+		errs = append(errs, errors.New("stream is required"))
+	}
+	if cfg.Durable == "" || strings.ContainsAny(cfg.Durable, ".*> \t\r\n/\\") {
+		errs = append(errs, errors.New("durable must be a non-empty name without dots, wildcards, whitespace or path separators"))
+	}
+	if cfg.AckWait <= 0 {
+		errs = append(errs, errors.New("ack_wait must be positive"))
+	}
+	if cfg.MaxAckPending <= 0 {
+		errs = append(errs, errors.New("max_ack_pending must be positive"))
+	}
+	if cfg.NakDelay <= 0 {
+		errs = append(errs, errors.New("nak_delay must be positive"))
+	}
+	if cfg.MaxNakDelay < cfg.NakDelay {
+		errs = append(errs, errors.New("max_nak_delay must not be lower than nak_delay"))
+	}
+	if cfg.DeliverPolicy != deliverPolicyAll && cfg.DeliverPolicy != deliverPolicyNew {
+		errs = append(errs, fmt.Errorf("deliver_policy %q must be one of %q or %q", cfg.DeliverPolicy, deliverPolicyAll, deliverPolicyNew))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsclient"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     func() *Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: func() *Config { return createDefaultConfig().(*Config) },
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "all_fields"),
+			expected: func() *Config {
+				return &Config{
+					ClientConfig: natsclient.ClientConfig{
+						Endpoint:          "nats://nats1:4222,nats://nats2:4222",
+						Name:              "otelcol",
+						ConnectionTimeout: time.Second,
+						ReconnectWait:     3 * time.Second,
+						TLS: configoptional.Some(configtls.ClientConfig{
+							Config: configtls.Config{CAFile: "ca.pem"},
+						}),
+						Auth: natsclient.AuthConfig{CredentialsFile: "user.creds"},
+					},
+					Logs: SignalConfig{
+						Subject:  "logs.>",
+						Encoding: "text",
+					},
+					Metrics: SignalConfig{
+						Subject:  "metrics.*",
+						Encoding: "otlp_json",
+					},
+					Traces: SignalConfig{
+						Subject:  "traces",
+						Encoding: "otlp_encoding/nats",
+					},
+					JetStream: configoptional.Some(JetStreamConfig{
+						Stream:        "OTLP",
+						Durable:       "edge",
+						AckWait:       time.Minute,
+						MaxAckPending: 10,
+						DeliverPolicy: deliverPolicyNew,
+						NakDelay:      5 * time.Second,
+						MaxNakDelay:   10 * time.Minute,
+					}),
+				}
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "jetstream_defaults"),
+			expected: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.JetStream = configoptional.Some(JetStreamConfig{
+					Stream:        "OTLP",
+					Durable:       defaultDurable,
+					AckWait:       defaultAckWait,
+					MaxAckPending: defaultMaxAckPending,
+					DeliverPolicy: deliverPolicyAll,
+					NakDelay:      defaultNakDelay,
+					MaxNakDelay:   defaultMaxNakDelay,
+				})
+				return cfg
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "queue_group"),
+			expected: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.QueueGroup = "collectors"
+				return cfg
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			errorMessage: "endpoint is required",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_subject"),
+			errorMessage: "traces::subject is required",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "multiple_auth_methods"),
+			errorMessage: "only one of auth::token, auth::username, auth::credentials_file and auth::nkey_file can be configured",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "queue_group_with_jetstream"),
+			errorMessage: "queue_group can't be used with jetstream",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_jetstream"),
+			errorMessage: "jetstream: stream is required\n" +
+				"durable must be a non-empty name without dots, wildcards, whitespace or path separators\n" +
+				"ack_wait must be positive\n" +
+				"max_nak_delay must not be lower than nak_delay\n" +
+				`deliver_policy "last" must be one of "all" or "new"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expected == nil {
+				assert.ErrorContains(t, xconfmap.Validate(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected(), cfg)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsclient"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver/internal/metadata"
+)
+
+const (
+	defaultEncoding = "otlp_proto"
+
+	defaultLogsSubject    = "otlp.logs"
+	defaultMetricsSubject = "otlp.metrics"
+	defaultTracesSubject  = "otlp.traces"
+
+	defaultDurable       = "otelcol"
+	defaultAckWait       = 30 * time.Second
+	defaultMaxAckPending = 1000
+	defaultNakDelay      = time.Second
+	defaultMaxNakDelay   = time.Minute
+)
+
+// NewFactory creates a factory for the NATS receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ClientConfig: natsclient.NewDefaultClientConfig(),
+		Logs: SignalConfig{
+			Subject:  defaultLogsSubject,
+			Encoding: defaultEncoding,
+		},
+		Metrics: SignalConfig{
+			Subject:  defaultMetricsSubject,
+			Encoding: defaultEncoding,
+		},
+		Traces: SignalConfig{
+			Subject:  defaultTracesSubject,
+			Encoding: defaultEncoding,
+		},
+		JetStream: configoptional.Default(JetStreamConfig{
+			Durable:       defaultDurable,
+			AckWait:       defaultAckWait,
+			MaxAckPending: defaultMaxAckPending,
+			DeliverPolicy: deliverPolicyAll,
+			NakDelay:      defaultNakDelay,
+			MaxNakDelay:   defaultMaxNakDelay,
+		}),
+	}
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (receiver.Logs, error) {
+	return newLogsReceiver(cfg.(*Config), set, nextConsumer)
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	return newMetricsReceiver(cfg.(*Config), set, nextConsumer)
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (receiver.Traces, error) {
+	return newTracesReceiver(cfg.(*Config), set, nextConsumer)
+}
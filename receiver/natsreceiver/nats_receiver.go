@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/natsclient"
+)
+
+const transport = "nats"
+
+// natsReceiver receives the messages of a signal from a core NATS subscription or a JetStream consumer
+type natsReceiver struct {
+	config     *Config
+	settings   receiver.Settings
+	signal     string
+	signalCfg  SignalConfig
+	obsrecv    *receiverhelper.ObsReport
+	newHandler func(host component.Host) (messaging.Handler, error)
+
+	cancel         context.CancelFunc
+	conn           *nats.Conn
+	subscription   *nats.Subscription
+	consumeContext jetstream.ConsumeContext
+}
+
+func newNatsReceiver(cfg *Config, set receiver.Settings, signal string, signalCfg SignalConfig) (*natsReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &natsReceiver{
+		config:    cfg,
+		settings:  set,
+		signal:    signal,
+		signalCfg: signalCfg,
+		obsrecv:   obsrecv,
+	}, nil
+}
+
+func newLogsReceiver(cfg *Config, set receiver.Settings, nextConsumer consumer.Logs) (*natsReceiver, error) {
+	r, err := newNatsReceiver(cfg, set, "logs", cfg.Logs)
+	if err != nil {
+		return nil, err
+	}
+	r.newHandler = func(host component.Host) (messaging.Handler, error) {
+		unmarshaler, err := messaging.NewLogsUnmarshaler(cfg.Logs.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		return messaging.NewLogsHandler(r.obsrecv, cfg.Logs.Encoding, unmarshaler, nextConsumer), nil
+	}
+	return r, nil
+}
+
+func newMetricsReceiver(cfg *Config, set receiver.Settings, nextConsumer consumer.Metrics) (*natsReceiver, error) {
+	r, err := newNatsReceiver(cfg, set, "metrics", cfg.Metrics)
+	if err != nil {
+		return nil, err
+	}
+	r.newHandler = func(host component.Host) (messaging.Handler, error) {
+		unmarshaler, err := messaging.NewMetricsUnmarshaler(cfg.Metrics.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		return messaging.NewMetricsHandler(r.obsrecv, cfg.Metrics.Encoding, unmarshaler, nextConsumer), nil
+	}
+	return r, nil
+}
+
+func newTracesReceiver(cfg *Config, set receiver.Settings, nextConsumer consumer.Traces) (*natsReceiver, error) {
+	r, err := newNatsReceiver(cfg, set, "traces", cfg.Traces)
+	if err != nil {
+		return nil, err
+	}
+	r.newHandler = func(host component.Host) (messaging.Handler, error) {
+		unmarshaler, err := messaging.NewTracesUnmarshaler(cfg.Traces.Encoding, host)
+		if err != nil {
+			return nil, err
+		}
+		return messaging.NewTracesHandler(r.obsrecv, cfg.Traces.Encoding, unmarshaler, nextConsumer), nil
+	}
+	return r, nil
+}
+
+func (r *natsReceiver) Start(ctx context.Context, host component.Host) error {
+	handler, err := r.newHandler(host)
+	if err != nil {
+		return err
+	}
+
+	conn, err := natsclient.Connect(ctx, r.config.ClientConfig, r.settings.Logger)
+	if err != nil {
+		return err
+	}
+	r.conn = conn
+
+	// the messages are handled with a context which is only cancelled when the receiver is shut down
+	handlerCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	if r.config.JetStream.HasValue() {
+		return r.consume(ctx, handlerCtx, handler)
+	}
+	return r.subscribe(handlerCtx, handler)
+}
+
+// subscribe receives messages from a core NATS subscription. Messages which can't be
+// handled are dropped, since core NATS doesn't redeliver messages.
+func (r *natsReceiver) subscribe(ctx context.Context, handler messaging.Handler) error {
+	subscription, err := r.conn.QueueSubscribe(r.signalCfg.Subject, r.config.QueueGroup, func(msg *nats.Msg) {
+		if err := handler(ctx, msg.Data, nil); err != nil {
+			r.settings.Logger.Error("Failed to handle message, dropping it",
+				zap.String("subject", msg.Subject), zap.Error(err))
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to %q: %w", r.signalCfg.Subject, err)
+	}
+	r.subscription = subscription
+	// make sure the server registered the subscription before returning
+	if err := r.conn.Flush(); err != nil {
+		return fmt.Errorf("failed to subscribe to %q: %w", r.signalCfg.Subject, err)
+	}
+	return nil
+}
+
+// consume receives messages from a durable JetStream consumer. Each message is acknowledged once
+// it was accepted by the pipeline, terminated when handling it failed with a permanent error, and
+// negatively acknowledged to be redelivered after a delay otherwise.
+func (r *natsReceiver) consume(ctx, handlerCtx context.Context, handler messaging.Handler) error {
+	js, err := jetstream.New(r.conn)
+	if err != nil {
+		return fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	jsConfig := r.config.JetStream.Get()
+	deliverPolicy := jetstream.DeliverAllPolicy
+	if jsConfig.DeliverPolicy == deliverPolicyNew {
+		deliverPolicy = jetstream.DeliverNewPolicy
+	}
+	durable := jsConfig.Durable + "-" + r.signal
+	jsConsumer, err := js.CreateOrUpdateConsumer(ctx, jsConfig.Stream, jetstream.ConsumerConfig{
+		Durable:       durable,
+		FilterSubject: r.signalCfg.Subject,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       jsConfig.AckWait,
+		MaxAckPending: jsConfig.MaxAckPending,
+		DeliverPolicy: deliverPolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create consumer %q of stream %q: %w", durable, jsConfig.Stream, err)
+	}
+
+	consumeContext, err := jsConsumer.Consume(func(msg jetstream.Msg) {
+		err := handler(handlerCtx, msg.Data(), nil)
+		switch {
+		case err == nil:
+			err = msg.Ack()
+		case consumererror.IsPermanent(err):
+			r.settings.Logger.Error("Failed to handle message, terminating it",
+				zap.String("subject", msg.Subject()), zap.Error(err))
+			err = msg.Term()
+		default:
+			delay := nakDelay(msg, jsConfig)
+			r.settings.Logger.Warn("Failed to handle message, it will be redelivered",
+				zap.String("subject", msg.Subject()), zap.Duration("delay", delay), zap.Error(err))
+			err = msg.NakWithDelay(delay)
+		}
+		if err != nil && !errors.Is(err, nats.ErrConnectionClosed) {
+			r.settings.Logger.Error("Failed to acknowledge message", zap.String("subject", msg.Subject()), zap.Error(err))
+		}
+	}, jetstream.ConsumeErrHandler(func(_ jetstream.ConsumeContext, err error) {
+		r.settings.Logger.Warn("JetStream consumer error", zap.String("consumer", durable), zap.Error(err))
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to consume from consumer %q of stream %q: %w", durable, jsConfig.Stream, err)
+	}
+	r.consumeContext = consumeContext
+	return nil
+}
+
+// nakDelay returns the delay before a refused message is redelivered, which is doubled for each
+// delivery of the message, so that messages refused under backpressure aren't redelivered in a loop.
+func nakDelay(msg jetstream.Msg, cfg *JetStreamConfig) time.Duration {
+	delay := cfg.NakDelay
+	metadata, err := msg.Metadata()
+	if err != nil {
+		return delay
+	}
+	for i := uint64(1); i < metadata.NumDelivered && delay < cfg.MaxNakDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, cfg.MaxNakDelay)
+}
+
+func (r *natsReceiver) Shutdown(context.Context) error {
+	var err error
+	if r.consumeContext != nil {
+		r.consumeContext.Stop()
+	}
+	if r.subscription != nil {
+		err = r.subscription.Unsubscribe()
+	}
+	if r.cancel != nil {
+		r.cancel()
+	}
+	if r.conn != nil {
+		r.conn.Close()
+	}
+	return err
+}
@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package natsreceiver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/natsreceiver/internal/metadata"
+)
+
+func newTestServer(t *testing.T, jetStream bool) *server.Server {
+	t.Helper()
+	s, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		NoLog:     true,
+		NoSigs:    true,
+		JetStream: jetStream,
+		StoreDir:  t.TempDir(),
+	})
+	require.NoError(t, err)
+	go s.Start()
+	require.True(t, s.ReadyForConnections(10*time.Second), "NATS server not ready")
+	t.Cleanup(func() {
+		s.Shutdown()
+		s.WaitForShutdown()
+	})
+	return s
+}
+
+func newTestConfig(s *server.Server) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = s.ClientURL()
+	return cfg
+}
+
+func connect(t *testing.T, s *server.Server) *nats.Conn {
+	t.Helper()
+	conn, err := nats.Connect(s.ClientURL())
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	return conn
+}
+
+func newTestStream(t *testing.T, conn *nats.Conn) (jetstream.JetStream, jetstream.Stream) {
+	t.Helper()
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	stream, err := js.CreateStream(t.Context(), jetstream.StreamConfig{Name: "OTLP", Subjects: []string{"otlp.>"}})
+	require.NoError(t, err)
+	return js, stream
+}
+
+func testLogsPayload(t *testing.T, body string) []byte {
+	t.Helper()
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(body)
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	return data
+}
+
+func startReceiver(t *testing.T, r *natsReceiver) {
+	t.Helper()
+	require.NoError(t, r.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, r.Shutdown(context.Background())) })
+}
+
+func TestReceiveLogsFromSubscription(t *testing.T) {
+	s := newTestServer(t, false)
+	conn := connect(t, s)
+
+	cfg := newTestConfig(s)
+	cfg.Logs.Subject = "logs.>"
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopSettings(metadata.Type), sink)
+	require.NoError(t, err)
+	startReceiver(t, r)
+
+	require.NoError(t, conn.Publish("logs.checkout", testLogsPayload(t, "first")))
+	// messages which can't be unmarshaled are dropped
+	require.NoError(t, conn.Publish("logs.checkout", []byte("invalid")))
+	require.NoError(t, conn.Publish("logs.payment", testLogsPayload(t, "second")))
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 2 }, 5*time.Second, 10*time.Millisecond)
+	logs := sink.AllLogs()
+	assert.Equal(t, "first", logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	assert.Equal(t, "second", logs[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestReceiveRawLogsWithQueueGroup(t *testing.T) {
+	s := newTestServer(t, false)
+	conn := connect(t, s)
+
+	cfg := newTestConfig(s)
+	cfg.Logs.Encoding = "text"
+	cfg.QueueGroup = "collectors"
+	sinks := []*consumertest.LogsSink{new(consumertest.LogsSink), new(consumertest.LogsSink)}
+	for _, sink := range sinks {
+		r, err := newLogsReceiver(cfg, receivertest.NewNopSettings(metadata.Type), sink)
+		require.NoError(t, err)
+		startReceiver(t, r)
+	}
+
+	for range 20 {
+		require.NoError(t, conn.Publish(defaultLogsSubject, []byte("raw message")))
+	}
+
+	// each message is delivered to a single member of the queue group
+	require.Eventually(t, func() bool {
+		return sinks[0].LogRecordCount()+sinks[1].LogRecordCount() == 20
+	}, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 20, sinks[0].LogRecordCount()+sinks[1].LogRecordCount())
+	body := sinks[0].AllLogs()
+	if len(body) == 0 {
+		body = sinks[1].AllLogs()
+	}
+	assert.Equal(t, "raw message", body[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestReceiveMetricsFromJetStream(t *testing.T) {
+	s := newTestServer(t, true)
+	conn := connect(t, s)
+	js, stream := newTestStream(t, conn)
+
+	// the messages published before the consumer was created are delivered
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	data, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	_, err = js.Publish(t.Context(), defaultMetricsSubject, data)
+	require.NoError(t, err)
+
+	cfg := newTestConfig(s)
+	cfg.Metrics.Encoding = "otlp_json"
+	cfg.JetStream.GetOrInsertDefault().Stream = "OTLP"
+	sink := new(consumertest.MetricsSink)
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopSettings(metadata.Type), sink)
+	require.NoError(t, err)
+	startReceiver(t, r)
+
+	require.Eventually(t, func() bool { return sink.DataPointCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	jsConsumer, err := stream.Consumer(t.Context(), "otelcol-metrics")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		info, err := jsConsumer.Info(t.Context())
+		return err == nil && info.AckFloor.Stream == 1 && info.NumAckPending == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestJetStreamRedeliversMessagesOnError(t *testing.T) {
+	s := newTestServer(t, true)
+	conn := connect(t, s)
+	js, _ := newTestStream(t, conn)
+
+	// the message is refused three times, as under backpressure, before being accepted
+	var mu sync.Mutex
+	var attempts []time.Time
+	sink := new(consumertest.TracesSink)
+	next, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		mu.Lock()
+		attempts = append(attempts, time.Now())
+		refused := len(attempts) <= 3
+		mu.Unlock()
+		if refused {
+			return errors.New("temporary failure")
+		}
+		return sink.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+
+	cfg := newTestConfig(s)
+	cfg.JetStream = configoptional.Some(JetStreamConfig{
+		Stream:        "OTLP",
+		Durable:       "test",
+		AckWait:       time.Minute,
+		MaxAckPending: 1,
+		DeliverPolicy: deliverPolicyNew,
+		NakDelay:      100 * time.Millisecond,
+		MaxNakDelay:   200 * time.Millisecond,
+	})
+	r, err := newTracesReceiver(cfg, receivertest.NewNopSettings(metadata.Type), next)
+	require.NoError(t, err)
+	startReceiver(t, r)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("test_span")
+	data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	_, err = js.Publish(t.Context(), defaultTracesSubject, data)
+	require.NoError(t, err)
+
+	// the message is redelivered after nak_delay, doubled for each redelivery up to max_nak_delay,
+	// without waiting for ack_wait
+	require.Eventually(t, func() bool { return sink.SpanCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, attempts, 4)
+	assert.GreaterOrEqual(t, attempts[1].Sub(attempts[0]), 100*time.Millisecond)
+	assert.GreaterOrEqual(t, attempts[2].Sub(attempts[1]), 200*time.Millisecond)
+	assert.GreaterOrEqual(t, attempts[3].Sub(attempts[2]), 200*time.Millisecond)
+	assert.Less(t, attempts[3].Sub(attempts[2]), time.Minute)
+}
+
+func TestJetStreamTerminatesInvalidMessages(t *testing.T) {
+	s := newTestServer(t, true)
+	conn := connect(t, s)
+	js, stream := newTestStream(t, conn)
+
+	cfg := newTestConfig(s)
+	cfg.JetStream.GetOrInsertDefault().Stream = "OTLP"
+	sink := new(consumertest.LogsSink)
+	r, err := newLogsReceiver(cfg, receivertest.NewNopSettings(metadata.Type), sink)
+	require.NoError(t, err)
+	startReceiver(t, r)
+
+	_, err = js.Publish(t.Context(), defaultLogsSubject, []byte("invalid"))
+	require.NoError(t, err)
+	_, err = js.Publish(t.Context(), defaultLogsSubject, testLogsPayload(t, "valid"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	jsConsumer, err := stream.Consumer(t.Context(), "otelcol-logs")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		info, err := jsConsumer.Info(t.Context())
+		return err == nil && info.AckFloor.Stream == 2 && info.NumAckPending == 0 && info.NumRedelivered == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStartErrors(t *testing.T) {
+	s := newTestServer(t, true)
+
+	cfg := newTestConfig(s)
+	cfg.Logs.Encoding = "unknown"
+	r, err := newLogsReceiver(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, r.Start(t.Context(), componenttest.NewNopHost()), `unrecognized logs encoding "unknown"`)
+	assert.NoError(t, r.Shutdown(t.Context()))
+
+	cfg = newTestConfig(s)
+	cfg.JetStream.GetOrInsertDefault().Stream = "MISSING"
+	r, err = newLogsReceiver(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.ErrorContains(t, r.Start(t.Context(), componenttest.NewNopHost()), `failed to create consumer "otelcol-logs" of stream "MISSING"`)
+	assert.NoError(t, r.Shutdown(t.Context()))
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Endpoint = "nats://127.0.0.1:1"
+	cfg.ConnectionTimeout = 100 * time.Millisecond
+	r, err = newLogsReceiver(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.ErrorContains(t, r.Start(t.Context(), componenttest.NewNopHost()), "failed to connect to the NATS server")
+	assert.NoError(t, r.Shutdown(t.Context()))
+}
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver"
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
+)
+
+// The dimensions by which flows can be aggregated, they are also the names of the data point attributes
+const (
+	dimensionSourceSubnet      = "source.subnet"
+	dimensionDestinationSubnet = "destination.subnet"
+	dimensionSourcePort        = "source.port"
+	dimensionDestinationPort   = "destination.port"
+	dimensionTransport         = "network.transport"
+	dimensionType              = "network.type"
+	dimensionSourceAS          = "source.as"
+	dimensionDestinationAS     = "destination.as"
+	dimensionSamplerAddress    = "flow.sampler_address"
+)
+
+const (
+	metricBytes   = "flow.io.bytes"
+	metricPackets = "flow.io.packets"
+	metricFlows   = "flow.count"
+)
+
+var (
+	supportedDimensions = []string{
+		dimensionSourceSubnet,
+		dimensionDestinationSubnet,
+		dimensionSourcePort,
+		dimensionDestinationPort,
+		dimensionTransport,
+		dimensionType,
+		dimensionSourceAS,
+		dimensionDestinationAS,
+		dimensionSamplerAddress,
+	}
+
+	defaultDimensions = []string{
+		dimensionSourceSubnet,
+		dimensionDestinationSubnet,
+		dimensionDestinationPort,
+		dimensionTransport,
+		dimensionSourceAS,
+		dimensionDestinationAS,
+	}
+)
+
+// flowStats holds the totals of the flows sharing the same dimension values
+type flowStats struct {
+	attributes pcommon.Map
+	bytes      uint64
+	packets    uint64
+	flows      uint64
+}
+
+// flowAggregator rolls flows up into bytes, packets and flows counts by the configured dimensions
+type flowAggregator struct {
+	cfg AggregationConfig
+
+	mu        sync.Mutex
+	startTime time.Time
+	stats     map[string]*flowStats
+}
+
+func newFlowAggregator(cfg AggregationConfig) *flowAggregator {
+	return &flowAggregator{
+		cfg:       cfg,
+		startTime: time.Now(),
+		stats:     make(map[string]*flowStats),
+	}
+}
+
+// add aggregates the flow into the totals of its dimension values.
+// Bytes and packets are multiplied by the sampling rate of the flow to estimate the actual traffic.
+func (a *flowAggregator) add(pm *protoproducer.ProtoProducerMessage) {
+	values := make([]any, len(a.cfg.Dimensions))
+	var key strings.Builder
+	for i, dimension := range a.cfg.Dimensions {
+		values[i] = a.dimensionValue(pm, dimension)
+		switch v := values[i].(type) {
+		case string:
+			key.WriteString(v)
+		case int64:
+			key.WriteString(strconv.FormatInt(v, 10))
+		}
+		key.WriteByte(0)
+	}
+
+	samplingRate := pm.SamplingRate
+	if samplingRate == 0 {
+		samplingRate = 1
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stats, ok := a.stats[key.String()]
+	if !ok {
+		stats = &flowStats{attributes: pcommon.NewMap()}
+		for i, dimension := range a.cfg.Dimensions {
+			switch v := values[i].(type) {
+			case string:
+				stats.attributes.PutStr(dimension, v)
+			case int64:
+				stats.attributes.PutInt(dimension, v)
+			}
+		}
+		a.stats[key.String()] = stats
+	}
+	stats.bytes += pm.Bytes * samplingRate
+	stats.packets += pm.Packets * samplingRate
+	stats.flows++
+}
+
+func (a *flowAggregator) dimensionValue(pm *protoproducer.ProtoProducerMessage, dimension string) any {
+	switch dimension {
+	case dimensionSourceSubnet:
+		return a.subnet(pm.SrcAddr)
+	case dimensionDestinationSubnet:
+		return a.subnet(pm.DstAddr)
+	case dimensionSourcePort:
+		return int64(pm.SrcPort)
+	case dimensionDestinationPort:
+		return int64(pm.DstPort)
+	case dimensionTransport:
+		return getTransportName(pm.Proto)
+	case dimensionType:
+		return getEtypeName(pm.Etype)
+	case dimensionSourceAS:
+		return int64(pm.SrcAs)
+	case dimensionDestinationAS:
+		return int64(pm.DstAs)
+	case dimensionSamplerAddress:
+		samplerAddr, _ := netip.AddrFromSlice(pm.SamplerAddress)
+		return samplerAddr.Unmap().String()
+	default:
+		return ""
+	}
+}
+
+// subnet returns the subnet of the address in CIDR notation, using the configured prefix lengths
+func (a *flowAggregator) subnet(b []byte) string {
+	addr, ok := netip.AddrFromSlice(b)
+	if !ok {
+		return addr.String()
+	}
+	addr = addr.Unmap()
+
+	bits := a.cfg.IPv6PrefixLength
+	if addr.Is4() {
+		bits = a.cfg.IPv4PrefixLength
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return addr.String()
+	}
+	return prefix.String()
+}
+
+// flush returns the totals aggregated since the previous flush as delta sums and resets them.
+// The returned metrics are empty if no flow was aggregated.
+func (a *flowAggregator) flush() pmetric.Metrics {
+	a.mu.Lock()
+	stats := a.stats
+	startTime := a.startTime
+	a.stats = make(map[string]*flowStats)
+	a.startTime = time.Now()
+	a.mu.Unlock()
+
+	metrics := pmetric.NewMetrics()
+	if len(stats) == 0 {
+		return metrics
+	}
+
+	scopeMetrics := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	scopeMetrics.Scope().SetName(metadata.ScopeName)
+	scopeMetrics.Scope().Attributes().PutStr("receiver", metadata.Type.String())
+
+	start := pcommon.NewTimestampFromTime(startTime)
+	now := pcommon.NewTimestampFromTime(time.Now())
+	bytesSum := newDeltaSum(scopeMetrics.Metrics(), metricBytes, "The number of bytes of the flows", "By")
+	packetsSum := newDeltaSum(scopeMetrics.Metrics(), metricPackets, "The number of packets of the flows", "{packet}")
+	flowsSum := newDeltaSum(scopeMetrics.Metrics(), metricFlows, "The number of flows", "{flow}")
+	for _, s := range stats {
+		addDataPoint(bytesSum, s.attributes, start, now, s.bytes)
+		addDataPoint(packetsSum, s.attributes, start, now, s.packets)
+		addDataPoint(flowsSum, s.attributes, start, now, s.flows)
+	}
+
+	return metrics
+}
+
+func newDeltaSum(metrics pmetric.MetricSlice, name, description, unit string) pmetric.Sum {
+	metric := metrics.AppendEmpty()
+	metric.SetName(name)
+	metric.SetDescription(description)
+	metric.SetUnit(unit)
+	sum := metric.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.SetIsMonotonic(true)
+	return sum
+}
+
+func addDataPoint(sum pmetric.Sum, attributes pcommon.Map, start, now pcommon.Timestamp, value uint64) {
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(now)
+	dp.SetIntValue(int64(value))
+	attributes.CopyTo(dp.Attributes())
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netflowreceiver
+
+import (
+	"net/netip"
+	"testing"
+
+	flowpb "github.com/netsampler/goflow2/v2/pb"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newFlow(src, dst string, dstPort uint32, bytes, packets, samplingRate uint64) *protoproducer.ProtoProducerMessage {
+	return &protoproducer.ProtoProducerMessage{
+		FlowMessage: flowpb.FlowMessage{
+			SrcAddr:      netip.MustParseAddr(src).AsSlice(),
+			DstAddr:      netip.MustParseAddr(dst).AsSlice(),
+			SrcPort:      51000,
+			DstPort:      dstPort,
+			Proto:        6,
+			SrcAs:        64500,
+			DstAs:        64501,
+			Bytes:        bytes,
+			Packets:      packets,
+			SamplingRate: samplingRate,
+		},
+	}
+}
+
+func TestFlowAggregator(t *testing.T) {
+	aggregator := newFlowAggregator(AggregationConfig{
+		Dimensions:       []string{dimensionSourceSubnet, dimensionDestinationSubnet, dimensionDestinationPort, dimensionSourceAS},
+		IPv4PrefixLength: 16,
+		IPv6PrefixLength: 48,
+	})
+
+	aggregator.add(newFlow("10.1.2.3", "192.0.2.1", 443, 1000, 10, 0))
+	aggregator.add(newFlow("10.1.200.4", "192.0.2.20", 443, 500, 5, 0))
+	// Bytes and packets are multiplied by the sampling rate
+	aggregator.add(newFlow("10.1.2.3", "192.0.2.1", 443, 100, 1, 10))
+	aggregator.add(newFlow("2001:db8:1:2::1", "2001:db8:2:3::1", 53, 80, 1, 0))
+
+	metrics := aggregator.flush()
+	require.Equal(t, 3, metrics.MetricCount())
+	values := map[string]map[string]int64{}
+	scopeMetrics := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0)
+	assert.Equal(t, "otelcol/netflowreceiver", scopeMetrics.Scope().Name())
+	for i := 0; i < scopeMetrics.Metrics().Len(); i++ {
+		metric := scopeMetrics.Metrics().At(i)
+		assert.Equal(t, pmetric.AggregationTemporalityDelta, metric.Sum().AggregationTemporality())
+		assert.True(t, metric.Sum().IsMonotonic())
+		values[metric.Name()] = map[string]int64{}
+		for j := 0; j < metric.Sum().DataPoints().Len(); j++ {
+			dp := metric.Sum().DataPoints().At(j)
+			src, _ := dp.Attributes().Get(dimensionSourceSubnet)
+			dstPort, _ := dp.Attributes().Get(dimensionDestinationPort)
+			srcAS, _ := dp.Attributes().Get(dimensionSourceAS)
+			assert.Equal(t, int64(64500), srcAS.Int())
+			assert.Equal(t, 4, dp.Attributes().Len())
+			assert.LessOrEqual(t, dp.StartTimestamp(), dp.Timestamp())
+			values[metric.Name()][src.Str()+" "+dstPort.AsString()] = dp.IntValue()
+		}
+	}
+	assert.Equal(t, map[string]map[string]int64{
+		metricBytes:   {"10.1.0.0/16 443": 2500, "2001:db8:1::/48 53": 80},
+		metricPackets: {"10.1.0.0/16 443": 25, "2001:db8:1::/48 53": 1},
+		metricFlows:   {"10.1.0.0/16 443": 3, "2001:db8:1::/48 53": 1},
+	}, values)
+
+	// The totals are reset by the flush
+	assert.Equal(t, 0, aggregator.flush().MetricCount())
+}
+
+func TestFlowAggregatorWithoutDimensions(t *testing.T) {
+	aggregator := newFlowAggregator(AggregationConfig{})
+	aggregator.add(newFlow("10.1.2.3", "192.0.2.1", 443, 1000, 10, 0))
+	aggregator.add(newFlow("10.2.2.3", "192.0.2.2", 80, 500, 5, 0))
+
+	metrics := aggregator.flush()
+	require.Equal(t, 3, metrics.DataPointCount())
+	dp := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(1500), dp.IntValue())
+	assert.Equal(t, 0, dp.Attributes().Len())
+}
@@ -5,12 +5,16 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 )
 
 // Config represents the receiver config settings within the collector's config.yaml
 type Config struct {
 	// The scheme defines the type of flow data that the listener will receive
-	// The scheme must be one of sflow, netflow, or flow
+	// The scheme must be one of sflow, netflow, or ipfix
 	Scheme string `mapstructure:"scheme"`
 
 	// The hostname or IP address that the listener will bind to
@@ -33,11 +37,29 @@ type Config struct {
 
 	// SendRaw determines whether to send raw flow messages instead of parsing them
 	SendRaw bool `mapstructure:"send_raw"`
+
+	// Aggregation configures how flows are rolled up into metrics when the receiver is used in a metrics pipeline
+	Aggregation AggregationConfig `mapstructure:"aggregation"`
+}
+
+// AggregationConfig represents the settings used to aggregate flows into metrics
+type AggregationConfig struct {
+	// The interval at which the aggregated flows are emitted as metrics
+	Interval time.Duration `mapstructure:"interval"`
+
+	// The dimensions by which the flows are aggregated, each of them is added as an attribute to the data points
+	Dimensions []string `mapstructure:"dimensions"`
+
+	// The prefix length used to compute the subnet of IPv4 addresses
+	IPv4PrefixLength int `mapstructure:"ipv4_prefix_length"`
+
+	// The prefix length used to compute the subnet of IPv6 addresses
+	IPv6PrefixLength int `mapstructure:"ipv6_prefix_length"`
 }
 
 // Validate checks if the receiver configuration is valid
 func (cfg *Config) Validate() error {
-	validSchemes := [2]string{"sflow", "netflow"}
+	validSchemes := [3]string{"sflow", "netflow", "ipfix"}
 
 	validScheme := false
 	for _, scheme := range validSchemes {
@@ -47,7 +69,7 @@ func (cfg *Config) Validate() error {
 		}
 	}
 	if !validScheme {
-		return errors.New("scheme must be netflow or sflow")
+		return errors.New("scheme must be netflow, ipfix or sflow")
 	}
 
 	if cfg.Sockets <= 0 {
@@ -66,5 +88,27 @@ func (cfg *Config) Validate() error {
 		return errors.New("port must be greater than 0")
 	}
 
+	return cfg.Aggregation.validate()
+}
+
+func (cfg *AggregationConfig) validate() error {
+	if cfg.Interval <= 0 {
+		return errors.New("aggregation interval must be greater than 0")
+	}
+
+	for _, dimension := range cfg.Dimensions {
+		if !slices.Contains(supportedDimensions, dimension) {
+			return fmt.Errorf("aggregation dimension %q is not supported, supported dimensions are %s", dimension, strings.Join(supportedDimensions, ", "))
+		}
+	}
+
+	if cfg.IPv4PrefixLength < 0 || cfg.IPv4PrefixLength > 32 {
+		return errors.New("aggregation ipv4_prefix_length must be between 0 and 32")
+	}
+
+	if cfg.IPv6PrefixLength < 0 || cfg.IPv6PrefixLength > 128 {
+		return errors.New("aggregation ipv6_prefix_length must be between 0 and 128")
+	}
+
 	return nil
 }
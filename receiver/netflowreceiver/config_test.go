@@ -6,6 +6,7 @@ package netflowreceiver
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,6 +23,8 @@ func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 
+	defaultAggregation := createDefaultConfig().(*Config).Aggregation
+
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -33,42 +36,73 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "one_listener"),
 			expected: &Config{
-				Scheme:    "netflow",
-				Port:      2055,
-				Sockets:   1,
-				Workers:   1,
-				QueueSize: 1000,
+				Scheme:      "netflow",
+				Port:        2055,
+				Sockets:     1,
+				Workers:     1,
+				QueueSize:   1000,
+				Aggregation: defaultAggregation,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "zero_queue"),
 			expected: &Config{
-				Scheme:    "netflow",
-				Port:      2055,
-				Sockets:   1,
-				Workers:   1,
-				QueueSize: 1000,
+				Scheme:      "netflow",
+				Port:        2055,
+				Sockets:     1,
+				Workers:     1,
+				QueueSize:   1000,
+				Aggregation: defaultAggregation,
 			},
 		},
 		{
 			id: component.NewIDWithName(metadata.Type, "sflow"),
 			expected: &Config{
-				Scheme:    "sflow",
-				Port:      6343,
-				Sockets:   1,
-				Workers:   1,
-				QueueSize: 1000,
+				Scheme:      "sflow",
+				Port:        6343,
+				Sockets:     1,
+				Workers:     1,
+				QueueSize:   1000,
+				Aggregation: defaultAggregation,
 			},
 		},
 		{
-			id: component.NewIDWithName(metadata.Type, "raw_logs"),
+			id: component.NewIDWithName(metadata.Type, "ipfix"),
+			expected: &Config{
+				Scheme:      "ipfix",
+				Port:        4739,
+				Sockets:     1,
+				Workers:     1,
+				QueueSize:   1000,
+				Aggregation: defaultAggregation,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "aggregation"),
 			expected: &Config{
 				Scheme:    "netflow",
 				Port:      2055,
 				Sockets:   1,
 				Workers:   1,
 				QueueSize: 1000,
-				SendRaw:   true,
+				Aggregation: AggregationConfig{
+					Interval:         30 * time.Second,
+					Dimensions:       []string{"source.subnet", "destination.subnet", "network.transport"},
+					IPv4PrefixLength: 16,
+					IPv6PrefixLength: 48,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "raw_logs"),
+			expected: &Config{
+				Scheme:      "netflow",
+				Port:        2055,
+				Sockets:     1,
+				Workers:     1,
+				QueueSize:   1000,
+				SendRaw:     true,
+				Aggregation: defaultAggregation,
 			},
 		},
 	}
@@ -100,7 +134,7 @@ func TestInvalidConfig(t *testing.T) {
 	}{
 		{
 			id:  component.NewIDWithName(metadata.Type, "invalid_schema"),
-			err: "scheme must be netflow or sflow",
+			err: "scheme must be netflow, ipfix or sflow",
 		},
 		{
 			id:  component.NewIDWithName(metadata.Type, "invalid_port"),
@@ -114,6 +148,22 @@ func TestInvalidConfig(t *testing.T) {
 			id:  component.NewIDWithName(metadata.Type, "zero_workers"),
 			err: "workers must be greater than 0",
 		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "zero_aggregation_interval"),
+			err: "aggregation interval must be greater than 0",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_aggregation_dimension"),
+			err: `aggregation dimension "source.address" is not supported`,
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_ipv4_prefix_length"),
+			err: "aggregation ipv4_prefix_length must be between 0 and 32",
+		},
+		{
+			id:  component.NewIDWithName(metadata.Type, "invalid_ipv6_prefix_length"),
+			err: "aggregation ipv6_prefix_length must be between 0 and 128",
+		},
 	}
 
 	for _, tt := range tests {
@@ -5,11 +5,14 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
@@ -20,14 +23,22 @@ const (
 	// that for a full queue of 1000 messages, the size in memory will be 9MB.
 	// Source: https://github.com/netsampler/goflow2/blob/v2.2.1/README.md#security-notes-and-assumptions
 	defaultQueueSize = 1_000
+
+	defaultAggregationInterval = time.Minute
+	defaultIPv4PrefixLength    = 24
+	defaultIPv6PrefixLength    = 64
 )
 
+// receivers shares the UDP listener between the logs and metrics receivers of a same configuration
+var receivers = sharedcomponent.NewSharedComponents()
+
 // NewFactory creates a factory for netflow receiver.
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
 }
 
 // Config defines configuration for netflow receiver.
@@ -39,19 +50,52 @@ func createDefaultConfig() component.Config {
 		Sockets:   defaultSockets,
 		Workers:   defaultWorkers,
 		QueueSize: defaultQueueSize,
+		Aggregation: AggregationConfig{
+			Interval:         defaultAggregationInterval,
+			Dimensions:       slices.Clone(defaultDimensions),
+			IPv4PrefixLength: defaultIPv4PrefixLength,
+			IPv6PrefixLength: defaultIPv6PrefixLength,
+		},
 	}
 }
 
-// createLogsReceiver creates a netflow receiver.
+// createLogsReceiver creates a netflow receiver emitting a log record per flow.
 // We also create the UDP receiver, which is the piece of software that actually listens
 // for incoming netflow traffic on an UDP port.
 func createLogsReceiver(_ context.Context, params receiver.Settings, cfg component.Config, consumer consumer.Logs) (receiver.Logs, error) {
-	conf := *(cfg.(*Config))
+	nr, err := getOrAddReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nr.Unwrap().(*netflowReceiver).logConsumer = consumer
+	return nr, nil
+}
 
-	nr, err := newNetflowLogsReceiver(params, conf, consumer)
+// createMetricsReceiver creates a netflow receiver aggregating the flows into metrics.
+// The UDP receiver is shared with the logs receiver of the same configuration.
+func createMetricsReceiver(_ context.Context, params receiver.Settings, cfg component.Config, consumer consumer.Metrics) (receiver.Metrics, error) {
+	nr, err := getOrAddReceiver(params, cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	nr.Unwrap().(*netflowReceiver).metricConsumer = consumer
 	return nr, nil
 }
+
+// getOrAddReceiver returns the receiver shared by the signals of the configuration. The receiver
+// is built before it's shared, so that a receiver which failed to be built is never cached.
+func getOrAddReceiver(params receiver.Settings, cfg component.Config) (*sharedcomponent.SharedComponent, error) {
+	rCfg := cfg.(*Config)
+	if err := rCfg.Validate(); err != nil {
+		return nil, err
+	}
+	r, err := newNetflowReceiver(params, *rCfg)
+	if err != nil {
+		return nil, err
+	}
+	return receivers.GetOrAdd(cfg, func() component.Component {
+		return r
+	}), nil
+}
@@ -29,3 +29,17 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, receiver, "receiver creation failed")
 }
+
+func TestCreateReceiverWithInvalidConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Sockets = 0
+	set := receivertest.NewNopSettings(metadata.Type)
+
+	_, err := factory.CreateLogs(t.Context(), set, cfg, consumertest.NewNop())
+	assert.EqualError(t, err, "sockets must be greater than 0")
+
+	// the receiver which failed to be built isn't shared with the other signals
+	_, err = factory.CreateMetrics(t.Context(), set, cfg, consumertest.NewNop())
+	assert.EqualError(t, err, "sockets must be greater than 0")
+}
@@ -4,6 +4,7 @@ go 1.24.0
 
 require (
 	github.com/netsampler/goflow2/v2 v2.2.6
+	github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent v0.143.0
 	github.com/stretchr/testify v1.11.1
 	go.opentelemetry.io/collector/component v1.49.1-0.20260115162016-5e41fb551263
 	go.opentelemetry.io/collector/component/componenttest v0.143.1-0.20260115162016-5e41fb551263
@@ -61,3 +62,5 @@ require (
 
 // Can be removed after 0.144.0 release
 replace go.opentelemetry.io/collector/internal/componentalias => go.opentelemetry.io/collector/internal/componentalias v0.0.0-20260115162016-5e41fb551263
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent => ../../internal/sharedcomponent
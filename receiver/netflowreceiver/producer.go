@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/netsampler/goflow2/v2/producer"
+	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
@@ -88,3 +89,45 @@ func newOtelLogsProducer(wrapped producer.ProducerInterface, logConsumer consume
 		sendRaw:     sendRaw,
 	}
 }
+
+// otelMetricsProducerWrapper is a wrapper around a producer.ProducerInterface that aggregates the messages into metrics
+type otelMetricsProducerWrapper struct {
+	wrapped    producer.ProducerInterface
+	aggregator *flowAggregator
+	logger     *zap.Logger
+}
+
+// Produce adds the flow messages to the aggregator, the metrics are sent to the metrics consumer when the aggregator is flushed
+func (o *otelMetricsProducerWrapper) Produce(msg any, args *producer.ProduceArgs) ([]producer.ProducerMessage, error) {
+	flowMessageSet, err := o.wrapped.Produce(msg, args)
+	if err != nil {
+		return flowMessageSet, err
+	}
+
+	for _, m := range flowMessageSet {
+		pm, ok := m.(*protoproducer.ProtoProducerMessage)
+		if !ok {
+			o.logger.Error("error aggregating message, this flow message is not ProtoProducerMessage")
+			continue
+		}
+		o.aggregator.add(pm)
+	}
+
+	return flowMessageSet, nil
+}
+
+func (o *otelMetricsProducerWrapper) Close() {
+	o.wrapped.Close()
+}
+
+func (o *otelMetricsProducerWrapper) Commit(flowMessageSet []producer.ProducerMessage) {
+	o.wrapped.Commit(flowMessageSet)
+}
+
+func newOtelMetricsProducer(wrapped producer.ProducerInterface, aggregator *flowAggregator, logger *zap.Logger) producer.ProducerInterface {
+	return &otelMetricsProducerWrapper{
+		wrapped:    wrapped,
+		aggregator: aggregator,
+		logger:     logger,
+	}
+}
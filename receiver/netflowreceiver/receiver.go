@@ -5,11 +5,15 @@ package netflowreceiver // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/netsampler/goflow2/v2/decoders/netflow"
+	"github.com/netsampler/goflow2/v2/producer"
 	protoproducer "github.com/netsampler/goflow2/v2/producer/proto"
 	"github.com/netsampler/goflow2/v2/utils"
 	"go.opentelemetry.io/collector/component"
@@ -18,6 +22,11 @@ import (
 	"go.uber.org/zap"
 )
 
+// The version number of IPFIX messages, see https://www.rfc-editor.org/rfc/rfc7011#section-3.1
+const ipfixVersion = 10
+
+var errNotIPFIX = errors.New("not an IPFIX message")
+
 var _ utils.ReceiverCallback = (*dropHandler)(nil)
 
 type dropHandler struct {
@@ -29,13 +38,17 @@ func (d dropHandler) Dropped(msg utils.Message) {
 }
 
 type netflowReceiver struct {
-	config      Config
-	logger      *zap.Logger
-	udpReceiver *utils.UDPReceiver
-	logConsumer consumer.Logs
+	config         Config
+	logger         *zap.Logger
+	udpReceiver    *utils.UDPReceiver
+	logConsumer    consumer.Logs
+	metricConsumer consumer.Metrics
+	aggregator     *flowAggregator
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
 }
 
-func newNetflowLogsReceiver(params receiver.Settings, cfg Config, consumer consumer.Logs) (receiver.Logs, error) {
+func newNetflowReceiver(params receiver.Settings, cfg Config) (*netflowReceiver, error) {
 	// UDP receiver configuration
 	udpCfg := &utils.UDPReceiverConfig{
 		Sockets:   cfg.Sockets,
@@ -54,8 +67,8 @@ func newNetflowLogsReceiver(params receiver.Settings, cfg Config, consumer consu
 	nr := &netflowReceiver{
 		logger:      params.Logger,
 		config:      cfg,
-		logConsumer: consumer,
 		udpReceiver: udpReceiver,
+		aggregator:  newFlowAggregator(cfg.Aggregation),
 	}
 
 	return nr, nil
@@ -76,6 +89,14 @@ func (nr *netflowReceiver) Start(_ context.Context, _ component.Host) error {
 	// This runs until the receiver is stoppped, consuming from an error channel
 	go nr.handleErrors()
 
+	// The aggregated flows are emitted periodically when the receiver is used in a metrics pipeline
+	if nr.metricConsumer != nil {
+		var ctx context.Context
+		ctx, nr.cancel = context.WithCancel(context.Background())
+		nr.wg.Add(1)
+		go nr.emitMetrics(ctx)
+	}
+
 	return nil
 }
 
@@ -87,12 +108,45 @@ func (nr *netflowReceiver) Shutdown(context.Context) error {
 	if err != nil {
 		nr.logger.Warn("Error stopping UDP receiver", zap.Error(err))
 	}
+	if nr.cancel != nil {
+		nr.cancel()
+	}
+	nr.wg.Wait()
 	return nil
 }
 
+// emitMetrics sends the aggregated flows to the metrics consumer at every aggregation interval,
+// and one last time when the receiver is stopped
+func (nr *netflowReceiver) emitMetrics(ctx context.Context) {
+	defer nr.wg.Done()
+
+	ticker := time.NewTicker(nr.config.Aggregation.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			nr.flushMetrics(ctx)
+		case <-ctx.Done():
+			nr.flushMetrics(context.Background())
+			return
+		}
+	}
+}
+
+func (nr *netflowReceiver) flushMetrics(ctx context.Context) {
+	metrics := nr.aggregator.flush()
+	if metrics.DataPointCount() == 0 {
+		return
+	}
+	if err := nr.metricConsumer.ConsumeMetrics(ctx, metrics); err != nil {
+		nr.logger.Error("error sending aggregated flow metrics", zap.Error(err))
+	}
+}
+
 // buildDecodeFunc creates a decode function based on the scheme
 // This is the fuction that will be invoked for every netflow packet received
-// The function depends on the type of schema (netflow, sflow, flow)
+// The function depends on the type of schema (netflow, ipfix, sflow)
 func (nr *netflowReceiver) buildDecodeFunc() (utils.DecoderFunc, error) {
 	// Eventually this can be used to configure mappings
 	cfgProducer := &protoproducer.ProducerConfig{}
@@ -106,12 +160,19 @@ func (nr *netflowReceiver) buildDecodeFunc() (utils.DecoderFunc, error) {
 		return nil, err
 	}
 
-	// the otel log producer converts those messages into OpenTelemetry logs
-	// it is a wrapper around the protobuf producer
-	otelLogsProducer := newOtelLogsProducer(protoProducer, nr.logConsumer, nr.logger, nr.config.SendRaw)
+	// the otel metrics producer aggregates those messages into OpenTelemetry metrics
+	// and the otel log producer converts them into OpenTelemetry logs
+	// both are wrappers around the protobuf producer
+	var flowProducer producer.ProducerInterface = protoProducer
+	if nr.metricConsumer != nil {
+		flowProducer = newOtelMetricsProducer(flowProducer, nr.aggregator, nr.logger)
+	}
+	if nr.logConsumer != nil {
+		flowProducer = newOtelLogsProducer(flowProducer, nr.logConsumer, nr.logger, nr.config.SendRaw)
+	}
 
 	cfgPipe := &utils.PipeConfig{
-		Producer: otelLogsProducer,
+		Producer: flowProducer,
 	}
 
 	var p utils.FlowPipe
@@ -120,12 +181,37 @@ func (nr *netflowReceiver) buildDecodeFunc() (utils.DecoderFunc, error) {
 		p = utils.NewSFlowPipe(cfgPipe)
 	case "netflow":
 		p = utils.NewNetFlowPipe(cfgPipe)
+	case "ipfix":
+		p = newIPFIXPipe(cfgPipe)
 	default:
 		return nil, fmt.Errorf("scheme does not exist: %s", nr.config.Scheme)
 	}
 	return p.DecodeFlow, nil
 }
 
+// ipfixPipe decodes IPFIX messages only, the templates are cached per exporter by the wrapped NetFlow pipe
+type ipfixPipe struct {
+	*utils.NetFlowPipe
+}
+
+func newIPFIXPipe(cfg *utils.PipeConfig) *ipfixPipe {
+	return &ipfixPipe{
+		NetFlowPipe: utils.NewNetFlowPipe(cfg),
+	}
+}
+
+// DecodeFlow decodes the message if its version is the one of IPFIX
+func (p *ipfixPipe) DecodeFlow(msg any) error {
+	pkt, ok := msg.(*utils.Message)
+	if !ok {
+		return errors.New("flow is not *Message")
+	}
+	if len(pkt.Payload) < 2 || binary.BigEndian.Uint16(pkt.Payload) != ipfixVersion {
+		return &utils.PipeMessageError{Message: pkt, Err: errNotIPFIX}
+	}
+	return p.NetFlowPipe.DecodeFlow(msg)
+}
+
 // handleErrors handles errors from the listener
 // We don't want the receiver to stop if there is an error processing a packet
 func (nr *netflowReceiver) handleErrors() {
@@ -4,12 +4,19 @@
 package netflowreceiver
 
 import (
+	"encoding/binary"
+	"net/netip"
 	"testing"
+	"time"
 
+	"github.com/netsampler/goflow2/v2/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netflowreceiver/internal/metadata"
 )
 
@@ -20,5 +27,126 @@ func TestCreateValidDefaultReceiver(t *testing.T) {
 	receiver, err := factory.CreateLogs(t.Context(), set, cfg, consumertest.NewNop())
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, receiver, "receiver creation failed")
-	assert.NotNil(t, receiver.(*netflowReceiver).udpReceiver)
+	assert.NotNil(t, receiver.(*sharedcomponent.SharedComponent).Unwrap().(*netflowReceiver).udpReceiver)
+}
+
+func TestCreateSharedReceiver(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := receivertest.NewNopSettings(metadata.Type)
+	logsSink := new(consumertest.LogsSink)
+	metricsSink := new(consumertest.MetricsSink)
+	logsReceiver, err := factory.CreateLogs(t.Context(), set, cfg, logsSink)
+	require.NoError(t, err)
+	metricsReceiver, err := factory.CreateMetrics(t.Context(), set, cfg, metricsSink)
+	require.NoError(t, err)
+
+	// Both signals share the same UDP listener
+	assert.Same(t, logsReceiver, metricsReceiver)
+	nr := logsReceiver.(*sharedcomponent.SharedComponent).Unwrap().(*netflowReceiver)
+	assert.Equal(t, logsSink, nr.logConsumer)
+	assert.Equal(t, metricsSink, nr.metricConsumer)
+	assert.NoError(t, logsReceiver.Shutdown(t.Context()))
+}
+
+// ipfixPacket returns an IPFIX message holding a template set followed by a data set of a single flow
+func ipfixPacket(t *testing.T) []byte {
+	t.Helper()
+	template := []uint16{
+		8, 4, // sourceIPv4Address
+		12, 4, // destinationIPv4Address
+		7, 2, // sourceTransportPort
+		11, 2, // destinationTransportPort
+		4, 1, // protocolIdentifier
+		1, 8, // octetDeltaCount
+		2, 8, // packetDeltaCount
+	}
+	templateSet := binary.BigEndian.AppendUint16(nil, 2)
+	templateSet = binary.BigEndian.AppendUint16(templateSet, uint16(8+2*len(template)))
+	templateSet = binary.BigEndian.AppendUint16(templateSet, 256)
+	templateSet = binary.BigEndian.AppendUint16(templateSet, uint16(len(template)/2))
+	for _, v := range template {
+		templateSet = binary.BigEndian.AppendUint16(templateSet, v)
+	}
+
+	record := []byte{10, 0, 1, 5, 192, 0, 2, 10}
+	record = binary.BigEndian.AppendUint16(record, 51000)
+	record = binary.BigEndian.AppendUint16(record, 443)
+	record = append(record, 6)
+	record = binary.BigEndian.AppendUint64(record, 1500)
+	record = binary.BigEndian.AppendUint64(record, 3)
+	dataSet := binary.BigEndian.AppendUint16(nil, 256)
+	dataSet = binary.BigEndian.AppendUint16(dataSet, uint16(4+len(record)))
+	dataSet = append(dataSet, record...)
+
+	packet := binary.BigEndian.AppendUint16(nil, ipfixVersion)
+	packet = binary.BigEndian.AppendUint16(packet, uint16(16+len(templateSet)+len(dataSet)))
+	packet = binary.BigEndian.AppendUint32(packet, uint32(time.Now().Unix()))
+	packet = binary.BigEndian.AppendUint32(packet, 1)
+	packet = binary.BigEndian.AppendUint32(packet, 42)
+	packet = append(packet, templateSet...)
+	return append(packet, dataSet...)
+}
+
+func TestIPFIX(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Scheme = "ipfix"
+	nr, err := newNetflowReceiver(receivertest.NewNopSettings(metadata.Type), *cfg)
+	require.NoError(t, err)
+	logsSink := new(consumertest.LogsSink)
+	metricsSink := new(consumertest.MetricsSink)
+	nr.logConsumer = logsSink
+	nr.metricConsumer = metricsSink
+
+	decodeFunc, err := nr.buildDecodeFunc()
+	require.NoError(t, err)
+
+	src := netip.MustParseAddrPort("192.0.2.1:4739")
+	require.NoError(t, decodeFunc(&utils.Message{Src: src, Payload: ipfixPacket(t), Received: time.Now()}))
+
+	// Messages which are not IPFIX are rejected
+	netflowV9 := binary.BigEndian.AppendUint16(nil, 9)
+	assert.ErrorIs(t, decodeFunc(&utils.Message{Src: src, Payload: netflowV9}), errNotIPFIX)
+
+	require.Equal(t, 1, logsSink.LogRecordCount())
+	attrs := logsSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw()
+	assert.Equal(t, "ipfix", attrs["flow.type"])
+	assert.Equal(t, "10.0.1.5", attrs["source.address"])
+	assert.Equal(t, "192.0.2.10", attrs["destination.address"])
+	assert.Equal(t, int64(443), attrs["destination.port"])
+	assert.Equal(t, "tcp", attrs["network.transport"])
+	assert.Equal(t, int64(1500), attrs["flow.io.bytes"])
+
+	nr.flushMetrics(t.Context())
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	metric := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, metricBytes, metric.Name())
+	dp := metric.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(1500), dp.IntValue())
+	assert.Equal(t, map[string]any{
+		dimensionSourceSubnet:      "10.0.1.0/24",
+		dimensionDestinationSubnet: "192.0.2.0/24",
+		dimensionDestinationPort:   int64(443),
+		dimensionTransport:         "tcp",
+		dimensionSourceAS:          int64(0),
+		dimensionDestinationAS:     int64(0),
+	}, dp.Attributes().AsRaw())
+}
+
+func TestMetricsEmittedOnShutdown(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Port = 0
+	nr, err := newNetflowReceiver(receivertest.NewNopSettings(metadata.Type), *cfg)
+	require.NoError(t, err)
+	metricsSink := new(consumertest.MetricsSink)
+	nr.metricConsumer = metricsSink
+
+	require.NoError(t, nr.Start(t.Context(), componenttest.NewNopHost()))
+	decodeFunc, err := nr.buildDecodeFunc()
+	require.NoError(t, err)
+	require.NoError(t, decodeFunc(&utils.Message{Src: netip.MustParseAddrPort("192.0.2.1:4739"), Payload: ipfixPacket(t)}))
+	require.NoError(t, nr.Shutdown(t.Context()))
+
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Equal(t, 3, metricsSink.AllMetrics()[0].MetricCount())
 }
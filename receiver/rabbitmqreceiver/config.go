@@ -7,9 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqreceiver/internal/metadata"
@@ -21,6 +25,13 @@ var (
 	errMissingPassword = errors.New(`"password" not specified in config`)
 
 	errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>:<port>`)
+
+	errMissingConsumeEndpoint = errors.New(`"consume::connection::endpoint" not specified in config`)
+	errMissingConsumeUsername = errors.New(`"consume::connection::auth::plain::username" not specified in config`)
+	errInvalidReconnectWait   = errors.New(`"consume::connection::reconnect_wait" must be positive`)
+	errInvalidPrefetchCount   = errors.New(`"consume::prefetch_count" must be positive`)
+	errInvalidRequeueDelay    = errors.New(`"consume::requeue_delay" must be positive`)
+	errInvalidMaxRequeueDelay = errors.New(`"consume::max_requeue_delay" must not be lower than "consume::requeue_delay"`)
 )
 
 const defaultEndpoint = "http://localhost:15672"
@@ -32,10 +43,65 @@ type Config struct {
 	Username                       string              `mapstructure:"username"`
 	Password                       configopaque.String `mapstructure:"password"`
 	metadata.MetricsBuilderConfig  `mapstructure:",squash"`
+	// Consume configures the receiver to consume the messages of queues, such as the ones
+	// the rabbitmq exporter publishes to, instead of scraping the management API.
+	Consume configoptional.Optional[ConsumeConfig] `mapstructure:"consume"`
+}
+
+// ConsumeConfig defines the queues the receiver consumes messages from, and how it connects to RabbitMQ.
+type ConsumeConfig struct {
+	Connection ConnectionConfig `mapstructure:"connection"`
+	Logs       QueueConfig      `mapstructure:"logs"`
+	Metrics    QueueConfig      `mapstructure:"metrics"`
+	Traces     QueueConfig      `mapstructure:"traces"`
+	// PrefetchCount is the maximum number of messages delivered to the receiver and not acknowledged yet.
+	PrefetchCount int `mapstructure:"prefetch_count"`
+	// RequeueDelay is the delay before a message refused by the pipeline is requeued. It's doubled
+	// for each message refused in a row, up to MaxRequeueDelay.
+	RequeueDelay time.Duration `mapstructure:"requeue_delay"`
+	// MaxRequeueDelay is the maximum delay before a message refused by the pipeline is requeued.
+	MaxRequeueDelay time.Duration `mapstructure:"max_requeue_delay"`
+	// EncodingExtensionID is the ID of the encoding extension used to unmarshal the messages,
+	// which are OTLP protobuf otherwise.
+	EncodingExtensionID *component.ID `mapstructure:"encoding_extension"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// ConnectionConfig defines how the receiver connects to RabbitMQ using the AMQP 0.9.1 protocol.
+type ConnectionConfig struct {
+	Endpoint          string                  `mapstructure:"endpoint"`
+	VHost             string                  `mapstructure:"vhost"`
+	TLSConfig         *configtls.ClientConfig `mapstructure:"tls"`
+	Auth              AuthConfig              `mapstructure:"auth"`
+	ConnectionTimeout time.Duration           `mapstructure:"connection_timeout"`
+	Heartbeat         time.Duration           `mapstructure:"heartbeat"`
+	ReconnectWait     time.Duration           `mapstructure:"reconnect_wait"`
+	Name              string                  `mapstructure:"name"`
+}
+
+type AuthConfig struct {
+	Plain PlainAuth `mapstructure:"plain"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+type PlainAuth struct {
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+}
+
+type QueueConfig struct {
+	// Queue is the name of the queue messages are consumed from.
+	Queue string `mapstructure:"queue"`
 }
 
 // Validate validates the configuration by checking for missing or invalid fields
 func (cfg *Config) Validate() error {
+	if consume := cfg.Consume.Get(); consume != nil {
+		return consume.validate()
+	}
+
 	var err []error
 	if cfg.Username == "" {
 		err = append(err, errMissingUsername)
@@ -53,3 +119,42 @@ func (cfg *Config) Validate() error {
 
 	return errors.Join(err...)
 }
+
+// validate validates the configuration of the consume mode. The settings of the management API
+// aren't validated in this mode, since they aren't used.
+func (cfg *ConsumeConfig) validate() error {
+	var err []error
+	if cfg.Connection.Endpoint == "" {
+		err = append(err, errMissingConsumeEndpoint)
+	}
+
+	// Password-less users are possible so only validate username
+	if cfg.Connection.Auth.Plain.Username == "" {
+		err = append(err, errMissingConsumeUsername)
+	}
+
+	if cfg.Connection.ReconnectWait <= 0 {
+		err = append(err, errInvalidReconnectWait)
+	}
+
+	if cfg.PrefetchCount <= 0 {
+		err = append(err, errInvalidPrefetchCount)
+	}
+
+	if cfg.RequeueDelay <= 0 {
+		err = append(err, errInvalidRequeueDelay)
+	} else if cfg.MaxRequeueDelay < cfg.RequeueDelay {
+		err = append(err, errInvalidMaxRequeueDelay)
+	}
+
+	for _, queue := range []struct {
+		signal string
+		QueueConfig
+	}{{"logs", cfg.Logs}, {"metrics", cfg.Metrics}, {"traces", cfg.Traces}} {
+		if queue.Queue == "" {
+			err = append(err, fmt.Errorf(`"consume::%s::queue" not specified in config`, queue.signal))
+		}
+	}
+
+	return errors.Join(err...)
+}
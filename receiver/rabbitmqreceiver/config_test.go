@@ -13,7 +13,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqreceiver/internal/metadata"
 )
@@ -82,6 +85,63 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			desc: "invalid consume config",
+			cfg: &Config{
+				ClientConfig: clientConfigInvalid,
+				Consume:      configoptional.Some(ConsumeConfig{}),
+			},
+			expectedErr: errors.Join(
+				errMissingConsumeEndpoint,
+				errMissingConsumeUsername,
+				errInvalidReconnectWait,
+				errInvalidPrefetchCount,
+				errInvalidRequeueDelay,
+				errors.New(`"consume::logs::queue" not specified in config`),
+				errors.New(`"consume::metrics::queue" not specified in config`),
+				errors.New(`"consume::traces::queue" not specified in config`),
+			),
+		},
+		{
+			desc: "valid consume config without the management API settings",
+			cfg: &Config{
+				ClientConfig: clientConfigInvalid,
+				Consume: configoptional.Some(ConsumeConfig{
+					Connection: ConnectionConfig{
+						Endpoint:      "amqp://localhost:5672",
+						Auth:          AuthConfig{Plain: PlainAuth{Username: "otelu"}},
+						ReconnectWait: time.Second,
+					},
+					Logs:          QueueConfig{Queue: "logs"},
+					Metrics:       QueueConfig{Queue: "metrics"},
+					Traces:        QueueConfig{Queue: "traces"},
+					PrefetchCount:   1,
+					RequeueDelay:    time.Second,
+					MaxRequeueDelay: time.Second,
+				}),
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "max requeue delay lower than the requeue delay",
+			cfg: &Config{
+				ClientConfig: clientConfigInvalid,
+				Consume: configoptional.Some(ConsumeConfig{
+					Connection: ConnectionConfig{
+						Endpoint:      "amqp://localhost:5672",
+						Auth:          AuthConfig{Plain: PlainAuth{Username: "otelu"}},
+						ReconnectWait: time.Second,
+					},
+					Logs:            QueueConfig{Queue: "logs"},
+					Metrics:         QueueConfig{Queue: "metrics"},
+					Traces:          QueueConfig{Queue: "traces"},
+					PrefetchCount:   1,
+					RequeueDelay:    time.Minute,
+					MaxRequeueDelay: time.Second,
+				}),
+			},
+			expectedErr: errInvalidMaxRequeueDelay,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -115,3 +175,45 @@ func TestLoadConfig(t *testing.T) {
 
 	require.Equal(t, expected, cfg)
 }
+
+func TestLoadConsumeConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "consume").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	encoding := component.MustNewIDWithName("otlp_encoding", "rabbitmq")
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.Consume = configoptional.Some(ConsumeConfig{
+		Connection: ConnectionConfig{
+			Endpoint: "amqps://localhost:5671",
+			VHost:    "telemetry",
+			TLSConfig: &configtls.ClientConfig{
+				Config: configtls.Config{CAFile: "ca.pem"},
+			},
+			Auth: AuthConfig{Plain: PlainAuth{
+				Username: "gateway",
+				Password: "${env:RABBITMQ_PASSWORD}",
+			}},
+			ConnectionTimeout: 10 * time.Second,
+			Heartbeat:         5 * time.Second,
+			ReconnectWait:     time.Second,
+			Name:              "gateway",
+		},
+		Logs:                QueueConfig{Queue: "gateway_logs"},
+		Metrics:             QueueConfig{Queue: "otlp_metrics"},
+		Traces:              QueueConfig{Queue: "otlp_spans"},
+		PrefetchCount:       500,
+		RequeueDelay:        5 * time.Second,
+		MaxRequeueDelay:     10 * time.Minute,
+		EncodingExtensionID: &encoding,
+	})
+
+	require.Equal(t, expected, cfg)
+}
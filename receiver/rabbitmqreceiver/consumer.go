@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rabbitmqreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqreceiver"
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/messaging"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/rabbitmq"
+)
+
+const (
+	transport = "amqp"
+
+	defaultLogsConnectionName    = "otel-collector-logs-consumer"
+	defaultMetricsConnectionName = "otel-collector-metrics-consumer"
+	defaultTracesConnectionName  = "otel-collector-traces-consumer"
+)
+
+type dialFunc = func(rabbitmq.DialConfig) (rabbitmq.Connection, error)
+
+// amqpConsumer consumes the messages of the queue of a signal, and acknowledges them
+// once the data was accepted by the pipeline.
+type amqpConsumer struct {
+	config         *ConsumeConfig
+	settings       receiver.Settings
+	queue          string
+	connectionName string
+	obsrecv        *receiverhelper.ObsReport
+	dial           dialFunc
+	newHandler     func(*unmarshaler) (messaging.Handler, error)
+
+	connection rabbitmq.Connection
+	// refused is the number of messages refused by the pipeline in a row
+	refused int
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func newAMQPConsumer(cfg *ConsumeConfig, set receiver.Settings, queue, defaultConnectionName string) (*amqpConsumer, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	connectionName := defaultConnectionName
+	if cfg.Connection.Name != "" {
+		connectionName = cfg.Connection.Name
+	}
+	return &amqpConsumer{
+		config:         cfg,
+		settings:       set,
+		queue:          queue,
+		connectionName: connectionName,
+		obsrecv:        obsrecv,
+		dial:           rabbitmq.NewAmqpClient(set.Logger).DialConfig,
+	}, nil
+}
+
+func newLogsConsumer(cfg *ConsumeConfig, set receiver.Settings, nextConsumer consumer.Logs) (*amqpConsumer, error) {
+	c, err := newAMQPConsumer(cfg, set, cfg.Logs.Queue, defaultLogsConnectionName)
+	if err != nil {
+		return nil, err
+	}
+	c.newHandler = func(u *unmarshaler) (messaging.Handler, error) {
+		if u.logsUnmarshaler == nil {
+			return nil, fmt.Errorf("encoding extension %q can't unmarshal logs", cfg.EncodingExtensionID)
+		}
+		return messaging.NewLogsHandler(c.obsrecv, u.encoding, u.logsUnmarshaler, nextConsumer), nil
+	}
+	return c, nil
+}
+
+func newMetricsConsumer(cfg *ConsumeConfig, set receiver.Settings, nextConsumer consumer.Metrics) (*amqpConsumer, error) {
+	c, err := newAMQPConsumer(cfg, set, cfg.Metrics.Queue, defaultMetricsConnectionName)
+	if err != nil {
+		return nil, err
+	}
+	c.newHandler = func(u *unmarshaler) (messaging.Handler, error) {
+		if u.metricsUnmarshaler == nil {
+			return nil, fmt.Errorf("encoding extension %q can't unmarshal metrics", cfg.EncodingExtensionID)
+		}
+		return messaging.NewMetricsHandler(c.obsrecv, u.encoding, u.metricsUnmarshaler, nextConsumer), nil
+	}
+	return c, nil
+}
+
+func newTracesConsumer(cfg *ConsumeConfig, set receiver.Settings, nextConsumer consumer.Traces) (*amqpConsumer, error) {
+	c, err := newAMQPConsumer(cfg, set, cfg.Traces.Queue, defaultTracesConnectionName)
+	if err != nil {
+		return nil, err
+	}
+	c.newHandler = func(u *unmarshaler) (messaging.Handler, error) {
+		if u.tracesUnmarshaler == nil {
+			return nil, fmt.Errorf("encoding extension %q can't unmarshal traces", cfg.EncodingExtensionID)
+		}
+		return messaging.NewTracesHandler(c.obsrecv, u.encoding, u.tracesUnmarshaler, nextConsumer), nil
+	}
+	return c, nil
+}
+
+// Start doesn't wait for the connection to RabbitMQ: the consumer connects in the background,
+// and reconnects whenever the connection is lost, so that the collector can start before RabbitMQ.
+func (c *amqpConsumer) Start(ctx context.Context, host component.Host) error {
+	u, err := newUnmarshaler(c.config.EncodingExtensionID, host)
+	if err != nil {
+		return err
+	}
+	handler, err := c.newHandler(u)
+	if err != nil {
+		return err
+	}
+
+	var tlsConfig *tls.Config
+	if c.config.Connection.TLSConfig != nil {
+		tlsConfig, err = c.config.Connection.TLSConfig.LoadTLSConfig(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	dialConfig := rabbitmq.DialConfig{
+		URL:   c.config.Connection.Endpoint,
+		Vhost: c.config.Connection.VHost,
+		Auth: &amqp.PlainAuth{
+			Username: c.config.Connection.Auth.Plain.Username,
+			Password: string(c.config.Connection.Auth.Plain.Password),
+		},
+		ConnectionTimeout: c.config.Connection.ConnectionTimeout,
+		Heartbeat:         c.config.Connection.Heartbeat,
+		TLS:               tlsConfig,
+		ConnectionName:    c.connectionName,
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.wg.Add(1)
+	go c.run(runCtx, dialConfig, handler)
+	return nil
+}
+
+func (c *amqpConsumer) run(ctx context.Context, dialConfig rabbitmq.DialConfig, handler messaging.Handler) {
+	defer c.wg.Done()
+	for {
+		err := c.consume(ctx, dialConfig, handler)
+		if ctx.Err() != nil {
+			return
+		}
+		c.settings.Logger.Warn("Stopped consuming from RabbitMQ, reconnecting",
+			zap.String("queue", c.queue), zap.Duration("reconnect_wait", c.config.Connection.ReconnectWait), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.config.Connection.ReconnectWait):
+		}
+	}
+}
+
+// consume handles the messages delivered to the consumer until the connection or the channel is closed
+func (c *amqpConsumer) consume(ctx context.Context, dialConfig rabbitmq.DialConfig, handler messaging.Handler) error {
+	if err := c.connect(dialConfig); err != nil {
+		return err
+	}
+
+	channel, err := c.connection.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+	defer func() {
+		if !channel.IsClosed() {
+			if err := channel.Close(); err != nil {
+				c.settings.Logger.Warn("Failed closing channel", zap.Error(err))
+			}
+		}
+	}()
+
+	if err := channel.Qos(c.config.PrefetchCount, 0, false); err != nil {
+		return fmt.Errorf("failed to set the prefetch count: %w", err)
+	}
+	// the consumer is cancelled when the context is done, which closes the deliveries
+	deliveries, err := channel.ConsumeWithContext(ctx, c.queue, c.connectionName, false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to consume from queue %q: %w", c.queue, err)
+	}
+	c.settings.Logger.Info("Consuming from RabbitMQ", zap.String("queue", c.queue))
+
+	for delivery := range deliveries {
+		c.handle(ctx, delivery, handler)
+	}
+	return errors.New("the delivery channel was closed")
+}
+
+func (c *amqpConsumer) connect(dialConfig rabbitmq.DialConfig) error {
+	if c.connection == nil {
+		connection, err := c.dial(dialConfig)
+		// the connection is kept even when the first attempt fails, to be restored later
+		if connection != nil {
+			c.connection = connection
+		}
+		return err
+	}
+	return c.connection.ReconnectIfUnhealthy()
+}
+
+// handle acknowledges the message once the data was accepted by the pipeline. Messages which can't be
+// unmarshaled, or are refused with a permanent error, are rejected without being requeued, so that RabbitMQ
+// dead-letters them when the queue has a dead letter exchange, and drops them otherwise. Other messages
+// are requeued to be redelivered after a delay, which blocks the consumer so that messages refused under
+// backpressure aren't redelivered in a loop.
+func (c *amqpConsumer) handle(ctx context.Context, delivery amqp.Delivery, handler messaging.Handler) {
+	err := handler(ctx, delivery.Body, nil)
+	switch {
+	case err == nil:
+		c.refused = 0
+		err = delivery.Ack(false)
+	case consumererror.IsPermanent(err):
+		c.refused = 0
+		c.settings.Logger.Error("Failed to handle message, rejecting it", zap.String("queue", c.queue), zap.Error(err))
+		err = delivery.Nack(false, false)
+	default:
+		c.refused++
+		delay := c.requeueDelay()
+		c.settings.Logger.Warn("Failed to handle message, requeuing it",
+			zap.String("queue", c.queue), zap.Duration("delay", delay), zap.Error(err))
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
+		err = delivery.Nack(false, true)
+	}
+	if err != nil {
+		c.settings.Logger.Warn("Failed to acknowledge message", zap.String("queue", c.queue), zap.Error(err))
+	}
+}
+
+// requeueDelay returns the delay before a refused message is requeued, which is doubled for
+// each message refused in a row.
+func (c *amqpConsumer) requeueDelay() time.Duration {
+	delay := c.config.RequeueDelay
+	for i := 1; i < c.refused && delay < c.config.MaxRequeueDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, c.config.MaxRequeueDelay)
+}
+
+func (c *amqpConsumer) Shutdown(context.Context) error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+	if c.connection != nil {
+		return c.connection.Close()
+	}
+	return nil
+}
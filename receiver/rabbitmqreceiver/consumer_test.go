@@ -0,0 +1,400 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rabbitmqreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqreceiver"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/rabbitmq"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqreceiver/internal/metadata"
+)
+
+func newConsumeConfig() *ConsumeConfig {
+	cfg := createDefaultConfig().(*Config)
+	consume := cfg.Consume.GetOrInsertDefault()
+	consume.Connection.Endpoint = "amqp://localhost:5672"
+	consume.Connection.Auth.Plain.Username = "user"
+	consume.Connection.ReconnectWait = 10 * time.Millisecond
+	consume.RequeueDelay = time.Millisecond
+	consume.MaxRequeueDelay = time.Millisecond
+	return consume
+}
+
+// fakeBroker delivers the messages sent to its deliveries channel to the consumers of its channels
+type fakeBroker struct {
+	deliveries chan amqp.Delivery
+
+	mu          sync.Mutex
+	dials       int
+	dialErr     error
+	reconnects  int
+	qos         []int
+	consumers   []string
+	connClosed  bool
+	acks        []uint64
+	nacks       []uint64
+	requeued    []uint64
+	failConsume bool
+}
+
+func newFakeBroker() *fakeBroker {
+	return &fakeBroker{deliveries: make(chan amqp.Delivery)}
+}
+
+func (b *fakeBroker) dial(rabbitmq.DialConfig) (rabbitmq.Connection, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.dials++
+	if b.dialErr != nil {
+		err := b.dialErr
+		b.dialErr = nil
+		return nil, err
+	}
+	return &fakeConnection{broker: b}, nil
+}
+
+func (b *fakeBroker) deliver(t *testing.T, tag uint64, body []byte) {
+	t.Helper()
+	select {
+	case b.deliveries <- amqp.Delivery{Acknowledger: b, DeliveryTag: tag, Body: body}:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "message not consumed")
+	}
+}
+
+func (b *fakeBroker) Ack(tag uint64, _ bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.acks = append(b.acks, tag)
+	return nil
+}
+
+func (b *fakeBroker) Nack(tag uint64, _, requeue bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if requeue {
+		b.requeued = append(b.requeued, tag)
+	} else {
+		b.nacks = append(b.nacks, tag)
+	}
+	return nil
+}
+
+func (b *fakeBroker) Reject(tag uint64, requeue bool) error {
+	return b.Nack(tag, false, requeue)
+}
+
+// settled returns the tags of the acknowledged, rejected and requeued messages
+func (b *fakeBroker) settled() (acks, nacks, requeued []uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]uint64(nil), b.acks...), append([]uint64(nil), b.nacks...), append([]uint64(nil), b.requeued...)
+}
+
+type fakeConnection struct {
+	broker *fakeBroker
+}
+
+func (c *fakeConnection) ReconnectIfUnhealthy() error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	c.broker.reconnects++
+	return nil
+}
+
+func (c *fakeConnection) IsClosed() bool {
+	return false
+}
+
+func (c *fakeConnection) Channel() (rabbitmq.Channel, error) {
+	return &fakeChannel{broker: c.broker}, nil
+}
+
+func (c *fakeConnection) NotifyClose(receiver chan *amqp.Error) chan *amqp.Error {
+	return receiver
+}
+
+func (c *fakeConnection) Close() error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	c.broker.connClosed = true
+	return nil
+}
+
+type fakeChannel struct {
+	broker *fakeBroker
+	closed bool
+}
+
+func (*fakeChannel) Confirm(bool) error {
+	return nil
+}
+
+func (*fakeChannel) PublishWithDeferredConfirmWithContext(context.Context, string, string, bool, bool, amqp.Publishing) (rabbitmq.DeferredConfirmation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (c *fakeChannel) Qos(prefetchCount, _ int, _ bool) error {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	c.broker.qos = append(c.broker.qos, prefetchCount)
+	return nil
+}
+
+// ConsumeWithContext forwards the deliveries of the broker until the context is done. It fails
+// when the broker is told to fail the next consumer, as RabbitMQ does when the queue doesn't exist.
+func (c *fakeChannel) ConsumeWithContext(ctx context.Context, queue, consumer string, _, _, _, _ bool, _ amqp.Table) (<-chan amqp.Delivery, error) {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+	c.broker.consumers = append(c.broker.consumers, queue+"/"+consumer)
+	if c.broker.failConsume {
+		c.broker.failConsume = false
+		return nil, errors.New("NOT_FOUND - no queue")
+	}
+	out := make(chan amqp.Delivery)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case delivery, ok := <-c.broker.deliveries:
+				if !ok {
+					return
+				}
+				out <- delivery
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (c *fakeChannel) IsClosed() bool {
+	return c.closed
+}
+
+func (c *fakeChannel) Close() error {
+	c.closed = true
+	return nil
+}
+
+func startConsumer(t *testing.T, c *amqpConsumer, broker *fakeBroker) {
+	t.Helper()
+	c.dial = broker.dial
+	require.NoError(t, c.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, c.Shutdown(context.Background())) })
+}
+
+func TestConsumeLogs(t *testing.T) {
+	broker := newFakeBroker()
+	cfg := newConsumeConfig()
+	cfg.PrefetchCount = 10
+	var attempts int
+	sink := new(consumertest.LogsSink)
+	next, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		attempts++
+		switch attempts {
+		case 2:
+			return errors.New("pipeline failure")
+		case 3:
+			return consumererror.NewPermanent(errors.New("invalid data"))
+		}
+		return sink.ConsumeLogs(ctx, ld)
+	})
+	require.NoError(t, err)
+	c, err := newLogsConsumer(cfg, receivertest.NewNopSettings(metadata.Type), next)
+	require.NoError(t, err)
+	startConsumer(t, c, broker)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("test")
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+
+	for tag := uint64(1); tag <= 3; tag++ {
+		broker.deliver(t, tag, data)
+	}
+	broker.deliver(t, 4, []byte("invalid"))
+	broker.deliver(t, 5, data)
+
+	require.Eventually(t, func() bool {
+		acks, nacks, requeued := broker.settled()
+		return len(acks)+len(nacks)+len(requeued) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	acks, nacks, requeued := broker.settled()
+	assert.Equal(t, []uint64{1, 5}, acks)
+	assert.Equal(t, []uint64{3, 4}, nacks)
+	assert.Equal(t, []uint64{2}, requeued)
+	assert.Equal(t, 2, sink.LogRecordCount())
+	assert.Equal(t, []int{10}, broker.qos)
+	assert.Equal(t, []string{"otlp_logs/otel-collector-logs-consumer"}, broker.consumers)
+}
+
+func TestConsumeDelaysRequeues(t *testing.T) {
+	broker := newFakeBroker()
+	cfg := newConsumeConfig()
+	cfg.RequeueDelay = 50 * time.Millisecond
+	cfg.MaxRequeueDelay = 100 * time.Millisecond
+	// the pipeline refuses three messages in a row, as under backpressure, accepts the fourth one,
+	// and refuses the fifth one
+	var attempts []time.Time
+	next, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		attempts = append(attempts, time.Now())
+		if len(attempts) == 4 {
+			return nil
+		}
+		return errors.New("pipeline refused the data")
+	})
+	require.NoError(t, err)
+	c, err := newLogsConsumer(cfg, receivertest.NewNopSettings(metadata.Type), next)
+	require.NoError(t, err)
+	startConsumer(t, c, broker)
+
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(plog.NewLogs())
+	require.NoError(t, err)
+	// the same message is redelivered after being requeued
+	for range 5 {
+		broker.deliver(t, 1, data)
+	}
+	require.Eventually(t, func() bool {
+		acks, _, requeued := broker.settled()
+		return len(acks)+len(requeued) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	acks, nacks, requeued := broker.settled()
+	assert.Equal(t, []uint64{1}, acks)
+	assert.Empty(t, nacks)
+	assert.Equal(t, []uint64{1, 1, 1, 1}, requeued)
+
+	// the next message is only delivered once the refused one was requeued, after a delay which
+	// is doubled for each message refused in a row, up to max_requeue_delay
+	require.Len(t, attempts, 5)
+	assert.GreaterOrEqual(t, attempts[1].Sub(attempts[0]), 50*time.Millisecond)
+	assert.GreaterOrEqual(t, attempts[2].Sub(attempts[1]), 100*time.Millisecond)
+	assert.GreaterOrEqual(t, attempts[3].Sub(attempts[2]), 100*time.Millisecond)
+	assert.Equal(t, 1, c.refused)
+}
+
+func TestConsumeMetricsAndTraces(t *testing.T) {
+	broker := newFakeBroker()
+	cfg := newConsumeConfig()
+	cfg.Connection.Name = "gateway"
+	metricsSink := new(consumertest.MetricsSink)
+	mc, err := newMetricsConsumer(cfg, receivertest.NewNopSettings(metadata.Type), metricsSink)
+	require.NoError(t, err)
+	startConsumer(t, mc, broker)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	data, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	broker.deliver(t, 1, data)
+	require.Eventually(t, func() bool { return metricsSink.DataPointCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, mc.Shutdown(t.Context()))
+
+	tracesSink := new(consumertest.TracesSink)
+	tc, err := newTracesConsumer(cfg, receivertest.NewNopSettings(metadata.Type), tracesSink)
+	require.NoError(t, err)
+	startConsumer(t, tc, broker)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("test")
+	data, err = (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	broker.deliver(t, 2, data)
+	require.Eventually(t, func() bool { return tracesSink.SpanCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, []string{"otlp_metrics/gateway", "otlp_spans/gateway"}, broker.consumers)
+}
+
+func TestConsumeReconnects(t *testing.T) {
+	broker := newFakeBroker()
+	broker.dialErr = errors.New("connection refused")
+	broker.failConsume = true
+	sink := new(consumertest.LogsSink)
+	c, err := newLogsConsumer(newConsumeConfig(), receivertest.NewNopSettings(metadata.Type), sink)
+	require.NoError(t, err)
+	startConsumer(t, c, broker)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	// the consumer connects again after the dial failure, and consumes again after the consume failure
+	broker.deliver(t, 1, data)
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	broker.mu.Lock()
+	assert.Equal(t, 2, broker.dials)
+	assert.Equal(t, 1, broker.reconnects)
+	assert.Len(t, broker.consumers, 2)
+	broker.mu.Unlock()
+
+	require.NoError(t, c.Shutdown(t.Context()))
+	broker.mu.Lock()
+	assert.True(t, broker.connClosed)
+	broker.mu.Unlock()
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type logsUnmarshalerExtension struct {
+	nopExtension
+	plog.ProtoUnmarshaler
+}
+
+type fakeHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h fakeHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestConsumeStartErrors(t *testing.T) {
+	encoding := component.MustNewIDWithName("otlp_encoding", "rabbitmq")
+	host := fakeHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{
+		encoding: &logsUnmarshalerExtension{},
+	}}
+
+	cfg := newConsumeConfig()
+	cfg.EncodingExtensionID = &encoding
+	lc, err := newLogsConsumer(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+	require.NoError(t, err)
+	lc.dial = newFakeBroker().dial
+	require.NoError(t, lc.Start(t.Context(), host))
+	require.NoError(t, lc.Shutdown(t.Context()))
+
+	tc, err := newTracesConsumer(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, tc.Start(t.Context(), host), `encoding extension "otlp_encoding/rabbitmq" can't unmarshal traces`)
+	require.NoError(t, tc.Shutdown(t.Context()))
+
+	unknown := component.MustNewID("unknown")
+	cfg.EncodingExtensionID = &unknown
+	mc, err := newMetricsConsumer(cfg, receivertest.NewNopSettings(metadata.Type), consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, mc.Start(t.Context(), host), `unknown encoding "unknown"`)
+	require.NoError(t, mc.Shutdown(t.Context()))
+}
@@ -10,6 +10,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/scraper"
@@ -18,14 +19,33 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqreceiver/internal/metadata"
 )
 
-var errConfigNotRabbit = errors.New("config was not a RabbitMQ receiver config")
+var (
+	errConfigNotRabbit      = errors.New("config was not a RabbitMQ receiver config")
+	errConsumeNotConfigured = errors.New("logs and traces can only be received when consume is configured")
+)
+
+const (
+	defaultAMQPConnectionTimeout = 10 * time.Second
+	defaultAMQPHeartbeat         = 5 * time.Second
+	defaultAMQPReconnectWait     = 5 * time.Second
+	defaultPrefetchCount         = 100
+	defaultRequeueDelay          = time.Second
+	defaultMaxRequeueDelay       = time.Minute
+
+	// the default queues are the ones the rabbitmq exporter publishes to with its default routing keys
+	defaultLogsQueue    = "otlp_logs"
+	defaultMetricsQueue = "otlp_metrics"
+	defaultTracesQueue  = "otlp_spans"
+)
 
 // NewFactory creates a new receiver factory
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability))
 }
 
 func createDefaultConfig() component.Config {
@@ -40,7 +60,44 @@ func createDefaultConfig() component.Config {
 		ControllerConfig:     cfg,
 		ClientConfig:         clientConfig,
 		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Consume: configoptional.Default(ConsumeConfig{
+			Connection: ConnectionConfig{
+				ConnectionTimeout: defaultAMQPConnectionTimeout,
+				Heartbeat:         defaultAMQPHeartbeat,
+				ReconnectWait:     defaultAMQPReconnectWait,
+			},
+			Logs:            QueueConfig{Queue: defaultLogsQueue},
+			Metrics:         QueueConfig{Queue: defaultMetricsQueue},
+			Traces:          QueueConfig{Queue: defaultTracesQueue},
+			PrefetchCount:   defaultPrefetchCount,
+			RequeueDelay:    defaultRequeueDelay,
+			MaxRequeueDelay: defaultMaxRequeueDelay,
+		}),
+	}
+}
+
+func createLogsReceiver(_ context.Context, params receiver.Settings, rConf component.Config, consumer consumer.Logs) (receiver.Logs, error) {
+	cfg, ok := rConf.(*Config)
+	if !ok {
+		return nil, errConfigNotRabbit
 	}
+	consume := cfg.Consume.Get()
+	if consume == nil {
+		return nil, errConsumeNotConfigured
+	}
+	return newLogsConsumer(consume, params, consumer)
+}
+
+func createTracesReceiver(_ context.Context, params receiver.Settings, rConf component.Config, consumer consumer.Traces) (receiver.Traces, error) {
+	cfg, ok := rConf.(*Config)
+	if !ok {
+		return nil, errConfigNotRabbit
+	}
+	consume := cfg.Consume.Get()
+	if consume == nil {
+		return nil, errConsumeNotConfigured
+	}
+	return newTracesConsumer(consume, params, consumer)
 }
 
 func createMetricsReceiver(_ context.Context, params receiver.Settings, rConf component.Config, consumer consumer.Metrics) (receiver.Metrics, error) {
@@ -49,6 +106,10 @@ func createMetricsReceiver(_ context.Context, params receiver.Settings, rConf co
 		return nil, errConfigNotRabbit
 	}
 
+	if consume := cfg.Consume.Get(); consume != nil {
+		return newMetricsConsumer(consume, params, consumer)
+	}
+
 	rabbitScraper := newScraper(params.Logger, cfg, params)
 	s, err := scraper.NewMetrics(rabbitScraper.scrape, scraper.WithStart(rabbitScraper.start))
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
@@ -45,6 +46,19 @@ func TestNewFactory(t *testing.T) {
 					},
 					ClientConfig:         clientConfig,
 					MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+					Consume: configoptional.Default(ConsumeConfig{
+						Connection: ConnectionConfig{
+							ConnectionTimeout: 10 * time.Second,
+							Heartbeat:         5 * time.Second,
+							ReconnectWait:     5 * time.Second,
+						},
+						Logs:            QueueConfig{Queue: "otlp_logs"},
+						Metrics:         QueueConfig{Queue: "otlp_metrics"},
+						Traces:          QueueConfig{Queue: "otlp_spans"},
+						PrefetchCount:   100,
+						RequeueDelay:    time.Second,
+						MaxRequeueDelay: time.Minute,
+					}),
 				}
 
 				require.Equal(t, expectedCfg, factory.CreateDefaultConfig())
@@ -77,6 +91,43 @@ func TestNewFactory(t *testing.T) {
 				require.ErrorIs(t, err, errConfigNotRabbit)
 			},
 		},
+		{
+			desc: "creates a new factory and CreateMetrics returns a consumer in consume mode",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig().(*Config)
+				cfg.Consume.GetOrInsertDefault().Connection.Endpoint = "amqp://localhost:5672"
+				r, err := factory.CreateMetrics(
+					t.Context(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.IsType(t, &amqpConsumer{}, r)
+			},
+		},
+		{
+			desc: "creates a new factory and CreateLogs and CreateTraces return error without consume mode",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				_, err := factory.CreateLogs(
+					t.Context(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.ErrorIs(t, err, errConsumeNotConfigured)
+				_, err = factory.CreateTraces(
+					t.Context(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.ErrorIs(t, err, errConsumeNotConfigured)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
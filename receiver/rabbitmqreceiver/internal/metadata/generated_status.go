@@ -12,5 +12,7 @@ var (
 )
 
 const (
+	LogsStability    = component.StabilityLevelDevelopment
+	TracesStability  = component.StabilityLevelDevelopment
 	MetricsStability = component.StabilityLevelBeta
 )
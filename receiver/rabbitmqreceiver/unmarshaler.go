@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rabbitmqreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/rabbitmqreceiver"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// unmarshaler decodes the messages published by the rabbitmq exporter, which uses
+// the same encodings: OTLP protobuf by default, or an encoding extension.
+type unmarshaler struct {
+	// encoding is the name of the encoding, reported in the telemetry of the receiver
+	encoding           string
+	logsUnmarshaler    plog.Unmarshaler
+	tracesUnmarshaler  ptrace.Unmarshaler
+	metricsUnmarshaler pmetric.Unmarshaler
+}
+
+func newUnmarshaler(encoding *component.ID, host component.Host) (*unmarshaler, error) {
+	var (
+		name                                   = "otlp_proto"
+		logsUnmarshaler    plog.Unmarshaler    = &plog.ProtoUnmarshaler{}
+		tracesUnmarshaler  ptrace.Unmarshaler  = &ptrace.ProtoUnmarshaler{}
+		metricsUnmarshaler pmetric.Unmarshaler = &pmetric.ProtoUnmarshaler{}
+	)
+
+	if encoding != nil {
+		ext, ok := host.GetExtensions()[*encoding]
+		if !ok {
+			return nil, fmt.Errorf("unknown encoding %q", encoding)
+		}
+
+		name = encoding.String()
+		logsUnmarshaler, _ = ext.(plog.Unmarshaler)
+		tracesUnmarshaler, _ = ext.(ptrace.Unmarshaler)
+		metricsUnmarshaler, _ = ext.(pmetric.Unmarshaler)
+	}
+
+	u := unmarshaler{
+		encoding:           name,
+		logsUnmarshaler:    logsUnmarshaler,
+		tracesUnmarshaler:  tracesUnmarshaler,
+		metricsUnmarshaler: metricsUnmarshaler,
+	}
+	return &u, nil
+}
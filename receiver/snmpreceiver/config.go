@@ -24,6 +24,7 @@ const (
 	defaultSecurityLevel      = "no_auth_no_priv"
 	defaultAuthType           = "MD5"
 	defaultPrivacyType        = "DES"
+	defaultTrapsEndpoint      = "udp://0.0.0.0:162"
 )
 
 var (
@@ -51,6 +52,8 @@ var (
 	errMsgColumnResourceAttributeBadName            = `metric '%s' column_oid resource_attribute '%s' must match a resource_attribute config`
 	errMsgColumnIndexedIdentifierRequired           = `metric '%s' column_oid must either have an indexed resource_attribute or an indexed_value_prefix/oid attribute`
 	errMsgMultipleKeysSetOnResourceAttribute        = `resource attribute '%s' must have only one of oid, scalar_oid, or indexed_value_prefix`
+	errMsgTrapCounterNoOID                          = `trap counter '%s' must contain a trap_oid`
+	errMsgInvalidTrapsEndpoint                      = `invalid traps endpoint '%s': must be in 'udp://[host]:[port]' format`
 	errScalarOIDResourceAttributeEndsInNonzeroDigit = `resource attribute '%s' has scalar_oid '%s' that ends in a nonzero digit (scalar oids should not be indexed)`
 	errColumnOIDResourceAttributeEndsInZero         = `resource attribute '%s' has oid '%s' that ends in a zero (column oids should be indexed)`
 
@@ -132,6 +135,38 @@ type Config struct {
 	// Metrics defines what SNMP metrics will be collected for this receiver and is composed of metric
 	// names along with their metric configurations
 	Metrics map[string]*MetricConfig `mapstructure:"metrics"`
+
+	// Traps configures the reception of SNMP traps and informs, which are turned into log records and
+	// optionally counted as metrics. The version, community and v3 security configs are used to
+	// authenticate the traps. Metrics are only required to be configured when Traps isn't.
+	Traps *TrapsConfig `mapstructure:"traps"`
+}
+
+// TrapsConfig contains config info about the reception of SNMP traps and informs
+type TrapsConfig struct {
+	// Endpoint is the address to listen on for traps. Must be formatted as udp://{host}:{port}.
+	// Default: udp://0.0.0.0:162
+	Endpoint string `mapstructure:"endpoint"`
+	// OIDNames is optional and maps OIDs to names. Names are resolved by longest prefix, the
+	// remaining suffix of the OID being appended to the name (Ex: ifIndex.2)
+	OIDNames map[string]string `mapstructure:"oid_names"`
+	// OIDNamesFiles is optional and is a list of files holding OID names derived from MIBs. Each
+	// line holds a name and an OID separated by whitespace, as in the output of `snmptranslate -Tz`
+	OIDNamesFiles []string `mapstructure:"oid_names_files"`
+	// Counters is optional and defines sum metrics counting the received traps, composed of metric
+	// names along with their counter configurations
+	Counters map[string]*TrapCounterConfig `mapstructure:"counters"`
+}
+
+// TrapCounterConfig contains config info about a metric counting the received traps of a given OID
+type TrapCounterConfig struct {
+	// Description is optional and describes what this metric represents
+	Description string `mapstructure:"description"`
+	// TrapOID is required and is the OID of the counted traps (snmpTrapOID.0 for v2c and v3 traps)
+	TrapOID string `mapstructure:"trap_oid"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
 }
 
 // ResourceAttributeConfig contains config info about all of the resource attributes that will be used by this receiver.
@@ -263,6 +298,29 @@ func (cfg *Config) Validate() error {
 		combinedErr = errors.Join(combinedErr, validateSecurity(cfg))
 	}
 	combinedErr = errors.Join(combinedErr, validateMetricConfigs(cfg))
+	if cfg.Traps != nil {
+		combinedErr = errors.Join(combinedErr, validateTraps(cfg.Traps))
+	}
+
+	return combinedErr
+}
+
+// validateTraps validates the TrapsConfig
+func validateTraps(traps *TrapsConfig) error {
+	var combinedErr error
+
+	if traps.Endpoint != "" {
+		u, err := url.Parse(traps.Endpoint)
+		if err != nil || !strings.EqualFold(u.Scheme, "udp") || u.Port() == "" {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgInvalidTrapsEndpoint, traps.Endpoint))
+		}
+	}
+
+	for metricName, counter := range traps.Counters {
+		if counter == nil || counter.TrapOID == "" {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf(errMsgTrapCounterNoOID, metricName))
+		}
+	}
 
 	return combinedErr
 }
@@ -391,9 +449,12 @@ func validateMetricConfigs(cfg *Config) error {
 	combinedErr = errors.Join(combinedErr, validateAttributeConfigs(cfg))
 	combinedErr = errors.Join(combinedErr, validateResourceAttributeConfigs(cfg))
 
-	// Ensure there is at least one MetricConfig
+	// Ensure there is at least one MetricConfig, unless traps are received
 	metrics := cfg.Metrics
 	if len(metrics) == 0 {
+		if cfg.Traps != nil {
+			return combinedErr
+		}
 		return errors.Join(combinedErr, errMetricRequired)
 	}
 
@@ -1142,3 +1142,82 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfigTrapsConfigs(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+
+	type testCase struct {
+		name        string
+		nameVal     string
+		expectedCfg *Config
+		expectedErr string
+	}
+
+	expectedConfigGood := factory.CreateDefaultConfig().(*Config)
+	expectedConfigGood.Traps = &TrapsConfig{
+		Endpoint:      "udp://0.0.0.0:1162",
+		OIDNames:      map[string]string{"1.3.6.1.6.3.1.1.5.3": "linkDown"},
+		OIDNamesFiles: []string{"testdata/traps/oid_names.txt"},
+		Counters: map[string]*TrapCounterConfig{
+			"snmp.traps.link_down": {
+				Description: "Number of linkDown traps",
+				TrapOID:     "1.3.6.1.6.3.1.1.5.3",
+			},
+		},
+	}
+
+	expectedConfigInvalidEndpoint := factory.CreateDefaultConfig().(*Config)
+	expectedConfigInvalidEndpoint.Traps = &TrapsConfig{
+		Endpoint: "tcp://0.0.0.0:1162",
+	}
+
+	expectedConfigCounterNoTrapOID := factory.CreateDefaultConfig().(*Config)
+	expectedConfigCounterNoTrapOID.Traps = &TrapsConfig{
+		Counters: map[string]*TrapCounterConfig{
+			"snmp.traps.link_down": {
+				Description: "Number of linkDown traps",
+			},
+		},
+	}
+
+	testCases := []testCase{
+		{
+			name:        "TrapsWithoutMetricsIsValid",
+			nameVal:     "traps_good",
+			expectedCfg: expectedConfigGood,
+			expectedErr: "",
+		},
+		{
+			name:        "TrapsInvalidEndpointErrors",
+			nameVal:     "traps_invalid_endpoint",
+			expectedCfg: expectedConfigInvalidEndpoint,
+			expectedErr: fmt.Sprintf(errMsgInvalidTrapsEndpoint, "tcp://0.0.0.0:1162"),
+		},
+		{
+			name:        "TrapsCounterNoTrapOIDErrors",
+			nameVal:     "traps_counter_no_trap_oid",
+			expectedCfg: expectedConfigCounterNoTrapOID,
+			expectedErr: fmt.Sprintf(errMsgTrapCounterNoOID, "snmp.traps.link_down"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			sub, err := cm.Sub(component.NewIDWithName(metadata.Type, test.nameVal).String())
+			require.NoError(t, err)
+
+			cfg := factory.CreateDefaultConfig()
+			require.NoError(t, sub.Unmarshal(cfg))
+			if test.expectedErr == "" {
+				require.NoError(t, xconfmap.Validate(cfg))
+			} else {
+				require.ErrorContains(t, xconfmap.Validate(cfg), test.expectedErr)
+			}
+
+			require.Equal(t, test.expectedCfg, cfg)
+		})
+	}
+}
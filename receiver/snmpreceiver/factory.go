@@ -17,17 +17,26 @@ import (
 	"go.opentelemetry.io/collector/scraper"
 	"go.opentelemetry.io/collector/scraper/scraperhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
 )
 
-var errConfigNotSNMP = errors.New("config was not a SNMP receiver config")
+var (
+	errConfigNotSNMP = errors.New("config was not a SNMP receiver config")
+	errTrapsRequired = errors.New("traps must be configured to receive logs")
+)
+
+// trapReceivers holds the trap receivers shared between the logs and metrics receivers of a config,
+// so that they listen on a single endpoint
+var trapReceivers = sharedcomponent.NewSharedComponents()
 
 // NewFactory creates a new receiver factory for SNMP
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability))
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
 }
 
 // createDefaultConfig creates a config for SNMP with as many default values as possible
@@ -62,13 +71,76 @@ func createMetricsReceiver(
 		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
 	}
 
-	snmpScraper := newScraper(params.Logger, snmpConfig, params)
-	s, err := scraper.NewMetrics(snmpScraper.scrape, scraper.WithStart(snmpScraper.start))
-	if err != nil {
-		return nil, err
+	var receivers []component.Component
+	if len(snmpConfig.Metrics) > 0 {
+		snmpScraper := newScraper(params.Logger, snmpConfig, params)
+		s, err := scraper.NewMetrics(snmpScraper.scrape, scraper.WithStart(snmpScraper.start))
+		if err != nil {
+			return nil, err
+		}
+
+		controller, err := scraperhelper.NewMetricsController(&snmpConfig.ControllerConfig, params, consumer, scraperhelper.AddScraper(metadata.Type, s))
+		if err != nil {
+			return nil, err
+		}
+		if snmpConfig.Traps == nil {
+			return controller, nil
+		}
+		receivers = append(receivers, controller)
 	}
 
-	return scraperhelper.NewMetricsController(&snmpConfig.ControllerConfig, params, consumer, scraperhelper.AddScraper(metadata.Type, s))
+	// Count the received traps
+	r := trapReceivers.GetOrAdd(snmpConfig, func() component.Component {
+		return newTrapReceiver(snmpConfig, params)
+	})
+	r.Unwrap().(*trapReceiver).metricsConsumer = consumer
+	return &multiReceiver{receivers: append(receivers, r)}, nil
+}
+
+// createLogsReceiver creates the logs receiver for SNMP, turning the received traps into logs
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.Settings,
+	config component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	snmpConfig, ok := config.(*Config)
+	if !ok {
+		return nil, errConfigNotSNMP
+	}
+
+	if snmpConfig.Traps == nil {
+		return nil, errTrapsRequired
+	}
+
+	r := trapReceivers.GetOrAdd(snmpConfig, func() component.Component {
+		return newTrapReceiver(snmpConfig, params)
+	})
+	r.Unwrap().(*trapReceiver).logsConsumer = consumer
+	return r, nil
+}
+
+// multiReceiver starts and shuts down several receivers, for metrics which are both polled and
+// counted from traps
+type multiReceiver struct {
+	receivers []component.Component
+}
+
+func (m *multiReceiver) Start(ctx context.Context, host component.Host) error {
+	for _, r := range m.receivers {
+		if err := r.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *multiReceiver) Shutdown(ctx context.Context) error {
+	var errs error
+	for _, r := range m.receivers {
+		errs = errors.Join(errs, r.Shutdown(ctx))
+	}
+	return errs
 }
 
 // addMissingConfigDefaults adds any missing config parameters that have defaults
@@ -259,6 +259,35 @@ func TestNewFactory(t *testing.T) {
 				require.Equal(t, "1", snmpCfg.Metrics["m1"].Unit)
 			},
 		},
+		{
+			desc: "creates a new factory and CreateLogs returns no error with traps config",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				cfg.(*Config).Traps = &TrapsConfig{}
+				_, err := factory.CreateLogs(
+					t.Context(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+			},
+		},
+		{
+			desc: "creates a new factory and CreateLogs returns error without traps config",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				_, err := factory.CreateLogs(
+					t.Context(),
+					receivertest.NewNopSettings(metadata.Type),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.ErrorIs(t, err, errTrapsRequired)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// oidResolver resolves OIDs to names
+type oidResolver struct {
+	names map[string]string
+}
+
+// newOIDResolver creates an oidResolver from the OID names and the OID names files of the config
+func newOIDResolver(cfg *TrapsConfig) (*oidResolver, error) {
+	resolver := &oidResolver{
+		names: make(map[string]string),
+	}
+
+	for _, file := range cfg.OIDNamesFiles {
+		if err := resolver.loadFile(file); err != nil {
+			return nil, err
+		}
+	}
+
+	// Names from the config take precedence over the ones from the files
+	for oid, name := range cfg.OIDNames {
+		resolver.names[normalizeOID(oid)] = name
+	}
+
+	return resolver, nil
+}
+
+// loadFile loads the OID names of a file. Each line holds a name and an OID separated by whitespace,
+// optionally surrounded by double quotes. Empty lines and lines starting with '#' are ignored.
+func (r *oidResolver) loadFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to open OID names file '%s': %w", file, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return fmt.Errorf("invalid line %d in OID names file '%s': must contain a name and an OID", lineNumber, file)
+		}
+		name, oid := strings.Trim(fields[0], `"`), strings.Trim(fields[1], `"`)
+		r.names[normalizeOID(oid)] = name
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read OID names file '%s': %w", file, err)
+	}
+
+	return nil
+}
+
+// resolve returns the name of the longest prefix of the OID which has a name, followed by the remaining
+// suffix of the OID. The normalized OID is returned if none of its prefixes has a name.
+func (r *oidResolver) resolve(oid string) string {
+	oid = normalizeOID(oid)
+	for prefix := oid; prefix != ""; {
+		if name, ok := r.names[prefix]; ok {
+			return name + oid[len(prefix):]
+		}
+		i := strings.LastIndexByte(prefix, '.')
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	return oid
+}
+
+// normalizeOID removes the leading dot of the OID if any
+func normalizeOID(oid string) string {
+	return strings.TrimPrefix(oid, ".")
+}
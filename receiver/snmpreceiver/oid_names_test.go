@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDResolver(t *testing.T) {
+	resolver, err := newOIDResolver(&TrapsConfig{
+		OIDNamesFiles: []string{"testdata/traps/oid_names.txt"},
+		OIDNames: map[string]string{
+			".1.3.6.1.6.3.1.1.5.3": "IF-MIB::linkDown",
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		oid      string
+		expected string
+	}{
+		{oid: ".1.3.6.1.2.1.1.3.0", expected: "sysUpTime.0"},
+		{oid: "1.3.6.1.2.1.1.5.0", expected: "system.5.0"},
+		{oid: "1.3.6.1.2.1.2.2.1.1.7", expected: "ifIndex.7"},
+		{oid: ".1.3.6.1.6.3.1.1.5.3", expected: "IF-MIB::linkDown"},
+		{oid: ".1.3.6.1.4.1.9999.1", expected: "1.3.6.1.4.1.9999.1"},
+		{oid: "", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.oid, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolver.resolve(tt.oid))
+		})
+	}
+}
+
+func TestOIDResolverInvalidFile(t *testing.T) {
+	_, err := newOIDResolver(&TrapsConfig{
+		OIDNamesFiles: []string{"testdata/traps/invalid_oid_names.txt"},
+	})
+	assert.EqualError(t, err, "invalid line 1 in OID names file 'testdata/traps/invalid_oid_names.txt': must contain a name and an OID")
+}
@@ -0,0 +1,388 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
+)
+
+const (
+	// sysUpTimeOID and snmpTrapOID are the OIDs of the first two varbinds of v2c and v3 traps
+	sysUpTimeOID = "1.3.6.1.2.1.1.3.0"
+	snmpTrapOID  = "1.3.6.1.6.3.1.1.4.1.0"
+	// genericTrapsOID is the prefix of the OIDs of the v1 generic traps, as translated by RFC 3584
+	genericTrapsOID = "1.3.6.1.6.3.1.1.5"
+	// enterpriseSpecificTrap is the generic trap type of the v1 enterprise specific traps
+	enterpriseSpecificTrap = 6
+
+	attributeTrapOID          = "snmp.trap.oid"
+	attributeTrapName         = "snmp.trap.name"
+	attributeTrapUptime       = "snmp.trap.uptime"
+	attributeTrapEnterprise   = "snmp.trap.enterprise"
+	attributeTrapAgentAddress = "snmp.trap.agent_address"
+	attributeVersion          = "snmp.version"
+	attributePDUType          = "snmp.pdu_type"
+	attributePeerAddress      = "network.peer.address"
+	attributePeerPort         = "network.peer.port"
+
+	// maxTrapSources is the number of source addresses tracked by a trap counter, beyond which the least
+	// recently seen source is evicted
+	maxTrapSources = 1000
+)
+
+// trapCounter counts the received traps of an OID by source address
+type trapCounter struct {
+	metricName  string
+	description string
+	trapOID     string
+	counts      map[string]*trapSourceCount
+	// seen orders the sources by their last trap, to evict the least recently seen one
+	seen int64
+	// evicted is set once a source has been evicted, the count of a source seen again restarting from now on
+	evicted bool
+}
+
+// trapSourceCount is the count of the traps received from a source address
+type trapSourceCount struct {
+	count    int64
+	start    pcommon.Timestamp
+	lastSeen int64
+}
+
+// trapReceiver listens for SNMP traps and informs, and turns them into logs and metrics
+type trapReceiver struct {
+	cfg      *Config
+	settings receiver.Settings
+
+	logsConsumer    consumer.Logs
+	metricsConsumer consumer.Metrics
+
+	resolver  *oidResolver
+	converter *snmpClient
+	// counters is sorted by metric name
+	counters  []*trapCounter
+	startTime pcommon.Timestamp
+
+	listener *gosnmp.TrapListener
+	wg       sync.WaitGroup
+}
+
+// newTrapReceiver creates a trapReceiver, the consumers being set by the logs and metrics receivers
+func newTrapReceiver(cfg *Config, settings receiver.Settings) *trapReceiver {
+	counters := make([]*trapCounter, 0, len(cfg.Traps.Counters))
+	for metricName, counterCfg := range cfg.Traps.Counters {
+		counters = append(counters, &trapCounter{
+			metricName:  metricName,
+			description: counterCfg.Description,
+			trapOID:     normalizeOID(counterCfg.TrapOID),
+			counts:      make(map[string]*trapSourceCount),
+		})
+	}
+	sort.Slice(counters, func(i, j int) bool {
+		return counters[i].metricName < counters[j].metricName
+	})
+
+	return &trapReceiver{
+		cfg:       cfg,
+		settings:  settings,
+		converter: &snmpClient{logger: settings.Logger},
+		counters:  counters,
+	}
+}
+
+// Start loads the OID names and starts listening for traps
+func (r *trapReceiver) Start(_ context.Context, _ component.Host) error {
+	resolver, err := newOIDResolver(r.cfg.Traps)
+	if err != nil {
+		return err
+	}
+	r.resolver = resolver
+	r.startTime = pcommon.NewTimestampFromTime(time.Now())
+
+	endpoint := r.cfg.Traps.Endpoint
+	if endpoint == "" {
+		endpoint = defaultTrapsEndpoint
+	}
+	// Checked in config
+	trapsURL, _ := url.Parse(endpoint)
+
+	listener := gosnmp.NewTrapListener()
+	listener.Params = newTrapParams(r.cfg)
+	listener.OnNewTrap = r.handleTrap
+	r.listener = listener
+
+	errs := make(chan error, 1)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if err := listener.Listen(trapsURL.Host); err != nil {
+			errs <- err
+		}
+	}()
+
+	select {
+	case <-listener.Listening():
+		return nil
+	case err := <-errs:
+		return fmt.Errorf("failed to listen for traps on '%s': %w", endpoint, err)
+	}
+}
+
+// Shutdown stops listening for traps
+func (r *trapReceiver) Shutdown(context.Context) error {
+	if r.listener == nil {
+		return nil
+	}
+	r.listener.Close()
+	r.wg.Wait()
+	return nil
+}
+
+// newTrapParams creates the gosnmp parameters used to decode and authenticate the traps based on config
+func newTrapParams(cfg *Config) *gosnmp.GoSNMP {
+	params := &otelGoSNMPWrapper{
+		gosnmp.GoSNMP{
+			MaxOids: gosnmp.Default.MaxOids,
+		},
+	}
+	switch cfg.Version {
+	case "v3":
+		params.SetVersion(gosnmp.Version3)
+		setV3ClientConfigs(params, cfg)
+	case "v1":
+		params.SetVersion(gosnmp.Version1)
+		params.SetCommunity(cfg.Community)
+	default:
+		params.SetVersion(gosnmp.Version2c)
+		params.SetCommunity(cfg.Community)
+	}
+	return &params.GoSNMP
+}
+
+// accepts returns whether the trap matches the configured version and community. v1 and v2c traps are
+// both accepted unless v3 is configured, as agents commonly send either.
+func (r *trapReceiver) accepts(packet *gosnmp.SnmpPacket) bool {
+	if r.cfg.Version == "v3" {
+		return packet.Version == gosnmp.Version3
+	}
+	return packet.Version != gosnmp.Version3 && packet.Community == r.cfg.Community
+}
+
+// handleTrap turns a received trap into logs and metrics
+func (r *trapReceiver) handleTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	if !r.accepts(packet) {
+		r.settings.Logger.Debug("Dropping trap with unexpected version or community", zap.Stringer("source", addr))
+		return
+	}
+
+	ctx := context.Background()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	oid := getTrapOID(packet)
+
+	if r.logsConsumer != nil {
+		if err := r.logsConsumer.ConsumeLogs(ctx, r.toLogs(packet, addr, oid, now)); err != nil {
+			r.settings.Logger.Error("Failed to consume trap logs", zap.Error(err))
+		}
+	}
+
+	if r.metricsConsumer != nil {
+		if metrics, ok := r.countTrap(addr, oid, now); ok {
+			if err := r.metricsConsumer.ConsumeMetrics(ctx, metrics); err != nil {
+				r.settings.Logger.Error("Failed to consume trap metrics", zap.Error(err))
+			}
+		}
+	}
+}
+
+// getTrapOID returns the OID identifying the trap. The OIDs of v1 traps are translated as defined by RFC 3584.
+func getTrapOID(packet *gosnmp.SnmpPacket) string {
+	if packet.Version == gosnmp.Version1 {
+		if packet.GenericTrap == enterpriseSpecificTrap {
+			return normalizeOID(packet.Enterprise) + ".0." + strconv.Itoa(packet.SpecificTrap)
+		}
+		return genericTrapsOID + "." + strconv.Itoa(packet.GenericTrap+1)
+	}
+	for _, variable := range packet.Variables {
+		if normalizeOID(variable.Name) == snmpTrapOID {
+			return normalizeOID(toString(variable.Value))
+		}
+	}
+	return ""
+}
+
+// toLogs creates a log record from the trap, its varbinds being added as attributes
+func (r *trapReceiver) toLogs(packet *gosnmp.SnmpPacket, addr *net.UDPAddr, oid string, now pcommon.Timestamp) plog.Logs {
+	logs := plog.NewLogs()
+	scopeLogs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName(metadata.ScopeName)
+	scopeLogs.Scope().SetVersion(r.settings.BuildInfo.Version)
+
+	logRecord := scopeLogs.LogRecords().AppendEmpty()
+	logRecord.SetTimestamp(now)
+	logRecord.SetObservedTimestamp(now)
+
+	attrs := logRecord.Attributes()
+	name := r.resolver.resolve(oid)
+	logRecord.Body().SetStr(name)
+	attrs.PutStr(attributeTrapOID, oid)
+	if name != oid {
+		attrs.PutStr(attributeTrapName, name)
+	}
+	attrs.PutStr(attributeVersion, versionName(packet.Version))
+	if packet.PDUType == gosnmp.InformRequest {
+		attrs.PutStr(attributePDUType, "inform")
+	} else {
+		attrs.PutStr(attributePDUType, "trap")
+	}
+	if addr != nil {
+		attrs.PutStr(attributePeerAddress, addr.IP.String())
+		attrs.PutInt(attributePeerPort, int64(addr.Port))
+	}
+	if packet.Version == gosnmp.Version1 {
+		attrs.PutStr(attributeTrapEnterprise, normalizeOID(packet.Enterprise))
+		attrs.PutStr(attributeTrapAgentAddress, packet.AgentAddress)
+		attrs.PutInt(attributeTrapUptime, int64(packet.Timestamp))
+	}
+
+	for _, variable := range packet.Variables {
+		switch normalizeOID(variable.Name) {
+		case sysUpTimeOID:
+			if uptime, err := r.converter.toInt64(variable.Name, variable.Value); err == nil {
+				attrs.PutInt(attributeTrapUptime, uptime)
+			}
+			continue
+		case snmpTrapOID:
+			continue
+		}
+		r.putVarbind(attrs, variable)
+	}
+
+	return logs
+}
+
+// putVarbind adds the varbind as an attribute named after its OID. Varbinds with values which don't
+// translate well to OTEL are skipped.
+func (r *trapReceiver) putVarbind(attrs pcommon.Map, variable gosnmp.SnmpPDU) {
+	key := r.resolver.resolve(variable.Name)
+	switch variable.Type {
+	case gosnmp.ObjectIdentifier:
+		attrs.PutStr(key, r.resolver.resolve(toString(variable.Value)))
+		return
+	case gosnmp.OctetString:
+		if value, ok := variable.Value.([]byte); ok && !utf8.Valid(value) {
+			attrs.PutStr(key, hex.EncodeToString(value))
+			return
+		}
+	}
+
+	data := r.converter.convertSnmpPDUToSnmpData(variable)
+	switch data.valueType {
+	case integerVal:
+		attrs.PutInt(key, data.value.(int64))
+	case floatVal:
+		attrs.PutDouble(key, data.value.(float64))
+	case stringVal:
+		attrs.PutStr(key, data.value.(string))
+	default:
+		r.settings.Logger.Debug("Skipping varbind with unsupported type", zap.String("oid", variable.Name), zap.Stringer("type", variable.Type))
+	}
+}
+
+// countTrap increments the counters of the trap OID, and returns the metrics of the incremented counters
+func (r *trapReceiver) countTrap(addr *net.UDPAddr, oid string, now pcommon.Timestamp) (pmetric.Metrics, bool) {
+	source := ""
+	if addr != nil {
+		source = addr.IP.String()
+	}
+
+	metrics := pmetric.NewMetrics()
+	var scopeMetrics pmetric.ScopeMetrics
+	for _, counter := range r.counters {
+		if counter.trapOID != oid {
+			continue
+		}
+		sourceCount := counter.count(source, r.startTime, now)
+
+		if metrics.MetricCount() == 0 {
+			scopeMetrics = metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+			scopeMetrics.Scope().SetName(metadata.ScopeName)
+			scopeMetrics.Scope().SetVersion(r.settings.BuildInfo.Version)
+		}
+		metric := scopeMetrics.Metrics().AppendEmpty()
+		metric.SetName(counter.metricName)
+		metric.SetDescription(counter.description)
+		metric.SetUnit("{trap}")
+		sum := metric.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(sourceCount.start)
+		dp.SetTimestamp(now)
+		dp.SetIntValue(sourceCount.count)
+		if source != "" {
+			dp.Attributes().PutStr(attributePeerAddress, source)
+		}
+	}
+
+	return metrics, metrics.MetricCount() > 0
+}
+
+// count increments the count of the source, evicting the least recently seen source when maxTrapSources are tracked
+func (c *trapCounter) count(source string, startTime, now pcommon.Timestamp) *trapSourceCount {
+	c.seen++
+	sourceCount, ok := c.counts[source]
+	if !ok {
+		if len(c.counts) >= maxTrapSources {
+			c.evictLeastRecentlySeen()
+		}
+		// The count of a source may have been evicted before, so it restarts now
+		if c.evicted {
+			startTime = now
+		}
+		sourceCount = &trapSourceCount{start: startTime}
+		c.counts[source] = sourceCount
+	}
+	sourceCount.count++
+	sourceCount.lastSeen = c.seen
+	return sourceCount
+}
+
+// evictLeastRecentlySeen removes the count of the source whose last trap is the oldest
+func (c *trapCounter) evictLeastRecentlySeen() {
+	oldest := ""
+	oldestSeen := int64(-1)
+	for source, sourceCount := range c.counts {
+		if oldestSeen < 0 || sourceCount.lastSeen < oldestSeen {
+			oldest = source
+			oldestSeen = sourceCount.lastSeen
+		}
+	}
+	delete(c.counts, oldest)
+	c.evicted = true
+}
+
+// versionName returns the name of the SNMP version as used in the config
+func versionName(version gosnmp.SnmpVersion) string {
+	return "v" + version.String()
+}
@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package snmpreceiver
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver/internal/metadata"
+)
+
+// getFreeUDPPort returns a UDP port which is available to listen for traps
+func getFreeUDPPort(t *testing.T) uint16 {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	return uint16(conn.LocalAddr().(*net.UDPAddr).Port)
+}
+
+// startTrapReceiver starts the logs and metrics receivers of the config, listening on the returned port
+func startTrapReceiver(t *testing.T, cfg *Config) (uint16, *consumertest.LogsSink, *consumertest.MetricsSink) {
+	t.Helper()
+	port := getFreeUDPPort(t)
+	cfg.Traps.Endpoint = fmt.Sprintf("udp://127.0.0.1:%d", port)
+
+	logsSink := new(consumertest.LogsSink)
+	metricsSink := new(consumertest.MetricsSink)
+	factory := NewFactory()
+	settings := receivertest.NewNopSettings(metadata.Type)
+	logsReceiver, err := factory.CreateLogs(t.Context(), settings, cfg, logsSink)
+	require.NoError(t, err)
+	metricsReceiver, err := factory.CreateMetrics(t.Context(), settings, cfg, metricsSink)
+	require.NoError(t, err)
+
+	require.NoError(t, logsReceiver.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, metricsReceiver.Start(t.Context(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, logsReceiver.Shutdown(t.Context()))
+		assert.NoError(t, metricsReceiver.Shutdown(t.Context()))
+	})
+	return port, logsSink, metricsSink
+}
+
+// sendTrap sends a trap with the given client parameters to the port, and returns the
+// response of the receiver when the trap is an inform
+func sendTrap(t *testing.T, client *gosnmp.GoSNMP, port uint16, trap gosnmp.SnmpTrap) *gosnmp.SnmpPacket {
+	t.Helper()
+	client.Target = "127.0.0.1"
+	client.Port = port
+	client.Timeout = time.Second
+	client.MaxOids = gosnmp.MaxOids
+	require.NoError(t, client.Connect())
+	defer client.Conn.Close()
+	response, err := client.SendTrap(trap)
+	require.NoError(t, err)
+	return response
+}
+
+func linkDownTrap() gosnmp.SnmpTrap {
+	return gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1234)},
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+			{Name: ".1.3.6.1.2.1.2.2.1.1.2", Type: gosnmp.Integer, Value: 2},
+			{Name: ".1.3.6.1.2.1.2.2.1.2.2", Type: gosnmp.OctetString, Value: "eth0"},
+			{Name: ".1.3.6.1.4.1.9999.1", Type: gosnmp.OctetString, Value: []byte{0xff, 0x01}},
+		},
+	}
+}
+
+func newTrapsConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Traps = &TrapsConfig{
+		OIDNames: map[string]string{
+			"1.3.6.1.6.3.1.1.5.3": "linkDown",
+			".1.3.6.1.2.1.2.2.1":  "ifEntry",
+			"1.3.6.1.2.1.2.2.1.1": "ifIndex",
+		},
+		Counters: map[string]*TrapCounterConfig{
+			"snmp.traps.link_down": {
+				Description: "Number of linkDown traps",
+				TrapOID:     ".1.3.6.1.6.3.1.1.5.3",
+			},
+		},
+	}
+	return cfg
+}
+
+func TestTrapReceiverV2c(t *testing.T) {
+	cfg := newTrapsConfig()
+	port, logsSink, metricsSink := startTrapReceiver(t, cfg)
+
+	sendTrap(t, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"}, port, linkDownTrap())
+	sendTrap(t, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"}, port, linkDownTrap())
+	// Dropped because of the community
+	sendTrap(t, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "private"}, port, linkDownTrap())
+
+	require.Eventually(t, func() bool {
+		return logsSink.LogRecordCount() == 2 && metricsSink.DataPointCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	logRecord := logsSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "linkDown", logRecord.Body().Str())
+	attrs := logRecord.Attributes().AsRaw()
+	assert.NotZero(t, attrs[attributePeerPort])
+	delete(attrs, attributePeerPort)
+	assert.Equal(t, map[string]any{
+		attributeTrapOID:     "1.3.6.1.6.3.1.1.5.3",
+		attributeTrapName:    "linkDown",
+		attributeTrapUptime:  int64(1234),
+		attributeVersion:     "v2c",
+		attributePDUType:     "trap",
+		attributePeerAddress: "127.0.0.1",
+		"ifIndex.2":          int64(2),
+		"ifEntry.2.2":        "eth0",
+		"1.3.6.1.4.1.9999.1": "ff01",
+	}, attrs)
+
+	metric := metricsSink.AllMetrics()[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "snmp.traps.link_down", metric.Name())
+	assert.Equal(t, "Number of linkDown traps", metric.Description())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, metric.Sum().AggregationTemporality())
+	assert.True(t, metric.Sum().IsMonotonic())
+	dp := metric.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(2), dp.IntValue())
+	assert.Equal(t, map[string]any{attributePeerAddress: "127.0.0.1"}, dp.Attributes().AsRaw())
+}
+
+func TestTrapReceiverV1(t *testing.T) {
+	cfg := newTrapsConfig()
+	port, logsSink, metricsSink := startTrapReceiver(t, cfg)
+
+	sendTrap(t, &gosnmp.GoSNMP{Version: gosnmp.Version1, Community: "public"}, port, gosnmp.SnmpTrap{
+		Enterprise:   ".1.3.6.1.4.1.9999",
+		AgentAddress: "192.0.2.1",
+		GenericTrap:  2,
+		Timestamp:    42,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.2.2.1.1.3", Type: gosnmp.Integer, Value: 3},
+		},
+	})
+	sendTrap(t, &gosnmp.GoSNMP{Version: gosnmp.Version1, Community: "public"}, port, gosnmp.SnmpTrap{
+		Enterprise:   ".1.3.6.1.4.1.9999",
+		AgentAddress: "192.0.2.1",
+		GenericTrap:  6,
+		SpecificTrap: 17,
+	})
+
+	require.Eventually(t, func() bool {
+		return logsSink.LogRecordCount() == 2 && metricsSink.DataPointCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var logRecords []plog.LogRecord
+	for _, logs := range logsSink.AllLogs() {
+		logRecords = append(logRecords, logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0))
+	}
+
+	// The generic linkDown trap is translated to its v2 OID
+	assert.Equal(t, "linkDown", logRecords[0].Body().Str())
+	attrs := logRecords[0].Attributes().AsRaw()
+	assert.Equal(t, "v1", attrs[attributeVersion])
+	assert.Equal(t, "1.3.6.1.4.1.9999", attrs[attributeTrapEnterprise])
+	assert.Equal(t, "192.0.2.1", attrs[attributeTrapAgentAddress])
+	assert.Equal(t, int64(42), attrs[attributeTrapUptime])
+	assert.Equal(t, int64(3), attrs["ifIndex.3"])
+
+	assert.Equal(t, "1.3.6.1.4.1.9999.0.17", logRecords[1].Body().Str())
+	_, ok := logRecords[1].Attributes().Get(attributeTrapName)
+	assert.False(t, ok)
+}
+
+func newV3TrapsConfig() *Config {
+	cfg := newTrapsConfig()
+	cfg.Version = "v3"
+	cfg.User = "otel"
+	cfg.SecurityLevel = "auth_priv"
+	cfg.AuthType = "SHA"
+	cfg.AuthPassword = "authpassword"
+	cfg.PrivacyType = "AES"
+	cfg.PrivacyPassword = "privacypassword"
+	return cfg
+}
+
+func newV3Client(authPassword string) *gosnmp.GoSNMP {
+	return &gosnmp.GoSNMP{
+		Version:       gosnmp.Version3,
+		SecurityModel: gosnmp.UserSecurityModel,
+		MsgFlags:      gosnmp.AuthPriv,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{
+			UserName:                 "otel",
+			AuthoritativeEngineID:    string([]byte{0x80, 0x00, 0x1f, 0x88, 0x80, 0x01, 0x02, 0x03, 0x04}),
+			AuthoritativeEngineBoots: 1,
+			AuthenticationProtocol:   gosnmp.SHA,
+			AuthenticationPassphrase: authPassword,
+			PrivacyProtocol:          gosnmp.AES,
+			PrivacyPassphrase:        "privacypassword",
+		},
+	}
+}
+
+func TestTrapReceiverV3(t *testing.T) {
+	port, logsSink, _ := startTrapReceiver(t, newV3TrapsConfig())
+
+	// Dropped because of the authentication
+	sendTrap(t, newV3Client("wrongpassword"), port, linkDownTrap())
+	sendTrap(t, newV3Client("authpassword"), port, linkDownTrap())
+
+	require.Eventually(t, func() bool {
+		return logsSink.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	logRecord := logsSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "linkDown", logRecord.Body().Str())
+	version, _ := logRecord.Attributes().Get(attributeVersion)
+	assert.Equal(t, "v3", version.Str())
+
+	// v2c traps are dropped when v3 is configured
+	sendTrap(t, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"}, port, linkDownTrap())
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, logsSink.LogRecordCount())
+}
+
+func TestTrapReceiverInform(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		client  *gosnmp.GoSNMP
+		version string
+	}{
+		{
+			name:    "v2c",
+			cfg:     newTrapsConfig(),
+			client:  &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"},
+			version: "v2c",
+		},
+		{
+			name:    "v3",
+			cfg:     newV3TrapsConfig(),
+			client:  newV3Client("authpassword"),
+			version: "v3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, logsSink, metricsSink := startTrapReceiver(t, tt.cfg)
+
+			inform := linkDownTrap()
+			inform.IsInform = true
+			// SendTrap fails when the inform is not acknowledged
+			response := sendTrap(t, tt.client, port, inform)
+			assert.Equal(t, gosnmp.GetResponse, response.PDUType)
+
+			require.Eventually(t, func() bool {
+				return logsSink.LogRecordCount() == 1 && metricsSink.DataPointCount() == 1
+			}, 5*time.Second, 10*time.Millisecond)
+			logRecord := logsSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			assert.Equal(t, "linkDown", logRecord.Body().Str())
+			version, _ := logRecord.Attributes().Get(attributeVersion)
+			assert.Equal(t, tt.version, version.Str())
+			pduType, _ := logRecord.Attributes().Get(attributePDUType)
+			assert.Equal(t, "inform", pduType.Str())
+
+			metric := metricsSink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+			assert.Equal(t, "snmp.traps.link_down", metric.Name())
+			assert.Equal(t, int64(1), metric.Sum().DataPoints().At(0).IntValue())
+		})
+	}
+}
+
+func TestTrapReceiverInvalidOIDNamesFile(t *testing.T) {
+	cfg := newTrapsConfig()
+	cfg.Traps.OIDNamesFiles = []string{"testdata/missing.txt"}
+	r := newTrapReceiver(cfg, receivertest.NewNopSettings(metadata.Type))
+	assert.ErrorContains(t, r.Start(t.Context(), componenttest.NewNopHost()), "failed to open OID names file 'testdata/missing.txt'")
+	assert.NoError(t, r.Shutdown(t.Context()))
+}
+
+func TestTrapCounterEvictsSources(t *testing.T) {
+	r := newTrapReceiver(newTrapsConfig(), receivertest.NewNopSettings(metadata.Type))
+	r.startTime = pcommon.Timestamp(1)
+	oid := "1.3.6.1.6.3.1.1.5.3"
+	sourceAddr := func(i int) *net.UDPAddr {
+		return &net.UDPAddr{IP: net.IPv4(10, 0, byte(i>>8), byte(i))}
+	}
+	countOf := func(metrics pmetric.Metrics) pmetric.NumberDataPoint {
+		return metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	}
+
+	for i := range maxTrapSources {
+		_, ok := r.countTrap(sourceAddr(i), oid, pcommon.Timestamp(2))
+		require.True(t, ok)
+	}
+	// The first source is seen again, so the second one is the least recently seen
+	metrics, _ := r.countTrap(sourceAddr(0), oid, pcommon.Timestamp(3))
+	assert.Equal(t, int64(2), countOf(metrics).IntValue())
+
+	metrics, _ = r.countTrap(sourceAddr(maxTrapSources), oid, pcommon.Timestamp(4))
+	dp := countOf(metrics)
+	assert.Equal(t, int64(1), dp.IntValue())
+	assert.Equal(t, pcommon.Timestamp(4), dp.StartTimestamp())
+	counts := r.counters[0].counts
+	assert.Len(t, counts, maxTrapSources)
+	assert.Contains(t, counts, sourceAddr(0).IP.String())
+	assert.NotContains(t, counts, sourceAddr(1).IP.String())
+
+	// The count of the evicted source restarts
+	metrics, _ = r.countTrap(sourceAddr(1), oid, pcommon.Timestamp(5))
+	dp = countOf(metrics)
+	assert.Equal(t, int64(1), dp.IntValue())
+	assert.Equal(t, pcommon.Timestamp(5), dp.StartTimestamp())
+	assert.Len(t, counts, maxTrapSources)
+	assert.NotContains(t, counts, sourceAddr(2).IP.String())
+}